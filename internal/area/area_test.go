@@ -17,7 +17,11 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package area
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestJoin(t *testing.T) {
@@ -231,3 +235,352 @@ func TestPunishmentSafe(t *testing.T) {
 		t.Errorf("SetPunishmentSafe(false) did not take effect")
 	}
 }
+
+func TestRemoveLastBufferLines(t *testing.T) {
+	a := NewArea(AreaData{}, 50, 5, EviAny)
+	for _, s := range []string{"a1", "b1", "a2", "a3", "b2"} {
+		a.UpdateBuffer(s)
+	}
+	isA := func(s string) bool { return strings.HasPrefix(s, "a") }
+
+	// Only the two newest matching lines go; order of the rest is preserved.
+	if n := a.RemoveLastBufferLines(isA, 2); n != 2 {
+		t.Fatalf("removed %d lines, want 2", n)
+	}
+	if got, want := strings.Join(a.Buffer(), ","), "a1,b1,b2"; got != want {
+		t.Errorf("buffer = %q, want %q", got, want)
+	}
+	if len(a.buffer) != 5 {
+		t.Errorf("buffer size changed to %d, want 5", len(a.buffer))
+	}
+
+	// The freed slots are reused by new lines without losing anything.
+	a.UpdateBuffer("b3")
+	if got, want := strings.Join(a.Buffer(), ","), "a1,b1,b2,b3"; got != want {
+		t.Errorf("buffer = %q, want %q", got, want)
+	}
+
+	if n := a.RemoveLastBufferLines(func(string) bool { return false }, 3); n != 0 {
+		t.Errorf("removed %d lines with no matches, want 0", n)
+	}
+}
+
+func TestEvidenceOwners(t *testing.T) {
+	a := NewArea(AreaData{}, 50, 0, EviAny)
+	a.AddEvidenceBy("knife&sharp&knife.png", "ipid-a")
+	a.AddEvidence("note&unsigned&note.png")
+	a.AddEvidenceBy("badge&shiny&badge.png", "ipid-b")
+
+	// Owners follow their evidence through swaps, edits and removals.
+	a.SwapEvidence(0, 2)
+	a.EditEvidence(0, "badge&tarnished&badge.png")
+	a.RemoveEvidence(1)
+
+	want := []struct{ evi, owner string }{
+		{"badge&tarnished&badge.png", "ipid-b"},
+		{"knife&sharp&knife.png", "ipid-a"},
+	}
+	for i, w := range want {
+		evi, owner, ok := a.EvidenceAt(i)
+		if !ok || evi != w.evi || owner != w.owner {
+			t.Errorf("EvidenceAt(%d) = %q, %q, %v; want %q, %q, true", i, evi, owner, ok, w.evi, w.owner)
+		}
+	}
+	if _, _, ok := a.EvidenceAt(2); ok {
+		t.Error("EvidenceAt past the end should report !ok")
+	}
+
+	a.Reset()
+	a.AddEvidence("fresh&start&x.png")
+	if _, owner, _ := a.EvidenceAt(0); owner != "" {
+		t.Errorf("owner after reset = %q, want empty", owner)
+	}
+}
+
+func TestSetStatusFor(t *testing.T) {
+	a := NewArea(AreaData{}, 50, 0, EviAny)
+
+	reverted := make(chan struct{}, 1)
+	a.SetStatusFor(StatusRecess, 10*time.Millisecond, func() { reverted <- struct{}{} })
+	if a.Status() != StatusRecess {
+		t.Fatalf("status = %v, want %v", a.Status(), StatusRecess)
+	}
+	select {
+	case <-reverted:
+	case <-time.After(time.Second):
+		t.Fatal("timed status never reverted")
+	}
+	if a.Status() != StatusIdle {
+		t.Errorf("status after revert = %v, want %v", a.Status(), StatusIdle)
+	}
+
+	// A manual change cancels the pending revert.
+	a.SetStatusFor(StatusRecess, 10*time.Millisecond, func() { reverted <- struct{}{} })
+	a.SetStatus(StatusCasing)
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case <-reverted:
+		t.Error("revert fired after the status was changed manually")
+	default:
+	}
+	if a.Status() != StatusCasing {
+		t.Errorf("status = %v, want %v", a.Status(), StatusCasing)
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	a := NewArea(AreaData{Name: "Courtroom", Bg: "default"}, 5, 10, EviAny)
+	a.SetBackground("gs4")
+	a.SetStatus(StatusCasing)
+	a.SetLock(LockSpectatable)
+	a.SetDoc("https://example.com/doc")
+	a.SetLockMusic(true)
+	a.AddEvidenceBy("knife&sharp&knife.png", "ipid1")
+	a.tr.Testimony = []string{"title", "one", "two"}
+
+	b := NewArea(AreaData{Name: "Courtroom", Bg: "default"}, 5, 10, EviAny)
+	b.Restore(a.Snapshot(false, false), false, false)
+	if b.Background() != "gs4" || b.Status() != StatusCasing || b.Lock() != LockFree ||
+		b.Doc() != "https://example.com/doc" || !b.LockMusic() {
+		t.Errorf("settings were not restored: %+v", b.Snapshot(false, false))
+	}
+	if len(b.Evidence()) != 0 || b.HasTestimony() {
+		t.Error("evidence must not be restored, and testimony and locks only when opted in")
+	}
+
+	a.SetPassword("hunter2")
+	c := NewArea(AreaData{Name: "Courtroom", Bg: "default"}, 5, 10, EviAny)
+	c.Restore(a.Snapshot(true, true), true, true)
+	if !c.HasTestimony() || c.TstState() != TRIdle {
+		t.Error("testimony should be restored and idle")
+	}
+	if c.Lock() != LockSpectatable || !c.HasPassword() {
+		t.Errorf("lock state should be restored, got %v", c.Lock())
+	}
+}
+
+func TestSaveLoadEvidence(t *testing.T) {
+	dir := t.TempDir()
+	a := NewArea(AreaData{Name: "Courtroom"}, 5, 10, EviAny)
+	a.AddEvidenceBy("knife&sharp&knife.png", "ipid1")
+	a.AddEvidence("badge&shiny&badge.png")
+	path := filepath.Join(dir, "Courtroom.json")
+	if err := a.SaveEvidence(path); err != nil {
+		t.Fatalf("SaveEvidence: %v", err)
+	}
+
+	b := NewArea(AreaData{Name: "Courtroom"}, 5, 10, EviAny)
+	if err := b.LoadEvidence(path); err != nil {
+		t.Fatalf("LoadEvidence: %v", err)
+	}
+	if ev := b.Evidence(); len(ev) != 2 || ev[0] != "knife&sharp&knife.png" || ev[1] != "badge&shiny&badge.png" {
+		t.Errorf("loaded evidence = %v", ev)
+	}
+	if _, owner, ok := b.EvidenceAt(0); !ok || owner != "ipid1" {
+		t.Errorf("evidence owner = %q, %v; want ipid1", owner, ok)
+	}
+
+	if err := b.LoadEvidence(filepath.Join(dir, "missing.json")); err != nil || len(b.Evidence()) != 0 {
+		t.Errorf("a missing file should start empty without an error, got %v, %v", b.Evidence(), err)
+	}
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.LoadEvidence(corrupt); err == nil || len(a.Evidence()) != 0 {
+		t.Errorf("a corrupt file should start empty with an error, got %v, %v", a.Evidence(), err)
+	}
+}
+
+func TestJoinCodes(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 0, EviAny)
+	now := time.Now()
+	a.AddJoinCode("ABC234", 2, now.Add(time.Minute))
+
+	if !a.RedeemJoinCode("ABC234", 1, now) || !a.HasInvited(1) {
+		t.Fatal("expected the first redemption to invite UID 1")
+	}
+	if !a.RedeemJoinCode("ABC234", 2, now) || !a.HasInvited(2) {
+		t.Fatal("expected the second redemption to invite UID 2")
+	}
+	if a.RedeemJoinCode("ABC234", 3, now) || a.HasJoinCode("ABC234") {
+		t.Error("expected the code to be removed once its uses are spent")
+	}
+
+	a.AddJoinCode("XYZ789", 1, now.Add(time.Minute))
+	if a.RedeemJoinCode("XYZ789", 4, now.Add(2*time.Minute)) || a.HasInvited(4) {
+		t.Error("expected an expired code to be refused")
+	}
+
+	a.AddJoinCode("OLD234", 1, now.Add(time.Minute))
+	a.AddJoinCode("NEW234", 1, now.Add(time.Hour))
+	if n := a.PruneJoinCodes(now.Add(2 * time.Minute)); n != 1 {
+		t.Errorf("PruneJoinCodes removed %d codes, want 1", n)
+	}
+	if !a.HasJoinCode("NEW234") {
+		t.Error("expected the unexpired code to survive pruning")
+	}
+
+	a.ClearInvited()
+	if a.HasJoinCode("NEW234") {
+		t.Error("expected ClearInvited to drop outstanding join codes")
+	}
+}
+
+func TestPassword(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 0, EviAny)
+	if a.HasPassword() || a.CheckPassword("") {
+		t.Fatal("a new area should have no password and accept none")
+	}
+	a.SetPassword("hunter2")
+	if !a.HasPassword() || !a.CheckPassword("hunter2") || a.CheckPassword("hunter3") {
+		t.Error("expected only the set password to be accepted")
+	}
+	a.SetPassword("")
+	if a.HasPassword() || a.CheckPassword("hunter2") {
+		t.Error("expected clearing the password to stop accepting it")
+	}
+	a.SetPassword("again")
+	a.Reset()
+	if a.HasPassword() {
+		t.Error("expected Reset to clear the password")
+	}
+}
+
+func TestDocHistory(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 0, EviAny)
+	if _, ok := a.RestorePreviousDoc(); ok {
+		t.Fatal("expected nothing to restore with an empty history")
+	}
+	a.SetDoc("first")
+	a.SetDoc("second")
+	a.SetDoc("")
+	if doc, ok := a.RestorePreviousDoc(); !ok || doc != "second" || a.Doc() != "second" {
+		t.Fatalf("RestorePreviousDoc() = %q, %v; want the doc cleared last", doc, ok)
+	}
+	if doc, _ := a.RestorePreviousDoc(); doc != "first" {
+		t.Errorf("second restore = %q, want %q", doc, "first")
+	}
+
+	for i := 0; i < maxDocHistory+3; i++ {
+		a.SetDoc(strings.Repeat("x", i+1))
+	}
+	var restored int
+	for {
+		if _, ok := a.RestorePreviousDoc(); !ok {
+			break
+		}
+		restored++
+	}
+	if restored != maxDocHistory {
+		t.Errorf("restored %d docs, want the history capped at %d", restored, maxDocHistory)
+	}
+}
+
+func TestJoinQueue(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 0, EviAny)
+	if !a.QueuePlayer(3) || !a.QueuePlayer(1) || !a.QueuePlayer(2) {
+		t.Fatal("expected new UIDs to be queued")
+	}
+	if a.QueuePlayer(1) {
+		t.Error("expected a duplicate UID to be refused")
+	}
+	if !a.UnqueuePlayer(1) || a.UnqueuePlayer(1) {
+		t.Error("expected UID 1 to be removed exactly once")
+	}
+	if got := a.Queue(); len(got) != 2 || got[0] != 3 || got[1] != 2 {
+		t.Errorf("Queue() = %v, want [3 2]", got)
+	}
+	if got := a.DrainQueue(); len(got) != 2 || len(a.Queue()) != 0 {
+		t.Errorf("DrainQueue() = %v and left %v, want both UIDs and an empty queue", got, a.Queue())
+	}
+}
+
+func TestSongQueue(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 0, EviAny)
+	if r := a.RequestSong(SongRequest{Song: "a.opus", UID: 1}, 3); r != SongQueued {
+		t.Fatalf("first request = %v, want SongQueued", r)
+	}
+	if r := a.RequestSong(SongRequest{Song: "A.opus", UID: 1}, 3); r != SongDuplicate {
+		t.Errorf("repeat request = %v, want SongDuplicate", r)
+	}
+	if r := a.RequestSong(SongRequest{Song: "a.opus", UID: 2}, 3); r != SongQueued {
+		t.Errorf("same song from another player = %v, want SongQueued", r)
+	}
+	if r := a.RequestSong(SongRequest{Song: "b.opus", UID: 1}, 3); r != SongQueued {
+		t.Errorf("new song = %v, want SongQueued", r)
+	}
+	if r := a.RequestSong(SongRequest{Song: "c.opus", UID: 3}, 3); r != SongQueueFull {
+		t.Errorf("request past the cap = %v, want SongQueueFull", r)
+	}
+	if got, ok := a.NextSong(); !ok || got.Song != "a.opus" || got.UID != 1 {
+		t.Errorf("NextSong() = %+v, %v, want the oldest request", got, ok)
+	}
+	if got := a.SongQueue(); len(got) != 2 || got[0].UID != 2 || got[1].Song != "b.opus" {
+		t.Errorf("SongQueue() = %+v, want the two remaining requests in order", got)
+	}
+	a.Reset()
+	if _, ok := a.NextSong(); ok {
+		t.Error("expected Reset to clear the song queue")
+	}
+}
+
+func TestBufferResize(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 3, EviAny)
+	for _, s := range []string{"a", "b", "c", "d"} {
+		a.UpdateBuffer(s)
+	}
+	if got := strings.Join(a.Buffer(), ","); got != "b,c,d" {
+		t.Fatalf("full buffer = %q, want the oldest line dropped", got)
+	}
+
+	a.SetBufferSize(5)
+	a.UpdateBuffer("e")
+	if got := strings.Join(a.Buffer(), ","); got != "b,c,d,e" || a.BufferSize() != 5 {
+		t.Errorf("grown buffer = %q (size %d), want history kept", got, a.BufferSize())
+	}
+
+	a.SetBufferSize(2)
+	if got := strings.Join(a.Buffer(), ","); got != "d,e" {
+		t.Errorf("shrunk buffer = %q, want the newest lines kept", got)
+	}
+
+	empty := NewArea(AreaData{}, 1, 0, EviAny)
+	empty.UpdateBuffer("x") // must not panic on a zero-size buffer
+}
+
+func TestAddPlayerVote(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 1, EviAny)
+	if prev := a.AddPlayerVote(1, 1); prev != 0 {
+		t.Fatalf("first vote returned previous option %d, want 0", prev)
+	}
+	a.AddPlayerVote(2, 2)
+	if prev := a.AddPlayerVote(1, 2); prev != 1 {
+		t.Fatalf("changed vote returned previous option %d, want 1", prev)
+	}
+	a.AddPlayerVote(2, 2) // repeating the same vote must not double-count
+	if v := a.PollVotes(); v[1] != 0 || v[2] != 2 {
+		t.Errorf("tallies = %v, want option 2 with both votes", v)
+	}
+
+	// A cleared poll starts from an empty tally.
+	a.ClearPoll()
+	for uid, option := range map[int]int{1: 3, 2: 1, 3: 3, 4: 2, 5: 3} {
+		if prev := a.AddPlayerVote(uid, option); prev != 0 {
+			t.Fatalf("UID %d kept vote %d from the cleared poll", uid, prev)
+		}
+	}
+	if v := a.PollVotes(); v[1] != 1 || v[2] != 1 || v[3] != 3 {
+		t.Errorf("tallies after several votes = %v, want 1/1/3", v)
+	}
+}
+
+func TestResetClearsJoinCodes(t *testing.T) {
+	a := NewArea(AreaData{}, 1, 0, EviAny)
+	now := time.Now()
+	a.AddJoinCode("ABC234", 1, now.Add(time.Hour))
+	a.Reset()
+	if a.HasJoinCode("ABC234") || a.RedeemJoinCode("ABC234", 1, now) {
+		t.Error("an outstanding join code should not survive the area resetting")
+	}
+}
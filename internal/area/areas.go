@@ -34,11 +34,13 @@ type EvidenceMode int
 type Status int
 type Lock int
 type TRState int
+type CapsFilter int
 
 const (
 	EviMods EvidenceMode = iota
 	EviAny
 	EviCMs
+	EviOwner // anyone may add; only the adder (by IPID), CMs and mods may edit or remove
 )
 const (
 	StatusIdle Status = iota
@@ -54,6 +56,12 @@ const (
 	LockLocked
 )
 
+const (
+	CapsOff    CapsFilter = iota // no shouting filter
+	CapsLower                    // lowercase shouted IC messages
+	CapsReject                   // refuse shouted IC messages
+)
+
 const (
 	TRIdle TRState = iota
 	TRRecording
@@ -75,14 +83,51 @@ type Poll struct {
 	CreatedAt time.Time
 	ClosesAt  time.Time
 	CreatedBy string
+	Public    bool // /poll -p: results list who voted for what
 }
 
 type CoinflipChallenge struct {
 	PlayerName string
+	IPID       string // challenger's IPID, for win/loss records
 	Choice     string
 	CreatedAt  time.Time
 }
 
+// TicTacToe is an area's /ttt game. Players[0] issued the challenge, plays X
+// and moves first. The athena package serialises access to the fields.
+type TicTacToe struct {
+	Players  [2]int    // UIDs of the two participants
+	Names    [2]string // their display names when the game started
+	IPIDs    [2]string // their IPIDs, for win/loss records
+	Board    [9]byte   // 0 for an empty cell, otherwise 'X' or 'O'
+	Turn     int       // index into Players of whoever moves next
+	Accepted bool      // false while the challenge waits on Players[1]
+	Moves    int       // moves made so far
+}
+
+// SongRequest is a track waiting in an area's /songqueue.
+type SongRequest struct {
+	Song string
+	UID  int    // requester's UID
+	Name string // requester's display name when they asked
+}
+
+// SongQueueResult reports the outcome of Area.RequestSong.
+type SongQueueResult int
+
+const (
+	SongQueued    SongQueueResult = iota
+	SongQueueFull                 // the queue already holds the maximum number of requests
+	SongDuplicate                 // the requester's latest pending request is the same song
+)
+
+// JoinCode is an outstanding /invitecode code: each redemption adds the
+// redeemer to the area's invite list until Uses runs out or it expires.
+type JoinCode struct {
+	Uses    int
+	Expires time.Time
+}
+
 type Area struct {
 	data                AreaData
 	defaults            defaults
@@ -93,23 +138,34 @@ type Area struct {
 	defhp               int
 	prohp               int
 	evidence            []string
+	evidenceOwners      []string // IPID that added each evidence item, parallel to evidence
+	keepEvidence        bool     // Reset leaves the evidence alone (evidence persistence is on)
 	buffer              []string
 	cms                 map[int]struct{}
 	last_msg            int
 	evi_mode            EvidenceMode
 	status              Status
+	statusTimer         *time.Timer // pending /status -t revert to idle; nil when none
 	lock                Lock
 	adminLocked         bool // /adminlock: only admins may enter; even BYPASS_LOCK mods/shadow mods are refused
 	invited             map[int]struct{}
+	joinCodes           map[string]JoinCode
+	password            string // /areapass: lets players self-admit with /move; "" = none
+	joinQueue           []int
+	songQueue           []SongRequest // /songqueue requests, oldest first
 	doc                 string
+	docHistory          []string
 	description         string
 	tr                  TestimonyRecorder
+	tstName             string              // name of the testimony in tr; "" = DefaultTestimony
+	tstStore            map[string][]string // other named testimonies, switched in with TstSwitch
 	activePoll          *Poll
 	lastPollTime        time.Time
 	pollVotes           map[int]int
 	playerVotes         map[int]int
 	activeCoinflip      *CoinflipChallenge
 	lastCoinflipTime    time.Time
+	activeTicTacToe     *TicTacToe
 	spectateMode        bool
 	spectateInvited     map[int]struct{}
 	casinoEnabled       bool
@@ -131,6 +187,8 @@ type Area struct {
 	logSilenced         bool               // whether area-log writing and modcall forwarding are suppressed
 	voiceAllowed        bool               // runtime toggle: whether voice chat is permitted in this area
 	musicFrozen         bool               // hard music lock: no one (including CMs/DJs/mods) can change music
+	capsFilter          CapsFilter         // /capsfilter: automatic handling of ALL-CAPS IC messages
+	maxMsgLen           int                // /maxlen: per-area IC length cap in characters (0 = server default)
 }
 
 type AreaData struct {
@@ -168,6 +226,10 @@ type AreaData struct {
 	// keep voice off by default for a quiet RP area even when the server has
 	// voice globally enabled.
 	Voice_allowed *bool `toml:"voice_allowed"`
+	// Music lists extra tracks (and categories) offered only in this area,
+	// shown after the server's global music list. Empty means the area uses
+	// the global list alone.
+	Music []string `toml:"music"`
 }
 
 type defaults struct {
@@ -241,6 +303,7 @@ func NewAreaWithVoiceDefault(data AreaData, charlen int, bufsize int, evi_mode E
 		description:         data.Description,
 		cms:                 make(map[int]struct{}),
 		invited:             make(map[int]struct{}),
+		joinCodes:           make(map[string]JoinCode),
 		spectateInvited:     make(map[int]struct{}),
 		casinoEnabled:       data.Casino_enabled,
 		casinoMinBet:        data.Casino_min_bet,
@@ -262,6 +325,13 @@ func (a *Area) Name() string {
 	return a.data.Name
 }
 
+// Music returns the area's own music list from areas.toml.
+func (a *Area) Music() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.data.Music
+}
+
 // Taken returns the area's taken list, where "-1" is taken and "0" is free
 func (a *Area) Taken() []string {
 	a.mu.Lock()
@@ -386,10 +456,17 @@ func (a *Area) Evidence() []string {
 	return a.evidence
 }
 
-// AddEvidence adds a piece of evidence to the area.
+// AddEvidence adds a piece of evidence to the area with no recorded owner.
 func (a *Area) AddEvidence(evi string) {
+	a.AddEvidenceBy(evi, "")
+}
+
+// AddEvidenceBy adds a piece of evidence to the area, recording the IPID of
+// the client that added it.
+func (a *Area) AddEvidenceBy(evi string, ipid string) {
 	a.mu.Lock()
 	a.evidence = append(a.evidence, evi)
+	a.evidenceOwners = append(a.evidenceOwners, ipid)
 	a.mu.Unlock()
 }
 
@@ -399,11 +476,13 @@ func (a *Area) RemoveEvidence(id int) {
 	if id >= 0 && id < len(a.evidence) {
 		copy(a.evidence[id:], a.evidence[id+1:])
 		a.evidence = a.evidence[:len(a.evidence)-1]
+		copy(a.evidenceOwners[id:], a.evidenceOwners[id+1:])
+		a.evidenceOwners = a.evidenceOwners[:len(a.evidenceOwners)-1]
 	}
 	a.mu.Unlock()
 }
 
-// EditEvidence replaces a piece of evidence.
+// EditEvidence replaces a piece of evidence. The original owner is kept.
 func (a *Area) EditEvidence(id int, evi string) {
 	a.mu.Lock()
 	if id >= 0 && id < len(a.evidence) {
@@ -420,16 +499,57 @@ func (a *Area) SwapEvidence(x int, y int) bool {
 		return false
 	}
 	a.evidence[x], a.evidence[y] = a.evidence[y], a.evidence[x]
+	a.evidenceOwners[x], a.evidenceOwners[y] = a.evidenceOwners[y], a.evidenceOwners[x]
 	return true
 }
 
-// UpdateBuffer adds a new line to the area's log buffer.
+// EvidenceAt returns the piece of evidence at id and the IPID of the client
+// that added it (empty if unknown). ok is false if id is out of range.
+func (a *Area) EvidenceAt(id int) (evi string, owner string, ok bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if id < 0 || id >= len(a.evidence) {
+		return "", "", false
+	}
+	return a.evidence[id], a.evidenceOwners[id], true
+}
+
+// UpdateBuffer adds a new line to the area's log buffer. The buffer holds a
+// fixed number of lines (see SetBufferSize); once it is full, the oldest line
+// is dropped to make room.
 func (a *Area) UpdateBuffer(s string) {
 	a.mu.Lock()
-	a.buffer = append(a.buffer[1:], s)
+	if n := len(a.buffer); n > 0 {
+		copy(a.buffer, a.buffer[1:])
+		a.buffer[n-1] = s
+	}
 	a.mu.Unlock()
 }
 
+// BufferSize returns the number of lines the area's log buffer holds.
+func (a *Area) BufferSize() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.buffer)
+}
+
+// SetBufferSize resizes the area's log buffer to n lines, keeping the most
+// recent ones. Shrinking drops the oldest lines.
+func (a *Area) SetBufferSize(n int) {
+	if n < 0 {
+		n = 0
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	buf := make([]string, n)
+	if len(a.buffer) > n {
+		copy(buf, a.buffer[len(a.buffer)-n:])
+	} else {
+		copy(buf[n-len(a.buffer):], a.buffer)
+	}
+	a.buffer = buf
+}
+
 // Buffer returns the area's log buffer.
 func (a *Area) Buffer() []string {
 	var returnList []string
@@ -443,6 +563,34 @@ func (a *Area) Buffer() []string {
 	return returnList
 }
 
+// RemoveLastBufferLines removes up to max of the most recent buffer lines for
+// which match returns true, and returns how many were removed. The buffer
+// keeps its fixed size; freed slots become empty lines at the oldest end.
+func (a *Area) RemoveLastBufferLines(match func(string) bool, max int) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	removed := 0
+	kept := make([]string, 0, len(a.buffer))
+	for i := len(a.buffer) - 1; i >= 0; i-- {
+		if removed < max && match(a.buffer[i]) {
+			removed++
+			continue
+		}
+		kept = append(kept, a.buffer[i])
+	}
+	if removed == 0 {
+		return 0
+	}
+	for i := range a.buffer {
+		a.buffer[i] = ""
+	}
+	// kept is newest-first; write it back oldest-first at the tail.
+	for i, s := range kept {
+		a.buffer[len(a.buffer)-1-i] = s
+	}
+	return removed
+}
+
 // CMs returns a slice of UID values for all CMs in the area.
 func (a *Area) CMs() []int {
 	a.mu.Lock()
@@ -602,13 +750,48 @@ func (a *Area) Status() Status {
 	return a.status
 }
 
-// SetStatus sets the area's status.
+// SetStatus sets the area's status, cancelling any pending timed revert.
 func (a *Area) SetStatus(status Status) {
 	a.mu.Lock()
+	a.stopStatusTimer()
 	a.status = status
 	a.mu.Unlock()
 }
 
+// SetStatusFor sets the area's status and reverts it to StatusIdle after d,
+// calling onRevert once it has. A later SetStatus or SetStatusFor cancels the
+// revert.
+func (a *Area) SetStatusFor(status Status, d time.Duration, onRevert func()) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stopStatusTimer()
+	a.status = status
+	var t *time.Timer
+	t = time.AfterFunc(d, func() {
+		a.mu.Lock()
+		if a.statusTimer != t {
+			// Replaced or cancelled after this timer had already fired.
+			a.mu.Unlock()
+			return
+		}
+		a.statusTimer = nil
+		a.status = StatusIdle
+		a.mu.Unlock()
+		if onRevert != nil {
+			onRevert()
+		}
+	})
+	a.statusTimer = t
+}
+
+// stopStatusTimer cancels a pending timed status revert. Callers hold a.mu.
+func (a *Area) stopStatusTimer() {
+	if a.statusTimer != nil {
+		a.statusTimer.Stop()
+		a.statusTimer = nil
+	}
+}
+
 // Lock returns the area's lock type.
 func (a *Area) Lock() Lock {
 	a.mu.Lock()
@@ -661,10 +844,12 @@ func (a *Area) RemoveInvited(uid int) bool {
 	return true
 }
 
-// ClearInvited clears the area's invite list.
+// ClearInvited clears the area's invite list along with any outstanding join
+// codes, since a code is only a deferred invite.
 func (a *Area) ClearInvited() {
 	a.mu.Lock()
 	a.invited = make(map[int]struct{})
+	a.joinCodes = make(map[string]JoinCode)
 	a.mu.Unlock()
 }
 
@@ -687,11 +872,190 @@ func (a *Area) HasInvited(uid int) bool {
 	return exists
 }
 
-// Reset returns all area settings to their default values.
+// AddJoinCode registers a join code that can be redeemed uses times before expires.
+func (a *Area) AddJoinCode(code string, uses int, expires time.Time) {
+	a.mu.Lock()
+	a.joinCodes[code] = JoinCode{Uses: uses, Expires: expires}
+	a.mu.Unlock()
+}
+
+// HasJoinCode returns whether code is an outstanding join code for the area.
+func (a *Area) HasJoinCode(code string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, exists := a.joinCodes[code]
+	return exists
+}
+
+// RedeemJoinCode consumes one use of code and adds uid to the invite list.
+// It returns false if the code is unknown or expired; a code is removed once
+// its last use is spent.
+func (a *Area) RedeemJoinCode(code string, uid int, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	jc, exists := a.joinCodes[code]
+	if !exists {
+		return false
+	}
+	if !now.Before(jc.Expires) {
+		delete(a.joinCodes, code)
+		return false
+	}
+	jc.Uses--
+	if jc.Uses <= 0 {
+		delete(a.joinCodes, code)
+	} else {
+		a.joinCodes[code] = jc
+	}
+	a.invited[uid] = struct{}{}
+	return true
+}
+
+// SetPassword sets the password players can give to /move to add themselves
+// to the invite list. An empty password removes it.
+func (a *Area) SetPassword(pw string) {
+	a.mu.Lock()
+	a.password = pw
+	a.mu.Unlock()
+}
+
+// HasPassword returns whether the area has a password set.
+func (a *Area) HasPassword() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.password != ""
+}
+
+// CheckPassword returns whether pw matches the area's password. It is always
+// false when no password is set.
+func (a *Area) CheckPassword(pw string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.password != "" && pw == a.password
+}
+
+// PruneJoinCodes removes join codes that have expired by now and returns how many were removed.
+func (a *Area) PruneJoinCodes(now time.Time) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var n int
+	for code, jc := range a.joinCodes {
+		if !now.Before(jc.Expires) {
+			delete(a.joinCodes, code)
+			n++
+		}
+	}
+	return n
+}
+
+// QueuePlayer adds uid to the end of the area's /queue join queue. It returns
+// false if the UID is already queued.
+func (a *Area) QueuePlayer(uid int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, q := range a.joinQueue {
+		if q == uid {
+			return false
+		}
+	}
+	a.joinQueue = append(a.joinQueue, uid)
+	return true
+}
+
+// UnqueuePlayer removes uid from the area's join queue. It returns false if
+// the UID was not queued.
+func (a *Area) UnqueuePlayer(uid int) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, q := range a.joinQueue {
+		if q == uid {
+			a.joinQueue = append(a.joinQueue[:i], a.joinQueue[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Queue returns the UIDs in the area's join queue, in the order they joined it.
+func (a *Area) Queue() []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]int(nil), a.joinQueue...)
+}
+
+// DrainQueue empties the area's join queue and returns the UIDs it held, in
+// the order they joined it.
+func (a *Area) DrainQueue() []int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	q := a.joinQueue
+	a.joinQueue = nil
+	return q
+}
+
+// RequestSong appends req to the area's song queue. limit caps the queue length
+// (0 or less for no cap), and a player may not queue the same song twice in a
+// row while the first request is still pending.
+func (a *Area) RequestSong(req SongRequest, limit int) SongQueueResult {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if limit > 0 && len(a.songQueue) >= limit {
+		return SongQueueFull
+	}
+	for i := len(a.songQueue) - 1; i >= 0; i-- {
+		if a.songQueue[i].UID == req.UID {
+			if strings.EqualFold(a.songQueue[i].Song, req.Song) {
+				return SongDuplicate
+			}
+			break
+		}
+	}
+	a.songQueue = append(a.songQueue, req)
+	return SongQueued
+}
+
+// NextSong removes and returns the oldest request in the area's song queue.
+// It returns false if the queue is empty.
+func (a *Area) NextSong() (SongRequest, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(a.songQueue) == 0 {
+		return SongRequest{}, false
+	}
+	req := a.songQueue[0]
+	a.songQueue = a.songQueue[1:]
+	return req, true
+}
+
+// SongQueue returns the requests in the area's song queue, oldest first.
+func (a *Area) SongQueue() []SongRequest {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]SongRequest(nil), a.songQueue...)
+}
+
+// SetKeepEvidence sets whether Reset keeps the area's evidence, so evidence
+// that is saved to disk is not lost when the area empties out.
+func (a *Area) SetKeepEvidence(b bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.keepEvidence = b
+}
+
+// Reset returns all area settings to their default values. The evidence is
+// kept when SetKeepEvidence is on.
 func (a *Area) Reset() {
 	a.mu.Lock()
-	a.evidence = []string{}
+	if !a.keepEvidence {
+		a.evidence = []string{}
+		a.evidenceOwners = []string{}
+	}
 	a.invited = make(map[int]struct{})
+	a.joinCodes = make(map[string]JoinCode)
+	a.password = ""
+	a.joinQueue = nil
+	a.songQueue = nil
+	a.stopStatusTimer()
 	a.status = StatusIdle
 	a.lock = LockFree
 	a.adminLocked = false
@@ -715,11 +1079,15 @@ func (a *Area) Reset() {
 	a.tr.Index = 0
 	a.tr.State = TRIdle
 	a.tr.Testimony = []string{}
+	a.tstName = ""
+	a.tstStore = nil
 	a.activePoll = nil
 	a.pollVotes = nil
 	a.playerVotes = nil
 	a.spectateMode = false
 	a.spectateInvited = make(map[int]struct{})
+	a.capsFilter = CapsOff
+	a.maxMsgLen = 0
 	a.mu.Unlock()
 }
 
@@ -853,13 +1221,37 @@ func (a *Area) Doc() string {
 	return a.doc
 }
 
-// SetDoc sets the area's doc.
+// maxDocHistory is how many replaced docs an area remembers for /doc -prev.
+const maxDocHistory = 5
+
+// SetDoc sets the area's doc. A non-empty doc being replaced is pushed onto
+// the doc history so it can be brought back with RestorePreviousDoc.
 func (a *Area) SetDoc(s string) {
 	a.mu.Lock()
+	if a.doc != "" && a.doc != s {
+		a.docHistory = append(a.docHistory, a.doc)
+		if len(a.docHistory) > maxDocHistory {
+			a.docHistory = a.docHistory[len(a.docHistory)-maxDocHistory:]
+		}
+	}
 	a.doc = s
 	a.mu.Unlock()
 }
 
+// RestorePreviousDoc replaces the doc with the most recent entry in the doc
+// history and removes that entry. It returns false if the history is empty.
+func (a *Area) RestorePreviousDoc() (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	n := len(a.docHistory)
+	if n == 0 {
+		return "", false
+	}
+	a.doc = a.docHistory[n-1]
+	a.docHistory = a.docHistory[:n-1]
+	return a.doc, true
+}
+
 // Description returns the area's description shown to players on entry.
 func (a *Area) Description() string {
 	a.mu.Lock()
@@ -890,7 +1282,11 @@ func (a *Area) Testimony() []string {
 		if i == 0 {
 			continue
 		}
-		rl = append(rl, strings.Split(s, "#")[4])
+		fields := strings.Split(s, "#")
+		if len(fields) < 5 {
+			continue
+		}
+		rl = append(rl, fields[4])
 	}
 	return rl
 }
@@ -936,6 +1332,8 @@ func (evimod EvidenceMode) String() string {
 		return "cms"
 	case EviMods:
 		return "mods"
+	case EviOwner:
+		return "owner"
 	}
 	return ""
 }
@@ -985,11 +1383,18 @@ func (a *Area) SetLastPollTime(t time.Time) {
 	a.mu.Unlock()
 }
 
-// PollVotes returns the poll votes map.
+// PollVotes returns a copy of the per-option vote counts.
 func (a *Area) PollVotes() map[int]int {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.pollVotes
+	if a.pollVotes == nil {
+		return nil
+	}
+	votes := make(map[int]int, len(a.pollVotes))
+	for k, v := range a.pollVotes {
+		votes[k] = v
+	}
+	return votes
 }
 
 // SetPollVotes sets the poll votes map.
@@ -999,11 +1404,18 @@ func (a *Area) SetPollVotes(votes map[int]int) {
 	a.mu.Unlock()
 }
 
-// PlayerVotes returns the player votes map.
+// PlayerVotes returns a copy of each player's chosen option, keyed by UID.
 func (a *Area) PlayerVotes() map[int]int {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	return a.playerVotes
+	if a.playerVotes == nil {
+		return nil
+	}
+	votes := make(map[int]int, len(a.playerVotes))
+	for k, v := range a.playerVotes {
+		votes[k] = v
+	}
+	return votes
 }
 
 // SetPlayerVotes sets the player votes map.
@@ -1041,18 +1453,42 @@ func (a *Area) SetLastCoinflipTime(t time.Time) {
 	a.mu.Unlock()
 }
 
-// AddPlayerVote adds a player's vote to the poll.
-func (a *Area) AddPlayerVote(uid int, option int) {
+// ActiveTicTacToe returns the area's tic-tac-toe game, or nil.
+func (a *Area) ActiveTicTacToe() *TicTacToe {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.activeTicTacToe
+}
+
+// SetActiveTicTacToe sets the area's tic-tac-toe game.
+func (a *Area) SetActiveTicTacToe(g *TicTacToe) {
+	a.mu.Lock()
+	a.activeTicTacToe = g
+	a.mu.Unlock()
+}
+
+// AddPlayerVote records a player's vote in the poll. A player who already
+// voted has their vote moved from the old option to the new one. It returns
+// the player's previous option, or 0 if this is their first vote.
+func (a *Area) AddPlayerVote(uid int, option int) int {
 	a.mu.Lock()
+	defer a.mu.Unlock()
 	if a.playerVotes == nil {
 		a.playerVotes = make(map[int]int)
 	}
 	if a.pollVotes == nil {
 		a.pollVotes = make(map[int]int)
 	}
+	prev := a.playerVotes[uid]
+	if prev == option {
+		return prev
+	}
+	if prev != 0 {
+		a.pollVotes[prev]--
+	}
 	a.playerVotes[uid] = option
 	a.pollVotes[option]++
-	a.mu.Unlock()
+	return prev
 }
 
 // HasPlayerVoted checks if a player has already voted.
@@ -1264,6 +1700,37 @@ func (a *Area) SetPunishmentArea(v bool) {
 	a.punishmentArea = v
 }
 
+// CapsFilter returns how this area treats ALL-CAPS IC messages.
+func (a *Area) CapsFilter() CapsFilter {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.capsFilter
+}
+
+// SetCapsFilter sets how this area treats ALL-CAPS IC messages. Like other
+// CM toggles it reverts to CapsOff when the area empties.
+func (a *Area) SetCapsFilter(f CapsFilter) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.capsFilter = f
+}
+
+// MaxMsgLen returns the area's IC message length cap in characters, or 0 if
+// the server-wide max_message_length applies.
+func (a *Area) MaxMsgLen() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.maxMsgLen
+}
+
+// SetMaxMsgLen sets the area's IC message length cap. 0 restores the server
+// default.
+func (a *Area) SetMaxMsgLen(n int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.maxMsgLen = n
+}
+
 // RecordICMessage appends a decoded IC message for the given IPID to this
 // area's icwarp history. Messages older than 24 hours are pruned on each call.
 // At most 500 messages per IPID are kept to bound memory use.
@@ -0,0 +1,171 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package area
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// State is the serializable snapshot of an area's runtime settings, used to
+// carry them across a restart. Anything tied to a connection (CMs, invites,
+// HP, polls) is deliberately left out: UIDs do not survive a restart, so a
+// restored lock comes back with an empty invite list. Evidence is saved on
+// its own by SaveEvidence.
+type State struct {
+	Background   string       `json:"background"`
+	Status       Status       `json:"status"`
+	Doc          string       `json:"doc"`
+	Description  string       `json:"description"`
+	EvidenceMode EvidenceMode `json:"evidence_mode"`
+	AllowIniswap bool         `json:"allow_iniswap"`
+	NoInterrupt  bool         `json:"force_nointerrupt"`
+	AllowCMs     bool         `json:"allow_cms"`
+	ForceBGList  bool         `json:"force_bglist"`
+	LockBG       bool         `json:"lock_bg"`
+	LockMusic    bool         `json:"lock_music"`
+	MusicFrozen  bool         `json:"music_frozen"`
+	SpectateMode bool         `json:"spectate_mode"`
+	CapsFilter   CapsFilter   `json:"caps_filter"`
+	MaxMsgLen    int          `json:"max_msg_len"`
+
+	// The lock, admin lock and password, and Testimony are only filled in
+	// when the caller opts in.
+	Lock        Lock     `json:"lock,omitempty"`
+	AdminLocked bool     `json:"admin_locked,omitempty"`
+	Password    string   `json:"password,omitempty"`
+	Testimony   []string `json:"testimony,omitempty"`
+}
+
+// Snapshot returns the area's current runtime settings. The recorded
+// testimony and the lock state are included only when requested.
+func (a *Area) Snapshot(withTestimony, withLocks bool) State {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s := State{
+		Background:   a.data.Bg,
+		Status:       a.status,
+		Doc:          a.doc,
+		Description:  a.description,
+		EvidenceMode: a.evi_mode,
+		AllowIniswap: a.data.Allow_iniswap,
+		NoInterrupt:  a.data.Force_noint,
+		AllowCMs:     a.data.Allow_cms,
+		ForceBGList:  a.data.Force_bglist,
+		LockBG:       a.data.Lock_bg,
+		LockMusic:    a.data.Lock_music,
+		MusicFrozen:  a.musicFrozen,
+		SpectateMode: a.spectateMode,
+		CapsFilter:   a.capsFilter,
+		MaxMsgLen:    a.maxMsgLen,
+	}
+	if withTestimony {
+		s.Testimony = append([]string(nil), a.tr.Testimony...)
+	}
+	if withLocks {
+		s.Lock, s.AdminLocked, s.Password = a.lock, a.adminLocked, a.password
+	}
+	return s
+}
+
+// Restore applies a snapshot taken by Snapshot. The testimony and lock state
+// are only replaced when the matching flag is set, so a snapshot saved
+// without them leaves the area's own defaults alone. A restored testimony
+// starts idle at its first statement.
+func (a *Area) Restore(s State, withTestimony, withLocks bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.data.Bg = s.Background
+	a.stopStatusTimer()
+	a.status = s.Status
+	a.doc = s.Doc
+	a.description = s.Description
+	a.evi_mode = s.EvidenceMode
+	a.data.Allow_iniswap = s.AllowIniswap
+	a.data.Force_noint = s.NoInterrupt
+	a.data.Allow_cms = s.AllowCMs
+	a.data.Force_bglist = s.ForceBGList
+	a.data.Lock_bg = s.LockBG
+	a.data.Lock_music = s.LockMusic
+	a.musicFrozen = s.MusicFrozen
+	a.spectateMode = s.SpectateMode
+	a.capsFilter = s.CapsFilter
+	a.maxMsgLen = s.MaxMsgLen
+	if withTestimony {
+		a.tr.Testimony = append([]string{}, s.Testimony...)
+		a.tr.Index = 0
+		a.tr.State = TRIdle
+	}
+	if withLocks {
+		a.lock, a.adminLocked, a.password = s.Lock, s.AdminLocked, s.Password
+	}
+}
+
+// evidenceFile is the on-disk form of an area's evidence.
+type evidenceFile struct {
+	Evidence []string `json:"evidence"`
+	Owners   []string `json:"owners,omitempty"`
+}
+
+// SaveEvidence writes the area's evidence, the same strings sent in the LE
+// packet, and the IPID that added each piece to path as JSON. The file is
+// written to a temporary name first and renamed into place so a crash
+// mid-write never leaves a truncated file behind.
+func (a *Area) SaveEvidence(path string) error {
+	a.mu.Lock()
+	f := evidenceFile{
+		Evidence: append([]string{}, a.evidence...),
+		Owners:   append([]string(nil), a.evidenceOwners...),
+	}
+	a.mu.Unlock()
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadEvidence replaces the area's evidence with the list SaveEvidence wrote
+// to path. The area starts with no evidence when the file is missing, which
+// is not an error, or when it is corrupt, in which case the error is
+// returned for the caller to log.
+func (a *Area) LoadEvidence(path string) error {
+	var f evidenceFile
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = json.Unmarshal(data, &f)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.evidence, a.evidenceOwners = []string{}, []string{}
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	a.evidence = append(a.evidence, f.Evidence...)
+	// Owners run parallel to evidence; pad or trim a mismatched file.
+	a.evidenceOwners = make([]string, len(a.evidence))
+	copy(a.evidenceOwners, f.Owners)
+	return nil
+}
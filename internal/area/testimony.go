@@ -18,10 +18,22 @@ package area
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/MangosArentLiterature/Athena/internal/packet"
 )
 
+// DefaultTestimony is the name of an area's testimony until a CM records or
+// switches to a named one.
+const DefaultTestimony = "default"
+
+// TestimonyInfo describes one of an area's stored testimonies.
+type TestimonyInfo struct {
+	Name       string
+	Statements int  // statements after the title
+	Active     bool // whether this is the testimony the recorder is using
+}
+
 // TstState returns the testimony recorder's current state.
 func (a *Area) TstState() TRState {
 	a.mu.Lock()
@@ -155,6 +167,24 @@ func (a *Area) TstClear() {
 	a.tr.Index = 0
 }
 
+// TstStatements returns a copy of the recorded testimony as raw IC
+// messages, title first, in the form TstLoad accepts.
+func (a *Area) TstStatements() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.tr.Testimony...)
+}
+
+// TstLoad replaces the recorded testimony with statements from
+// TstStatements and leaves the recorder idle at the title.
+func (a *Area) TstLoad(statements []string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.tr.Testimony = append([]string{}, statements...)
+	a.tr.Index = 0
+	a.tr.State = TRIdle
+}
+
 // TstLen returns the length of the testimony.
 func (a *Area) TstLen() int {
 	a.mu.Lock()
@@ -174,3 +204,70 @@ func (a *Area) TstJump(i int) {
 	}
 	a.tr.Index = i
 }
+
+// TstName returns the name of the testimony the recorder is using.
+func (a *Area) TstName() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.activeTstName()
+}
+
+func (a *Area) activeTstName() string {
+	if a.tstName == "" {
+		return DefaultTestimony
+	}
+	return a.tstName
+}
+
+// TstSwitch stores the active testimony under its name and makes name the
+// active one, leaving the recorder idle at its title. A name that has not
+// been stored starts empty when create is true; otherwise TstSwitch returns
+// false and changes nothing.
+func (a *Area) TstSwitch(name string, create bool) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	current := a.activeTstName()
+	if name == current {
+		a.tr.Index = 0
+		a.tr.State = TRIdle
+		return true
+	}
+	next, ok := a.tstStore[name]
+	if !ok && !create {
+		return false
+	}
+	if a.tstStore == nil {
+		a.tstStore = make(map[string][]string)
+	}
+	if len(a.tr.Testimony) > 0 {
+		a.tstStore[current] = a.tr.Testimony
+	}
+	delete(a.tstStore, name)
+	if next == nil {
+		next = []string{}
+	}
+	a.tr.Testimony = next
+	a.tr.Index = 0
+	a.tr.State = TRIdle
+	a.tstName = name
+	return true
+}
+
+// TstList returns the area's testimonies, including the active one, sorted
+// by name.
+func (a *Area) TstList() []TestimonyInfo {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	count := func(t []string) int {
+		if len(t) == 0 {
+			return 0
+		}
+		return len(t) - 1
+	}
+	list := []TestimonyInfo{{Name: a.activeTstName(), Statements: count(a.tr.Testimony), Active: true}}
+	for name, t := range a.tstStore {
+		list = append(list, TestimonyInfo{Name: name, Statements: count(t)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
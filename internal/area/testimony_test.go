@@ -107,3 +107,42 @@ func TestTestimonyJumpClamp(t *testing.T) {
 		t.Fatalf("index %d should not be negative", a.CurrentTstIndex())
 	}
 }
+
+func TestTestimonySwitch(t *testing.T) {
+	a := NewArea(AreaData{}, 50, 0, EviAny)
+	if a.TstName() != DefaultTestimony {
+		t.Fatalf("TstName() = %q, want %q", a.TstName(), DefaultTestimony)
+	}
+	a.TstLoad([]string{"title", "one"})
+
+	if a.TstSwitch("Witness B", false) {
+		t.Fatal("switching to an unknown testimony without create should fail")
+	}
+	if !a.TstSwitch("Witness B", true) || a.TstName() != "Witness B" || a.TstLen() != 0 {
+		t.Fatalf("creating Witness B should start an empty testimony, got %q with %d entries", a.TstName(), a.TstLen())
+	}
+	a.TstLoad([]string{"title", "one", "two"})
+	a.SetTstState(TRPlayback)
+
+	if !a.TstSwitch(DefaultTestimony, false) || a.TstLen() != 2 || a.TstState() != TRIdle {
+		t.Fatalf("switching back should restore the default testimony idle, got %d entries in state %v", a.TstLen(), a.TstState())
+	}
+	list := a.TstList()
+	if len(list) != 2 || list[0].Name != "Witness B" || list[0].Statements != 2 || list[0].Active ||
+		list[1].Name != DefaultTestimony || list[1].Statements != 1 || !list[1].Active {
+		t.Errorf("TstList() = %+v", list)
+	}
+
+	a.Reset()
+	if a.TstName() != DefaultTestimony || len(a.TstList()) != 1 {
+		t.Errorf("Reset should drop stored testimonies, got %+v", a.TstList())
+	}
+}
+
+func TestTestimonySkipsMalformed(t *testing.T) {
+	a := NewArea(AreaData{}, 50, 0, EviAny)
+	a.TstLoad([]string{"title", "plain text", "0#-#Phoenix#normal#hello#wit"})
+	if got := a.Testimony(); len(got) != 1 || got[0] != "hello" {
+		t.Errorf("Testimony() = %q, want [\"hello\"]", got)
+	}
+}
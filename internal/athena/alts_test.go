@@ -0,0 +1,68 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+)
+
+func TestAlts(t *testing.T) {
+	newTestClients(t)
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	t.Cleanup(setupTestAreas([]*area.Area{lobby}))
+
+	newClient := func(uid int, ipid, hdid, ooc string) *Client {
+		c := &Client{conn: &captureConn{}, uid: uid, ipid: ipid, hdid: hdid, char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+		c.SetOocName(ooc)
+		c.SetArea(lobby)
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+		return c
+	}
+	conn := &captureConn{}
+	mod := newClient(1, "mod-ip", "mod-hd", "Mod")
+	mod.conn = conn
+	newClient(2, "evader-ip", "evader-hd", "Evader")
+	newClient(3, "evader-ip", "other-hd", "Roommate")
+	newClient(4, "vpn-ip", "evader-hd", "VPNHopper")
+	newClient(5, "clean-ip", "clean-hd", "Clean")
+
+	cmdAlts(mod, []string{"2"}, "")
+	out := conn.String()
+	ipidPart, hdidPart, ok := strings.Cut(out, "Same HDID")
+	if !ok {
+		t.Fatalf("expected separate IPID and HDID sections, got %q", out)
+	}
+	if !strings.Contains(ipidPart, "[3] ") || strings.Contains(ipidPart, "[4] ") {
+		t.Errorf("IPID section = %q, want only UID 3", ipidPart)
+	}
+	if !strings.Contains(hdidPart, "[4] ") || strings.Contains(hdidPart, "[3] ") {
+		t.Errorf("HDID section = %q, want only UID 4", hdidPart)
+	}
+	if strings.Contains(out, "[2] ") || strings.Contains(out, "[5] ") {
+		t.Errorf("output should list neither the target nor unrelated players: %q", out)
+	}
+
+	seen := len(conn.String())
+	cmdAlts(mod, []string{"5"}, "")
+	if got := conn.String()[seen:]; !strings.Contains(got, "No connected alts found for UID 5.") {
+		t.Errorf("expected explicit no-alts message, got %q", got)
+	}
+}
@@ -0,0 +1,62 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+// TestAreaMotdIsAreaDescAlias verifies /areamotd sets the area's entry
+// description, which is sent once to players moving into the area.
+func TestAreaMotdIsAreaDescAlias(t *testing.T) {
+	initCommands()
+	newTestClients(t)
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{lobby, court})()
+
+	conn := &captureConn{}
+	c := &Client{conn: conn, uid: 1, ipid: "ipid", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1},
+		perms: permissions.PermissionField["MODIFY_AREA"]}
+	c.SetArea(court)
+	clients.AddClient(c)
+	clients.RegisterUID(c)
+
+	motd, ok := Commands["areamotd"]
+	if !ok {
+		t.Fatal("areamotd command is not registered in Commands map")
+	}
+	motd.handler(c, []string{"Court", "is", "in", "session", "at", "8."}, motd.usage)
+	if got := court.Description(); got != "Court is in session at 8." {
+		t.Fatalf("/areamotd set description %q", got)
+	}
+
+	if !c.ChangeArea(lobby) {
+		t.Fatal("ChangeArea(lobby) failed")
+	}
+	prev := len(conn.String())
+	if !c.ChangeArea(court) {
+		t.Fatal("ChangeArea(court) failed")
+	}
+	if n := strings.Count(conn.String()[prev:], "Court is in session at 8."); n != 1 {
+		t.Errorf("entering the court should send the description once, got %d: %q", n, conn.String()[prev:])
+	}
+}
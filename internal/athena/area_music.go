@@ -0,0 +1,56 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+	"github.com/MangosArentLiterature/Athena/internal/sliceutil"
+)
+
+// areaMusicList returns the music list offered in a: the global list followed
+// by any of the area's own tracks that aren't already in it.
+func areaMusicList(a *area.Area) []string {
+	global := getMusicList()
+	extra := a.Music()
+	if len(extra) == 0 {
+		return global
+	}
+	list := make([]string, len(global), len(global)+len(extra))
+	copy(list, global)
+	for _, m := range extra {
+		if !sliceutil.ContainsString(list, m) {
+			list = append(list, m)
+		}
+	}
+	return list
+}
+
+// sendAreaMusic sends the client the music list for to when it differs from
+// the one they had in from. from may be nil for a fresh join, where the
+// client already has the global list from SM.
+func sendAreaMusic(client *Client, from, to *area.Area) {
+	if len(to.Music()) == 0 && (from == nil || len(from.Music()) == 0) {
+		return
+	}
+	list := areaMusicList(to)
+	items := make([]string, len(list))
+	for i, m := range list {
+		items[i] = encode(m)
+	}
+	client.Send(&packet.FM{Items: items})
+}
@@ -0,0 +1,86 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// TestMovePasswordAdmitsToLockedArea verifies that /move <area> <password>
+// lets a player into a locked area only with the current password, and that
+// /areainfo never reveals it.
+func TestMovePasswordAdmitsToLockedArea(t *testing.T) {
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	vault := area.NewArea(area.AreaData{Name: "Vault"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{lobby, vault})()
+	vault.SetLock(area.LockLocked)
+
+	conn := &captureConn{}
+	c := &Client{conn: conn, uid: 1, ipid: "ipid", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+	c.SetArea(lobby)
+	clients.AddClient(c)
+	clients.RegisterUID(c)
+
+	vault.SetPassword("opensesame")
+	cmdMove(c, []string{"1", "wrong"}, "usage")
+	if c.Area() != lobby || !strings.Contains(conn.String(), "Incorrect area password.") {
+		t.Fatalf("a wrong password should be refused: %q", conn.String())
+	}
+	if !strings.Contains(strings.Join(lobby.Buffer(), "\n"), "Gave a wrong password for Vault.") {
+		t.Error("a wrong password should be logged to the area buffer")
+	}
+
+	// Guessing again straight away is refused, even with the right password.
+	cmdMove(c, []string{"Vault", "opensesame"}, "usage")
+	if c.Area() != lobby || !strings.Contains(conn.String(), "before trying another area password") {
+		t.Fatalf("a guess inside the cooldown should be refused: %q", conn.String())
+	}
+
+	c.mu.Lock()
+	c.lastAreaPassFail = time.Now().Add(-areaPassCooldown)
+	c.mu.Unlock()
+	cmdMove(c, []string{"Vault", "opensesame"}, "usage")
+	if c.Area() != vault || !vault.HasInvited(1) {
+		t.Fatal("the correct password should invite and move the player")
+	}
+
+	prev := len(conn.String())
+	cmdAreaInfo(c, nil, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "Password: set") || strings.Contains(out, "opensesame") {
+		t.Errorf("/areainfo should show only that a password is set: %q", out)
+	}
+
+	// Leaving empties the vault, which resets its lock and invites.
+	c.ChangeArea(lobby)
+	vault.SetLock(area.LockLocked)
+	vault.SetPassword("opensesame")
+	vault.SetPassword("")
+	prev = len(conn.String())
+	cmdMove(c, []string{"1", "opensesame"}, "usage")
+	if c.Area() != lobby || !strings.Contains(conn.String()[prev:], "Incorrect area password.") {
+		t.Errorf("a cleared password should stop working: %q", conn.String()[prev:])
+	}
+}
@@ -0,0 +1,220 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+const areaStateFile = "area_state.json"
+
+// areaStateMu serialises snapshot writes; the save loop, evidence changes and
+// shutdown can all save at once and would otherwise share the temporary file.
+var areaStateMu sync.Mutex
+
+// areaStatePath returns where persist_area_state keeps its snapshot.
+func areaStatePath() string {
+	return filepath.Join(settings.ConfigPath, areaStateFile)
+}
+
+// saveAreaState writes a snapshot of every area to path, keyed by area name.
+// The file is written to a temporary name first and renamed into place so a
+// crash mid-write never leaves a truncated snapshot behind.
+func saveAreaState(path string, list []*area.Area, withTestimony, withLocks bool) error {
+	states := make(map[string]area.State, len(list))
+	for _, a := range list {
+		states[a.Name()] = a.Snapshot(withTestimony, withLocks)
+	}
+	data, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// restoreAreaState applies a snapshot written by saveAreaState to the areas
+// with matching names. A missing file is not an error, and a corrupt one is
+// logged and skipped so every area starts from its configured defaults. Areas
+// that no longer exist are ignored, and a saved background that is no longer
+// in backgrounds.txt is replaced by the area's configured one.
+func restoreAreaState(path string, list []*area.Area, bgSet map[string]struct{}, withTestimony, withLocks bool) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var states map[string]area.State
+	if err := json.Unmarshal(data, &states); err != nil {
+		logger.LogWarningf("Ignoring corrupt area state file %v: %v", path, err)
+		return nil
+	}
+	restored := 0
+	for _, a := range list {
+		s, ok := states[a.Name()]
+		if !ok {
+			continue
+		}
+		if _, valid := bgSet[s.Background]; !valid {
+			s.Background = a.Background()
+		}
+		a.Restore(s, withTestimony, withLocks)
+		restored++
+	}
+	logger.LogInfof("Restored saved state for %d area(s).", restored)
+	return nil
+}
+
+// startAreaStateLoop periodically saves area state while the server runs.
+func startAreaStateLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		persistAreaState(areas)
+	}
+}
+
+// persistAreaState saves area state using the configured opt-ins, logging
+// rather than returning any failure.
+func persistAreaState(list []*area.Area) {
+	areaStateMu.Lock()
+	defer areaStateMu.Unlock()
+	if err := saveAreaState(areaStatePath(), list, config.PersistAreaTestimony, config.PersistAreaLocks); err != nil {
+		logger.LogErrorf("Failed to save area state: %v", err)
+	}
+}
+
+// evidenceDirPath resolves the evidence_dir setting against the config
+// directory.
+func evidenceDirPath(dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(settings.ConfigPath, dir)
+}
+
+// evidenceFileRe matches the characters that are not safe in an evidence
+// file name.
+var evidenceFileRe = regexp.MustCompile(`[^A-Za-z0-9 _-]`)
+
+// evidencePath returns the file in dir an area's evidence is saved to: the
+// area name with unsafe characters replaced, plus a hash of the full name so
+// names that only differ in those characters never share a file.
+func evidencePath(dir string, a *area.Area) string {
+	sum := sha256.Sum256([]byte(a.Name()))
+	name := evidenceFileRe.ReplaceAllString(a.Name(), "_") + "-" + hex.EncodeToString(sum[:4])
+	return filepath.Join(evidenceDirPath(dir), name+".json")
+}
+
+// loadAreaEvidence loads each area's evidence saved in dir at startup. An
+// area whose file is corrupt is logged and starts with no evidence. Areas
+// sharing a name would share a file, so nothing is loaded and an error is
+// returned when two do.
+func loadAreaEvidence(dir string, list []*area.Area) error {
+	seen := make(map[string]string, len(list))
+	for _, a := range list {
+		path := evidencePath(dir, a)
+		if other, ok := seen[path]; ok {
+			return fmt.Errorf("areas %q and %q would share the evidence file %v", other, a.Name(), path)
+		}
+		seen[path] = a.Name()
+	}
+	for _, a := range list {
+		if err := a.LoadEvidence(evidencePath(dir, a)); err != nil {
+			logger.LogWarningf("Ignoring corrupt evidence file for %v: %v", a.Name(), err)
+		}
+	}
+	return nil
+}
+
+// saveAreaEvidence writes the evidence of each area in list to its file in
+// dir, logging rather than returning any failure.
+func saveAreaEvidence(dir string, list []*area.Area) {
+	evidenceSaveMu.Lock()
+	defer evidenceSaveMu.Unlock()
+	if err := os.MkdirAll(evidenceDirPath(dir), 0755); err != nil {
+		logger.LogErrorf("Failed to create the evidence directory: %v", err)
+		return
+	}
+	for _, a := range list {
+		if err := a.SaveEvidence(evidencePath(dir, a)); err != nil {
+			logger.LogErrorf("Failed to save evidence for %v: %v", a.Name(), err)
+		}
+	}
+}
+
+// evidenceSaveDelay is how long saveEvidenceChange waits before writing, so a
+// burst of evidence packets costs one save instead of one per packet.
+var evidenceSaveDelay = 2 * time.Second
+
+var (
+	// evidenceSaveMu serialises evidence writes, which would otherwise share
+	// an area's temporary file.
+	evidenceSaveMu sync.Mutex
+
+	// evidencePendingMu guards evidencePending, the areas whose evidence
+	// changed since the last scheduled save.
+	evidencePendingMu sync.Mutex
+	evidencePending   = map[*area.Area]struct{}{}
+)
+
+// saveEvidenceChange schedules a save of a's evidence shortly after it
+// changes when evidence persistence is on. Changes made while a save is
+// pending are picked up by that save.
+func saveEvidenceChange(a *area.Area) {
+	if config == nil || !config.PersistAreaEvidence {
+		return
+	}
+	evidencePendingMu.Lock()
+	defer evidencePendingMu.Unlock()
+	if len(evidencePending) == 0 {
+		time.AfterFunc(evidenceSaveDelay, flushEvidenceSaves)
+	}
+	evidencePending[a] = struct{}{}
+}
+
+// flushEvidenceSaves saves the evidence of every area with a pending change.
+func flushEvidenceSaves() {
+	evidencePendingMu.Lock()
+	list := make([]*area.Area, 0, len(evidencePending))
+	for a := range evidencePending {
+		list = append(list, a)
+	}
+	evidencePending = map[*area.Area]struct{}{}
+	evidencePendingMu.Unlock()
+	if len(list) > 0 && config != nil {
+		saveAreaEvidence(config.EvidenceDir, list)
+	}
+}
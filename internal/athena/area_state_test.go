@@ -0,0 +1,193 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestAreaStateSaveRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), areaStateFile)
+	saved := []*area.Area{makeTestArea("Lobby"), makeTestArea("Courtroom")}
+	saved[0].SetBackground("gone")
+	saved[1].SetBackground("gs4")
+	saved[1].SetStatus(area.StatusRecess)
+	saved[1].SetIniswapAllowed(true)
+	if err := saveAreaState(path, saved, false, false); err != nil {
+		t.Fatalf("saveAreaState: %v", err)
+	}
+
+	fresh := []*area.Area{makeTestArea("Lobby"), makeTestArea("Courtroom")}
+	bgSet := map[string]struct{}{"default": {}, "gs4": {}}
+	if err := restoreAreaState(path, fresh, bgSet, false, false); err != nil {
+		t.Fatalf("restoreAreaState: %v", err)
+	}
+	if fresh[1].Background() != "gs4" || fresh[1].Status() != area.StatusRecess || !fresh[1].IniswapAllowed() {
+		t.Error("Courtroom settings were not restored")
+	}
+	if fresh[0].Background() != "default" {
+		t.Errorf("unknown saved background should fall back, got %q", fresh[0].Background())
+	}
+}
+
+func TestAreaStateRestoreMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), areaStateFile)
+	if err := restoreAreaState(path, []*area.Area{makeTestArea("Lobby")}, nil, false, false); err != nil {
+		t.Errorf("a missing snapshot should not be an error, got %v", err)
+	}
+}
+
+func TestAreaStateRestoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), areaStateFile)
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	a := makeTestArea("Lobby")
+	a.SetBackground("gs4")
+	if err := restoreAreaState(path, []*area.Area{a}, nil, false, false); err != nil {
+		t.Errorf("a corrupt snapshot should be skipped, got %v", err)
+	}
+	if a.Background() != "gs4" {
+		t.Errorf("a corrupt snapshot should leave the area alone, got background %q", a.Background())
+	}
+}
+
+// TestEvidenceChangeSavesEvidence verifies that evidence changes are saved
+// to the area's own file once per burst, only when evidence persistence is
+// enabled, whether or not area state is persisted.
+func TestEvidenceChangeSavesEvidence(t *testing.T) {
+	origConfig, origPath := config, settings.ConfigPath
+	t.Cleanup(func() { config, settings.ConfigPath = origConfig, origPath })
+	settings.ConfigPath = t.TempDir()
+	origDelay := evidenceSaveDelay
+	t.Cleanup(func() { evidenceSaveDelay = origDelay })
+	evidenceSaveDelay = time.Hour // the test flushes by hand
+	lobby := makeTestArea("Lobby")
+	lobby.AddEvidence("knife&sharp&knife.png")
+
+	config = &settings.Config{}
+	config.EvidenceDir = "evidence"
+	saveEvidenceChange(lobby)
+	flushEvidenceSaves()
+	if _, err := os.Stat(evidencePath(config.EvidenceDir, lobby)); err == nil {
+		t.Fatal("evidence should not be saved unless persist_area_evidence is on")
+	}
+
+	config.PersistAreaEvidence = true
+	saveEvidenceChange(lobby)
+	saveEvidenceChange(lobby)
+	if _, err := os.Stat(evidencePath(config.EvidenceDir, lobby)); err == nil {
+		t.Fatal("the save should wait for the burst to end")
+	}
+	flushEvidenceSaves()
+	fresh := makeTestArea("Lobby")
+	if err := loadAreaEvidence(config.EvidenceDir, []*area.Area{fresh}); err != nil {
+		t.Fatalf("loadAreaEvidence: %v", err)
+	}
+	if ev := fresh.Evidence(); len(ev) != 1 || ev[0] != "knife&sharp&knife.png" {
+		t.Errorf("loaded evidence = %v, want the knife", ev)
+	}
+}
+
+// TestAreaEvidenceFiles verifies that each area's evidence goes to its own
+// file, that a missing or corrupt file starts the area empty, and that an
+// area keeping its evidence through a reset saves what players still see.
+func TestAreaEvidenceFiles(t *testing.T) {
+	dir := t.TempDir()
+	lobby, court := makeTestArea("Lobby"), makeTestArea("Court/Room 1")
+	lobby.AddEvidence("knife&sharp&knife.png")
+	court.AddEvidence("badge&shiny&badge.png")
+	saveAreaEvidence(dir, []*area.Area{lobby, court})
+	base := filepath.Base(evidencePath(dir, court))
+	if !strings.HasPrefix(base, "Court_Room 1-") || !strings.HasSuffix(base, ".json") {
+		t.Errorf("evidence file = %q, want a safe name", base)
+	}
+	for _, name := range []string{"Court:Room 1", "Court?Room 1"} {
+		if evidencePath(dir, makeTestArea(name)) == evidencePath(dir, court) {
+			t.Errorf("%q shares the evidence file of %q", name, court.Name())
+		}
+	}
+	if err := loadAreaEvidence(dir, []*area.Area{makeTestArea("Twin"), makeTestArea("Twin")}); err == nil {
+		t.Error("areas sharing a name should be refused")
+	}
+
+	restarted := []*area.Area{makeTestArea("Lobby"), makeTestArea("Court/Room 1"), makeTestArea("New")}
+	if err := os.WriteFile(evidencePath(dir, restarted[1]), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := loadAreaEvidence(dir, restarted); err != nil {
+		t.Fatalf("loadAreaEvidence: %v", err)
+	}
+	if ev := restarted[0].Evidence(); len(ev) != 1 || ev[0] != "knife&sharp&knife.png" {
+		t.Errorf("Lobby evidence = %v, want the knife", ev)
+	}
+	if len(restarted[1].Evidence()) != 0 || len(restarted[2].Evidence()) != 0 {
+		t.Error("a corrupt or missing evidence file should start the area empty")
+	}
+
+	restarted[0].SetKeepEvidence(true)
+	restarted[0].Reset()
+	if ev := restarted[0].Evidence(); len(ev) != 1 {
+		t.Errorf("live evidence after the area emptied = %v, want the knife kept", ev)
+	}
+	restarted[0].AddEvidence("badge&shiny&badge.png")
+	saveAreaEvidence(dir, restarted[:1])
+	check := makeTestArea("Lobby")
+	if err := loadAreaEvidence(dir, []*area.Area{check}); err != nil {
+		t.Fatalf("loadAreaEvidence: %v", err)
+	}
+	if ev := check.Evidence(); len(ev) != 2 {
+		t.Errorf("saved evidence = %v, want the knife and the badge", ev)
+	}
+}
+
+// TestAreaStateRestoreLocks verifies that a restored area comes back
+// unlocked unless persist_area_locks is on.
+func TestAreaStateRestoreLocks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), areaStateFile)
+	before := makeTestArea("Lobby")
+	before.SetLock(area.LockLocked)
+	before.SetPassword("hunter2")
+	if err := saveAreaState(path, []*area.Area{before}, false, false); err != nil {
+		t.Fatalf("saveAreaState: %v", err)
+	}
+	restarted := makeTestArea("Lobby")
+	if err := restoreAreaState(path, []*area.Area{restarted}, nil, false, true); err != nil {
+		t.Fatalf("restoreAreaState: %v", err)
+	}
+	if restarted.Lock() != area.LockFree || restarted.HasPassword() {
+		t.Errorf("a lock saved without persist_area_locks came back: %v", restarted.Lock())
+	}
+
+	if err := saveAreaState(path, []*area.Area{before}, false, true); err != nil {
+		t.Fatalf("saveAreaState: %v", err)
+	}
+	restarted = makeTestArea("Lobby")
+	if err := restoreAreaState(path, []*area.Area{restarted}, nil, false, true); err != nil {
+		t.Fatalf("restoreAreaState: %v", err)
+	}
+	if restarted.Lock() != area.LockLocked || !restarted.HasPassword() {
+		t.Errorf("restored lock = %v, want locked with its password", restarted.Lock())
+	}
+}
@@ -0,0 +1,135 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// checkAreaTemplateBackgrounds drops template backgrounds that are not in
+// backgrounds.txt, so applying the template never sets an unknown BG.
+func checkAreaTemplateBackgrounds(templates map[string]settings.AreaTemplate, bgSet map[string]struct{}) {
+	for name, t := range templates {
+		if t.Background == "" {
+			continue
+		}
+		if _, ok := bgSet[t.Background]; !ok {
+			logger.LogWarningf("Area template %v has an unknown background %q, ignoring it.", name, t.Background)
+			t.Background = ""
+			templates[name] = t
+		}
+	}
+}
+
+// applyAreaTemplate copies a template's settings onto an area through its
+// setters. It reports whether the background changed so callers can push
+// the new BG to the area.
+func applyAreaTemplate(a *area.Area, t settings.AreaTemplate) (bgChanged bool) {
+	if t.Background != "" && t.Background != a.Background() {
+		a.SetBackground(t.Background)
+		bgChanged = true
+	}
+	switch t.EvidenceMode {
+	case "any":
+		a.SetEvidenceMode(area.EviAny)
+	case "cms":
+		a.SetEvidenceMode(area.EviCMs)
+	case "mods":
+		a.SetEvidenceMode(area.EviMods)
+	case "owner":
+		a.SetEvidenceMode(area.EviOwner)
+	}
+	if t.AllowIniswap != nil {
+		a.SetIniswapAllowed(*t.AllowIniswap)
+	}
+	if t.NoInterrupt != nil {
+		a.SetNoInterrupt(*t.NoInterrupt)
+	}
+	if t.LockBG != nil {
+		a.SetLockBG(*t.LockBG)
+	}
+	if t.LockMusic != nil {
+		a.SetLockMusic(*t.LockMusic)
+	}
+	return bgChanged
+}
+
+// describeAreaTemplate summarises the settings a template changes.
+func describeAreaTemplate(t settings.AreaTemplate) string {
+	var parts []string
+	if t.Background != "" {
+		parts = append(parts, "bg="+t.Background)
+	}
+	if t.EvidenceMode != "" {
+		parts = append(parts, "evidence="+t.EvidenceMode)
+	}
+	for _, f := range []struct {
+		key string
+		v   *bool
+	}{
+		{"iniswap", t.AllowIniswap},
+		{"nointerrupt", t.NoInterrupt},
+		{"lock_bg", t.LockBG},
+		{"lock_music", t.LockMusic},
+	} {
+		if f.v != nil {
+			parts = append(parts, fmt.Sprintf("%v=%t", f.key, *f.v))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Handles /areatemplates
+
+func cmdAreaTemplates(client *Client, args []string, usage string) {
+	if len(config.AreaTemplates) == 0 {
+		client.SendServerMessage("No area templates are configured.")
+		return
+	}
+	if len(args) == 0 {
+		names := make([]string, 0, len(config.AreaTemplates))
+		for name := range config.AreaTemplates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		var b strings.Builder
+		b.WriteString("Area templates:")
+		for _, name := range names {
+			fmt.Fprintf(&b, "\n%v: %v", name, describeAreaTemplate(config.AreaTemplates[name]))
+		}
+		client.SendServerMessage(b.String())
+		return
+	}
+	name := args[0]
+	t, ok := config.AreaTemplates[name]
+	if !ok {
+		client.SendServerMessage(fmt.Sprintf("No area template named %v.\n%v", name, usage))
+		return
+	}
+	if applyAreaTemplate(client.Area(), t) {
+		broadcastToArea(client.Area(), &packet.BN{Background: t.Background})
+	}
+	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v applied the %v area template.", client.OOCName(), name))
+	addToBuffer(client, "CMD", fmt.Sprintf("Applied area template %v.", name), false)
+}
@@ -0,0 +1,63 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestApplyAreaTemplate(t *testing.T) {
+	yes, no := true, false
+	a := makeTestArea("Courtroom")
+	a.SetNoInterrupt(true)
+
+	changed := applyAreaTemplate(a, settings.AreaTemplate{
+		Background:   "gs4",
+		EvidenceMode: "mods",
+		AllowIniswap: &no,
+		LockMusic:    &yes,
+	})
+	if !changed || a.Background() != "gs4" {
+		t.Errorf("background = %q (changed %t), want gs4", a.Background(), changed)
+	}
+	if a.EvidenceMode() != area.EviMods || a.IniswapAllowed() || !a.LockMusic() {
+		t.Error("template settings were not applied")
+	}
+	if !a.NoInterrupt() {
+		t.Error("a key missing from the template must leave the area alone")
+	}
+	if applyAreaTemplate(a, settings.AreaTemplate{Background: "gs4"}) {
+		t.Error("re-applying the same background should not report a change")
+	}
+}
+
+func TestCheckAreaTemplateBackgrounds(t *testing.T) {
+	templates := map[string]settings.AreaTemplate{
+		"court": {Background: "gs4"},
+		"bad":   {Background: "missing", EvidenceMode: "any"},
+	}
+	checkAreaTemplateBackgrounds(templates, map[string]struct{}{"gs4": {}})
+	if templates["court"].Background != "gs4" {
+		t.Error("a known background should be kept")
+	}
+	if bad := templates["bad"]; bad.Background != "" || bad.EvidenceMode != "any" {
+		t.Errorf("unknown background should be dropped, rest kept; got %+v", bad)
+	}
+}
@@ -19,12 +19,10 @@ package athena
 import (
 	"bufio"
 	"fmt"
-	"math/rand"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 	"unicode/utf8"
 
@@ -59,19 +57,9 @@ const (
 // autoModAction caches the parsed action so autoModCheck is allocation-free.
 var autoModAction autoModActionKind
 
-// tormentRng is a shared random source for all torment operations.
-// A single instance avoids per-call heap allocations; the mutex is held only
-// for the duration of one Intn call (nanoseconds), so contention is negligible.
-var (
-	tormentRng   = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
-	tormentRngMu sync.Mutex
-)
-
 // tormentIntn returns a non-negative random int in [0, n) using the shared RNG.
 func tormentIntn(n int) int {
-	tormentRngMu.Lock()
-	defer tormentRngMu.Unlock()
-	return tormentRng.Intn(n)
+	return rng.Intn(n)
 }
 
 // The normalized banned-word list lives behind an atomic.Pointer (bannedWordsPtr
@@ -222,7 +210,7 @@ func autoModCheck(client *Client, msg string, source string) autoModResult {
 		}
 		client.SetMuted(ICOOCMuted)
 		client.SetUnmuteTime(time.Time{}) // zero = permanent
-		client.SendServerMessage("You have been muted for prohibited language.")
+		client.SendLocalized("muted_automod")
 		alertCensorTrip(client, source, matched, msg, "They were permanently muted.")
 		logger.LogInfof("automod: permanently muted %v (uid %d) — matched word %q", client.Ipid(), client.Uid(), matched)
 		return autoModBlocked
@@ -0,0 +1,47 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+)
+
+// startBanPurgeLoop periodically nullifies timed bans that have run out. Runs
+// for the lifetime of the server process.
+func startBanPurgeLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		purgeExpiredBans()
+	}
+}
+
+// purgeExpiredBans nullifies expired bans and writes an audit entry for each.
+func purgeExpiredBans() {
+	bans, err := db.PurgeExpiredBans()
+	if err != nil {
+		logger.LogErrorf("Failed to purge expired bans: %v", err)
+		return
+	}
+	for _, b := range bans {
+		logger.WriteAudit(fmt.Sprintf("%v | BAN_EXPIRED | ID:%v | IPID:%v | %v | By: %v", time.Now().UTC().Format("15:04:05"), b.Id, b.Ipid, b.Reason, b.Moderator))
+	}
+}
@@ -0,0 +1,72 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+)
+
+// isShouting reports whether text is mostly uppercase by the configured
+// caps_filter_ratio. Only letters count toward the ratio, and messages with
+// fewer than caps_filter_min_length letters are never considered shouting so
+// short interjections like "OBJECTION!" pass through.
+func isShouting(text string, ratio float64, minLetters int) bool {
+	var letters, upper int
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		if unicode.IsUpper(r) {
+			upper++
+		}
+	}
+	if letters == 0 || letters < minLetters {
+		return false
+	}
+	return float64(upper)/float64(letters) >= ratio
+}
+
+// Handles /capsfilter <off|lower|reject> - sets how this area treats IC
+// messages that are mostly uppercase. This is an automatic area rule, unlike
+// the /uppercase and /lowercase punishments, and runs on the speaker's own
+// text before any punishment transforms.
+
+func cmdCapsFilter(client *Client, args []string, usage string) {
+	var (
+		mode   area.CapsFilter
+		result string
+	)
+	switch strings.ToLower(args[0]) {
+	case "off", "false":
+		mode, result = area.CapsOff, "disabled the caps filter"
+	case "lower", "on", "true":
+		mode, result = area.CapsLower, "set the caps filter to lowercase shouted messages"
+	case "reject":
+		mode, result = area.CapsReject, "set the caps filter to reject shouted messages"
+	default:
+		client.SendServerMessage("Argument not recognized. " + usage)
+		return
+	}
+	client.Area().SetCapsFilter(mode)
+	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v has %v in this area.", client.OOCName(), result))
+	addToBuffer(client, "CMD", fmt.Sprintf("Set caps filter to %v.", strings.ToLower(args[0])), false)
+}
@@ -0,0 +1,38 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import "testing"
+
+func TestIsShouting(t *testing.T) {
+	cases := []struct {
+		text string
+		want bool
+	}{
+		{"OBJECTION!", false},                 // short interjection: exempt
+		{"WHY WOULD YOU DO THAT TO ME", true}, // plain shouting
+		{"Why would you do that to me", false},
+		{"I SAW THE DEFENDANT at the scene", false}, // under the ratio
+		{"!!!!!!!!!!!!!!!!!!!!!!!!", false},         // no letters at all
+		{"ÉCOUTEZ-MOI TOUS MAINTENANT", true},       // non-ASCII letters count
+	}
+	for _, c := range cases {
+		if got := isShouting(c.text, 0.7, 12); got != c.want {
+			t.Errorf("isShouting(%q) = %v, want %v", c.text, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+)
+
+// admitCaseQueue drains a's join queue, adds every queued player who is still
+// connected to the invite list and tells them the case is starting. It
+// returns the UIDs that were admitted.
+func admitCaseQueue(a *area.Area) []int {
+	var admitted []int
+	for _, uid := range a.DrainQueue() {
+		c, err := getClientByUid(uid)
+		if err != nil {
+			continue
+		}
+		a.AddInvited(uid)
+		admitted = append(admitted, uid)
+		if c.Area() != a {
+			c.SendServerMessage(fmt.Sprintf("🎬 The case in %v is starting and you're on the invite list. Join with /move %d.", a.Name(), getAreaIndex(a)))
+		}
+	}
+	return admitted
+}
+
+// dropCaseQueue empties a's join queue when the area stops looking for
+// players without /startcase, telling each queued player why.
+func dropCaseQueue(a *area.Area, status area.Status) {
+	msg := fmt.Sprintf("%v is no longer looking for players, so you were removed from its queue.", a.Name())
+	if status == area.StatusCasing {
+		msg = fmt.Sprintf("The case in %v started without the queue being admitted, so you were removed from it.", a.Name())
+	}
+	for _, uid := range a.DrainQueue() {
+		if c, err := getClientByUid(uid); err == nil {
+			c.SendServerMessage(msg)
+		}
+	}
+}
+
+// Handles /queue
+
+func cmdQueue(client *Client, args []string, usage string) {
+	if len(args) == 0 {
+		a := client.Area()
+		queue := a.Queue()
+		if len(queue) == 0 {
+			client.SendServerMessage(fmt.Sprintf("Nobody is queued for %v.", a.Name()))
+			return
+		}
+		var lines []string
+		for i, uid := range queue {
+			name := "(disconnected)"
+			if c, err := getClientByUid(uid); err == nil {
+				name = c.OOCName()
+			}
+			lines = append(lines, fmt.Sprintf("  %d. [%d] %v", i+1, uid, name))
+		}
+		client.SendServerMessage(fmt.Sprintf("Queue for %v:\n%v", a.Name(), strings.Join(lines, "\n")))
+		return
+	}
+
+	leave := strings.ToLower(args[0]) == "leave"
+	if leave {
+		args = args[1:]
+		if len(args) == 0 {
+			client.SendServerMessage("Not enough arguments:\n" + usage)
+			return
+		}
+	}
+	a, _, err := resolveArea(strings.Join(args, " "))
+	if err != nil {
+		client.SendServerMessage(invalidAreaMessage())
+		return
+	}
+	if leave {
+		if !a.UnqueuePlayer(client.Uid()) {
+			client.SendServerMessage(fmt.Sprintf("You are not queued for %v.", a.Name()))
+			return
+		}
+		client.SendServerMessage(fmt.Sprintf("You left the queue for %v.", a.Name()))
+		return
+	}
+	if a.Status() != area.StatusPlayers {
+		client.SendServerMessage(fmt.Sprintf("%v is not looking for players.", a.Name()))
+		return
+	}
+	if client.Area() == a {
+		client.SendServerMessage("You are already in that area.")
+		return
+	}
+	if !a.QueuePlayer(client.Uid()) {
+		client.SendServerMessage(fmt.Sprintf("You are already queued for %v.", a.Name()))
+		return
+	}
+	client.SendServerMessage(fmt.Sprintf("You joined the queue for %v (position %d). You'll be invited when the case starts.", a.Name(), len(a.Queue())))
+	notifyAreaCMs(a, fmt.Sprintf("%v joined the queue for this area.", client.OOCName()))
+}
+
+// notifyAreaCMs sends msg to a's CMs who are in the area. Queue joins go
+// only to them so repeated /queue and /queue leave cannot flood the area.
+func notifyAreaCMs(a *area.Area, msg string) {
+	for _, uid := range a.CMs() {
+		if c, err := getClientByUid(uid); err == nil && c.Area() == a {
+			c.SendServerMessage(msg)
+		}
+	}
+}
+
+// Handles /startcase
+
+func cmdStartCase(client *Client, _ []string, _ string) {
+	a := client.Area()
+	admitted := admitCaseQueue(a)
+	a.SetStatus(area.StatusCasing)
+	sendStatusArup()
+	msg := fmt.Sprintf("%v started the case.", client.OOCName())
+	if len(admitted) > 0 {
+		msg += fmt.Sprintf(" %d queued player(s) were invited.", len(admitted))
+	}
+	sendAreaServerMessage(a, msg)
+	addToBuffer(client, "CMD", fmt.Sprintf("Started the case, admitting %d queued player(s).", len(admitted)), false)
+}
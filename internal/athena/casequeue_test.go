@@ -0,0 +1,104 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+)
+
+// TestStartCaseAdmitsQueue verifies the /queue → /startcase flow: a queued
+// player is invited into the locked area and told the case is starting.
+func TestStartCaseAdmitsQueue(t *testing.T) {
+	newTestClients(t)
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	t.Cleanup(setupTestAreas([]*area.Area{lobby, court}))
+	court.SetLock(area.LockLocked)
+
+	newClient := func(uid int, a *area.Area) (*Client, *captureConn) {
+		conn := &captureConn{}
+		c := &Client{conn: conn, uid: uid, char: -1, jailAreaID: -1}
+		c.SetArea(a)
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+		return c, conn
+	}
+	cm, cmConn := newClient(1, court)
+	court.AddCM(1)
+	_, bystanderConn := newClient(3, court)
+	player, playerConn := newClient(2, lobby)
+
+	cmdQueue(player, []string{"Court"}, "usage")
+	if !strings.Contains(playerConn.String(), "not looking for players") || len(court.Queue()) != 0 {
+		t.Fatalf("queueing for an idle area should be refused: %q", playerConn.String())
+	}
+
+	court.SetStatus(area.StatusPlayers)
+	cmdQueue(player, []string{"1"}, "usage")
+	if got := court.Queue(); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("queue = %v, want [2]", got)
+	}
+	if !strings.Contains(cmConn.String(), "joined the queue") {
+		t.Errorf("the area's CM was not told about the queue join: %q", cmConn.String())
+	}
+	if strings.Contains(bystanderConn.String(), "joined the queue") {
+		t.Errorf("queue joins should only reach CMs, bystander got %q", bystanderConn.String())
+	}
+
+	cmdStartCase(cm, nil, "")
+	if court.Status() != area.StatusCasing {
+		t.Errorf("status = %v, want casing", court.Status())
+	}
+	if !court.HasInvited(2) || len(court.Queue()) != 0 {
+		t.Error("expected the queued player to be invited and the queue emptied")
+	}
+	if !strings.Contains(playerConn.String(), "The case in Court is starting") {
+		t.Errorf("queued player was not told the case started: %q", playerConn.String())
+	}
+}
+
+// TestStatusChangeDropsQueue verifies that leaving looking-for-players with
+// /status instead of /startcase empties the queue and tells those waiting.
+func TestStatusChangeDropsQueue(t *testing.T) {
+	newTestClients(t)
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	t.Cleanup(setupTestAreas([]*area.Area{lobby, court}))
+	court.SetStatus(area.StatusPlayers)
+
+	cm := &Client{conn: &captureConn{}, uid: 1, char: -1, jailAreaID: -1}
+	cm.SetArea(court)
+	playerConn := &captureConn{}
+	player := &Client{conn: playerConn, uid: 2, char: -1, jailAreaID: -1}
+	player.SetArea(lobby)
+	for _, c := range []*Client{cm, player} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+	court.QueuePlayer(2)
+
+	cmdStatus(cm, []string{"casing"}, "usage")
+	if len(court.Queue()) != 0 || court.HasInvited(2) {
+		t.Error("expected /status casing to empty the queue without inviting anyone")
+	}
+	if !strings.Contains(playerConn.String(), "started without the queue") {
+		t.Errorf("queued player was not told the case started without them: %q", playerConn.String())
+	}
+}
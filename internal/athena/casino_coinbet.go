@@ -0,0 +1,120 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+)
+
+const (
+	coinBetCooldown = 5 * time.Second
+	dailyCooldown   = int64(24 * 60 * 60) // seconds between /daily claims
+	dailyJobKey     = "daily"             // JOB_COOLDOWNS key; must be stable
+)
+
+// ============================================================
+// /bet — Coinflip wager
+// ============================================================
+
+// cmdBet handles /bet <amount> <heads|tails>: an even-money coinflip against
+// the house. A correct call pays double the stake.
+func cmdBet(client *Client, args []string, usage string) {
+	bet, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || bet <= 0 {
+		client.SendServerMessage("Invalid bet amount.\n" + usage)
+		return
+	}
+	choice := strings.ToLower(args[1])
+	if choice != "heads" && choice != "tails" {
+		client.SendServerMessage("Invalid choice. Use: heads or tails.")
+		return
+	}
+	if last := client.LastCoinBetTime(); !last.IsZero() && time.Since(last) < coinBetCooldown {
+		remaining := int((coinBetCooldown - time.Since(last)).Seconds()) + 1
+		client.SendServerMessage(fmt.Sprintf("Please wait %d seconds before betting again.", remaining))
+		return
+	}
+	if ok, reason := validateBet(client, bet); !ok {
+		client.SendServerMessage(reason)
+		return
+	}
+	balAfterBet, ok := spendBet(client, bet)
+	if !ok {
+		return
+	}
+	client.SetLastCoinBetTime(time.Now().UTC())
+
+	coinResult := flipCoin()
+	var bal int64
+	var result string
+	if coinResult == choice {
+		bal, _ = db.AddChips(client.Ipid(), bet*2)
+		result = fmt.Sprintf("WIN +%d chips", bet)
+	} else {
+		bal = balAfterBet
+		result = fmt.Sprintf("lost %d chips", bet)
+	}
+
+	sendAreaGamblingMessage(client.Area(),
+		fmt.Sprintf("🪙 Coin bet: %s called %s, the coin landed on %s — %s!", client.OOCName(), choice, coinResult, result))
+	client.SendServerMessage(fmt.Sprintf(
+		"🪙 Coin Bet | Called: %s | Landed: %s | %s | Balance: %d", choice, coinResult, result, bal))
+	addToBuffer(client, "GAME", fmt.Sprintf("Coin bet %d on %v: landed %v", bet, choice, coinResult), false)
+}
+
+// ============================================================
+// /daily — Daily chip stipend
+// ============================================================
+
+// cmdDaily handles /daily: claims daily_chips once per 24 hours per IPID.
+// The claim time lives in JOB_COOLDOWNS, so it survives reconnects and
+// restarts.
+func cmdDaily(client *Client, _ []string, _ string) {
+	amount := int64(config.DailyChips)
+	if amount <= 0 {
+		client.SendServerMessage("The daily stipend is disabled on this server.")
+		return
+	}
+	onCooldown, remaining, err := db.CheckAndSetJobCooldown(client.Ipid(), dailyJobKey, dailyCooldown)
+	if err != nil {
+		logger.LogErrorf("daily: CheckAndSetJobCooldown failed for ipid=%v: %v", client.Ipid(), err)
+		client.SendServerMessage("Something went wrong. Please try again later.")
+		return
+	}
+	if onCooldown {
+		client.SendServerMessage(fmt.Sprintf("📅 You already claimed today's stipend. Come back in %dh %dm.",
+			remaining/3600, remaining%3600/60))
+		return
+	}
+	bal, err := db.AddChips(client.Ipid(), amount)
+	if err != nil {
+		logger.LogErrorf("daily: AddChips failed for ipid=%v: %v", client.Ipid(), err)
+		// The claim was recorded above; give it back so a retry can succeed.
+		if err := db.ClearJobCooldown(client.Ipid(), dailyJobKey); err != nil {
+			logger.LogErrorf("daily: ClearJobCooldown failed for ipid=%v: %v", client.Ipid(), err)
+		}
+		client.SendServerMessage("Something went wrong awarding chips. Please try again later.")
+		return
+	}
+	client.SendServerMessage(fmt.Sprintf("📅 Daily stipend claimed: +%d chips | Balance: %d chips", amount, bal))
+}
@@ -0,0 +1,121 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// Chip balances live in the db package, so these tests need a temp DB.
+func setupCoinBetTestDB(t *testing.T) {
+	t.Helper()
+	tmp, err := os.CreateTemp("", "athena-coinbet-*.db")
+	if err != nil {
+		t.Fatalf("failed to create temp db: %v", err)
+	}
+	tmp.Close()
+	db.DBPath = tmp.Name()
+	if err := db.Open(); err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		os.Remove(tmp.Name())
+	})
+}
+
+func TestCmdBetSettlesStake(t *testing.T) {
+	setupCoinBetTestDB(t)
+	newTestClients(t)
+	conn := &captureConn{}
+	client := &Client{conn: conn, uid: 1, ipid: "coinbet-ipid", area: newTestArea(), oocName: "Phoenix"}
+	if err := db.EnsureChipBalance(client.Ipid()); err != nil {
+		t.Fatalf("EnsureChipBalance: %v", err)
+	}
+	before, _ := db.GetChipBalance(client.Ipid())
+
+	cmdBet(client, []string{"10", "heads"}, "usage")
+	after, _ := db.GetChipBalance(client.Ipid())
+	if diff := after - before; diff != 10 && diff != -10 {
+		t.Errorf("balance changed by %d, want ±10", diff)
+	}
+
+	cmdBet(client, []string{"10", "heads"}, "usage")
+	if !strings.Contains(conn.String(), "before betting again") {
+		t.Error("a second bet inside the cooldown should be refused")
+	}
+	if again, _ := db.GetChipBalance(client.Ipid()); again != after {
+		t.Errorf("a refused bet must not move chips: %d -> %d", after, again)
+	}
+}
+
+func TestCmdDailyClaimsOnce(t *testing.T) {
+	setupCoinBetTestDB(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	config.DailyChips = 25
+
+	conn := &captureConn{}
+	client := &Client{conn: conn, uid: 1, ipid: "daily-ipid"}
+	if err := db.EnsureChipBalance(client.Ipid()); err != nil {
+		t.Fatalf("EnsureChipBalance: %v", err)
+	}
+	before, _ := db.GetChipBalance(client.Ipid())
+
+	cmdDaily(client, nil, "")
+	cmdDaily(client, nil, "")
+	after, _ := db.GetChipBalance(client.Ipid())
+	if after-before != 25 {
+		t.Errorf("balance changed by %d, want one 25-chip claim", after-before)
+	}
+	if !strings.Contains(conn.String(), "already claimed") {
+		t.Error("the second claim should be refused")
+	}
+}
+
+// TestCmdDailyFailedAwardKeepsClaim verifies that a claim whose chips could
+// not be awarded does not start the 24-hour cooldown.
+func TestCmdDailyFailedAwardKeepsClaim(t *testing.T) {
+	setupCoinBetTestDB(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	config.DailyChips = 25
+
+	conn := &captureConn{}
+	client := &Client{conn: conn, uid: 1, ipid: "daily-fail-ipid"}
+	// Without a chip balance row AddChips fails.
+	cmdDaily(client, nil, "")
+	if !strings.Contains(conn.String(), "Something went wrong awarding chips.") {
+		t.Fatalf("expected the award to fail, got %q", conn.String())
+	}
+
+	if err := db.EnsureChipBalance(client.Ipid()); err != nil {
+		t.Fatalf("EnsureChipBalance: %v", err)
+	}
+	before, _ := db.GetChipBalance(client.Ipid())
+	cmdDaily(client, nil, "")
+	if after, _ := db.GetChipBalance(client.Ipid()); after-before != 25 {
+		t.Errorf("retry changed the balance by %d, want the 25-chip claim", after-before)
+	}
+}
@@ -0,0 +1,208 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+// chatChannel identifies a chat path that /chatlockdown can silence.
+type chatChannel int
+
+const (
+	chatOOC chatChannel = 1 << iota
+	chatGlobal
+	chatIC
+)
+
+// chatLockdownAll silences every chat channel.
+const chatLockdownAll = chatOOC | chatGlobal | chatIC
+
+// chatLockdownDefault is the scope used by "/chatlockdown on" when no scope is
+// given: raids are usually OOC spam, so IC roleplay is left alone by default.
+const chatLockdownDefault = chatOOC | chatGlobal
+
+var (
+	chatLockdownMu    sync.Mutex
+	chatLockdownScope chatChannel // bitmask of silenced channels; 0 = lockdown off
+	chatLockdownPanic bool        // set by /panic: only MOD_SPEAK staff bypass the lockdown
+)
+
+// chatLockdownBlocks reports whether the server-wide chat lockdown silences
+// client on the given channel. Moderators always bypass it, except in panic
+// mode, where only staff with MOD_SPEAK do.
+func chatLockdownBlocks(client *Client, ch chatChannel) bool {
+	chatLockdownMu.Lock()
+	scope, panicMode := chatLockdownScope, chatLockdownPanic
+	chatLockdownMu.Unlock()
+	if scope&ch == 0 {
+		return false
+	}
+	if panicMode {
+		return !permissions.HasPermission(client.Perms(), permissions.PermissionField["MOD_SPEAK"])
+	}
+	return !permissions.IsModerator(client.Perms())
+}
+
+// chatLockdownNotice returns the message for a player the lockdown silenced:
+// msg, or the panic mode notice while panic mode is on.
+func chatLockdownNotice(msg string) string {
+	chatLockdownMu.Lock()
+	defer chatLockdownMu.Unlock()
+	if chatLockdownPanic {
+		return "The server is in panic mode."
+	}
+	return msg
+}
+
+// setChatLockdown replaces the lockdown scope, ending panic mode, and returns
+// the previous scope.
+func setChatLockdown(scope chatChannel) chatChannel {
+	chatLockdownMu.Lock()
+	defer chatLockdownMu.Unlock()
+	prev := chatLockdownScope
+	chatLockdownScope = scope
+	chatLockdownPanic = false
+	return prev
+}
+
+// setPanicMode turns panic mode, a lockdown of every channel that only
+// MOD_SPEAK staff bypass, on or off and returns whether it was on. Turning it
+// off lifts every lockdown, including a /chatlockdown set before the panic,
+// so chat is back to normal once the emergency is over.
+func setPanicMode(on bool) bool {
+	chatLockdownMu.Lock()
+	defer chatLockdownMu.Unlock()
+	prev := chatLockdownPanic
+	if on && !prev {
+		chatLockdownScope, chatLockdownPanic = chatLockdownAll, true
+	} else if !on && prev {
+		chatLockdownScope, chatLockdownPanic = 0, false
+	}
+	return prev
+}
+
+// parseChatLockdownScope parses a comma-separated list of channels
+// ("ooc", "global", "ic", "all").
+func parseChatLockdownScope(s string) (chatChannel, bool) {
+	var scope chatChannel
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		switch strings.TrimSpace(part) {
+		case "ooc":
+			scope |= chatOOC
+		case "global":
+			scope |= chatGlobal
+		case "ic":
+			scope |= chatIC
+		case "all":
+			scope |= chatLockdownAll
+		default:
+			return 0, false
+		}
+	}
+	return scope, scope != 0
+}
+
+// String lists the channels in scope, e.g. "OOC, global".
+func (scope chatChannel) String() string {
+	var names []string
+	if scope&chatOOC != 0 {
+		names = append(names, "OOC")
+	}
+	if scope&chatGlobal != 0 {
+		names = append(names, "global")
+	}
+	if scope&chatIC != 0 {
+		names = append(names, "IC")
+	}
+	return strings.Join(names, ", ")
+}
+
+// Handles /chatlockdown
+//
+//	/chatlockdown on [ooc,global,ic|all] - silence non-mod chat on the given channels
+//	/chatlockdown off                    - lift the lockdown
+//	/chatlockdown status                 - show the current scope
+func cmdChatLockdown(client *Client, args []string, usage string) {
+	switch strings.ToLower(args[0]) {
+	case "on":
+		scope := chatLockdownDefault
+		if len(args) > 1 {
+			var ok bool
+			if scope, ok = parseChatLockdownScope(args[1]); !ok {
+				client.SendServerMessage("Invalid scope. Use any of ooc, global, ic (comma-separated) or all.")
+				return
+			}
+		}
+		setChatLockdown(scope)
+		sendGlobalServerMessage(fmt.Sprintf("🔇 Chat lockdown is now ACTIVE (%v). Only moderators can speak there until it is lifted.", scope))
+		client.SendServerMessage(fmt.Sprintf("Chat lockdown enabled for %v.", scope))
+		addToBuffer(client, "CMD", fmt.Sprintf("Enabled chat lockdown (%v).", scope), true)
+	case "off":
+		if setChatLockdown(0) == 0 {
+			client.SendServerMessage("Chat lockdown is not active.")
+			return
+		}
+		sendGlobalServerMessage("🔊 Chat lockdown has been LIFTED. Everyone can speak again.")
+		client.SendServerMessage("Chat lockdown disabled.")
+		addToBuffer(client, "CMD", "Disabled chat lockdown.", true)
+	case "status":
+		chatLockdownMu.Lock()
+		scope, panicMode := chatLockdownScope, chatLockdownPanic
+		chatLockdownMu.Unlock()
+		if scope == 0 {
+			client.SendServerMessage("Chat lockdown is off.")
+			return
+		}
+		if panicMode {
+			client.SendServerMessage("Panic mode is active: all chat is locked down for everyone without MOD_SPEAK.")
+			return
+		}
+		client.SendServerMessage(fmt.Sprintf("Chat lockdown is active for %v.", scope))
+	default:
+		client.SendServerMessage("Invalid argument. " + usage)
+	}
+}
+
+// Handles /panic
+
+func cmdPanic(client *Client, args []string, usage string) {
+	switch strings.ToLower(args[0]) {
+	case "on":
+		if setPanicMode(true) {
+			client.SendServerMessage("Panic mode is already active.")
+			return
+		}
+		sendGlobalServerMessage("🚨 The server is in PANIC MODE. All IC and OOC chat is silenced until the moderators lift it.")
+		client.SendServerMessage("Panic mode enabled.")
+		addToBuffer(client, "CMD", "Enabled panic mode.", true)
+	case "off":
+		if !setPanicMode(false) {
+			client.SendServerMessage("Panic mode is not active.")
+			return
+		}
+		sendGlobalServerMessage("✅ Panic mode has been lifted. Everyone can speak again.")
+		client.SendServerMessage("Panic mode disabled.")
+		addToBuffer(client, "CMD", "Disabled panic mode.", true)
+	default:
+		client.SendServerMessage("Invalid argument. " + usage)
+	}
+}
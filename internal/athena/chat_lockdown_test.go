@@ -0,0 +1,125 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+func TestParseChatLockdownScope(t *testing.T) {
+	cases := []struct {
+		in   string
+		want chatChannel
+		ok   bool
+	}{
+		{"ooc", chatOOC, true},
+		{"OOC,ic", chatOOC | chatIC, true},
+		{"all", chatOOC | chatGlobal | chatIC, true},
+		{"global, ic", chatGlobal | chatIC, true},
+		{"shout", 0, false},
+		{"ooc,", 0, false},
+	}
+	for _, c := range cases {
+		got, ok := parseChatLockdownScope(c.in)
+		if ok != c.ok || (ok && got != c.want) {
+			t.Errorf("parseChatLockdownScope(%q) = %v, %v; want %v, %v", c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestChatLockdownBlocksNonModsOnly(t *testing.T) {
+	t.Cleanup(func() { setChatLockdown(0) })
+
+	player := &Client{conn: &testConn{}, uid: 1, ipid: "ip-player"}
+	cm := &Client{conn: &testConn{}, uid: 2, ipid: "ip-cm", perms: permissions.PermissionField["CM"]}
+	mod := &Client{conn: &testConn{}, uid: 3, ipid: "ip-mod", perms: permissions.PermissionField["MUTE"]}
+
+	if chatLockdownBlocks(player, chatOOC) {
+		t.Fatal("lockdown off must not block anyone")
+	}
+
+	setChatLockdown(chatLockdownDefault)
+	if !chatLockdownBlocks(player, chatOOC) || !chatLockdownBlocks(player, chatGlobal) {
+		t.Error("default lockdown should block a player's OOC and global chat")
+	}
+	if chatLockdownBlocks(player, chatIC) {
+		t.Error("default lockdown should leave IC alone")
+	}
+	if !chatLockdownBlocks(cm, chatOOC) {
+		t.Error("CM permission alone should not bypass the lockdown")
+	}
+	if chatLockdownBlocks(mod, chatOOC) {
+		t.Error("moderators should bypass the lockdown")
+	}
+
+	if prev := setChatLockdown(0); prev != chatLockdownDefault {
+		t.Errorf("setChatLockdown returned previous scope %v, want %v", prev, chatLockdownDefault)
+	}
+	if chatLockdownBlocks(player, chatOOC) {
+		t.Error("lifting the lockdown should unblock players")
+	}
+}
+
+func TestPanicModeSilencesAllButModSpeak(t *testing.T) {
+	t.Cleanup(func() { setChatLockdown(0) })
+
+	player := &Client{conn: &testConn{}, uid: 1, ipid: "ip-player"}
+	mod := &Client{conn: &testConn{}, uid: 2, ipid: "ip-mod", perms: permissions.PermissionField["MUTE"]}
+	speaker := &Client{conn: &testConn{}, uid: 3, ipid: "ip-speaker", perms: permissions.PermissionField["MOD_SPEAK"]}
+
+	if chatLockdownBlocks(player, chatIC) {
+		t.Fatal("panic mode off must not block anyone")
+	}
+	if setPanicMode(true) {
+		t.Error("setPanicMode should report that panic mode was off")
+	}
+	for _, ch := range []chatChannel{chatOOC, chatGlobal, chatIC} {
+		if !chatLockdownBlocks(player, ch) || !chatLockdownBlocks(mod, ch) {
+			t.Errorf("panic mode should block everyone without MOD_SPEAK on %v", ch)
+		}
+		if chatLockdownBlocks(speaker, ch) {
+			t.Errorf("MOD_SPEAK staff should bypass panic mode on %v", ch)
+		}
+	}
+	if !setPanicMode(false) {
+		t.Error("setPanicMode should report that panic mode was on")
+	}
+	if chatLockdownBlocks(player, chatIC) {
+		t.Error("turning panic mode off should unblock players")
+	}
+
+	setChatLockdown(chatLockdownDefault)
+	if setPanicMode(false) || chatLockdownScope != chatLockdownDefault {
+		t.Error("turning panic mode off should leave a plain lockdown alone")
+	}
+	if got := chatLockdownNotice("OOC chat is locked down by the moderators."); got != "OOC chat is locked down by the moderators." {
+		t.Errorf("plain lockdown notice = %q", got)
+	}
+	setPanicMode(true)
+	if got := chatLockdownNotice("OOC chat is locked down by the moderators."); got != "The server is in panic mode." {
+		t.Errorf("panic mode notice = %q", got)
+	}
+	if !chatLockdownBlocks(player, chatIC) {
+		t.Error("panic mode should cover every channel on top of a plain lockdown")
+	}
+	setPanicMode(false)
+	if chatLockdownScope != 0 || chatLockdownBlocks(player, chatOOC) {
+		t.Errorf("turning panic mode off should lift the earlier lockdown too, got %v", chatLockdownScope)
+	}
+}
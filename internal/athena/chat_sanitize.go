@@ -0,0 +1,84 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Because WebAO frames are read as MessageBinary, nothing upstream of the
+// packet handlers guarantees chat text is valid UTF-8 or free of raw control
+// bytes. The protocol's own delimiters (#, %, $, &) are escaped by
+// encode/decode and are not control characters, so they pass through here
+// untouched.
+
+// isDisallowedChatRune reports whether r must not appear in chat text:
+// C0/C1 control characters other than newline and tab, and U+FFFD, which
+// range-over-string yields for each invalid UTF-8 byte.
+func isDisallowedChatRune(r rune) bool {
+	if r == '\n' || r == '\t' {
+		return false
+	}
+	return r == utf8.RuneError || unicode.IsControl(r)
+}
+
+// cleanChatText strips disallowed runes and invalid UTF-8 from s. ok is false
+// when anything had to be removed.
+func cleanChatText(s string) (clean string, ok bool) {
+	dirty := !utf8.ValidString(s)
+	if !dirty {
+		for _, r := range s {
+			if isDisallowedChatRune(r) {
+				dirty = true
+				break
+			}
+		}
+	}
+	if !dirty {
+		return s, true
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if isDisallowedChatRune(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String(), false
+}
+
+// sanitizeChatFields applies the configured chat_control_chars policy to each
+// field in place. It returns false when the message must be dropped ("reject"
+// policy and at least one field was dirty); the sender is told why.
+func sanitizeChatFields(client *Client, fields ...*string) bool {
+	reject := config != nil && strings.EqualFold(strings.TrimSpace(config.ChatControlChars), "reject")
+	for _, f := range fields {
+		clean, ok := cleanChatText(*f)
+		if ok {
+			continue
+		}
+		if reject {
+			client.SendServerMessage("Your message contained control characters or invalid text and was not sent.")
+			return false
+		}
+		*f = clean
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestCleanChatText(t *testing.T) {
+	cases := []struct {
+		name, in, want string
+		ok             bool
+	}{
+		{"plain", "Objection!", "Objection!", true},
+		{"protocol escapes untouched", "<num><percent><dollar><and>", "<num><percent><dollar><and>", true},
+		{"newline and tab kept", "line one\nline\ttwo", "line one\nline\ttwo", true},
+		{"multibyte kept", "異議あり！ 🎉", "異議あり！ 🎉", true},
+		{"NUL and BEL", "hi\x00there\x07", "hithere", false},
+		{"ANSI escape", "\x1b[31mred\x1b[0m", "[31mred[0m", false},
+		{"carriage return", "fake\rline", "fakeline", false},
+		{"DEL", "a\x7fb", "ab", false},
+		{"C1 control", "a\u0085b", "ab", false},
+		{"invalid UTF-8", "ok\xff\xfeok", "okok", false},
+		{"truncated sequence", "caf\xc3", "caf", false},
+	}
+	for _, c := range cases {
+		got, ok := cleanChatText(c.in)
+		if got != c.want || ok != c.ok {
+			t.Errorf("%s: cleanChatText(%q) = %q, %v; want %q, %v", c.name, c.in, got, ok, c.want, c.ok)
+		}
+	}
+}
+
+func TestSanitizeChatFieldsPolicy(t *testing.T) {
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	client := &Client{conn: &testConn{}, uid: 1, ipid: "ip-sanitize"}
+
+	config = &settings.Config{ServerConfig: settings.ServerConfig{ChatControlChars: "strip"}}
+	msg, name := "bad\x00msg", "good"
+	if !sanitizeChatFields(client, &msg, &name) {
+		t.Fatal("strip policy should let the message through")
+	}
+	if msg != "badmsg" || name != "good" {
+		t.Errorf("strip policy produced %q, %q", msg, name)
+	}
+
+	config = &settings.Config{ServerConfig: settings.ServerConfig{ChatControlChars: "reject"}}
+	msg = "bad\x00msg"
+	if sanitizeChatFields(client, &msg) {
+		t.Error("reject policy should drop a dirty message")
+	}
+	msg = "clean"
+	if !sanitizeChatFields(client, &msg) {
+		t.Error("reject policy should pass a clean message")
+	}
+}
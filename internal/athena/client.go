@@ -319,6 +319,7 @@ type PunishmentState struct {
 	customData     string     // For PunishmentTranslator: target language name or "random"
 	icWarpArea     *area.Area // For PunishmentICWarp: the area where the warp applies; nil = inert
 	issuerTier     IssuerTier // who issued this — protects shadow/admin punishments from self-removal
+	oocScope       bool       // set by -ooc: the text effect also applies to OOC chat
 }
 
 type ClientPairInfo struct {
@@ -359,6 +360,10 @@ type Client struct {
 	narrator            bool
 	jailedUntil         time.Time
 	lastRpsTime         time.Time
+	lastSoloFlipTime    time.Time
+	last8ballTime       time.Time
+	lastCoinBetTime     time.Time
+	locale              string // /lang choice; empty means default_locale
 	punishments         []PunishmentState
 	msgTimestamps       []time.Time    // Tracks message timestamps for rate limiting
 	oocMsgTimestamps    []time.Time    // Tracks OOC message timestamps for OOC rate limiting
@@ -371,6 +376,10 @@ type Client struct {
 	lastDJBgTime        time.Time      // Tracks last /bg time for DJ rate limit (1 min)
 	lastRandomSongTime  time.Time      // Tracks last /randomsong time for cooldown
 	lastTranslateTime   time.Time      // Tracks last /translate time for cooldown
+	lastShoutTime       time.Time      // Tracks last IC shout for interjection_cooldown
+	lastOOCRename       time.Time      // Tracks last OOC name change for ooc_name_cooldown
+	lastScreenEffect    time.Time      // Tracks last IC screenshake/realization for effect_cooldown
+	lastAreaPassFail    time.Time      // Tracks last wrong /move area password for areaPassCooldown
 	forcePairUID        int            // UID of the client this client is force-paired with (-1 if none)
 	possessing          int            // UID of the client being possessed (-1 if not possessing anyone)
 	possessedPos        string         // Position of the possessed target (saved at time of possession)
@@ -384,6 +393,7 @@ type Client struct {
 	forcedIniswapIDStr  string         // Pre-computed strconv.Itoa(charID) matching forcedIniswapChar ("" = none)
 	connectedAt         time.Time      // Time the client joined the server (uid assigned); zero if not yet joined
 	jailAreaID          int            // Area index where this client is jailed; -1 = no specific jail area
+	preJailArea         *area.Area     // Area the client was moved out of by /jail (nil if jailed in place)
 	emergencyBypassArea *area.Area     // Locked area the client most recently tried to enter as a mod; nil = no pending bypass
 	emergencyBypassAt   time.Time      // Time of the first locked-area attempt; used with emergencyBypassArea to confirm an emergency override
 	hidden              bool           // Whether the client is hidden from the player list and area counts
@@ -398,9 +408,13 @@ type Client struct {
 	sessionChipsAwarded int64          // Chips already awarded mid-session (hourly ticker); subtracted at disconnect to avoid double-counting
 	ignoredIPIDs        sync.Map       // Set of IPIDs permanently ignored by this client. Key: IPID string, Value: struct{}. Lock-free reads.
 	lastPingNano        atomic.Int64   // Unix nanosecond timestamp of the last CH packet; 0 until seeded on join.
+	lastActivityNano    atomic.Int64   // Unix nanosecond timestamp of the last IC/OOC message; seeded on join. Drives the idle_kick_minutes sweep.
+	joinReported        atomic.Bool    // Set on the client's first character pick; gates the webhook_joins/webhook_leaves posts.
 	masoPunishment      PunishmentType // Active self-applied maso punishment type; PunishmentNone if inactive.
 	lookingForPair      bool           // Whether the client is flagged as Looking For Pair (/lfp); shown by /pairlist.
 	lovePotionUntil     time.Time      // While in the future, the next area speaker receives a pair request from this client. Zero = not armed.
+	away                bool           // Whether the client is marked /away; cleared by /back or their next IC message.
+	awayMsg             string         // Optional /away message, shown in /players and returned to anyone who PMs them.
 
 	// Self-service idle auto-disconnect (/dc, /dctime). Opt-in and isolated to
 	// the client that sets it: the watcher goroutine only ever closes THIS
@@ -667,7 +681,7 @@ func (client *Client) HandleClient() {
 		// than any legitimate client ever would. The ban is committed synchronously before
 		// the connection closes so the flooder cannot immediately reconnect.
 		if client.CheckRawPacketRateLimit() {
-			client.SendServerMessage("You have been banned for packet flooding.")
+			client.SendLocalized("banned_flood")
 			logger.LogInfof("Client (IPID:%v UID:%v) banned for raw packet flooding", client.Ipid(), client.Uid())
 			logger.WriteAudit(fmt.Sprintf("%v | PACKET_FLOOD | IPID:%v | UID:%v | Auto-banned for packet flooding", time.Now().UTC().Format("15:04:05"), client.Ipid(), client.Uid()))
 			autoBanPacketFlooder(client.Ipid())
@@ -927,7 +941,17 @@ func (client *Client) logSnapshot() clientLogSnapshot {
 // clientCleanup cleans up a disconnected client.
 func (client *Client) clientCleanup() {
 	if client.Uid() != -1 {
-		logger.LogInfof("Client (IPID:%v UID:%v) left the server", client.ipid, client.Uid())
+		var session time.Duration
+		if connAt := client.ConnectedAt(); !connAt.IsZero() {
+			session = time.Since(connAt).Round(time.Second)
+		}
+		logger.LogInfof("Client (IPID:%v UID:%v) left the server after %v", client.ipid, client.Uid(), session)
+
+		// Record the departure in the area buffer and audit log before the UID
+		// goes back to the heap, so a recycled UID can be told apart from the
+		// client that held it previously.
+		addToBuffer(client, "DISCONNECT", fmt.Sprintf("Left the server (UID %v, session %v).", client.Uid(), session), true)
+		reportLeave(client)
 
 		// Accumulate session playtime and award 1 chip per newly-completed hour.
 		// AddPlaytimeReturning is a single atomic SQL operation, so concurrent
@@ -985,6 +1009,7 @@ func (client *Client) clientCleanup() {
 		})
 
 		leaveVoiceForClient(client)
+		tttLeave(client, client.Area())
 		if client.Area().PlayerCount() <= 1 {
 			client.Area().Reset()
 			sendLockArup()
@@ -1004,6 +1029,7 @@ func (client *Client) clientCleanup() {
 			if a.Lock() != area.LockFree {
 				a.RemoveInvited(client.Uid())
 			}
+			a.UnqueuePlayer(client.Uid())
 		}
 		clearVoiceRateStateForUID(client.Uid())
 		uids.ReleaseUid(client.Uid())
@@ -1020,6 +1046,7 @@ func (client *Client) clientCleanup() {
 	}
 	handleCasinoDisconnect(client)
 	handleMafiaDisconnect(client)
+	handleTriviaDisconnect(client)
 	// Lower the /forcedisplay gate if this client was a pinned target, so the
 	// area stops rendering everyone as their character once they're gone.
 	client.releaseForceDisplayGate()
@@ -1047,7 +1074,7 @@ func (client *Client) SendMotd(motd string) {
 // Message-based rate limits always result in a kick, not a ban. Only raw packet flooding
 // (handled separately) results in an automatic ban.
 func (client *Client) KickForRateLimit() {
-	client.SendServerMessage("You have been kicked for spamming.")
+	client.SendLocalized("kicked_spam")
 	logger.LogInfof("Client (IPID:%v UID:%v) kicked for exceeding rate limit", client.Ipid(), client.Uid())
 	client.conn.Close()
 }
@@ -1436,6 +1463,9 @@ func (client *Client) restorePunishments() {
 				remaining = time.Until(expiresAt)
 			}
 			tier := IssuerTier(p.IssuerTier)
+			// A leading 0x1E marks a punishment issued with -ooc.
+			ooc := strings.HasPrefix(p.Reason, oocScopeMarker)
+			p.Reason = strings.TrimPrefix(p.Reason, oocScopeMarker)
 			// Text-punishment reasons may embed per-instance metadata (e.g. the
 			// translator target language) separated from the user-visible reason
 			// by an ASCII Unit Separator (0x1F). Decode that here so the custom
@@ -1449,6 +1479,9 @@ func (client *Client) restorePunishments() {
 			} else {
 				client.AddPunishmentBy(pType, remaining, p.Reason, tier)
 			}
+			if ooc {
+				client.UpdatePunishmentState(pType, func(ps *PunishmentState) { ps.oocScope = true })
+			}
 		}
 	}
 	client.SendServerMessage("Your active punishments have been restored.")
@@ -1571,9 +1604,11 @@ func (client *Client) ChangeArea(a *area.Area) bool {
 			return false
 		}
 	}
-	if client.Area() != nil {
+	from := client.Area()
+	if from != nil {
 		addToBuffer(client, "AREA", "Left area.", false)
 		leaveVoiceForClient(client)
+		tttLeave(client, from)
 		if client.Area().PlayerCount() <= 1 {
 			client.Area().Reset()
 			sendLockArup()
@@ -1594,6 +1629,8 @@ func (client *Client) ChangeArea(a *area.Area) bool {
 		}
 	}
 	client.JoinArea(a)
+	sendAreaMusic(client, from, a)
+	reportMove(client, from, a)
 	broadcastToAll(&packet.PU{ID: client.Uid(), Type: 3, Data: strconv.Itoa(getAreaIndex(a))})
 	if client.CharID() == -1 {
 		// Send DONE before BN so WebAO's character-select viewport is
@@ -1685,7 +1722,7 @@ func (client *Client) CanJud() bool {
 // CheckUnmute checks the client's mute duration, unmuting them if nessecary, and returning whether the client is still muted.
 func (client *Client) CheckUnmute() bool {
 	if time.Now().UTC().After(client.UnmuteTime()) && !client.UnmuteTime().IsZero() {
-		client.SendServerMessage("You have been unmuted.")
+		client.SendLocalized("unmuted")
 		client.SetMuted(Unmuted)
 		go func(ipid string) {
 			if err := db.DeleteMute(ipid); err != nil {
@@ -1773,8 +1810,26 @@ func (client *Client) CanAlterEvidence() bool {
 	return true
 }
 
+// CanAlterEvidenceItem reports whether the client may edit or remove a piece
+// of evidence added by owner (an IPID, empty if unknown). Only owner mode
+// restricts individual items: there the adder, CMs and evidence mods may.
+func (client *Client) CanAlterEvidenceItem(owner string) bool {
+	if client.Area().EvidenceMode() != area.EviOwner {
+		return true
+	}
+	if owner != "" && owner == client.Ipid() {
+		return true
+	}
+	return client.HasCMPermission() || permissions.HasPermission(client.Perms(), permissions.PermissionField["MOD_EVI"])
+}
+
 // ChangeCharacter changes the client's character to the given character.
 func (client *Client) ChangeCharacter(id int) {
+	// Taking a character past max_players is refused here so that potions,
+	// curses and forced swaps respect the spectator cap too.
+	if id != -1 && client.characterSlotDenied() {
+		return
+	}
 	if client.Area().SwitchChar(client.CharID(), id) {
 		client.SetCharID(id)
 		// Do not reset showname here; it is set from IC messages so the
@@ -1933,6 +1988,20 @@ func (client *Client) SetJailAreaID(id int) {
 	client.mu.Unlock()
 }
 
+// PreJailArea returns the area the client was moved out of when jailed, or nil.
+func (client *Client) PreJailArea() *area.Area {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.preJailArea
+}
+
+// SetPreJailArea records the area the client was moved out of when jailed.
+func (client *Client) SetPreJailArea(a *area.Area) {
+	client.mu.Lock()
+	client.preJailArea = a
+	client.mu.Unlock()
+}
+
 // IsCharStuck returns true if the client is currently under a character-stuck restriction.
 // Both fields are read under a single mutex lock to avoid double-locking.
 func (client *Client) IsCharStuck() bool {
@@ -2005,7 +2074,9 @@ func (client *Client) SetGambleHide(h bool) {
 // the jailed-player area-lock and area invitation checks that ChangeArea enforces.
 // Used to place a jailed player into their designated cell (both at jail time and on reconnect).
 func (client *Client) forceChangeArea(a *area.Area) {
+	from := client.Area()
 	addToBuffer(client, "AREA", "Left area.", false)
+	tttLeave(client, from)
 	if client.Area().PlayerCount() <= 1 {
 		client.Area().Reset()
 		sendLockArup()
@@ -2025,6 +2096,7 @@ func (client *Client) forceChangeArea(a *area.Area) {
 		}
 	}
 	client.JoinArea(a)
+	sendAreaMusic(client, from, a)
 	broadcastToAll(&packet.PU{ID: client.Uid(), Type: 3, Data: strconv.Itoa(getAreaIndex(a))})
 	if client.CharID() == -1 {
 		// Send DONE before BN for the same reason as ChangeArea: WebAO
@@ -2052,6 +2124,62 @@ func (client *Client) SetLastRpsTime(t time.Time) {
 	client.mu.Unlock()
 }
 
+// LastSoloFlipTime returns the last time the client used /coinflip -s.
+func (client *Client) LastSoloFlipTime() time.Time {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.lastSoloFlipTime
+}
+
+// SetLastSoloFlipTime sets the last time the client used /coinflip -s.
+func (client *Client) SetLastSoloFlipTime(t time.Time) {
+	client.mu.Lock()
+	client.lastSoloFlipTime = t
+	client.mu.Unlock()
+}
+
+// Last8ballTime returns the last time the client asked the Magic 8-Ball.
+func (client *Client) Last8ballTime() time.Time {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.last8ballTime
+}
+
+// SetLast8ballTime sets the last time the client asked the Magic 8-Ball.
+func (client *Client) SetLast8ballTime(t time.Time) {
+	client.mu.Lock()
+	client.last8ballTime = t
+	client.mu.Unlock()
+}
+
+// Locale returns the client's chosen message locale, or "" for the default.
+func (client *Client) Locale() string {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.locale
+}
+
+// SetLocale sets the client's message locale.
+func (client *Client) SetLocale(code string) {
+	client.mu.Lock()
+	client.locale = code
+	client.mu.Unlock()
+}
+
+// LastCoinBetTime returns the last time the client placed a /bet.
+func (client *Client) LastCoinBetTime() time.Time {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.lastCoinBetTime
+}
+
+// SetLastCoinBetTime sets the last time the client placed a /bet.
+func (client *Client) SetLastCoinBetTime(t time.Time) {
+	client.mu.Lock()
+	client.lastCoinBetTime = t
+	client.mu.Unlock()
+}
+
 // CheckModcallCooldown checks if the client is within the modcall cooldown period.
 // Returns true (and the remaining seconds, rounded up) if the client must wait, false otherwise.
 // When the cooldown is disabled (0), always returns false.
@@ -2080,6 +2208,35 @@ func (client *Client) SetLastModcallTime() {
 	client.mu.Unlock()
 }
 
+// areaPassCooldown is how long a client must wait after a wrong /move area
+// password before guessing again.
+const areaPassCooldown = 5 * time.Second
+
+// CheckAreaPassCooldown checks if the client is within the cooldown after a
+// wrong area password. Returns true (and the remaining seconds, rounded up)
+// if the client must wait, false otherwise.
+func (client *Client) CheckAreaPassCooldown() (bool, int) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	if client.lastAreaPassFail.IsZero() {
+		return false, 0
+	}
+	elapsed := time.Since(client.lastAreaPassFail)
+	if elapsed < areaPassCooldown {
+		remaining := int(math.Ceil((areaPassCooldown - elapsed).Seconds()))
+		return true, remaining
+	}
+	return false, 0
+}
+
+// SetLastAreaPassFail records the current time as the client's last wrong
+// area password.
+func (client *Client) SetLastAreaPassFail() {
+	client.mu.Lock()
+	client.lastAreaPassFail = time.Now()
+	client.mu.Unlock()
+}
+
 const barDrinkCooldown = 20 * time.Second
 
 // CheckBarDrinkCooldown checks if the client is within the bar drink cooldown period.
@@ -2183,6 +2340,52 @@ func (client *Client) CheckAndUpdateTranslateCooldown(cooldown time.Duration) (b
 	return true, 0
 }
 
+// CheckAndUpdateInterjectionCooldown atomically checks whether the
+// interjection cooldown has elapsed and, if so, records the current time as
+// the new last-shout timestamp. It returns (true, 0) when the shout is
+// allowed, or (false, remaining) when the client is still in cooldown.
+func (client *Client) CheckAndUpdateInterjectionCooldown(cooldown time.Duration) (bool, time.Duration) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	now := time.Now()
+	elapsed := now.Sub(client.lastShoutTime)
+	if !client.lastShoutTime.IsZero() && elapsed < cooldown {
+		return false, cooldown - elapsed
+	}
+	client.lastShoutTime = now
+	return true, 0
+}
+
+// CheckAndUpdateEffectCooldown atomically checks whether the screen effect
+// cooldown has elapsed and, if so, records the current time as the last
+// effect. It returns true when the effect is allowed.
+func (client *Client) CheckAndUpdateEffectCooldown(cooldown time.Duration) bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	now := time.Now()
+	if !client.lastScreenEffect.IsZero() && now.Sub(client.lastScreenEffect) < cooldown {
+		return false
+	}
+	client.lastScreenEffect = now
+	return true
+}
+
+// CheckAndUpdateOOCRenameCooldown atomically checks whether the OOC name
+// cooldown has elapsed and, if so, records the current time as the last
+// rename. It returns (true, 0) when the rename is allowed, or
+// (false, remaining) when the client is still in cooldown.
+func (client *Client) CheckAndUpdateOOCRenameCooldown(cooldown time.Duration) (bool, time.Duration) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	now := time.Now()
+	elapsed := now.Sub(client.lastOOCRename)
+	if !client.lastOOCRename.IsZero() && elapsed < cooldown {
+		return false, cooldown - elapsed
+	}
+	client.lastOOCRename = now
+	return true, 0
+}
+
 // String returns the string representation of a mute state.
 func (m MuteState) String() string {
 	switch m {
@@ -2469,24 +2672,47 @@ func (client *Client) CheckExpiredPunishments() bool {
 	client.mu.Lock()
 	defer client.mu.Unlock()
 
-	now := time.Now().UTC()
-	removed := false
+	expired := client.dropExpiredLocked(time.Now().UTC())
+	for _, pType := range expired {
+		go func(ipid string, t PunishmentType) {
+			if err := db.DeleteTextPunishment(ipid, int(t)); err != nil {
+				logger.LogErrorf("Failed to remove expired punishment from DB for %v: %v", ipid, err)
+			}
+		}(client.ipid, pType)
+	}
+	return len(expired) > 0
+}
 
-	for i := len(client.punishments) - 1; i >= 0; i-- {
-		p := &client.punishments[i]
+// ExpirePunishments removes every punishment whose duration has elapsed and
+// returns their types. Unlike CheckExpiredPunishments it leaves the database
+// alone so the caller can delete the persisted rows and notify the client.
+func (client *Client) ExpirePunishments() []PunishmentType {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	return client.dropExpiredLocked(time.Now().UTC())
+}
+
+// dropExpiredLocked removes every punishment that expired before now and
+// returns their types, keeping the force-display counter in step.
+// The caller must hold client.mu.
+func (client *Client) dropExpiredLocked(now time.Time) []PunishmentType {
+	var expired []PunishmentType
+	w := 0
+	for i := range client.punishments {
+		p := client.punishments[i]
 		if !p.expiresAt.IsZero() && now.After(p.expiresAt) {
-			pType := p.punishmentType
-			client.punishments = append(client.punishments[:i], client.punishments[i+1:]...)
-			removed = true
-			go func(ipid string, t PunishmentType) {
-				if err := db.DeleteTextPunishment(ipid, int(t)); err != nil {
-					logger.LogErrorf("Failed to remove expired punishment from DB for %v: %v", ipid, err)
-				}
-			}(client.ipid, pType)
+			expired = append(expired, p.punishmentType)
+			if p.punishmentType == PunishmentForceDisplay {
+				activeForceDisplay.Add(-1)
+			}
+			continue
 		}
+		client.punishments[w] = p
+		w++
 	}
-
-	return removed
+	client.punishments = client.punishments[:w]
+	return expired
 }
 
 // GetActivePunishments returns a copy of all active punishments.
@@ -2522,29 +2748,16 @@ func (client *Client) CheckExpiredAndGetPunishments() (wasExpired bool, active [
 		return false, nil
 	}
 
-	now := time.Now().UTC()
-	w := 0
-	for i := range client.punishments {
-		p := &client.punishments[i]
-		if !p.expiresAt.IsZero() && now.After(p.expiresAt) {
-			wasExpired = true
-			pType := p.punishmentType
-			if pType == PunishmentForceDisplay {
-				activeForceDisplay.Add(-1)
+	for _, pType := range client.dropExpiredLocked(time.Now().UTC()) {
+		wasExpired = true
+		go func(ipid string, t PunishmentType) {
+			if err := db.DeleteTextPunishment(ipid, int(t)); err != nil {
+				logger.LogErrorf("Failed to remove expired punishment from DB for %v: %v", ipid, t)
 			}
-			ipid := client.ipid
-			go func() {
-				if err := db.DeleteTextPunishment(ipid, int(pType)); err != nil {
-					logger.LogErrorf("Failed to remove expired punishment from DB for %v: %v", ipid, pType)
-				}
-			}()
-		} else {
-			client.punishments[w] = client.punishments[i]
-			w++
-		}
+		}(client.ipid, pType)
 	}
-	client.punishments = client.punishments[:w]
 
+	w := len(client.punishments)
 	if w == 0 {
 		return wasExpired, nil
 	}
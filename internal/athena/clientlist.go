@@ -115,6 +115,24 @@ func (cl *ClientList) Count() int {
 	return n
 }
 
+// GetByHDID returns a slice of all clients whose HDID matches hdid, with the
+// same allocation and locking behaviour as GetByIPID. An empty hdid matches
+// nothing, since clients that never sent HI have no HDID.
+func (cl *ClientList) GetByHDID(hdid string) []*Client {
+	if hdid == "" {
+		return nil
+	}
+	cl.mu.RLock()
+	var result []*Client
+	for c := range cl.list {
+		if c.Hdid() == hdid {
+			result = append(result, c)
+		}
+	}
+	cl.mu.RUnlock()
+	return result
+}
+
 // GetByIPID returns a slice of all clients whose IPID matches ipid.
 // The slice is freshly allocated on each call; the read lock is held only
 // for the iteration itself so callers may safely invoke client methods after.
@@ -17,6 +17,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package athena
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -95,3 +96,129 @@ func TestCoinflipChoiceValidation(t *testing.T) {
 		}
 	}
 }
+
+// TestCoinflipSolo checks that -s flips right away without opening an area
+// challenge, and that the solo cooldown applies.
+func TestCoinflipSolo(t *testing.T) {
+	newTestClients(t)
+	a := newTestArea()
+	conn := &captureConn{}
+	client := &Client{conn: conn, uid: 1, area: a, oocName: "Phoenix"}
+	clients.AddClient(client)
+	clients.RegisterUID(client)
+
+	cmdCoinflip(client, []string{"-s", "heads"}, "usage")
+	if a.ActiveCoinflip() != nil {
+		t.Fatal("a solo flip must not create an area challenge")
+	}
+	if out := conn.String(); !strings.Contains(out, "called heads") || !strings.Contains(out, "landed on") {
+		t.Errorf("solo result was not announced, got %q", out)
+	}
+
+	cmdCoinflip(client, []string{"-s", "tails"}, "usage")
+	if !strings.Contains(conn.String(), "before flipping again") {
+		t.Error("a second solo flip inside the cooldown should be refused")
+	}
+}
+
+// TestRpsCooldown verifies /rps honours the configured cooldown, and that a
+// cooldown of 0 lets a player start a new game straight away.
+func TestRpsCooldown(t *testing.T) {
+	newTestClients(t)
+	a := newTestArea()
+	t.Cleanup(func() {
+		rpsStateMu.Lock()
+		delete(rpsState, a)
+		rpsStateMu.Unlock()
+	})
+	orig := rpsCooldown
+	t.Cleanup(func() { rpsCooldown = orig })
+
+	conn := &captureConn{}
+	first := &Client{conn: conn, uid: 1, ipid: "a", area: a, oocName: "Phoenix"}
+	second := &Client{conn: &captureConn{}, uid: 2, ipid: "b", area: a, oocName: "Edgeworth"}
+	for _, c := range []*Client{first, second} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdRps(first, []string{"rock"}, "usage")
+	cmdRps(second, []string{"paper"}, "usage")
+	before := len(conn.String())
+	cmdRps(first, []string{"rock"}, "usage")
+	if !strings.Contains(conn.String()[before:], "Please wait") {
+		t.Fatal("a second game inside the cooldown should be refused")
+	}
+
+	rpsCooldown = 0
+	before = len(conn.String())
+	cmdRps(first, []string{"rock"}, "usage")
+	if !strings.Contains(conn.String()[before:], "has thrown an RPS challenge") {
+		t.Errorf("with the cooldown off a new challenge should open, got %q", conn.String()[before:])
+	}
+}
+
+// TestPollTallies runs a three-voter poll, including a changed vote, and
+// checks the results the area sees.
+func TestPollTallies(t *testing.T) {
+	newTestClients(t)
+	a := newTestArea()
+	t.Cleanup(a.ClearPoll)
+
+	conn := &captureConn{}
+	voters := make([]*Client, 3)
+	for i := range voters {
+		voters[i] = &Client{conn: conn, uid: i + 1, ipid: "v", area: a, oocName: "Voter"}
+		clients.AddClient(voters[i])
+		clients.RegisterUID(voters[i])
+	}
+
+	cmdPoll(voters[0], strings.Split("Best attorney?|Phoenix|Edgeworth|Mia", " "), "usage")
+	cmdVote(voters[0], []string{"1"}, "usage")
+	cmdVote(voters[1], []string{"2"}, "usage")
+	cmdVote(voters[2], []string{"2"}, "usage")
+	cmdVote(voters[1], []string{"3"}, "usage")
+
+	if v := a.PollVotes(); v[1] != 1 || v[2] != 1 || v[3] != 1 {
+		t.Errorf("tallies = %v, want one vote for each option", v)
+	}
+	out := conn.String()
+	if !strings.Contains(out, "You changed your vote from Edgeworth to Mia") {
+		t.Error("a changed vote should be confirmed to the voter")
+	}
+	if !strings.Contains(out, "1. Phoenix - 1 votes\n2. Edgeworth - 1 votes\n3. Mia - 1 votes") {
+		t.Errorf("the last update should show the corrected tallies, got %q", out)
+	}
+}
+
+// TestPollPublicMode checks that only -p polls reveal who voted for what,
+// in the results and in the area buffer.
+func TestPollPublicMode(t *testing.T) {
+	newTestClients(t)
+	conn := &captureConn{}
+	for _, public := range []bool{false, true} {
+		a := newTestArea()
+		voter := &Client{conn: conn, uid: 7, ipid: "v", area: a, oocName: "Voter"}
+		clients.AddClient(voter)
+		clients.RegisterUID(voter)
+
+		args := strings.Split("Guilty?|Yes|No", " ")
+		if public {
+			args = append([]string{"-p"}, args...)
+		}
+		cmdPoll(voter, args, "usage")
+		before := len(conn.String())
+		cmdVote(voter, []string{"2"}, "usage")
+		out := conn.String()[before:]
+		buffer := strings.Join(a.Buffer(), "\n")
+		a.ClearPoll()
+
+		if public {
+			if !strings.Contains(out, "2. No - 1 votes (UID 7)") || !strings.Contains(buffer, "Voted for option 2") {
+				t.Errorf("public poll should list voters, got %q / %q", out, buffer)
+			}
+		} else if strings.Contains(out, "UID") || strings.Contains(buffer, "option 2") || !strings.Contains(buffer, "Voted in poll") {
+			t.Errorf("anonymous poll leaked the vote, got %q / %q", out, buffer)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+)
+
+func TestAnnounceScopesToAreas(t *testing.T) {
+	newTestClients(t)
+	origLogPath := logger.LogPath
+	t.Cleanup(func() { logger.LogPath = origLogPath })
+	logger.LogPath = t.TempDir()
+
+	lobby := makeTestArea("Lobby")
+	court := makeTestArea("Courtroom")
+	defer setupTestAreas([]*area.Area{lobby, court})()
+
+	modConn, lobbyConn, courtConn := &captureConn{}, &captureConn{}, &captureConn{}
+	mod := &Client{conn: modConn, uid: 1, ipid: "ip-mod", area: lobby}
+	inLobby := &Client{conn: lobbyConn, uid: 2, ipid: "ip-lobby", area: lobby}
+	inCourt := &Client{conn: courtConn, uid: 3, ipid: "ip-court", area: court}
+	for _, c := range []*Client{mod, inLobby, inCourt} {
+		clients.AddClient(c)
+	}
+
+	cmdAnnounce(mod, []string{"-a", "1", "Trial", "starts", "soon"}, "")
+	if !strings.Contains(courtConn.String(), "Trial starts soon") {
+		t.Error("player in the targeted area did not get the announcement")
+	}
+	if strings.Contains(lobbyConn.String(), "Trial starts soon") {
+		t.Error("player outside the targeted area got the announcement")
+	}
+	if !strings.Contains(modConn.String(), "1 player(s) in Courtroom") {
+		t.Errorf("unexpected confirmation: %q", modConn.String())
+	}
+
+	cmdAnnounce(mod, []string{"Server", "restart"}, "")
+	for name, c := range map[string]*captureConn{"lobby": lobbyConn, "court": courtConn} {
+		if !strings.Contains(c.String(), "Server restart") {
+			t.Errorf("%v player missed the server-wide announcement", name)
+		}
+	}
+
+	cmdAnnounce(mod, []string{"-a", "7", "nope"}, "")
+	if !strings.Contains(modConn.String(), "Invalid area ID") {
+		t.Error("out-of-range area ID was not rejected")
+	}
+}
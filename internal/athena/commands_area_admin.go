@@ -17,6 +17,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package athena
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -25,11 +26,14 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
 	"github.com/MangosArentLiterature/Athena/internal/packet"
 	"github.com/MangosArentLiterature/Athena/internal/permissions"
 	"github.com/MangosArentLiterature/Athena/internal/sliceutil"
+	str2duration "github.com/xhit/go-str2duration/v2"
 )
 
 func cmdAbout(client *Client, _ []string, _ string) {
@@ -99,9 +103,17 @@ func cmdAreaInfo(client *Client, _ []string, _ string) {
 			casinoStatus += fmt.Sprintf(", jackpot pool: %d", a.CasinoJackpotPool())
 		}
 	}
-	out := fmt.Sprintf("\nBG: %v\nEvi mode: %v\nAllow iniswap: %v\nNon-interrupting pres: %v\nCMs allowed: %v\nForce BG list: %v\nBG locked: %v\nMusic locked (CM-only): %v\nMusic frozen (all blocked): %v\nSpectate mode: %v\nCasino: %v",
+	maxLen := fmt.Sprintf("%d (server default)", config.MaxMsg)
+	if n := a.MaxMsgLen(); n > 0 {
+		maxLen = fmt.Sprintf("%d", n)
+	}
+	password := "unset"
+	if a.HasPassword() {
+		password = "set"
+	}
+	out := fmt.Sprintf("\nBG: %v\nEvi mode: %v\nAllow iniswap: %v\nNon-interrupting pres: %v\nCMs allowed: %v\nForce BG list: %v\nBG locked: %v\nMusic locked (CM-only): %v\nMusic frozen (all blocked): %v\nSpectate mode: %v\nPassword: %v\nMax IC length: %v\nLog buffer: %d lines\nCasino: %v",
 		a.Background(), a.EvidenceMode().String(), a.IniswapAllowed(), a.NoInterrupt(),
-		a.CMsAllowed(), a.ForceBGList(), a.LockBG(), a.LockMusic(), a.MusicFrozen(), a.SpectateMode(), casinoStatus)
+		a.CMsAllowed(), a.ForceBGList(), a.LockBG(), a.LockMusic(), a.MusicFrozen(), a.SpectateMode(), password, maxLen, a.BufferSize(), casinoStatus)
 	client.SendServerMessage(out)
 }
 
@@ -169,7 +181,7 @@ func cmdCharSelect(client *Client, args []string, _ string) {
 			client.SendServerMessage("You do not have permission to use that command.")
 			return
 		}
-		toChange := getUidList(strings.Split(args[0], ","))
+		toChange := getUidList(client, strings.Split(args[0], ","))
 		var count int
 		var report string
 		for _, c := range toChange {
@@ -178,7 +190,7 @@ func cmdCharSelect(client *Client, args []string, _ string) {
 			}
 			c.ChangeCharacter(-1)
 			c.Send(&packet.DONE{})
-			c.SendServerMessage("You were moved back to character select.")
+			c.SendLocalized("moved_to_charselect")
 			count++
 			report += fmt.Sprintf("%v, ", c.Uid())
 		}
@@ -212,6 +224,9 @@ func cmdRandomChar(client *Client, _ []string, _ string) {
 		client.SendServerMessage("You have been tunged and cannot change characters until the effect is removed.")
 		return
 	}
+	if client.characterSlotBlocked() {
+		return
+	}
 	newid := getRandomFreeChar(client)
 	if newid == -1 {
 		client.SendServerMessage("No free characters available.")
@@ -270,6 +285,10 @@ func cmdForceRandomChar(client *Client, args []string, _ string) {
 			client.SendServerMessage(fmt.Sprintf("Client with UID %v does not exist.", uid))
 			return
 		}
+		if target.characterSlotDenied() {
+			client.SendServerMessage("All player slots are taken, so that spectator cannot be given a character.")
+			return
+		}
 		newid := getRandomFreeChar(target)
 		if newid == -1 {
 			client.SendServerMessage("No free characters available for that player.")
@@ -294,6 +313,9 @@ func cmdForceRandomChar(client *Client, args []string, _ string) {
 			return
 		}
 		c.ChangeCharacter(newid)
+		if c.CharID() != newid {
+			return
+		}
 		if c != client {
 			c.SendServerMessage("An admin forced all players in the area to a random character.")
 		}
@@ -344,7 +366,7 @@ func cmdForcePos(client *Client, args []string, _ string) {
 			}
 		})
 	} else {
-		targets = getUidList(strings.Split(targetArg, ","))
+		targets = getUidList(client, strings.Split(targetArg, ","))
 	}
 
 	var count int
@@ -395,7 +417,7 @@ func cmdCM(client *Client, args []string, _ string) {
 			client.SendServerMessage("You do not have permission to use that command.")
 			return
 		}
-		toCM := getUidList(strings.Split(args[0], ","))
+		toCM := getUidList(client, strings.Split(args[0], ","))
 		var count int
 		var report string
 		for _, c := range toCM {
@@ -420,6 +442,7 @@ func cmdDoc(client *Client, args []string, _ string) {
 	flags := flag.NewFlagSet("", 0)
 	flags.SetOutput(io.Discard)
 	clear := flags.Bool("c", false, "")
+	prev := flags.Bool("prev", false, "")
 	flags.Parse(args)
 	if len(args) == 0 {
 		if client.Area().Doc() == "" {
@@ -432,18 +455,46 @@ func cmdDoc(client *Client, args []string, _ string) {
 		if !client.HasCMPermission() {
 			client.SendServerMessage("You do not have permission to change the doc.")
 			return
+		} else if *prev {
+			if _, ok := client.Area().RestorePreviousDoc(); !ok {
+				client.SendServerMessage("There is no previous doc to restore.")
+				return
+			}
+			sendAreaServerMessage(client.Area(), fmt.Sprintf("%v restored the previous doc.", client.OOCName()))
+			return
 		} else if *clear {
 			client.Area().SetDoc("")
 			sendAreaServerMessage(client.Area(), fmt.Sprintf("%v cleared the doc.", client.OOCName()))
 			return
 		} else if len(flags.Args()) != 0 {
-			client.Area().SetDoc(flags.Arg(0))
+			doc := strings.Join(flags.Args(), " ")
+			if !validDocLink(doc) {
+				client.SendServerMessage("That looks like a link but isn't a valid http(s) URL.")
+				return
+			}
+			client.Area().SetDoc(doc)
 			sendAreaServerMessage(client.Area(), fmt.Sprintf("%v updated the doc.", client.OOCName()))
 			return
 		}
 	}
 }
 
+// validDocLink reports whether a /doc value is acceptable. Plain text,
+// including text with a link in it, is always accepted; a lone token that
+// looks like a link (it contains a scheme or starts with "www.") must be an
+// http(s) URL with a host.
+func validDocLink(doc string) bool {
+	lower := strings.ToLower(doc)
+	if strings.ContainsAny(doc, " \t\n") || (!strings.Contains(lower, "://") && !strings.HasPrefix(lower, "www.")) {
+		return true
+	}
+	if strings.HasPrefix(lower, "www.") {
+		doc = "https://" + doc
+	}
+	u, err := url.ParseRequestURI(doc)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
 // Handles /editban
 
 func cmdSetEviMod(client *Client, args []string, _ string) {
@@ -462,6 +513,8 @@ func cmdSetEviMod(client *Client, args []string, _ string) {
 		client.Area().SetEvidenceMode(area.EviCMs)
 	case "any":
 		client.Area().SetEvidenceMode(area.EviAny)
+	case "owner":
+		client.Area().SetEvidenceMode(area.EviOwner)
 	default:
 		client.SendServerMessage("Invalid evidence mode.")
 		return
@@ -470,14 +523,52 @@ func cmdSetEviMod(client *Client, args []string, _ string) {
 	addToBuffer(client, "CMD", fmt.Sprintf("Set the evidence mode to %v.", args[0]), false)
 }
 
+// bgListPageSize is how many backgrounds one page of /bglist shows.
+const bgListPageSize = 50
+
 // Handles /bglist
 
-func cmdBgList(client *Client, _ []string, _ string) {
-	if len(getBackgrounds()) == 0 {
+func cmdBgList(client *Client, args []string, usage string) {
+	bgs := getBackgrounds()
+	if len(bgs) == 0 {
 		client.SendServerMessage("No backgrounds are available.")
 		return
 	}
-	client.SendServerMessage(getBgListStr())
+	page := 1
+	if len(args) > 0 {
+		v, err := strconv.Atoi(args[0])
+		if err != nil || v < 1 {
+			client.SendServerMessage(usage)
+			return
+		}
+		page = v
+	}
+	totalPages := (len(bgs) + bgListPageSize - 1) / bgListPageSize
+	if page > totalPages {
+		client.SendServerMessage(fmt.Sprintf("No page %d; the background list has %d page(s).", page, totalPages))
+		return
+	}
+	note := ""
+	if client.Area().ForceBGList() {
+		note = "\nThis area only accepts backgrounds from this list."
+	}
+	if totalPages == 1 {
+		client.SendServerMessage(getBgListStr() + note)
+		return
+	}
+
+	start := (page - 1) * bgListPageSize
+	end := start + bgListPageSize
+	if end > len(bgs) {
+		end = len(bgs)
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Available backgrounds — Page %d/%d (%d total):\n", page, totalPages, len(bgs)))
+	sb.WriteString(strings.Join(bgs[start:end], "\n"))
+	if page < totalPages {
+		sb.WriteString(fmt.Sprintf("\nUse /bglist %d for the next page.", page+1))
+	}
+	client.SendServerMessage(sb.String() + note)
 }
 
 // Handles /forcebglist
@@ -515,6 +606,7 @@ func cmdInvite(client *Client, args []string, _ string) {
 	// "all" (any case) invites every player currently in the caller's area;
 	// otherwise the argument is a comma-separated UID list resolved server-wide.
 	var toInvite []*Client
+	var notFound []string
 	if strings.EqualFold(args[0], "all") {
 		targetArea := client.Area()
 		clients.ForEach(func(c *Client) {
@@ -523,31 +615,58 @@ func cmdInvite(client *Client, args []string, _ string) {
 			}
 		})
 	} else {
-		toInvite = getUidList(strings.Split(args[0], ","))
+		uids := strings.Split(args[0], ",")
+		toInvite = getUidList(nil, uids)
+		notFound = unresolvedUids(uids)
 	}
 	var count int
 	var report string
+	var already, notHere []string
 	for _, c := range toInvite {
-		invited := false
+		invited, wasInvited := false, false
 		// Locked area: grant entry to the area.
-		if locked && client.Area().AddInvited(c.Uid()) {
-			c.SendServerMessage(fmt.Sprintf("You were invited to area %v.", client.Area().Name()))
-			invited = true
+		if locked {
+			if client.Area().AddInvited(c.Uid()) {
+				c.SendServerMessage(fmt.Sprintf("You were invited to area %v.", client.Area().Name()))
+				invited = true
+			} else {
+				wasInvited = true
+			}
 		}
 		// Spectate mode: grant the right to speak in IC. The spectate-invite
 		// list mirrors /spectate invite and only applies to players already
 		// in the area, so guard on that as /spectate invite does.
-		if spectating && c.Area() == client.Area() && client.Area().AddSpectateInvited(c.Uid()) {
-			c.SendServerMessage("You were invited to speak in IC during spectate mode.")
-			invited = true
+		if spectating && c.Area() == client.Area() {
+			if client.Area().AddSpectateInvited(c.Uid()) {
+				c.SendServerMessage("You were invited to speak in IC during spectate mode.")
+				invited = true
+			} else {
+				wasInvited = true
+			}
 		}
-		if invited {
+		switch {
+		case invited:
 			count++
 			report += fmt.Sprintf("%v, ", c.Uid())
+		case wasInvited:
+			already = append(already, strconv.Itoa(c.Uid()))
+		default:
+			// Spectate mode only, and the target is in another area.
+			notHere = append(notHere, strconv.Itoa(c.Uid()))
 		}
 	}
 	report = strings.TrimSuffix(report, ", ")
-	client.SendServerMessage(fmt.Sprintf("Invited %v users.", count))
+	msg := fmt.Sprintf("Invited %v users.", count)
+	if len(already) > 0 {
+		msg += fmt.Sprintf("\nAlready invited: %v.", strings.Join(already, ", "))
+	}
+	if len(notHere) > 0 {
+		msg += fmt.Sprintf("\nNot in this area (spectate invites need them here): %v.", strings.Join(notHere, ", "))
+	}
+	if len(notFound) > 0 {
+		msg += fmt.Sprintf("\nNo player found with UID: %v.", strings.Join(notFound, ", "))
+	}
+	client.SendServerMessage(msg)
 	addToBuffer(client, "CMD", fmt.Sprintf("Invited %v to the area.", report), false)
 }
 
@@ -558,7 +677,7 @@ func cmdAreaKick(client *Client, args []string, _ string) {
 		client.SendServerMessage("Failed to kick: Cannot kick a user from area 0.")
 		return
 	}
-	toKick := getUidList(strings.Split(args[0], ","))
+	toKick := getUidList(client, strings.Split(args[0], ","))
 	originArea := client.Area()
 
 	var count int
@@ -705,6 +824,51 @@ func cmdJudgeButtons(client *Client, args []string, _ string) {
 	addToBuffer(client, "CMD", fmt.Sprintf("Set judge buttons to %v.", args[0]), false)
 }
 
+// Handles /maxlen <n|off> - caps IC message length in this area. The cap can
+// only tighten the server-wide max_message_length, never raise it.
+
+func cmdMaxLen(client *Client, args []string, usage string) {
+	if strings.ToLower(args[0]) == "off" {
+		client.Area().SetMaxMsgLen(0)
+		sendAreaServerMessage(client.Area(), fmt.Sprintf("%v has removed the IC length limit in this area.", client.OOCName()))
+		addToBuffer(client, "CMD", "Removed the area IC length limit.", false)
+		return
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < 1 || n > config.MaxMsg {
+		client.SendServerMessage(fmt.Sprintf("Length must be between 1 and %d, or 'off'.\n%v", config.MaxMsg, usage))
+		return
+	}
+	client.Area().SetMaxMsgLen(n)
+	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v has limited IC messages in this area to %d characters.", client.OOCName(), n))
+	addToBuffer(client, "CMD", fmt.Sprintf("Set the area IC length limit to %d.", n), false)
+}
+
+// maxAreaBufSize caps /bufsize so a CM can't make an area hold an unbounded log.
+const maxAreaBufSize = 1000
+
+// Handles /bufsize
+
+func cmdBufSize(client *Client, args []string, usage string) {
+	a := client.Area()
+	if strings.ToLower(args[0]) == "off" {
+		a.SetBufferSize(config.BufSize)
+		sendAreaServerMessage(a, fmt.Sprintf("%v has restored this area's log buffer to the server default (%d lines).", client.OOCName(), config.BufSize))
+		addToBuffer(client, "CMD", fmt.Sprintf("Restored the area log buffer to %d lines.", config.BufSize), false)
+		return
+	}
+	// The floor is the server default so the command can only extend history,
+	// never shrink it to wipe recent lines out of /log.
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n < config.BufSize || n > maxAreaBufSize {
+		client.SendServerMessage(fmt.Sprintf("Size must be between %d and %d lines, or 'off'.\n%v", config.BufSize, maxAreaBufSize, usage))
+		return
+	}
+	a.SetBufferSize(n)
+	sendAreaServerMessage(a, fmt.Sprintf("%v has set this area's log buffer to %d lines.", client.OOCName(), n))
+	addToBuffer(client, "CMD", fmt.Sprintf("Set the area log buffer to %d lines.", n), false)
+}
+
 // Handles /punishmentsafe <true|false> - toggles punishment-safe mode in this
 // area. While enabled, moderators, shadow mods, and admins cannot apply any
 // punishment-system effect (text effects, dere archetypes, protocol/voice
@@ -755,19 +919,35 @@ func cmdMusicUnlock(client *Client, _ []string, _ string) {
 
 // Handles /log
 
-func cmdLog(client *Client, args []string, _ string) {
-	wantedArea, err := strconv.Atoi(args[0])
+func cmdLog(client *Client, args []string, usage string) {
+	flags := flag.NewFlagSet("", 0)
+	flags.SetOutput(io.Discard)
+	useRegex := flags.Bool("e", false, "")
+	if err := flags.Parse(args); err != nil || flags.NArg() == 0 {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
+	wantedArea, _, err := resolveArea(flags.Arg(0))
 	if err != nil {
-		client.SendServerMessage("Invalid area.")
+		client.SendServerMessage(invalidAreaMessage())
 		return
 	}
-	for i, a := range areas {
-		if i == wantedArea {
-			client.SendServerMessage(strings.Join(a.Buffer(), "\n"))
-			return
+	match, err := compileLogFilter(strings.Join(flags.Args()[1:], " "), *useRegex)
+	if err != nil {
+		client.SendServerMessage(err.Error())
+		return
+	}
+	var lines []string
+	for _, line := range wantedArea.Buffer() {
+		if match(line) {
+			lines = append(lines, line)
 		}
 	}
-	client.SendServerMessage("Invalid area.")
+	if len(lines) == 0 {
+		client.SendServerMessage("No matching log entries.")
+		return
+	}
+	client.SendServerMessage(strings.Join(lines, "\n"))
 }
 
 // Handles /login
@@ -776,6 +956,29 @@ func cmdMotd(client *Client, _ []string, _ string) {
 	client.SendMotd(GetMotd())
 }
 
+// Handles /areapass
+
+func cmdAreaPass(client *Client, args []string, usage string) {
+	flags := flag.NewFlagSet("", 0)
+	flags.SetOutput(io.Discard)
+	clear := flags.Bool("c", false, "")
+	flags.Parse(args)
+	if *clear {
+		client.Area().SetPassword("")
+		sendAreaServerMessage(client.Area(), fmt.Sprintf("%v removed the area password.", client.OOCName()))
+		addToBuffer(client, "CMD", "Removed the area password.", false)
+		return
+	}
+	if len(flags.Args()) != 1 {
+		client.SendServerMessage("The password must be a single word.\n" + usage)
+		return
+	}
+	client.Area().SetPassword(flags.Arg(0))
+	client.SendServerMessage(fmt.Sprintf("Area password set. Players can now enter with /move %v <password>.", getAreaIndex(client.Area())))
+	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v set an area password.", client.OOCName()))
+	addToBuffer(client, "CMD", "Set the area password.", false)
+}
+
 // Handles /move
 
 func cmdMove(client *Client, args []string, usage string) {
@@ -783,32 +986,54 @@ func cmdMove(client *Client, args []string, usage string) {
 	flags.SetOutput(io.Discard)
 	uids := &[]string{}
 	flags.Var(&cmdParamList{uids}, "u", "")
+	from := flags.String("f", "", "")
 	flags.Parse(args)
 
 	if len(flags.Args()) < 1 {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	areaID, err := strconv.Atoi(flags.Arg(0))
-	if err != nil || areaID < 0 || areaID > len(areas)-1 {
-		client.SendServerMessage("Invalid area.")
+	wantedArea, areaID, err := resolveArea(strings.Join(flags.Args(), " "))
+	// "/move <area> <password>": if the whole argument isn't an area, try the
+	// last word as the area's password.
+	var password string
+	if err != nil && len(*uids) == 0 && *from == "" && len(flags.Args()) > 1 {
+		last := len(flags.Args()) - 1
+		wantedArea, areaID, err = resolveArea(strings.Join(flags.Args()[:last], " "))
+		password = flags.Args()[last]
+	}
+	if err != nil {
+		client.SendServerMessage(invalidAreaMessage())
+		return
+	}
+
+	if *from != "" {
+		if !permissions.HasPermission(client.Perms(), permissions.PermissionField["MOVE_USERS"]) {
+			client.SendServerMessage("You do not have permission to use that command.")
+			return
+		}
+		source, fromID, err := resolveArea(*from)
+		if err != nil {
+			client.SendServerMessage("Invalid source area.")
+			return
+		}
+		moveAreaOccupants(client, source, fromID, wantedArea, areaID)
 		return
 	}
-	wantedArea := areas[areaID]
 
 	if len(*uids) > 0 {
 		if !permissions.HasPermission(client.Perms(), permissions.PermissionField["MOVE_USERS"]) {
 			client.SendServerMessage("You do not have permission to use that command.")
 			return
 		}
-		toMove := getUidList(*uids)
+		toMove := getUidList(client, *uids)
 		var count int
 		var report string
 		for _, c := range toMove {
 			if !c.ChangeArea(wantedArea) {
 				continue
 			}
-			c.SendServerMessage(fmt.Sprintf("You were moved to %v.", wantedArea.Name()))
+			c.SendLocalized("moved_to", wantedArea.Name())
 			count++
 			report += fmt.Sprintf("%v, ", c.Uid())
 		}
@@ -816,6 +1041,19 @@ func cmdMove(client *Client, args []string, usage string) {
 		client.SendServerMessage(fmt.Sprintf("Moved %v users.", count))
 		addToBuffer(client, "CMD", fmt.Sprintf("Moved %v to %v.", report, wantedArea.Name()), false)
 	} else {
+		if password != "" {
+			if wait, remaining := client.CheckAreaPassCooldown(); wait {
+				client.SendServerMessage(fmt.Sprintf("Please wait %d second(s) before trying another area password.", remaining))
+				return
+			}
+			if !wantedArea.CheckPassword(password) {
+				client.SetLastAreaPassFail()
+				addToBuffer(client, "CMD", fmt.Sprintf("Gave a wrong password for %v.", wantedArea.Name()), false)
+				client.SendServerMessage("Incorrect area password.")
+				return
+			}
+			wantedArea.AddInvited(client.Uid())
+		}
 		if !client.ChangeArea(wantedArea) {
 			client.SendServerMessage("You are not invited to that area.")
 		}
@@ -823,6 +1061,54 @@ func cmdMove(client *Client, args []string, usage string) {
 	}
 }
 
+// moveAreaOccupants handles /move -f: everyone in source (area fromID) is
+// moved to dest (area toID). A locked destination gets each mover added to
+// its invite list first, so the consolidation isn't blocked by the lock.
+func moveAreaOccupants(client *Client, source *area.Area, fromID int, dest *area.Area, toID int) {
+	if fromID == toID {
+		client.SendServerMessage("The source and destination areas are the same.")
+		return
+	}
+	// Area 0 is where every new connection lands; sweeping it would keep
+	// catching people mid-join, so it can only be a destination.
+	if fromID == 0 {
+		client.SendServerMessage("Cannot bulk-move everyone out of area 0.")
+		return
+	}
+
+	var toMove []*Client
+	clients.ForEach(func(c *Client) {
+		if c.Uid() != -1 && c.Area() == source {
+			toMove = append(toMove, c)
+		}
+	})
+	if len(toMove) == 0 {
+		client.SendServerMessage(fmt.Sprintf("Nobody is in %v.", source.Name()))
+		return
+	}
+
+	var moved, skipped []string
+	for _, c := range toMove {
+		if dest.Lock() != area.LockFree {
+			dest.AddInvited(c.Uid())
+		}
+		if !c.ChangeArea(dest) {
+			skipped = append(skipped, strconv.Itoa(c.Uid()))
+			continue
+		}
+		if c != client {
+			c.SendLocalized("moved_to", dest.Name())
+		}
+		moved = append(moved, strconv.Itoa(c.Uid()))
+	}
+	msg := fmt.Sprintf("Moved %v user(s) from %v to %v.", len(moved), source.Name(), dest.Name())
+	if len(skipped) > 0 {
+		msg += fmt.Sprintf(" Skipped %v: %v.", len(skipped), strings.Join(skipped, ", "))
+	}
+	client.SendServerMessage(msg)
+	addToBuffer(client, "CMD", fmt.Sprintf("Moved everyone in %v (%v) to %v.", source.Name(), strings.Join(moved, ", "), dest.Name()), false)
+}
+
 // Handles /summon
 
 func cmdSummon(client *Client, args []string, usage string) {
@@ -831,12 +1117,11 @@ func cmdSummon(client *Client, args []string, usage string) {
 		return
 	}
 
-	areaID, err := strconv.Atoi(args[0])
-	if err != nil || areaID < 0 || areaID > len(areas)-1 {
-		client.SendServerMessage("Invalid area.")
+	wantedArea, _, err := resolveArea(strings.Join(args, " "))
+	if err != nil {
+		client.SendServerMessage(invalidAreaMessage())
 		return
 	}
-	wantedArea := areas[areaID]
 	wantedAreaName := wantedArea.Name()
 
 	var count int
@@ -923,30 +1208,114 @@ func cmdPlay(client *Client, args []string, _ string) {
 		client.SendServerMessage("You are not allowed to change the music in this area.")
 		return
 	}
-	s := strings.Join(args, " ")
+	playTrack(client, strings.Join(args, " "))
+}
 
-	// Check if the song we got is a URL for streaming
+// trackError reports why s cannot be played in a, or "" if it can. URLs must
+// be YouTube links (when configured) or come from a whitelisted CDN; with the
+// music locked, other tracks must come from the area's music list.
+func trackError(a *area.Area, s string) string {
 	if _, err := url.ParseRequestURI(s); err == nil {
 		s, err = url.QueryUnescape(s) // Unescape any URL encoding
 		if err != nil {
-			client.SendServerMessage("Error parsing URL.")
-			return
+			return "Error parsing URL."
 		}
-		// YouTube short-circuit: if the URL is a recognized YouTube link and
-		// the feature is configured, hand off to the yt-dlp pipeline. The
-		// handler emits its own MC packet (or OOC error) so we stop here.
-		if tryYouTubePlay(client, s) {
-			return
+		if extractYouTubeID(s) != "" {
+			if !youTubeEnabled() {
+				return "YouTube /play is not configured on this server."
+			}
+			return ""
 		}
 		if !isAllowedCDN(s) {
-			client.SendServerMessage("That URL is not from a whitelisted CDN. Add the domain to cdns.txt to allow it.")
-			return
+			return "That URL is not from a whitelisted CDN. Add the domain to cdns.txt to allow it."
+		}
+	} else if a.LockMusic() && !sliceutil.ContainsString(areaMusicList(a), s) {
+		return "That track is not in this area's music list."
+	}
+	return ""
+}
+
+// playTrack plays s in client's area as client, applying the same URL, CDN
+// and locked-music checks as /play. Permission checks are left to the caller.
+// It reports whether the track was accepted.
+func playTrack(client *Client, s string) bool {
+	if msg := trackError(client.Area(), s); msg != "" {
+		client.SendServerMessage(msg)
+		return false
+	}
+	if _, err := url.ParseRequestURI(s); err == nil {
+		s, _ = url.QueryUnescape(s)
+		// YouTube short-circuit: hand off to the yt-dlp pipeline, which emits
+		// its own MC packet (or OOC error).
+		if tryYouTubePlay(client, s) {
+			return true
 		}
 	}
 	broadcastToArea(client.Area(), &packet.MCToClient{
 		Name: s, CharID: client.CharID(), Showname: client.Showname(),
 		Looping: "1", Channel: "0", Effects: "0",
 	})
+	return true
+}
+
+// Handles /songqueue
+
+func cmdSongQueue(client *Client, args []string, usage string) {
+	a := client.Area()
+	if len(args) == 0 {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
+	switch strings.ToLower(args[0]) {
+	case "list":
+		queue := a.SongQueue()
+		if len(queue) == 0 {
+			client.SendServerMessage("No songs are queued in this area.")
+			return
+		}
+		lines := make([]string, 0, len(queue))
+		for i, req := range queue {
+			lines = append(lines, fmt.Sprintf("  %d. %v (requested by [%d] %v)", i+1, req.Song, req.UID, req.Name))
+		}
+		client.SendServerMessage(fmt.Sprintf("Song queue for %v:\n%v", a.Name(), strings.Join(lines, "\n")))
+		return
+	case "next":
+		if !client.HasCMPermission() && !permissions.HasPermission(client.Perms(), permissions.PermissionField["DJ"]) {
+			client.SendServerMessage("You must be a CM or DJ to play the next queued song.")
+			return
+		}
+		if a.MusicFrozen() && !permissions.IsModerator(client.Perms()) && !client.HasCMPermission() {
+			client.SendServerMessage("Music is locked in this area - no changes allowed.")
+			return
+		}
+		req, ok := a.NextSong()
+		if !ok {
+			client.SendServerMessage("No songs are queued in this area.")
+			return
+		}
+		if !playTrack(client, req.Song) {
+			return
+		}
+		sendAreaServerMessage(a, fmt.Sprintf("🎵 Now playing %v, requested by %v.", req.Song, req.Name))
+		addToBuffer(client, "CMD", fmt.Sprintf("Played queued song %v requested by %v.", req.Song, req.Name), false)
+		return
+	}
+
+	song := strings.Join(args, " ")
+	if msg := trackError(a, song); msg != "" {
+		client.SendServerMessage(msg)
+		return
+	}
+	switch a.RequestSong(area.SongRequest{Song: song, UID: client.Uid(), Name: client.OOCName()}, config.SongQueueMax) {
+	case area.SongQueueFull:
+		client.SendServerMessage(fmt.Sprintf("The song queue is full (%d requests). Try again once a CM plays the next song.", config.SongQueueMax))
+	case area.SongDuplicate:
+		client.SendServerMessage("You already have that song queued.")
+	default:
+		client.SendServerMessage(fmt.Sprintf("Queued %v (position %d).", song, len(a.SongQueue())))
+		sendAreaServerMessage(a, fmt.Sprintf("%v requested %v. A CM can play it with /songqueue next.", client.OOCName(), song))
+		addToBuffer(client, "CMD", fmt.Sprintf("Requested song %v.", song), false)
+	}
 }
 
 // Handles /randomsong
@@ -1000,29 +1369,73 @@ func cmdRandomSong(client *Client, _ []string, _ string) {
 	addToBuffer(client, "CMD", fmt.Sprintf("Played random song (%v).", song), false)
 }
 
-// Handles /players
+// Handles /status <status> [-t duration] - with -t the status reverts to idle
+// on its own once the duration passes.
 
-func cmdStatus(client *Client, args []string, _ string) {
-	switch strings.ToLower(args[0]) {
+func cmdStatus(client *Client, args []string, usage string) {
+	var name, durationStr string
+	for i := 0; i < len(args); i++ {
+		if args[i] == "-t" && i+1 < len(args) {
+			durationStr = args[i+1]
+			i++
+			continue
+		}
+		if name == "" {
+			name = args[i]
+		}
+	}
+	if name == "" {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
+	var status area.Status
+	switch strings.ToLower(name) {
 	case "idle":
-		client.Area().SetStatus(area.StatusIdle)
+		status = area.StatusIdle
 	case "looking-for-players", "lfp":
-		client.Area().SetStatus(area.StatusPlayers)
+		status = area.StatusPlayers
 	case "casing":
-		client.Area().SetStatus(area.StatusCasing)
+		status = area.StatusCasing
 	case "recess":
-		client.Area().SetStatus(area.StatusRecess)
+		status = area.StatusRecess
 	case "rp":
-		client.Area().SetStatus(area.StatusRP)
+		status = area.StatusRP
 	case "gaming":
-		client.Area().SetStatus(area.StatusGaming)
+		status = area.StatusGaming
 	default:
 		client.SendServerMessage("Status not recognized. Recognized statuses: idle, looking-for-players (or lfp), casing, recess, rp, gaming")
 		return
 	}
-	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v set the status to %v.", client.OOCName(), args[0]))
+	a := client.Area()
+	if a.Status() == area.StatusPlayers && status != area.StatusPlayers {
+		dropCaseQueue(a, status)
+	}
+	if durationStr == "" {
+		a.SetStatus(status)
+		sendAreaServerMessage(a, fmt.Sprintf("%v set the status to %v.", client.OOCName(), name))
+		sendStatusArup()
+		addToBuffer(client, "CMD", fmt.Sprintf("Set the status to %v.", name), false)
+		return
+	}
+	duration, err := str2duration.ParseDuration(durationStr)
+	if err != nil || duration <= 0 {
+		client.SendServerMessage("Invalid duration format. Use format like: 10m, 1h, 30s")
+		return
+	}
+	if duration > 24*time.Hour {
+		duration = 24 * time.Hour
+		client.SendServerMessage("Duration capped at 24 hours.")
+	}
+	a.SetStatusFor(status, duration, func() {
+		if status == area.StatusPlayers {
+			dropCaseQueue(a, area.StatusIdle)
+		}
+		sendAreaServerMessage(a, "The area status has reverted to idle.")
+		sendStatusArup()
+	})
+	sendAreaServerMessage(a, fmt.Sprintf("%v set the status to %v for %v.", client.OOCName(), name, duration))
 	sendStatusArup()
-	addToBuffer(client, "CMD", fmt.Sprintf("Set the status to %v.", args[0]), false)
+	addToBuffer(client, "CMD", fmt.Sprintf("Set the status to %v for %v.", name, duration), false)
 }
 
 // Handles swapevi
@@ -1040,15 +1453,68 @@ func cmdSwapEvi(client *Client, args []string, _ string) {
 	if err != nil {
 		return
 	}
+	_, owner1, ok1 := client.Area().EvidenceAt(evi1)
+	_, owner2, ok2 := client.Area().EvidenceAt(evi2)
+	if ok1 && ok2 && (!client.CanAlterEvidenceItem(owner1) || !client.CanAlterEvidenceItem(owner2)) {
+		client.SendServerMessage("You can only move evidence you added in this area.")
+		return
+	}
 	if client.Area().SwapEvidence(evi1, evi2) {
 		client.SendServerMessage("Evidence swapped.")
 		broadcastToArea(client.Area(), &packet.LE{Items: client.Area().Evidence()})
-		addToBuffer(client, "CMD", fmt.Sprintf("Swapped posistions of evidence %v and %v.", evi1, evi2), false)
+		addToBuffer(client, "EVI", fmt.Sprintf("Swapped positions of evidence %v and %v.", evi1, evi2), true)
+		saveEvidenceChange(client.Area())
 	} else {
 		client.SendServerMessage("Invalid arguments.")
 	}
 }
 
+// Handles /evidence who <id> - shows who added a piece of evidence in this area.
+
+func cmdEvidence(client *Client, args []string, usage string) {
+	if strings.ToLower(args[0]) != "who" {
+		client.SendServerMessage("Invalid arguments.\n" + usage)
+		return
+	}
+	id, err := strconv.Atoi(args[1])
+	if err != nil {
+		client.SendServerMessage("Invalid evidence ID.")
+		return
+	}
+	evi, owner, ok := client.Area().EvidenceAt(id)
+	if !ok {
+		client.SendServerMessage("No evidence with that ID in this area.")
+		return
+	}
+	msg := fmt.Sprintf("Evidence %v (%v) was added by %v.", id, evidenceName(evi), evidenceOwnerLabel(owner))
+	if owner != "" {
+		var online []string
+		for _, c := range getClientsByIpid(owner) {
+			if c.Uid() != -1 {
+				online = append(online, fmt.Sprintf("[%v] %v", c.Uid(), oocDisplayName(c)))
+			}
+		}
+		if len(online) > 0 {
+			msg += " Online as: " + strings.Join(online, ", ")
+		}
+	}
+	client.SendServerMessage(msg)
+}
+
+// evidenceName returns the name field of an "name&description&image" evidence string.
+func evidenceName(evi string) string {
+	name, _, _ := strings.Cut(evi, "&")
+	return name
+}
+
+// evidenceOwnerLabel renders an evidence owner's IPID for logs and /evidence who.
+func evidenceOwnerLabel(ipid string) string {
+	if ipid == "" {
+		return "unknown"
+	}
+	return "IPID " + ipid
+}
+
 // Handles /testify
 
 func cmdTestify(client *Client, _ []string, _ string) {
@@ -1162,9 +1628,51 @@ func cmdTestimony(client *Client, args []string, _ string) {
 			client.SendServerMessage("The recorder is currently active.")
 			return
 		}
+		// An unnamed recording always goes to the default testimony, so a
+		// bare record never wipes one the CM switched to.
+		name := area.DefaultTestimony
+		if len(args) > 1 {
+			var ok bool
+			name, ok = testimonyName(args[1:])
+			if !ok {
+				client.SendServerMessage(fmt.Sprintf("Testimony names can be at most %d characters.", maxTestimonyNameLen))
+				return
+			}
+		}
+		client.Area().TstSwitch(name, true)
 		client.Area().TstClear()
 		client.Area().SetTstState(area.TRRecording)
-		client.SendServerMessage("Recording testimony.")
+		client.SendServerMessage(fmt.Sprintf("Recording testimony '%v'.", client.Area().TstName()))
+		if len(args) == 1 {
+			client.SendServerMessage("Tip: name it with /testimony record <name> to keep several testimonies and change between them with /testimony switch.")
+		}
+	case "list":
+		var sb strings.Builder
+		sb.WriteString("Testimonies in this area:")
+		for _, t := range client.Area().TstList() {
+			marker := ""
+			if t.Active {
+				marker = " (active)"
+			}
+			fmt.Fprintf(&sb, "\n  %v: %d statement(s)%v", t.Name, t.Statements, marker)
+		}
+		client.SendServerMessage(sb.String())
+	case "switch":
+		if len(args) < 2 {
+			client.SendServerMessage("Usage: /testimony switch <name>")
+			return
+		}
+		if client.Area().TstState() != area.TRIdle {
+			client.SendServerMessage("The recorder is currently active.")
+			return
+		}
+		name, _ := testimonyName(args[1:])
+		if !client.Area().TstSwitch(name, false) {
+			client.SendServerMessage(fmt.Sprintf("There is no testimony named '%v'. See /testimony list.", name))
+			return
+		}
+		client.SendServerMessage(fmt.Sprintf("Switched to testimony '%v'. Use /testimony play to start it.", name))
+		addToBuffer(client, "CMD", fmt.Sprintf("Switched to testimony '%v'.", name), false)
 	case "stop":
 		client.Area().SetTstState(area.TRIdle)
 		client.SendServerMessage("Recorder stopped.")
@@ -1202,9 +1710,58 @@ func cmdTestimony(client *Client, args []string, _ string) {
 				client.SendServerMessage("Failed to delete statement.")
 			}
 		}
+	case "save", "load":
+		if len(args) < 2 || !validTestimonyNameRe.MatchString(args[1]) {
+			client.SendServerMessage("Usage: /testimony " + args[0] + " <name>\nNames may use letters, digits, '-' and '_' (up to 32 characters).")
+			return
+		}
+		name := args[1]
+		if args[0] == "save" {
+			if client.Area().TstLen() < 2 {
+				client.SendServerMessage("No testimony recorded.")
+				return
+			}
+			force := len(args) > 2 && args[2] == "-f"
+			switch err := saveTestimony(client.Area(), name, force); {
+			case errors.Is(err, errTestimonyExists):
+				client.SendServerMessage(fmt.Sprintf("A testimony named '%v' is already saved. Use /testimony save %v -f to overwrite it.", name, name))
+				return
+			case errors.Is(err, errTestimonyLimit):
+				client.SendServerMessage(fmt.Sprintf("The server already holds %d saved testimonies. Overwrite one with -f instead.", maxSavedTestimonies))
+				return
+			case err != nil:
+				logger.LogErrorf("while saving testimony %v: %v", name, err)
+				client.SendServerMessage("Failed to save the testimony.")
+				return
+			}
+			client.SendServerMessage(fmt.Sprintf("Saved the testimony as '%v'.", name))
+			addToBuffer(client, "CMD", fmt.Sprintf("Saved testimony '%v'.", name), false)
+			return
+		}
+		if client.Area().TstState() != area.TRIdle {
+			client.SendServerMessage("The recorder is currently active.")
+			return
+		}
+		n, err := loadTestimony(client.Area(), name)
+		if err != nil {
+			client.SendServerMessage(fmt.Sprintf("Failed to load the testimony: %v.", err))
+			return
+		}
+		client.SendServerMessage(fmt.Sprintf("Loaded testimony '%v' (%d statement(s)). Use /testimony play to start it.", name, n))
+		addToBuffer(client, "CMD", fmt.Sprintf("Loaded testimony '%v'.", name), false)
 	}
 }
 
+// maxTestimonyNameLen caps the names given to /testimony record and switch.
+const maxTestimonyNameLen = 32
+
+// testimonyName joins the words of a testimony name. It reports false when
+// the name is empty or longer than maxTestimonyNameLen.
+func testimonyName(words []string) (string, bool) {
+	name := strings.TrimSpace(strings.Join(words, " "))
+	return name, name != "" && utf8.RuneCountInString(name) <= maxTestimonyNameLen
+}
+
 // Handles /unban
 
 func cmdUnCM(client *Client, args []string, _ string) {
@@ -1217,7 +1774,7 @@ func cmdUnCM(client *Client, args []string, _ string) {
 		client.SendServerMessage("You are no longer a CM in this area.")
 		addToBuffer(client, "CMD", "Un-CMed self.", false)
 	} else {
-		toCM := getUidList(strings.Split(args[0], ","))
+		toCM := getUidList(client, strings.Split(args[0], ","))
 		var count int
 		var report string
 		for _, c := range toCM {
@@ -1238,31 +1795,61 @@ func cmdUnCM(client *Client, args []string, _ string) {
 
 // Handles /uninvite
 
-func cmdUninvite(client *Client, args []string, _ string) {
+func cmdUninvite(client *Client, args []string, usage string) {
+	flags := flag.NewFlagSet("", 0)
+	flags.SetOutput(io.Discard)
+	force := flags.Bool("k", false, "")
+	flags.Parse(args)
+	if flags.NArg() == 0 {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
 	if client.Area().Lock() == area.LockFree {
 		client.SendServerMessage("This area is unlocked.")
 		return
 	}
-	toUninvite := getUidList(strings.Split(args[0], ","))
+	toUninvite := getUidList(client, strings.Split(flags.Arg(0), ","))
 	var count int
 	var report string
+	var moved, stayed []string
 	for _, c := range toUninvite {
 		if c == client || client.Area().HasCM(c.Uid()) {
 			continue
 		}
-		if client.Area().RemoveInvited(c.Uid()) {
-			if c.Area() == client.Area() && client.Area().Lock() == area.LockLocked && !permissions.HasPermission(c.Perms(), permissions.PermissionField["BYPASS_LOCK"]) {
-				c.SendServerMessage("You were kicked from the area!")
-				c.ChangeArea(areas[0])
+		wasInvited := client.Area().RemoveInvited(c.Uid())
+		// Without -k only an invited user in a fully locked area is ejected;
+		// -k also removes spectators from a spectatable area, invited or not.
+		eject := c.Area() == client.Area() && !permissions.HasPermission(c.Perms(), permissions.PermissionField["BYPASS_LOCK"]) &&
+			((wasInvited && client.Area().Lock() == area.LockLocked) || *force)
+		if !wasInvited && !eject {
+			continue
+		}
+		if eject {
+			c.SendServerMessage("You were kicked from the area!")
+			if c.ChangeArea(areas[0]) {
+				moved = append(moved, strconv.Itoa(c.Uid()))
+			} else {
+				stayed = append(stayed, strconv.Itoa(c.Uid()))
 			}
+		} else if c.Area() == client.Area() {
+			stayed = append(stayed, strconv.Itoa(c.Uid()))
+		}
+		if wasInvited {
 			c.SendServerMessage(fmt.Sprintf("You were uninvited from area %v.", client.Area().Name()))
-			count++
-			report += fmt.Sprintf("%v, ", c.Uid())
 		}
+		count++
+		report += fmt.Sprintf("%v, ", c.Uid())
 	}
 	report = strings.TrimSuffix(report, ", ")
-	client.SendServerMessage(fmt.Sprintf("Uninvited %v users.", count))
-	addToBuffer(client, "CMD", fmt.Sprintf("Uninvited %v to the area.", report), false)
+	msg := fmt.Sprintf("Uninvited %v users.", count)
+	if len(moved) > 0 {
+		msg += fmt.Sprintf("\nMoved out of the area: %v.", strings.Join(moved, ", "))
+	}
+	if len(stayed) > 0 {
+		msg += fmt.Sprintf("\nStill in the area: %v.", strings.Join(stayed, ", "))
+	}
+	client.SendServerMessage(msg)
+	addToBuffer(client, "CMD", fmt.Sprintf("Uninvited %v from the area.", report), false)
 }
 
 // Handles /unlock
@@ -1352,7 +1939,7 @@ func cmdSpectate(client *Client, args []string, usage string) {
 			client.SendServerMessage("Spectate mode is not enabled.")
 			return
 		}
-		toInvite := getUidList(strings.Split(args[1], ","))
+		toInvite := getUidList(client, strings.Split(args[1], ","))
 		var count int
 		var report string
 		for _, c := range toInvite {
@@ -1377,7 +1964,7 @@ func cmdSpectate(client *Client, args []string, usage string) {
 			client.SendServerMessage("Spectate mode is not enabled.")
 			return
 		}
-		toUninvite := getUidList(strings.Split(args[1], ","))
+		toUninvite := getUidList(client, strings.Split(args[1], ","))
 		var count int
 		var report string
 		for _, c := range toUninvite {
@@ -61,6 +61,7 @@ func init() {
 	sb.WriteString("  /mines start <mines> <bet> | /mines pick <n> | /mines cashout | /mines quit\n")
 	sb.WriteString("  /keno pick <numbers...> <bet>\n")
 	sb.WriteString("  /wheel spin <bet>\n")
+	sb.WriteString("  /bet <amount> <heads|tails>\n")
 	sb.WriteString("  /plinko drop <low|med|high> <bet>\n")
 	sb.WriteString(fmt.Sprintf("\n🍻 THE BAR — /bar menu | /bar buy <drink>\n  %d drinks, ALL with risk & huge variance!\n", len(barMenu)))
 	sb.WriteString("  beer wine whiskey tequila vodka rum gin mojito mead sake champagne margarita\n")
@@ -69,6 +70,7 @@ func init() {
 	sb.WriteString("  thundermead devilswhiskey angelwine ghostshot electriclemonade voiddrink luckybrew\n")
 	sb.WriteString("\n── Other ──\n")
 	sb.WriteString("  /rob [bank|casino|vault|atm|store|mint|armored|museum]\n")
+	sb.WriteString("  /daily — claim your daily chip stipend\n")
 	sb.WriteString("  /shop · /shop <category> · /shop passes · /shop passive · /shop buy <id>\n")
 	sb.WriteString("  /settag <tag_id>|none\n")
 	casinoCmdsSection = sb.String()
@@ -0,0 +1,173 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestParseChoices(t *testing.T) {
+	cases := []struct {
+		in   string
+		want []string
+	}{
+		{"pizza | tacos |  sushi", []string{"pizza", "tacos", "sushi"}},
+		{"red, green,, blue", []string{"red", "green", "blue"}},
+		{"left right", []string{"left", "right"}},
+		{"a, b | c", []string{"a, b", "c"}},
+		{"  ", []string{}},
+	}
+	for _, c := range cases {
+		if got := parseChoices(c.in); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseChoices(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestPickChoice(t *testing.T) {
+	if _, err := pickChoice([]string{"only"}); err == nil {
+		t.Error("a single option should be rejected")
+	}
+	options := []string{"a", "b", "c"}
+	for i := 0; i < 20; i++ {
+		got, err := pickChoice(options)
+		if err != nil {
+			t.Fatalf("pickChoice returned %v", err)
+		}
+		if got != "a" && got != "b" && got != "c" {
+			t.Fatalf("pickChoice returned %q, not one of the options", got)
+		}
+	}
+}
+
+func TestRollDiceLimits(t *testing.T) {
+	orig := config
+	t.Cleanup(func() { config = orig })
+	config = &settings.Config{}
+	config.MaxDice = 3
+	config.MaxSide = 6
+
+	result, err := rollDice("3d6")
+	if err != nil || len(result.Rolls) != 1 || len(result.Rolls[0]) != 3 {
+		t.Fatalf("rollDice(3d6) = %v, %v; want three results", result, err)
+	}
+	for _, spec := range []string{"4d6", "1d7", "0d6", "dice", "2d6+2d6", "5"} {
+		if _, err := rollDice(spec); err == nil {
+			t.Errorf("rollDice(%q) should have failed", spec)
+		}
+	}
+}
+
+func TestRollDiceExpressions(t *testing.T) {
+	orig := config
+	t.Cleanup(func() { config = orig })
+	config = &settings.Config{}
+	config.MaxDice = 100
+	config.MaxSide = 100
+
+	cases := []struct {
+		spec     string
+		groups   int
+		min, max int
+	}{
+		{"d20", 1, 1, 20},
+		{"2d6+3", 1, 5, 15},
+		{"d20-1", 1, 0, 19},
+		{"3d8 + 2d4", 2, 5, 32},
+	}
+	for _, c := range cases {
+		for i := 0; i < 50; i++ {
+			r, err := rollDice(c.spec)
+			if err != nil {
+				t.Fatalf("rollDice(%q) failed: %v", c.spec, err)
+			}
+			groups := 0
+			for _, rolls := range r.Rolls {
+				if rolls != nil {
+					groups++
+				}
+			}
+			if groups != c.groups || r.Total < c.min || r.Total > c.max {
+				t.Fatalf("rollDice(%q) = %v; want %d group(s) totalling %d-%d", c.spec, r, c.groups, c.min, c.max)
+			}
+		}
+	}
+
+	r, _ := rollDice("2d6+3")
+	if got := r.String(); !strings.HasPrefix(got, "2d6 (") || !strings.Contains(got, ") + 3 = ") {
+		t.Errorf("String() = %q, want the rolls, modifier and total", got)
+	}
+	for _, spec := range []string{"1000d1000", "51d6+50d6", "2d6+", "2d", "3x4", "2d6 3", "1d0", "d0", "5d0+2"} {
+		if _, err := rollDice(spec); err == nil {
+			t.Errorf("rollDice(%q) should have failed", spec)
+		}
+	}
+}
+
+// TestRandomPicksUseSharedRNG verifies dice and game punishment picks draw
+// from the shared rng rather than a per-call or global source: with rng
+// swapped for a fixed seed, each pick must match the same seed's sequence.
+func TestRandomPicksUseSharedRNG(t *testing.T) {
+	orig, origRNG := config, rng
+	t.Cleanup(func() { config, rng = orig, origRNG })
+	config = &settings.Config{}
+	config.MaxDice = 5
+	config.MaxSide = 1000
+
+	rng = rand.New(rand.NewSource(42))
+	want := rand.New(rand.NewSource(42))
+	r, err := rollDice("5d1000")
+	if err != nil {
+		t.Fatalf("rollDice: %v", err)
+	}
+	for i, got := range r.Rolls[0] {
+		if exp := want.Intn(1000) + 1; got != exp {
+			t.Fatalf("roll %d = %d, want %d from the shared rng", i, got, exp)
+		}
+	}
+
+	pool := enabledPunishments(hotPotatoPunishmentPool)
+	for i := 0; i < 5; i++ {
+		if got, exp := randomQuickdrawPunishment(), pool[want.Intn(len(pool))]; got != exp {
+			t.Fatalf("quickdraw pick %d = %v, want %v from the shared rng", i, got, exp)
+		}
+	}
+}
+
+func TestEightBallCooldown(t *testing.T) {
+	newTestClients(t)
+	a := newTestArea()
+	conn := &captureConn{}
+	client := &Client{conn: conn, uid: 1, area: a, oocName: "Maya"}
+	clients.AddClient(client)
+	clients.RegisterUID(client)
+
+	cmd8Ball(client, []string{"Will", "I", "win?"}, "usage")
+	if !strings.Contains(conn.String(), "The Magic 8-Ball says") {
+		t.Fatalf("the answer was not announced, got %q", conn.String())
+	}
+	before := len(conn.String())
+	cmd8Ball(client, []string{"Really?"}, "usage")
+	if out := conn.String()[before:]; !strings.Contains(out, "before asking the 8-Ball again") {
+		t.Errorf("a second question inside the cooldown should be refused, got %q", out)
+	}
+}
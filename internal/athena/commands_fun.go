@@ -17,11 +17,11 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package athena
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io"
-	"math/rand"
-	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -512,28 +512,225 @@ func cmdRoll(client *Client, args []string, _ string) {
 	flags.SetOutput(io.Discard)
 	private := flags.Bool("p", false, "")
 	flags.Parse(args)
-	b, _ := regexp.MatchString("([[:digit:]])d([[:digit:]])", flags.Arg(0))
-	if !b {
-		client.SendServerMessage("Argument not recognized.")
-		return
-	}
-	s := strings.Split(flags.Arg(0), "d")
-	num, _ := strconv.Atoi(s[0])
-	sides, _ := strconv.Atoi(s[1])
-	if num <= 0 || num > config.MaxDice || sides <= 0 || sides > config.MaxSide {
-		client.SendServerMessage("Invalid num/side.")
+	spec := strings.Join(flags.Args(), " ")
+	result, err := rollDice(spec)
+	if err != nil {
+		client.SendServerMessage(err.Error())
 		return
 	}
-	var result []string
-	for i := 0; i < num; i++ {
-		result = append(result, fmt.Sprint(rand.Intn(sides)+1))
-	}
 	if *private {
-		client.SendServerMessage(fmt.Sprintf("Results: %v.", strings.Join(result, ", ")))
+		client.SendServerMessage(fmt.Sprintf("Results: %v.", result))
 	} else {
-		sendAreaServerMessage(client.Area(), fmt.Sprintf("%v rolled %v. Results: %v.", oocDisplayName(client), flags.Arg(0), strings.Join(result, ", ")))
+		sendAreaServerMessage(client.Area(), fmt.Sprintf("%v rolled %v. Results: %v.", oocDisplayName(client), spec, result))
 	}
-	addToBuffer(client, "CMD", fmt.Sprintf("Rolled %v.", flags.Arg(0)), false)
+	addToBuffer(client, "CMD", fmt.Sprintf("Rolled %v: %v.", spec, result.Total), false)
+}
+
+// maxDiceTerms caps how many dice groups and modifiers one /roll may add up.
+const maxDiceTerms = 20
+
+// maxDiceNumber caps the digits of any count, side or modifier in a /roll
+// expression, keeping totals far from overflow.
+const maxDiceNumber = 999999
+
+var errDiceSyntax = errors.New("Argument not recognized. Use dice like d20, 2d6+3 or 3d8+2d4-1.")
+
+// diceTerm is one term of a /roll expression: count dice with sides faces
+// when isDice is set, otherwise a flat modifier of count. sign is 1 or -1.
+type diceTerm struct {
+	sign   int
+	count  int
+	sides  int
+	isDice bool
+}
+
+// diceResult is a rolled expression: each term with its rolls, and the total.
+type diceResult struct {
+	Terms []diceTerm
+	Rolls [][]int // parallel to Terms; nil for modifiers
+	Total int
+}
+
+// String formats the result as e.g. "2d6 (3, 5) + 3 = 11".
+func (r diceResult) String() string {
+	var sb strings.Builder
+	for i, t := range r.Terms {
+		switch {
+		case i > 0 && t.sign < 0:
+			sb.WriteString(" - ")
+		case i > 0:
+			sb.WriteString(" + ")
+		case t.sign < 0:
+			sb.WriteString("-")
+		}
+		if !t.isDice {
+			sb.WriteString(strconv.Itoa(t.count))
+			continue
+		}
+		rolls := make([]string, len(r.Rolls[i]))
+		for j, n := range r.Rolls[i] {
+			rolls[j] = strconv.Itoa(n)
+		}
+		fmt.Fprintf(&sb, "%dd%d (%v)", t.count, t.sides, strings.Join(rolls, ", "))
+	}
+	fmt.Fprintf(&sb, " = %d", r.Total)
+	return sb.String()
+}
+
+// parseDiceExpr parses a /roll expression made of NdM dice groups (N
+// defaults to 1) and integer modifiers joined by + and -, e.g. "3d8+2d4-1".
+// At least one dice group is required. Spaces are allowed around + and -.
+func parseDiceExpr(spec string) ([]diceTerm, error) {
+	fields := strings.Fields(spec)
+	for k := 1; k < len(fields); k++ {
+		prev, cur := fields[k-1], fields[k]
+		if !strings.ContainsAny(prev[len(prev)-1:], "+-") && !strings.ContainsAny(cur[:1], "+-") {
+			return nil, errDiceSyntax
+		}
+	}
+	s := strings.ToLower(strings.Join(fields, ""))
+	number := func(i int) (int, int, error) {
+		j := i
+		for j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			j++
+		}
+		if j == i {
+			return 0, i, nil
+		}
+		n, err := strconv.Atoi(s[i:j])
+		if err != nil || n > maxDiceNumber {
+			return 0, j, fmt.Errorf("Numbers in a roll can be at most %d.", maxDiceNumber)
+		}
+		return n, j, nil
+	}
+	var terms []diceTerm
+	hasDice := false
+	for i := 0; i < len(s); {
+		t := diceTerm{sign: 1}
+		if s[i] == '+' || s[i] == '-' {
+			if s[i] == '-' {
+				t.sign = -1
+			}
+			i++
+		} else if len(terms) > 0 {
+			return nil, errDiceSyntax
+		}
+		start := i
+		n, j, err := number(i)
+		if err != nil {
+			return nil, err
+		}
+		if j < len(s) && s[j] == 'd' {
+			if j == start {
+				n = 1
+			}
+			sides, k, err := number(j + 1)
+			if err != nil {
+				return nil, err
+			}
+			if k == j+1 {
+				return nil, errDiceSyntax
+			}
+			t.count, t.sides, t.isDice = n, sides, true
+			hasDice = true
+			i = k
+		} else {
+			if j == start {
+				return nil, errDiceSyntax
+			}
+			t.count = n
+			i = j
+		}
+		terms = append(terms, t)
+	}
+	if !hasDice {
+		return nil, errDiceSyntax
+	}
+	if len(terms) > maxDiceTerms {
+		return nil, fmt.Errorf("A roll can have at most %d terms.", maxDiceTerms)
+	}
+	return terms, nil
+}
+
+// rollDice rolls a /roll expression within the configured dice limits:
+// max_dice caps the dice across the whole expression and max_side the sides
+// of each. It backs both /roll and the Discord /roll command.
+func rollDice(spec string) (diceResult, error) {
+	terms, err := parseDiceExpr(spec)
+	if err != nil {
+		return diceResult{}, err
+	}
+	dice := 0
+	for _, t := range terms {
+		if !t.isDice {
+			continue
+		}
+		if t.count <= 0 || t.sides <= 0 || t.sides > config.MaxSide {
+			return diceResult{}, fmt.Errorf("Invalid num/side. Dice can have 1 to %d sides.", config.MaxSide)
+		}
+		dice += t.count
+	}
+	if dice > config.MaxDice {
+		return diceResult{}, fmt.Errorf("Invalid num/side. At most %d dice can be rolled at once.", config.MaxDice)
+	}
+	r := diceResult{Terms: terms, Rolls: make([][]int, len(terms))}
+	for i, t := range terms {
+		if !t.isDice {
+			r.Total += t.sign * t.count
+			continue
+		}
+		rolls := make([]int, t.count)
+		for j := range rolls {
+			rolls[j] = rng.Intn(t.sides) + 1
+			r.Total += t.sign * rolls[j]
+		}
+		r.Rolls[i] = rolls
+	}
+	return r, nil
+}
+
+// parseChoices splits /choose input into options. Options are separated by
+// "|" when present, otherwise by commas, otherwise by whitespace.
+func parseChoices(s string) []string {
+	var parts []string
+	switch {
+	case strings.Contains(s, "|"):
+		parts = strings.Split(s, "|")
+	case strings.Contains(s, ","):
+		parts = strings.Split(s, ",")
+	default:
+		parts = strings.Fields(s)
+	}
+	options := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			options = append(options, p)
+		}
+	}
+	return options
+}
+
+// pickChoice picks one option at random. It backs both /choose and the
+// Discord /choose command.
+func pickChoice(options []string) (string, error) {
+	if len(options) < 2 {
+		return "", errors.New("Give at least two options to choose from.")
+	}
+	return options[rng.Intn(len(options))], nil
+}
+
+// Handles /choose
+
+func cmdChoose(client *Client, args []string, usage string) {
+	options := parseChoices(strings.Join(args, " "))
+	choice, err := pickChoice(options)
+	if err != nil {
+		client.SendServerMessage(err.Error() + "\n" + usage)
+		return
+	}
+	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v asked me to choose between %v.\n👉 I choose: %v",
+		oocDisplayName(client), strings.Join(options, ", "), choice))
+	addToBuffer(client, "GAME", fmt.Sprintf("Choose: %v -> %v", strings.Join(options, " | "), choice), false)
 }
 
 // rpsChallenge records the first player's hidden RPS commitment in an area.
@@ -541,6 +738,7 @@ func cmdRoll(client *Client, args []string, _ string) {
 // they can't game-theory the result by watching the first move.
 type rpsChallenge struct {
 	UID       int
+	IPID      string
 	Name      string
 	Choice    string
 	CreatedAt time.Time
@@ -571,7 +769,7 @@ func rpsBeats(a, b string) bool {
 // Replaces the prior server-vs-player coin-flip-style version, which felt
 // pointless when there are real opponents in the room.
 //
-// 30-second window per player. Challenges auto-expire after 30s.
+// rps_cooldown (30s by default) per player. Challenges auto-expire after 30s.
 func cmdRps(client *Client, args []string, _ string) {
 	choice := strings.ToLower(args[0])
 	if choice != "rock" && choice != "paper" && choice != "scissors" {
@@ -579,8 +777,8 @@ func cmdRps(client *Client, args []string, _ string) {
 		return
 	}
 
-	if !client.LastRpsTime().IsZero() && time.Since(client.LastRpsTime()) < 30*time.Second {
-		remaining := int((30*time.Second - time.Since(client.LastRpsTime())).Seconds()) + 1
+	if !client.LastRpsTime().IsZero() && time.Since(client.LastRpsTime()) < rpsCooldown {
+		remaining := int((rpsCooldown - time.Since(client.LastRpsTime())).Seconds()) + 1
 		client.SendServerMessage(fmt.Sprintf("Please wait %d seconds before playing RPS again.", remaining))
 		return
 	}
@@ -601,6 +799,7 @@ func cmdRps(client *Client, args []string, _ string) {
 		// First mover: stash the hidden challenge and announce it.
 		rpsState[a] = &rpsChallenge{
 			UID:       client.Uid(),
+			IPID:      client.Ipid(),
 			Name:      oocDisplayName(client),
 			Choice:    choice,
 			CreatedAt: time.Now().UTC(),
@@ -628,8 +827,10 @@ func cmdRps(client *Client, args []string, _ string) {
 		result = "It's a tie!"
 	case rpsBeats(pending.Choice, choice):
 		result = fmt.Sprintf("%v wins!", pending.Name)
+		recordGameOutcome(gameRPS, pending.IPID, pending.Name, client.Ipid(), oocDisplayName(client))
 	default:
 		result = fmt.Sprintf("%v wins!", oocDisplayName(client))
+		recordGameOutcome(gameRPS, client.Ipid(), oocDisplayName(client), pending.IPID, pending.Name)
 	}
 	sendAreaServerMessage(a, fmt.Sprintf(
 		"%v (%s) vs %v (%s) — %s",
@@ -637,18 +838,35 @@ func cmdRps(client *Client, args []string, _ string) {
 	addToBuffer(client, "GAME", fmt.Sprintf("RPS: %v vs %v -> %v", pending.Choice, choice, result), false)
 }
 
-// Handles /coinflip
+// coinflipSoloCooldown is how often a player may flip against the server.
+const coinflipSoloCooldown = 10 * time.Second
 
-func cmdCoinflip(client *Client, args []string, _ string) {
+// Handles /coinflip [-s] <heads|tails>
+//
+// By default the first call opens an area challenge and the second, with the
+// opposite side, settles it. -s flips immediately against the server instead.
+func cmdCoinflip(client *Client, args []string, usage string) {
 	if isPunishmentBlocked(client.Ipid()) {
 		client.SendServerMessage("A moderator has disabled self-applied punishment commands for you.")
 		return
 	}
+	solo := args[0] == "-s"
+	if solo {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
 	choice := strings.ToLower(args[0])
 	if choice != "heads" && choice != "tails" {
 		client.SendServerMessage("Invalid choice. Use: heads or tails.")
 		return
 	}
+	if solo {
+		coinflipSolo(client, choice)
+		return
+	}
 
 	// Check if there's an active coinflip challenge in the area
 	activeChallenge := client.Area().ActiveCoinflip()
@@ -657,6 +875,7 @@ func cmdCoinflip(client *Client, args []string, _ string) {
 		// No active challenge - create a new one
 		challenge := &area.CoinflipChallenge{
 			PlayerName: client.OOCName(),
+			IPID:       client.Ipid(),
 			Choice:     choice,
 			CreatedAt:  time.Now().UTC(),
 		}
@@ -672,11 +891,12 @@ func cmdCoinflip(client *Client, args []string, _ string) {
 	} else {
 		// There's an active challenge
 
-		// Check if challenge has expired (30 seconds)
-		if time.Now().UTC().After(activeChallenge.CreatedAt.Add(30 * time.Second)) {
+		// Check if challenge has expired (coinflip_expiry)
+		if time.Now().UTC().After(activeChallenge.CreatedAt.Add(coinflipExpiry)) {
 			// Challenge expired, create new one
 			challenge := &area.CoinflipChallenge{
 				PlayerName: client.OOCName(),
+				IPID:       client.Ipid(),
 				Choice:     choice,
 				CreatedAt:  time.Now().UTC(),
 			}
@@ -704,17 +924,16 @@ func cmdCoinflip(client *Client, args []string, _ string) {
 		}
 
 		// Battle time! Flip the coin
-		coinResult := "heads"
-		if rand.Intn(2) == 1 {
-			coinResult = "tails"
-		}
+		coinResult := flipCoin()
 
 		// Determine winner
 		var winner string
 		if coinResult == activeChallenge.Choice {
 			winner = activeChallenge.PlayerName
+			recordGameOutcome(gameCoinflip, activeChallenge.IPID, activeChallenge.PlayerName, client.Ipid(), client.OOCName())
 		} else {
 			winner = client.OOCName()
+			recordGameOutcome(gameCoinflip, client.Ipid(), client.OOCName(), activeChallenge.IPID, activeChallenge.PlayerName)
 		}
 
 		// Announce result
@@ -733,6 +952,34 @@ func cmdCoinflip(client *Client, args []string, _ string) {
 	}
 }
 
+// coinflipSolo flips a coin for the client against the server and announces
+// the result, without touching the area's challenge.
+func coinflipSolo(client *Client, choice string) {
+	if last := client.LastSoloFlipTime(); !last.IsZero() && time.Since(last) < coinflipSoloCooldown {
+		remaining := int((coinflipSoloCooldown - time.Since(last)).Seconds()) + 1
+		client.SendServerMessage(fmt.Sprintf("Please wait %d seconds before flipping again.", remaining))
+		return
+	}
+	client.SetLastSoloFlipTime(time.Now().UTC())
+
+	coinResult := flipCoin()
+	outcome := "loses"
+	if coinResult == choice {
+		outcome = "wins"
+	}
+	sendAreaServerMessage(client.Area(), fmt.Sprintf("🪙 %v called %v and flipped a coin... it landed on %v! %v %v.",
+		client.OOCName(), choice, coinResult, client.OOCName(), outcome))
+	addToBuffer(client, "GAME", fmt.Sprintf("Solo coinflip: called %v, landed %v", choice, coinResult), false)
+}
+
+// flipCoin returns "heads" or "tails" with equal probability.
+func flipCoin() string {
+	if rng.Intn(2) == 1 {
+		return "tails"
+	}
+	return "heads"
+}
+
 // oppositeChoice returns the opposite coinflip choice
 func oppositeChoice(choice string) string {
 	if choice == "heads" {
@@ -744,15 +991,24 @@ func oppositeChoice(choice string) string {
 // Handles /poll
 
 func cmdPoll(client *Client, args []string, usage string) {
+	public := args[0] == "-p"
+	if public {
+		args = args[1:]
+		if len(args) == 0 {
+			client.SendServerMessage("Not enough arguments:\n" + usage)
+			return
+		}
+	}
+
 	// Check if there's already an active poll
 	if client.Area().ActivePoll() != nil {
 		client.SendServerMessage("There is already an active poll in this area.")
 		return
 	}
 
-	// Check cooldown (5 minutes)
-	if time.Now().UTC().Before(client.Area().LastPollTime().Add(5*time.Minute)) && !client.Area().LastPollTime().IsZero() {
-		remaining := time.Until(client.Area().LastPollTime().Add(5 * time.Minute))
+	// Check cooldown (poll_cooldown)
+	if time.Now().UTC().Before(client.Area().LastPollTime().Add(pollCooldown)) && !client.Area().LastPollTime().IsZero() {
+		remaining := time.Until(client.Area().LastPollTime().Add(pollCooldown))
 		client.SendServerMessage(fmt.Sprintf("Please wait %v before creating another poll in this area.", remaining.Round(time.Second)))
 		return
 	}
@@ -786,8 +1042,9 @@ func cmdPoll(client *Client, args []string, usage string) {
 		Question:  question,
 		Options:   options,
 		CreatedAt: time.Now().UTC(),
-		ClosesAt:  time.Now().UTC().Add(2 * time.Minute),
+		ClosesAt:  time.Now().UTC().Add(pollDuration),
 		CreatedBy: client.OOCName(),
+		Public:    public,
 	}
 
 	client.Area().SetActivePoll(poll)
@@ -800,26 +1057,21 @@ func cmdPoll(client *Client, args []string, usage string) {
 	for i, opt := range options {
 		pollMsg += fmt.Sprintf("%v. %v\n", i+1, opt)
 	}
-	pollMsg += fmt.Sprintf("\nUse /vote <number> to vote. Poll closes in 2 minutes.")
+	pollMsg += fmt.Sprintf("\nUse /vote <number> to vote. Poll closes in %v.", formatDurationShort(pollDuration))
+	if public {
+		pollMsg += "\nThis poll is public: the results show who voted for what."
+	}
 	sendAreaServerMessage(client.Area(), pollMsg)
 	addToBuffer(client, "CMD", fmt.Sprintf("Created poll: %v", question), false)
 
-	// Schedule auto-close after 2 minutes
+	// Schedule auto-close after poll_duration
 	go func(a *area.Area, pollID int64) {
-		time.Sleep(2 * time.Minute)
+		time.Sleep(pollDuration)
 		currentPoll := a.ActivePoll()
 		if currentPoll != nil && currentPoll.ID == pollID {
 			// Close poll
 			resultMsg := fmt.Sprintf("=== POLL CLOSED ===\n%v\nResults:\n", currentPoll.Question)
-			votes := a.PollVotes()
-			for i, opt := range currentPoll.Options {
-				count := 0
-				if votes != nil {
-					count = votes[i+1]
-				}
-				resultMsg += fmt.Sprintf("%v. %v - %v votes\n", i+1, opt, count)
-			}
-			sendAreaServerMessage(a, resultMsg)
+			sendAreaServerMessage(a, resultMsg+pollResults(a, currentPoll))
 			a.ClearPoll()
 		}
 	}(client.Area(), poll.ID)
@@ -842,12 +1094,6 @@ func cmdVote(client *Client, args []string, usage string) {
 		return
 	}
 
-	// Check if player has already voted
-	if client.Area().HasPlayerVoted(client.Uid()) {
-		client.SendServerMessage("You have already voted in this poll.")
-		return
-	}
-
 	// Parse vote option
 	option, err := strconv.Atoi(args[0])
 	if err != nil || option < 1 || option > len(poll.Options) {
@@ -855,22 +1101,59 @@ func cmdVote(client *Client, args []string, usage string) {
 		return
 	}
 
-	// Record vote
-	client.Area().AddPlayerVote(client.Uid(), option)
-	client.SendServerMessage(fmt.Sprintf("You voted for: %v", poll.Options[option-1]))
+	// Record vote; voting again moves the player's vote to the new option.
+	prev := client.Area().AddPlayerVote(client.Uid(), option)
+	switch prev {
+	case option:
+		client.SendServerMessage(fmt.Sprintf("You already voted for: %v", poll.Options[option-1]))
+		return
+	case 0:
+		client.SendServerMessage(fmt.Sprintf("You voted for: %v", poll.Options[option-1]))
+	default:
+		client.SendServerMessage(fmt.Sprintf("You changed your vote from %v to %v", poll.Options[prev-1], poll.Options[option-1]))
+	}
 
 	// Broadcast updated results to area
 	resultMsg := fmt.Sprintf("=== POLL UPDATE ===\n%v\nCurrent Results:\n", poll.Question)
-	votes := client.Area().PollVotes()
+	sendAreaServerMessage(client.Area(), resultMsg+pollResults(client.Area(), poll))
+	// An anonymous poll's buffer entry must not reveal the choice, since
+	// the entry itself names the voter.
+	switch {
+	case !poll.Public:
+		addToBuffer(client, "VOTE", "Voted in poll", false)
+	case prev != 0:
+		addToBuffer(client, "VOTE", fmt.Sprintf("Changed poll vote from option %v to %v", prev, option), false)
+	default:
+		addToBuffer(client, "VOTE", fmt.Sprintf("Voted for option %v in poll", option), false)
+	}
+}
+
+// pollResults renders one line per option with its vote count. Public polls
+// also list the UIDs that chose each option; anonymous polls only ever show
+// the counts.
+func pollResults(a *area.Area, poll *area.Poll) string {
+	votes := a.PollVotes()
+	var voters map[int][]int
+	if poll.Public {
+		voters = make(map[int][]int)
+		for uid, option := range a.PlayerVotes() {
+			voters[option] = append(voters[option], uid)
+		}
+	}
+	var sb strings.Builder
 	for i, opt := range poll.Options {
-		count := 0
-		if votes != nil {
-			count = votes[i+1]
+		sb.WriteString(fmt.Sprintf("%v. %v - %v votes", i+1, opt, votes[i+1]))
+		if uids := voters[i+1]; len(uids) > 0 {
+			sort.Ints(uids)
+			strs := make([]string, len(uids))
+			for j, uid := range uids {
+				strs[j] = strconv.Itoa(uid)
+			}
+			sb.WriteString(" (UID " + strings.Join(strs, ", ") + ")")
 		}
-		resultMsg += fmt.Sprintf("%v. %v - %v votes\n", i+1, opt, count)
+		sb.WriteString("\n")
 	}
-	sendAreaServerMessage(client.Area(), resultMsg)
-	addToBuffer(client, "VOTE", fmt.Sprintf("Voted for option %v in poll", option), false)
+	return sb.String()
 }
 
 // cmdPunishment is a generic handler for punishment commands
@@ -895,7 +1178,7 @@ var erpMessages = []string{
 }
 
 func cmdErp(client *Client, _ []string, _ string) {
-	msg := erpMessages[rand.Intn(len(erpMessages))]
+	msg := erpMessages[rng.Intn(len(erpMessages))]
 	client.SendSync(&packet.KK{Reason: msg})
 	client.conn.Close()
 }
@@ -922,6 +1205,11 @@ func cmdMaso(client *Client, args []string, _ string) {
 	if duration > 24*time.Hour {
 		duration = 24 * time.Hour
 	}
+	pool := enabledPunishments(areaRandomPunishments)
+	if len(pool) == 0 {
+		client.SendServerMessage("Every punishment in the pool is disabled on this server.")
+		return
+	}
 
 	client.mu.Lock()
 	prev := client.masoPunishment
@@ -933,10 +1221,10 @@ func cmdMaso(client *Client, args []string, _ string) {
 	}
 
 	// Pick a random punishment; if rerolling, ensure it differs from the previous one.
-	newType := areaRandomPunishments[rand.Intn(len(areaRandomPunishments))]
-	if prev != PunishmentNone && len(areaRandomPunishments) > 1 {
+	newType := pool[rng.Intn(len(pool))]
+	if prev != PunishmentNone && len(pool) > 1 {
 		for newType == prev {
-			newType = areaRandomPunishments[rand.Intn(len(areaRandomPunishments))]
+			newType = pool[rng.Intn(len(pool))]
 		}
 	}
 
@@ -1006,6 +1294,9 @@ func cmdGetMusic(client *Client, _ []string, _ string) {
 	client.Send(&packet.MCToClient{Name: song, CharID: cid, Showname: "Server", Looping: "1", Channel: "0", Effects: "0"})
 }
 
+// eightBallCooldown is how often a player may ask the Magic 8-Ball.
+const eightBallCooldown = 10 * time.Second
+
 // Handles /8ball
 func cmd8Ball(client *Client, args []string, _ string) {
 	question := strings.TrimSpace(strings.Join(args, " "))
@@ -1013,11 +1304,22 @@ func cmd8Ball(client *Client, args []string, _ string) {
 		client.SendServerMessage("Usage: /8ball <question>")
 		return
 	}
+	if last := client.Last8ballTime(); !last.IsZero() && time.Since(last) < eightBallCooldown {
+		remaining := int((eightBallCooldown - time.Since(last)).Seconds()) + 1
+		client.SendServerMessage(fmt.Sprintf("Please wait %d seconds before asking the 8-Ball again.", remaining))
+		return
+	}
+	client.SetLast8ballTime(time.Now().UTC())
+	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v asked: %s\n🎱 The Magic 8-Ball says: %s",
+		oocDisplayName(client), question, eightBallAnswer()))
+}
+
+// eightBallAnswer draws a Magic 8-Ball answer from 8ball.txt, or the classic
+// list when it is missing. It backs both /8ball and the Discord /8ball command.
+func eightBallAnswer() string {
 	pool := getEightBall()
 	if len(pool) == 0 {
 		pool = defaultEightBallAnswers
 	}
-	answer := pool[rand.Intn(len(pool))]
-	sendAreaServerMessage(client.Area(), fmt.Sprintf("%v asked: %s\n🎱 The Magic 8-Ball says: %s",
-		oocDisplayName(client), question, answer))
+	return pool[rng.Intn(len(pool))]
 }
@@ -0,0 +1,115 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// setupJailTest builds a lobby, a courtroom and a holding cell, with the
+// configured jail area pointed at jailArea (-1 to disable).
+func setupJailTest(t *testing.T, jailArea int) (lobby, court, cell *area.Area, mod, target *Client) {
+	t.Helper()
+	newTestClients(t)
+
+	origAreas, origConfig := areas, config
+	t.Cleanup(func() { areas = origAreas; config = origConfig })
+	lobby = area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	court = area.NewArea(area.AreaData{Name: "Courtroom"}, 5, 10, area.EviAny)
+	cell = area.NewArea(area.AreaData{Name: "Holding Cell"}, 5, 10, area.EviAny)
+	areas = []*area.Area{lobby, court, cell}
+	config = &settings.Config{ServerConfig: settings.ServerConfig{JailArea: jailArea}}
+
+	mod = &Client{conn: &testConn{}, uid: 1, ipid: "ip-mod", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+	mod.SetPerms(permissions.PermissionField["BAN"])
+	mod.SetArea(court)
+	target = &Client{conn: &testConn{}, uid: 2, ipid: "ip-target", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+	target.SetArea(court)
+	for _, c := range []*Client{mod, target} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+	return
+}
+
+func TestJailUsesConfiguredJailArea(t *testing.T) {
+	defer setupAreaMuteTestDB(t)()
+	_, court, cell, mod, target := setupJailTest(t, 2)
+
+	cmdJail(mod, []string{"2"}, "")
+	if target.Area() != cell {
+		t.Fatalf("jailed target is in %q, want the configured jail area %q", target.Area().Name(), cell.Name())
+	}
+	if target.JailAreaID() != 2 {
+		t.Errorf("JailAreaID = %d, want 2", target.JailAreaID())
+	}
+	if target.ChangeArea(court) {
+		t.Error("a jailed target should not be able to leave the jail area")
+	}
+
+	cmdUnjail(mod, []string{"2"}, "")
+	if target.IsJailed() {
+		t.Error("target should no longer be jailed after /unjail")
+	}
+	if target.Area() != court {
+		t.Errorf("released target is in %q, want to be returned to %q", target.Area().Name(), court.Name())
+	}
+}
+
+func TestUnjailFallsBackToFirstArea(t *testing.T) {
+	defer setupAreaMuteTestDB(t)()
+	lobby, _, _, mod, target := setupJailTest(t, 2)
+
+	cmdJail(mod, []string{"2"}, "")
+	// Simulate a reconnect: the pre-jail area is not persisted.
+	target.SetPreJailArea(nil)
+
+	cmdUnjail(mod, []string{"2"}, "")
+	if target.Area() != lobby {
+		t.Errorf("released target is in %q, want area 0 (%q)", target.Area().Name(), lobby.Name())
+	}
+}
+
+func TestJailInPlaceWithoutConfiguredArea(t *testing.T) {
+	defer setupAreaMuteTestDB(t)()
+	_, court, _, mod, target := setupJailTest(t, -1)
+
+	cmdJail(mod, []string{"2"}, "")
+	if target.Area() != court {
+		t.Errorf("target moved to %q; with no jail_area they should stay in %q", target.Area().Name(), court.Name())
+	}
+	if target.JailAreaID() != -1 {
+		t.Errorf("JailAreaID = %d, want -1 for an in-place jail", target.JailAreaID())
+	}
+}
+
+func TestJailIgnoresOutOfRangeJailArea(t *testing.T) {
+	defer setupAreaMuteTestDB(t)()
+	_, court, _, mod, target := setupJailTest(t, 9)
+
+	cmdJail(mod, []string{"2"}, "")
+	if target.Area() != court {
+		t.Errorf("target moved to %q; an out-of-range jail_area should jail in place", target.Area().Name())
+	}
+	if !target.IsJailed() {
+		t.Error("target should still be jailed when jail_area is out of range")
+	}
+}
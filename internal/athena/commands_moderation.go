@@ -50,14 +50,61 @@ func cmdBan(client *Client, args []string, usage string) {
 	flags.Var(&cmdParamList{uids}, "u", "")
 	flags.Var(&cmdParamList{ipids}, "i", "")
 	duration := flags.String("d", config.BanLen, "")
+	dryRun := flags.Bool("n", false, "")
+	areaArg := flags.String("area", "", "")
 	flags.Parse(args)
 
-	if len(flags.Args()) < 1 {
+	byArea := *areaArg != ""
+	if byArea && (len(*uids) > 0 || len(*ipids) > 0) {
+		client.SendServerMessage("-area cannot be combined with -u or -i.\n" + usage)
+		return
+	}
+	if !byArea && len(*uids) == 0 && len(*ipids) == 0 {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
 
-	if len(*uids) == 0 && len(*ipids) == 0 {
+	var uidTargets []*Client
+	targetIPIDs := *ipids
+	if byArea {
+		var err error
+		uidTargets, err = areaTargets(client, *areaArg)
+		if err != nil {
+			client.SendServerMessage(invalidAreaMessage())
+			return
+		}
+		targetIPIDs = nil
+		for _, c := range uidTargets {
+			targetIPIDs = append(targetIPIDs, c.Ipid())
+		}
+	} else if len(*uids) > 0 {
+		uidTargets = getUidList(client, *uids)
+		targetIPIDs = nil
+		for _, c := range uidTargets {
+			targetIPIDs = append(targetIPIDs, c.Ipid())
+		}
+	}
+	// Computed before anyone is disconnected so the warning reflects who was
+	// sharing the IPID at the time of the ban.
+	sharedWarning := sharedIPIDWarning(targetIPIDs)
+
+	if *dryRun {
+		targets := uidTargets
+		var offline []string
+		if len(*uids) == 0 && !byArea {
+			for _, ipid := range *ipids {
+				online := getClientsByIpid(ipid)
+				if len(online) == 0 {
+					offline = append(offline, ipid)
+				}
+				targets = append(targets, online...)
+			}
+		}
+		client.SendServerMessage(punishPreview("ban", targets, offline) + sharedWarning)
+		return
+	}
+
+	if len(flags.Args()) < 1 {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
@@ -85,8 +132,8 @@ func cmdBan(client *Client, args []string, usage string) {
 	var count int
 	var reportBuilder strings.Builder
 	seenIPIDs := make(map[string]struct{})
-	if len(*uids) > 0 {
-		for _, c := range getUidList(*uids) {
+	if len(*uids) > 0 || byArea {
+		for _, c := range uidTargets {
 			id, err := db.AddBan(c.Ipid(), c.Hdid(), banTime, until, reason, client.StoredModName())
 			if err != nil {
 				continue
@@ -98,7 +145,7 @@ func cmdBan(client *Client, args []string, usage string) {
 				}
 				reportBuilder.WriteString(c.Ipid())
 			}
-			c.SendSync(&packet.KB{Reason: fmt.Sprintf("%v\nUntil: %v\nID: %v", reason, untilS, id)})
+			c.SendSync(&packet.KB{Reason: c.Localize("ban_notice", reason, untilS, id)})
 			c.conn.Close()
 			forgetIP(c.Ipid())
 			count++
@@ -138,12 +185,12 @@ func cmdBan(client *Client, args []string, usage string) {
 				forgetIP(ipid)
 				for _, c := range onlineClients {
 					if id, ok := banIDByHdid[c.Hdid()]; ok {
-						c.SendSync(&packet.KB{Reason: fmt.Sprintf("%v\nUntil: %v\nID: %v", reason, untilS, id)})
+						c.SendSync(&packet.KB{Reason: c.Localize("ban_notice", reason, untilS, id)})
 						if err := webhook.PostBan(c.CurrentCharacter(), c.Showname(), c.OOCName(), ipid, c.Uid(), id, *duration, reason, client.DisplayModName()); err != nil {
 							logger.LogErrorf("while posting ban webhook: %v", err)
 						}
 					} else {
-						c.SendSync(&packet.KB{Reason: fmt.Sprintf("%v\nUntil: %v", reason, untilS)})
+						c.SendSync(&packet.KB{Reason: c.Localize("ban_notice_no_id", reason, untilS)})
 					}
 					c.conn.Close()
 				}
@@ -160,14 +207,75 @@ func cmdBan(client *Client, args []string, usage string) {
 	}
 	report := reportBuilder.String()
 	if len(*ipids) > 0 {
-		client.SendServerMessage(fmt.Sprintf("Banned %v IPID(s).", count))
+		client.SendServerMessage(fmt.Sprintf("Banned %v IPID(s).", count) + sharedWarning)
 	} else {
-		client.SendServerMessage(fmt.Sprintf("Banned %v clients.", count))
+		client.SendServerMessage(fmt.Sprintf("Banned %v clients.", count) + sharedWarning)
 	}
 	sendPlayerArup()
 	addToBuffer(client, "CMD", fmt.Sprintf("Banned %v from server for %v: %v.", report, *duration, reason), true)
 }
 
+// punishPreview lists the clients a /kick or /ban -n dry run would hit, one
+// per line with UID, character and IPID, plus any IPIDs that are offline and
+// would only be banned by IPID. Nothing is kicked or banned.
+func punishPreview(verb string, targets []*Client, offline []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Dry run: /%v would affect %d connected client(s).", verb, len(targets))
+	for _, c := range targets {
+		fmt.Fprintf(&sb, "\n  [%v] %v (%v) — IPID %v", c.Uid(), c.CurrentCharacter(), c.OOCName(), c.Ipid())
+	}
+	for _, ipid := range offline {
+		fmt.Fprintf(&sb, "\n  IPID %v — offline, would be banned by IPID only", ipid)
+	}
+	sb.WriteString("\nNothing was done. Run the command again without -n to apply it.")
+	return sb.String()
+}
+
+// areaTargets resolves an -area argument to every connected client in that
+// area, leaving out the caller and staff with BYPASS_LOCK.
+func areaTargets(caller *Client, token string) ([]*Client, error) {
+	a, _, err := resolveArea(token)
+	if err != nil {
+		return nil, err
+	}
+	var l []*Client
+	clients.ForEach(func(c *Client) {
+		if c == caller || c.Uid() == -1 || c.Area() != a ||
+			permissions.HasPermission(c.Perms(), permissions.PermissionField["BYPASS_LOCK"]) {
+			return
+		}
+		l = append(l, c)
+	})
+	return l, nil
+}
+
+// sharedIPIDWarning returns a warning, starting with a newline, for every
+// IPID in ipids that more than one connected UID is using (NAT, VPN, a
+// shared household), listing who else the ban hits. It returns "" when no
+// IPID is shared. The warning is informational; the ban still goes ahead.
+func sharedIPIDWarning(ipids []string) string {
+	var sb strings.Builder
+	seen := make(map[string]struct{}, len(ipids))
+	for _, ipid := range ipids {
+		if _, dup := seen[ipid]; dup {
+			continue
+		}
+		seen[ipid] = struct{}{}
+		online := getClientsByIpid(ipid)
+		sort.Slice(online, func(i, j int) bool { return online[i].Uid() < online[j].Uid() })
+		var names []string
+		for _, c := range online {
+			if c.Uid() != -1 {
+				names = append(names, fmt.Sprintf("[%v] %v", c.Uid(), c.CurrentCharacter()))
+			}
+		}
+		if len(names) > 1 {
+			fmt.Fprintf(&sb, "\n⚠️ IPID %v is shared by %d connected clients: %v. A ban on it affects all of them.", ipid, len(names), strings.Join(names, ", "))
+		}
+	}
+	return sb.String()
+}
+
 // Handles /bg
 
 func cmdEditBan(client *Client, args []string, usage string) {
@@ -233,12 +341,16 @@ func cmdEditBan(client *Client, args []string, usage string) {
 
 // Handles /evimode
 
-func cmdGetBan(client *Client, args []string, _ string) {
+func cmdGetBan(client *Client, args []string, usage string) {
 	flags := flag.NewFlagSet("", 0)
 	flags.SetOutput(io.Discard)
 	banid := flags.Int("b", -1, "")
 	ipid := flags.String("i", "", "")
-	flags.Parse(args)
+	hdid := flags.String("h", "", "")
+	if err := flags.Parse(args); err != nil {
+		client.SendServerMessage(usage)
+		return
+	}
 	var sb strings.Builder
 	sb.WriteString("Bans:\n----------")
 	entry := func(b db.BanInfo) {
@@ -267,6 +379,15 @@ func cmdGetBan(client *Client, args []string, _ string) {
 		for _, b := range bans {
 			entry(b)
 		}
+	} else if h := strings.TrimSpace(*hdid); h != "" {
+		bans, err := db.GetBan(db.HDID, h)
+		if err != nil || len(bans) == 0 {
+			client.SendServerMessage("No bans with that HDID exist.")
+			return
+		}
+		for _, b := range bans {
+			entry(b)
+		}
 	} else {
 		bans, err := db.GetRecentBans()
 		if err != nil {
@@ -281,6 +402,65 @@ func cmdGetBan(client *Client, args []string, _ string) {
 	client.SendServerMessage(sb.String())
 }
 
+// Handles /alts
+
+func cmdAlts(client *Client, args []string, usage string) {
+	uid, err := strconv.Atoi(args[0])
+	if err != nil {
+		client.SendServerMessage("Invalid UID.\n" + usage)
+		return
+	}
+	target, err := getClientByUid(uid)
+	if err != nil {
+		client.SendServerMessage("Client does not exist.")
+		return
+	}
+	isAdmin := permissions.HasPermission(client.Perms(), permissions.PermissionField["ADMIN"])
+	visible := func(c *Client) bool {
+		return c != target && c.Uid() != -1 && (isAdmin || !c.Hidden())
+	}
+	byUID := func(list []*Client) {
+		sort.Slice(list, func(i, j int) bool { return list[i].Uid() < list[j].Uid() })
+	}
+
+	ipid := target.Ipid()
+	var ipidMatches []*Client
+	for _, c := range getClientsByIpid(ipid) {
+		if visible(c) {
+			ipidMatches = append(ipidMatches, c)
+		}
+	}
+	// Same-IPID clients are already listed above, so the HDID section only
+	// adds players who share the hardware ID from a different address.
+	var hdidMatches []*Client
+	for _, c := range getClientsByHdid(target.Hdid()) {
+		if visible(c) && c.Ipid() != ipid {
+			hdidMatches = append(hdidMatches, c)
+		}
+	}
+	if len(ipidMatches) == 0 && len(hdidMatches) == 0 {
+		client.SendServerMessage(fmt.Sprintf("No connected alts found for UID %d.", uid))
+		return
+	}
+	byUID(ipidMatches)
+	byUID(hdidMatches)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Alts of UID %d (IPID %v):", uid, ipid)
+	section := func(title string, list []*Client) {
+		fmt.Fprintf(&b, "\n%v (%d):", title, len(list))
+		if len(list) == 0 {
+			b.WriteString("\n  none")
+		}
+		for _, c := range list {
+			b.WriteString("\n" + playerLocationLine(c))
+		}
+	}
+	section("Same IPID — same connection, likely the same person", ipidMatches)
+	section("Same HDID, different IPID — same device, possibly a VPN or proxy", hdidMatches)
+	client.SendServerMessage(b.String())
+}
+
 // Handles /global
 
 func cmdGlobal(client *Client, args []string, _ string) {
@@ -292,6 +472,10 @@ func cmdGlobal(client *Client, args []string, _ string) {
 		client.SendServerMessage("You are muted from sending OOC messages.")
 		return
 	}
+	if chatLockdownBlocks(client, chatGlobal) {
+		client.SendServerMessage(chatLockdownNotice("Global chat is locked down by the moderators."))
+		return
+	}
 	if limited, remaining := checkNewIPIDOOCCooldown(client.Ipid()); limited {
 		unit := "seconds"
 		if remaining == 1 {
@@ -304,7 +488,8 @@ func cmdGlobal(client *Client, args []string, _ string) {
 	if tag != "" {
 		tag += " "
 	}
-	broadcastToAll(&packet.CTToClient{Name: fmt.Sprintf("[GLOBAL] [UID %d] %s%v", client.Uid(), tag, oocDisplayName(client)), Message: strings.Join(args, " "), IsFromServer: "1"})
+	msg := applyOOCPunishments(client, strings.Join(args, " "))
+	broadcastToAll(&packet.CTToClient{Name: fmt.Sprintf("[GLOBAL] [UID %d] %s%v", client.Uid(), tag, oocDisplayName(client)), Message: msg, IsFromServer: "1"})
 }
 
 // Handles /hide
@@ -336,16 +521,24 @@ func cmdKick(client *Client, args []string, usage string) {
 	ipids := &[]string{}
 	flags.Var(&cmdParamList{uids}, "u", "")
 	flags.Var(&cmdParamList{ipids}, "i", "")
+	dryRun := flags.Bool("n", false, "")
+	areaArg := flags.String("area", "", "")
 	flags.Parse(args)
 
-	if len(flags.Args()) < 1 {
-		client.SendServerMessage("Not enough arguments:\n" + usage)
-		return
-	}
-
 	var toKick []*Client
-	if len(*uids) > 0 {
-		toKick = getUidList(*uids)
+	if *areaArg != "" {
+		if len(*uids) > 0 || len(*ipids) > 0 {
+			client.SendServerMessage("-area cannot be combined with -u or -i.\n" + usage)
+			return
+		}
+		var err error
+		toKick, err = areaTargets(client, *areaArg)
+		if err != nil {
+			client.SendServerMessage(invalidAreaMessage())
+			return
+		}
+	} else if len(*uids) > 0 {
+		toKick = getUidList(client, *uids)
 	} else if len(*ipids) > 0 {
 		toKick = getIpidList(*ipids)
 	} else {
@@ -353,6 +546,15 @@ func cmdKick(client *Client, args []string, usage string) {
 		return
 	}
 
+	if *dryRun {
+		client.SendServerMessage(punishPreview("kick", toKick, nil))
+		return
+	}
+	if len(flags.Args()) < 1 {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
+
 	var count int
 	var reportBuilder strings.Builder
 	reason := strings.Join(flags.Args(), " ")
@@ -483,19 +685,98 @@ func cmdMod(client *Client, args []string, usage string) {
 	}
 	msg := strings.Join(flags.Args(), " ")
 	if *global {
-		broadcastToAll(&packet.CTToClient{Name: fmt.Sprintf("[MOD] [GLOBAL] %v", client.OOCName()), Message: msg, IsFromServer: "1"})
+		broadcastToAll(&packet.CTToClient{Name: fmt.Sprintf("[MOD] [GLOBAL] %v", modSpeakName(client)), Message: msg, IsFromServer: "1"})
 	} else {
-		broadcastToArea(client.Area(), &packet.CTToClient{Name: fmt.Sprintf("[MOD] %v", client.OOCName()), Message: msg, IsFromServer: "1"})
+		broadcastToArea(client.Area(), &packet.CTToClient{Name: fmt.Sprintf("[MOD] %v", modSpeakName(client)), Message: msg, IsFromServer: "1"})
 	}
 	addToBuffer(client, "OOC", msg, false)
 }
 
+// modSpeakName returns the name /mod and /modchat show for client, per
+// mod_speak_name. Shadow mods and clients without a moderator username keep
+// their OOC name so the option can't unmask them.
+func modSpeakName(client *Client) string {
+	name := client.OOCName()
+	user := client.ModName()
+	if user == "" || permissions.IsShadow(client.Perms()) {
+		return name
+	}
+	switch strings.ToLower(strings.TrimSpace(config.ModSpeakName)) {
+	case "username":
+		return user
+	case "both":
+		return fmt.Sprintf("%v (%v)", name, user)
+	}
+	return name
+}
+
+// announceBorder frames /announce text so it stands out from normal OOC.
+const announceBorder = "━━━━━━━━━━━━━━━━━━━━"
+
+// Handles /announce
+
+func cmdAnnounce(client *Client, args []string, usage string) {
+	flags := flag.NewFlagSet("", 0)
+	flags.SetOutput(io.Discard)
+	areaList := flags.String("a", "", "")
+	flags.Parse(args)
+	if len(flags.Args()) == 0 {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
+	msg := strings.Join(flags.Args(), " ")
+
+	var targets map[*area.Area]struct{}
+	if *areaList != "" {
+		targets = make(map[*area.Area]struct{})
+		for _, s := range strings.Split(*areaList, ",") {
+			a, _, err := resolveArea(s)
+			if err != nil {
+				client.SendServerMessage(fmt.Sprintf("Invalid area ID: %v", s))
+				return
+			}
+			targets[a] = struct{}{}
+		}
+	}
+
+	p := &packet.CTToClient{
+		Name:         "📢 ANNOUNCEMENT",
+		Message:      announceBorder + "\n" + msg + "\n" + announceBorder,
+		IsFromServer: "1",
+	}
+	sent := 0
+	clients.ForEach(func(c *Client) {
+		if c.Uid() == -1 {
+			return
+		}
+		if targets != nil {
+			if _, ok := targets[c.Area()]; !ok {
+				return
+			}
+		}
+		c.Send(p)
+		sent++
+	})
+
+	scope := "all areas"
+	if targets != nil {
+		names := make([]string, 0, len(targets))
+		for a := range targets {
+			names = append(names, a.Name())
+		}
+		sort.Strings(names)
+		scope = strings.Join(names, ", ")
+	}
+	client.SendServerMessage(fmt.Sprintf("Announcement sent to %d player(s) in %v.", sent, scope))
+	addToBuffer(client, "CMD", fmt.Sprintf("Announced to %v: %v", scope, msg), true)
+}
+
 // Handles /modchat
 
 func cmdModChat(client *Client, args []string, _ string) {
 	msg := strings.Join(args, " ")
 	senderIsShadow := permissions.IsShadow(client.Perms())
-	realSender := client.OOCName()
+	realSender := modSpeakName(client)
 	clients.ForEach(func(c *Client) {
 		if permissions.HasPermission(c.Perms(), permissions.PermissionField["MOD_CHAT"]) {
 			// Shadow mods appear as "Moderator" to everyone except admins.
@@ -536,18 +817,11 @@ func cmdMute(client *Client, args []string, usage string) {
 	default:
 		m = ICMuted
 	}
-	msg := fmt.Sprintf("You have been muted from %v", m.String())
-	if *duration != -1 {
-		msg += fmt.Sprintf(" for %v seconds", *duration)
-	}
-	if *reason != "" {
-		msg += " for reason: " + *reason
-	}
 	if len(flags.Args()) == 0 {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toMute := getUidList(strings.Split(flags.Arg(0), ","))
+	toMute := getUidList(client, strings.Split(flags.Arg(0), ","))
 	var count int
 	var reportBuilder strings.Builder
 	for _, c := range toMute {
@@ -567,6 +841,13 @@ func cmdMute(client *Client, args []string, usage string) {
 		if err := db.UpsertMute(c.Ipid(), int(m), expires); err != nil {
 			logger.LogErrorf("Failed to persist mute for %v: %v", c.Ipid(), err)
 		}
+		msg := c.Localize("muted", m.String())
+		if *duration != -1 {
+			msg += c.Localize("muted_for_seconds", *duration)
+		}
+		if *reason != "" {
+			msg += c.Localize("muted_reason", *reason)
+		}
 		c.SendServerMessage(msg)
 		count++
 		if reportBuilder.Len() > 0 {
@@ -598,7 +879,7 @@ func cmdParrot(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toParrot := getUidList(strings.Split(flags.Arg(0), ","))
+	toParrot := getUidList(client, strings.Split(flags.Arg(0), ","))
 	var count int
 	var reportBuilder strings.Builder
 	for _, c := range toParrot {
@@ -685,7 +966,7 @@ func cmdPlayers(client *Client, args []string, _ string) {
 		if prefix != "" {
 			prefix += " "
 		}
-		fmt.Fprintf(b, "%s[%v] %v\n", prefix, c.Uid(), c.CurrentCharacter())
+		fmt.Fprintf(b, "%s[%v] %v%v\n", prefix, c.Uid(), c.CurrentCharacter(), awayLabel(c))
 		// Show showname only to players in the same area — prevents stalking
 		// across rooms while still letting area-mates see IC display names.
 		if sameArea {
@@ -717,6 +998,7 @@ func cmdPlayers(client *Client, args []string, _ string) {
 				}
 			}
 			fmt.Fprintf(b, "IPID: %v\n", c.Ipid())
+			writePlayerNotes(b, c.Ipid())
 		}
 		if ooc := c.OOCName(); ooc != "" {
 			fmt.Fprintf(b, "OOC: %v\n", ooc)
@@ -789,11 +1071,17 @@ func cmdPM(client *Client, args []string, _ string) {
 		return
 	}
 	msg := strings.Join(args[1:], " ")
-	toPM := getUidList(strings.Split(args[0], ","))
+	toPM := getUidList(client, strings.Split(args[0], ","))
 	var recipientNames []string
 	for _, c := range toPM {
 		c.Send(&packet.CTToClient{Name: fmt.Sprintf("[PM] [UID %d] %v", client.Uid(), oocDisplayName(client)), Message: msg, IsFromServer: "1"})
 		recipientNames = append(recipientNames, fmt.Sprintf("[%d] %v", c.Uid(), oocDisplayName(c)))
+		if away, awayMsg := c.Away(); away {
+			if awayMsg == "" {
+				awayMsg = "no message"
+			}
+			client.SendServerMessage(fmt.Sprintf("[%d] %v is away: %v", c.Uid(), oocDisplayName(c), awayMsg))
+		}
 	}
 	// Echo the message back to the sender so they can see what they sent.
 	if len(recipientNames) > 0 {
@@ -801,6 +1089,18 @@ func cmdPM(client *Client, args []string, _ string) {
 	}
 }
 
+// Handles /modcall
+
+func cmdModcall(client *Client, args []string, _ string) {
+	reason := strings.TrimSpace(strings.Join(args, " "))
+	if reason == "" {
+		reason = "No reason given."
+	}
+	if sendModcall(client, reason) {
+		client.SendServerMessage("Your modcall was sent to the moderators.")
+	}
+}
+
 // validPositions is the set of positions a player can move to with /pos.
 var validPositions = []string{"def", "pro", "wit", "jud", "hld", "hlp", "jur", "sea"}
 
@@ -940,8 +1240,20 @@ func cmdRemoveRole(client *Client, args []string, _ string) {
 
 // Handles /status
 
-func cmdUnban(client *Client, args []string, _ string) {
-	toUnban := strings.Split(args[0], ",")
+func cmdUnban(client *Client, args []string, usage string) {
+	flags := flag.NewFlagSet("", 0)
+	flags.SetOutput(io.Discard)
+	ipid := flags.String("i", "", "")
+	flags.Parse(args)
+	if *ipid != "" {
+		unbanIPID(client, *ipid)
+		return
+	}
+	if flags.NArg() == 0 {
+		client.SendServerMessage("Not enough arguments:\n" + usage)
+		return
+	}
+	toUnban := strings.Split(flags.Arg(0), ",")
 	var reportBuilder strings.Builder
 	for _, s := range toUnban {
 		id, err := strconv.Atoi(s)
@@ -959,16 +1271,7 @@ func cmdUnban(client *Client, args []string, _ string) {
 		}
 		reportBuilder.WriteString(s)
 		if dbErr == nil && len(bans) > 0 {
-			b := bans[0]
-			var durStr string
-			if b.Duration == -1 {
-				durStr = "Permanent"
-			} else {
-				durStr = time.Unix(b.Duration, 0).UTC().Format("02 Jan 2006 15:04 MST")
-			}
-			if err := webhook.PostUnban(id, b.Ipid, b.Reason, durStr, RenderStoredModName(b.Moderator, 0), client.DisplayModName()); err != nil {
-				logger.LogErrorf("while posting unban webhook: %v", err)
-			}
+			postUnbanWebhook(client, bans[0])
 		}
 	}
 	report := reportBuilder.String()
@@ -976,10 +1279,45 @@ func cmdUnban(client *Client, args []string, _ string) {
 	addToBuffer(client, "CMD", fmt.Sprintf("Nullified bans: %v", report), true)
 }
 
+// unbanIPID handles /unban -i: nullifies every active ban on the IPID.
+func unbanIPID(client *Client, ipid string) {
+	bans, err := db.UnbanByIPID(ipid)
+	if err != nil {
+		logger.LogErrorf("Failed to unban IPID %v: %v", ipid, err)
+		client.SendServerMessage("Failed to nullify bans.")
+		return
+	}
+	if len(bans) == 0 {
+		client.SendServerMessage(fmt.Sprintf("No active bans found for IPID %v.", ipid))
+		return
+	}
+	ids := make([]string, 0, len(bans))
+	for _, b := range bans {
+		ids = append(ids, strconv.Itoa(b.Id))
+		postUnbanWebhook(client, b)
+	}
+	report := strings.Join(ids, ", ")
+	client.SendServerMessage(fmt.Sprintf("Nullified %d ban(s) for IPID %v: %v", len(bans), ipid, report))
+	addToBuffer(client, "CMD", fmt.Sprintf("Nullified bans for IPID %v: %v", ipid, report), true)
+}
+
+// postUnbanWebhook posts the unban embed for a ban record looked up before it was nullified.
+func postUnbanWebhook(client *Client, b db.BanInfo) {
+	var durStr string
+	if b.Duration == -1 {
+		durStr = "Permanent"
+	} else {
+		durStr = time.Unix(b.Duration, 0).UTC().Format("02 Jan 2006 15:04 MST")
+	}
+	if err := webhook.PostUnban(b.Id, b.Ipid, b.Reason, durStr, RenderStoredModName(b.Moderator, 0), client.DisplayModName()); err != nil {
+		logger.LogErrorf("while posting unban webhook: %v", err)
+	}
+}
+
 // Handles /uncm
 
 func cmdUnmute(client *Client, args []string, _ string) {
-	toUnmute := getUidList(strings.Split(args[0], ","))
+	toUnmute := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var reportBuilder strings.Builder
 	for _, c := range toUnmute {
@@ -990,7 +1328,7 @@ func cmdUnmute(client *Client, args []string, _ string) {
 		if err := db.DeleteMute(c.Ipid()); err != nil {
 			logger.LogErrorf("Failed to remove persistent mute for %v: %v", c.Ipid(), err)
 		}
-		c.SendServerMessage("You have been unmuted.")
+		c.SendLocalized("unmuted")
 		count++
 		if reportBuilder.Len() > 0 {
 			reportBuilder.WriteString(", ")
@@ -1031,16 +1369,19 @@ func cmdJail(client *Client, args []string, usage string) {
 	// Optional area argument: /jail <uid> <area_id> [-d ...] [-r ...]
 	jailAreaID := -1
 	if flags.NArg() >= 2 {
-		id, err := strconv.Atoi(flags.Arg(1))
+		_, id, err := resolveArea(flags.Arg(1))
 		if err != nil {
-			client.SendServerMessage("Invalid area ID: must be a number.")
-			return
-		}
-		if id < 0 || id >= len(areas) {
-			client.SendServerMessage(fmt.Sprintf("Area ID %d is out of range (0–%d).", id, len(areas)-1))
+			client.SendServerMessage(invalidAreaMessage())
 			return
 		}
 		jailAreaID = id
+	} else if id := config.JailArea; id >= 0 {
+		// No explicit area: fall back to the configured holding area, if valid.
+		if id < len(areas) {
+			jailAreaID = id
+		} else {
+			logger.LogWarningf("jail_area %d is out of range (0–%d); jailing in place.", id, len(areas)-1)
+		}
 	}
 
 	isPerma := strings.ToLower(*duration) == "perma"
@@ -1059,7 +1400,14 @@ func cmdJail(client *Client, args []string, usage string) {
 	// Force-move the target to the jail area first (before setting jailed state so
 	// any existing jail doesn't block the move), then apply the jail.
 	if jailAreaID >= 0 {
-		target.forceChangeArea(areas[jailAreaID])
+		// Remember where the target came from so /unjail can return them. A
+		// re-jail while already held keeps the original pre-jail area.
+		if !target.IsJailed() || target.PreJailArea() == nil {
+			target.SetPreJailArea(target.Area())
+		}
+		if target.Area() != areas[jailAreaID] {
+			target.forceChangeArea(areas[jailAreaID])
+		}
 	}
 
 	target.SetJailedUntil(jailUntil)
@@ -1105,19 +1453,32 @@ func cmdJail(client *Client, args []string, usage string) {
 // Handles /unjail
 
 func cmdUnjail(client *Client, args []string, _ string) {
-	toUnjail := getUidList(strings.Split(args[0], ","))
+	toUnjail := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var reportBuilder strings.Builder
 	for _, c := range toUnjail {
 		if !c.IsJailed() {
 			continue
 		}
+		jailArea := c.JailAreaID()
 		c.SetJailedUntil(time.Time{})
 		c.SetJailAreaID(-1)
 		if err := db.DeleteJail(c.Ipid()); err != nil {
 			logger.LogErrorf("Failed to remove persistent jail for %v: %v", c.Ipid(), err)
 		}
 		c.SendServerMessage("You have been released from jail.")
+		// Players held in a dedicated jail area are returned to where they were
+		// jailed from; if that is unknown (e.g. they reconnected), area 0.
+		if jailArea >= 0 {
+			dest := c.PreJailArea()
+			if dest == nil {
+				dest = areas[0]
+			}
+			if c.Area() != dest {
+				c.forceChangeArea(dest)
+			}
+		}
+		c.SetPreJailArea(nil)
 		count++
 		if reportBuilder.Len() > 0 {
 			reportBuilder.WriteString(", ")
@@ -1844,7 +2205,7 @@ func cmdCharStuck(client *Client, args []string, usage string) {
 // Handles /uncharstuck
 
 func cmdUnCharStuck(client *Client, args []string, _ string) {
-	toUnstuck := getUidList(strings.Split(args[0], ","))
+	toUnstuck := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var sb strings.Builder
 	for _, c := range toUnstuck {
@@ -1897,6 +2258,10 @@ func cmdCharCurse(client *Client, args []string, usage string) {
 		client.SendServerMessage(fmt.Sprintf("Character \"%s\" is already taken in that area.", charName))
 		return
 	}
+	if target.characterSlotDenied() {
+		client.SendServerMessage("All player slots are taken, so that spectator cannot be given a character.")
+		return
+	}
 
 	target.ChangeCharacter(charID)
 	target.SendServerMessage(fmt.Sprintf("A moderator has forced you to play as %s. You may change characters freely.", charName))
@@ -2045,10 +2410,45 @@ func cmdUnignore(client *Client, args []string, usage string) {
 	addToBuffer(client, "CMD", fmt.Sprintf("unignored list entry #%d (IPID: %v)", n, targetIPID), false)
 }
 
-// cmdModnote manages per-IPID freeform moderator notes.
-// Usage: /modnote add <ipid> <note>
+// maxPlayerListNotes caps how many moderator notes /players shows per entry.
+const maxPlayerListNotes = 3
+
+// modnoteTarget resolves the <uid|ipid> argument of /modnote: the IPID of a
+// connected client when arg is their UID, otherwise arg itself as an IPID.
+func modnoteTarget(arg string) string {
+	if uid, err := strconv.Atoi(arg); err == nil {
+		if c, err := getClientByUid(uid); err == nil {
+			return c.Ipid()
+		}
+	}
+	return arg
+}
+
+// writePlayerNotes appends the newest moderator notes on ipid to a /players
+// entry, pointing at /modnote list when some are left out.
+func writePlayerNotes(b *strings.Builder, ipid string) {
+	notes, err := db.GetModnotes(ipid)
+	if err != nil {
+		logger.LogErrorf("Failed to fetch modnotes for IPID %v: %v", ipid, err)
+		return
+	}
+	shown := notes
+	if len(shown) > maxPlayerListNotes {
+		shown = shown[len(shown)-maxPlayerListNotes:]
+	}
+	for _, n := range shown {
+		fmt.Fprintf(b, "Note [%d]: %v\n", n.ID, n.Note)
+	}
+	if more := len(notes) - len(shown); more > 0 {
+		fmt.Fprintf(b, "(+%d older note(s), see /modnote list %v)\n", more, ipid)
+	}
+}
+
+// cmdModnote manages per-IPID freeform moderator notes. <target> is an
+// online player's UID or an IPID.
+// Usage: /modnote add <target> <note>
 //
-//	/modnote list <ipid>
+//	/modnote list <target>
 //	/modnote delete <id>
 func cmdModnote(client *Client, args []string, usage string) {
 	if len(args) < 1 {
@@ -2062,7 +2462,7 @@ func cmdModnote(client *Client, args []string, usage string) {
 			client.SendServerMessage("Not enough arguments:\n" + usage)
 			return
 		}
-		ipid := args[1]
+		ipid := modnoteTarget(args[1])
 		note := strings.Join(args[2:], " ")
 		if err := db.AddModnote(ipid, note, client.StoredModName()); err != nil {
 			logger.LogErrorf("Failed to add modnote for IPID %v: %v", ipid, err)
@@ -2077,7 +2477,7 @@ func cmdModnote(client *Client, args []string, usage string) {
 			client.SendServerMessage("Not enough arguments:\n" + usage)
 			return
 		}
-		ipid := args[1]
+		ipid := modnoteTarget(args[1])
 		notes, err := db.GetModnotes(ipid)
 		if err != nil {
 			logger.LogErrorf("Failed to fetch modnotes for IPID %v: %v", ipid, err)
@@ -2096,7 +2496,7 @@ func cmdModnote(client *Client, args []string, usage string) {
 		}
 		client.SendServerMessage(strings.TrimRight(b.String(), "\n"))
 
-	case "delete":
+	case "delete", "del":
 		if len(args) < 2 {
 			client.SendServerMessage("Not enough arguments:\n" + usage)
 			return
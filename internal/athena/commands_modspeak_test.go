@@ -0,0 +1,53 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestModSpeakName(t *testing.T) {
+	oldConfig := config
+	defer func() { config = oldConfig }()
+	config = &settings.Config{}
+
+	mod := &Client{conn: &testConn{}, uid: 1, char: -1, oocName: "Judge", mod_name: "Mango", perms: permissions.PermissionField["MUTE"]}
+	tests := []struct {
+		mode string
+		want string
+	}{
+		{"", "Judge"},
+		{"ooc", "Judge"},
+		{"username", "Mango"},
+		{"Both", "Judge (Mango)"},
+	}
+	for _, tt := range tests {
+		config.ModSpeakName = tt.mode
+		if got := modSpeakName(mod); got != tt.want {
+			t.Errorf("mod_speak_name %q: got %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+
+	config.ModSpeakName = "username"
+	shadow := &Client{conn: &testConn{}, uid: 2, char: -1, oocName: "Lurker", mod_name: "Hidden", perms: permissions.PermissionField["SHADOW"] | permissions.PermissionField["MUTE"]}
+	if got := modSpeakName(shadow); got != "Lurker" {
+		t.Errorf("shadow mod: got %q, want their OOC name", got)
+	}
+}
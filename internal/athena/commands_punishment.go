@@ -20,7 +20,6 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -79,11 +78,24 @@ func extractHiddenFlag(args []string) ([]string, bool) {
 }
 
 func cmdPunishment(client *Client, args []string, usage string, pType PunishmentType) {
+	cmdPunishmentWithData(client, args, usage, pType, "")
+}
+
+// cmdPunishmentWithData is cmdPunishment for effects that carry per-instance
+// metadata (e.g. /drunk's intensity). A non-empty customData is stored on the
+// punishment and persisted via the 0x1F reason convention, like /translator.
+func cmdPunishmentWithData(client *Client, args []string, usage string, pType PunishmentType, customData string) {
 	// -h suppresses the per-target OOC notification so the punishment applies
 	// silently. Extracted before flag.Parse because Go's flag package stops at
 	// the first positional, so trailing "-h" (e.g. "/tsundere 7 -h") would
 	// otherwise be ignored.
 	args, hidden := extractHiddenFlag(args)
+	// -ooc makes the effect transform the target's OOC messages as well.
+	args, ooc := extractOOCFlag(args)
+	if punishmentDisabled(pType.String()) {
+		client.SendServerMessage("That punishment is disabled on this server.")
+		return
+	}
 
 	flags := flag.NewFlagSet("", 0)
 	flags.SetOutput(io.Discard)
@@ -111,8 +123,36 @@ func cmdPunishment(client *Client, args []string, usage string, pType Punishment
 	}
 
 	tier := issuerTierFor(client)
+	storedReason := *reason
+	if customData != "" {
+		storedReason = customData + "\x1f" + *reason
+	}
+	if ooc {
+		storedReason = oocScopeMarker + storedReason
+	}
+	addPunishment := func(c *Client) {
+		if customData != "" {
+			c.AddPunishmentWithData(pType, duration, *reason, customData)
+			c.setPunishmentTier(pType, tier)
+		} else {
+			c.AddPunishmentBy(pType, duration, *reason, tier)
+		}
+		if ooc {
+			c.UpdatePunishmentState(pType, func(ps *PunishmentState) { ps.oocScope = true })
+		}
+		var expires int64
+		if duration > 0 {
+			expires = time.Now().UTC().Add(duration).Unix()
+		}
+		if err := db.UpsertTextPunishmentBy(c.Ipid(), int(pType), expires, storedReason, int(tier)); err != nil {
+			logger.LogErrorf("Failed to persist text punishment for %v: %v", c.Ipid(), err)
+		}
+	}
 
 	msg := fmt.Sprintf("You have been punished with '%v' effect", pType.String())
+	if ooc {
+		msg += " (OOC included)"
+	}
 	if duration > 0 {
 		msg += fmt.Sprintf(" for %v", duration)
 	}
@@ -136,14 +176,7 @@ func cmdPunishment(client *Client, args []string, usage string, pType Punishment
 				notePunishmentSafeSkip(&skipped, &skippedReport, c)
 				return
 			}
-			c.AddPunishmentBy(pType, duration, *reason, tier)
-			var expires int64
-			if duration > 0 {
-				expires = time.Now().UTC().Add(duration).Unix()
-			}
-			if err := db.UpsertTextPunishmentBy(c.Ipid(), int(pType), expires, *reason, int(tier)); err != nil {
-				logger.LogErrorf("Failed to persist text punishment for %v: %v", c.Ipid(), err)
-			}
+			addPunishment(c)
 			if !hidden {
 				c.SendServerMessage(msg)
 			}
@@ -159,10 +192,11 @@ func cmdPunishment(client *Client, args []string, usage string, pType Punishment
 		client.SendServerMessage(summary)
 		addToBuffer(client, "CMD", fmt.Sprintf("Applied '%v' punishment globally to %v.", pType.String(), report), false)
 		alertPunishmentIssued(client, pType.String(), report, count, duration, *reason, hidden)
+		announcePunishment(client, pType.String(), report, count, hidden)
 		return
 	}
 
-	toPunish := getUidList(strings.Split(flags.Arg(0), ","))
+	toPunish := getUidList(client, strings.Split(flags.Arg(0), ","))
 	var count int
 	var report string
 	var skipped int
@@ -173,14 +207,7 @@ func cmdPunishment(client *Client, args []string, usage string, pType Punishment
 			notePunishmentSafeSkip(&skipped, &skippedReport, c)
 			continue
 		}
-		c.AddPunishmentBy(pType, duration, *reason, tier)
-		var expires int64
-		if duration > 0 {
-			expires = time.Now().UTC().Add(duration).Unix()
-		}
-		if err := db.UpsertTextPunishmentBy(c.Ipid(), int(pType), expires, *reason, int(tier)); err != nil {
-			logger.LogErrorf("Failed to persist text punishment for %v: %v", c.Ipid(), err)
-		}
+		addPunishment(c)
 		if !hidden {
 			c.SendServerMessage(msg)
 		}
@@ -197,6 +224,7 @@ func cmdPunishment(client *Client, args []string, usage string, pType Punishment
 	client.SendServerMessage(summary)
 	addToBuffer(client, "CMD", fmt.Sprintf("Applied '%v' punishment to %v.", pType.String(), report), false)
 	alertPunishmentIssued(client, pType.String(), report, count, duration, *reason, hidden)
+	announcePunishment(client, pType.String(), report, count, hidden)
 }
 
 // Handlers for all punishment commands
@@ -354,8 +382,73 @@ func cmdParanoid(client *Client, args []string, usage string) {
 	cmdPunishment(client, args, usage, PunishmentParanoid)
 }
 
+// cmdDrunk handles /drunk. -i sets the intensity (1-5) stored on the
+// punishment; without it the effect keeps its standard strength.
 func cmdDrunk(client *Client, args []string, usage string) {
-	cmdPunishment(client, args, usage, PunishmentDrunk)
+	args, level, ok := extractIntensityFlag(args)
+	var customData string
+	if ok {
+		n, err := strconv.Atoi(level)
+		if err != nil || n < 1 || n > drunkMaxIntensity {
+			client.SendServerMessage(fmt.Sprintf("Intensity must be between 1 and %v.", drunkMaxIntensity))
+			return
+		}
+		customData = strconv.Itoa(n)
+	}
+	cmdPunishmentWithData(client, args, usage, PunishmentDrunk, customData)
+}
+
+// oocScopeMarker prefixes the stored reason of a punishment issued with -ooc,
+// ahead of any 0x1F customData, so the OOC scope survives a reconnect.
+const oocScopeMarker = "\x1e"
+
+// extractOOCFlag pulls "-ooc" out of args from anywhere in the list, for the
+// same reason extractHiddenFlag does.
+func extractOOCFlag(args []string) ([]string, bool) {
+	out := make([]string, 0, len(args))
+	ooc := false
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if a == "-ooc" || a == "--ooc" {
+			ooc = true
+			continue
+		}
+		// Preserve "-r value" / "-d value" pairs intact.
+		if (a == "-r" || a == "-d" || a == "--r" || a == "--d") && i+1 < len(args) {
+			out = append(out, a, args[i+1])
+			i++
+			continue
+		}
+		out = append(out, a)
+	}
+	return out, ooc
+}
+
+// extractIntensityFlag pulls "-i <n>" out of args from anywhere in the list,
+// for the same reason extractHiddenFlag does, and returns its value.
+func extractIntensityFlag(args []string) (out []string, value string, found bool) {
+	return extractValueFlag(args, "i")
+}
+
+// extractValueFlag pulls "-<name> <value>" out of args from anywhere in the
+// list, leaving -r and -d values alone, and returns the value.
+func extractValueFlag(args []string, name string) (out []string, value string, found bool) {
+	out = make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		if (a == "-r" || a == "-d" || a == "--r" || a == "--d") && i+1 < len(args) {
+			out = append(out, a, args[i+1])
+			i++
+			continue
+		}
+		if (a == "-"+name || a == "--"+name) && i+1 < len(args) {
+			value, found = args[i+1], true
+			i++
+			continue
+		}
+		out = append(out, a)
+	}
+	return out, value, found
 }
 
 func cmdHiccup(client *Client, args []string, usage string) {
@@ -567,7 +660,7 @@ func cmdUnpunish(client *Client, args []string, usage string) {
 		}
 	}
 
-	toUnpunish := getUidList(uidTokens)
+	toUnpunish := getUidList(client, uidTokens)
 	var count int
 	var report string
 
@@ -1061,6 +1154,10 @@ func cmdStack(client *Client, args []string, usage string) {
 			client.SendServerMessage(fmt.Sprintf("Unknown punishment type: %v", name))
 			return
 		}
+		if punishmentDisabled(name) || punishmentDisabled(pType.String()) {
+			client.SendServerMessage(fmt.Sprintf("The %v punishment is disabled on this server.", name))
+			return
+		}
 		punishmentTypes = append(punishmentTypes, pType)
 	}
 
@@ -1119,7 +1216,7 @@ func cmdStack(client *Client, args []string, usage string) {
 			report += fmt.Sprintf("%v, ", c.Uid())
 		})
 	} else {
-		for _, c := range getUidList(strings.Split(uidStr, ",")) {
+		for _, c := range getUidList(client, strings.Split(uidStr, ",")) {
 			if punishmentSafeBlocked(c) {
 				notePunishmentSafeSkip(&skipped, &skippedReport, c)
 				continue
@@ -1140,6 +1237,7 @@ func cmdStack(client *Client, args []string, usage string) {
 	client.SendServerMessage(summary)
 	addToBuffer(client, "CMD", fmt.Sprintf("Applied stacked punishments [%v] to %v.", punishmentList, report), false)
 	alertPunishmentIssued(client, fmt.Sprintf("stack[%s]", punishmentList), report, count, duration, *reason, hidden)
+	announcePunishment(client, strings.Join(punishmentNames, " + "), report, count, hidden)
 }
 
 // cmdLovebomb applies the lovebomb punishment.
@@ -1269,7 +1367,7 @@ func cmdLovebomb(client *Client, args []string, usage string) {
 		return
 	case 1:
 		// Specific uid(s), random area target
-		for _, c := range getUidList(strings.Split(fargs[0], ",")) {
+		for _, c := range getUidList(client, strings.Split(fargs[0], ",")) {
 			if punishmentSafeBlocked(c) {
 				notePunishmentSafeSkip(&skipped, &skippedReport, c)
 				continue
@@ -1289,7 +1387,7 @@ func cmdLovebomb(client *Client, args []string, usage string) {
 			client.SendServerMessage(fmt.Sprintf("Target UID %v not found.", targetUID))
 			return
 		}
-		for _, c := range getUidList(strings.Split(fargs[0], ",")) {
+		for _, c := range getUidList(client, strings.Split(fargs[0], ",")) {
 			if punishmentSafeBlocked(c) {
 				notePunishmentSafeSkip(&skipped, &skippedReport, c)
 				continue
@@ -1320,7 +1418,7 @@ func cmdUnlovebomb(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1391,7 +1489,7 @@ func cmdUndegrade(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1417,7 +1515,7 @@ func cmdUnslang(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1443,7 +1541,7 @@ func cmdUnthesaurusoverload(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1469,7 +1567,7 @@ func cmdUnvalleygirl(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1495,7 +1593,7 @@ func cmdUnbabytalk(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1521,7 +1619,7 @@ func cmdUnthirdperson(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1547,7 +1645,7 @@ func cmdUnunreliablenarrator(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1573,7 +1671,7 @@ func cmdUnuncannyvalley(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1603,7 +1701,7 @@ func cmdUn51(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1633,7 +1731,7 @@ func cmdUnphilosopher(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1663,7 +1761,7 @@ func cmdUnpoet(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1693,7 +1791,7 @@ func cmdUnupsidedown(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1723,7 +1821,7 @@ func cmdUnsarcasm(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1753,7 +1851,7 @@ func cmdUnacademic(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1783,7 +1881,7 @@ func cmdUnrecipe(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1813,7 +1911,7 @@ func cmdUnquote(client *Client, args []string, usage string) {
 		client.SendServerMessage("Not enough arguments:\n" + usage)
 		return
 	}
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 	for _, c := range toUnpunish {
@@ -1915,7 +2013,7 @@ func cmdTranslator(client *Client, args []string, usage string) {
 			return
 		}
 	} else {
-		toPunish = getUidList(strings.Split(targetArg, ","))
+		toPunish = getUidList(client, strings.Split(targetArg, ","))
 	}
 
 	toPunish, skipped, skippedReport := partitionPunishmentSafe(toPunish)
@@ -1988,7 +2086,7 @@ func cmdUntranslator(client *Client, args []string, usage string) {
 			}
 		})
 	} else {
-		toUnpunish = getUidList(strings.Split(args[1], ","))
+		toUnpunish = getUidList(client, strings.Split(args[1], ","))
 	}
 	var count int
 	var report string
@@ -2065,6 +2163,11 @@ func cmdRandomPunishAll(client *Client, args []string, usage string) {
 		client.SendServerMessage("No players in this area to punish.")
 		return
 	}
+	pool := enabledPunishments(randompunishPool)
+	if len(pool) == 0 {
+		client.SendServerMessage("Every punishment in the pool is disabled on this server.")
+		return
+	}
 
 	msg := "You have been hit by /randompunishall"
 	if duration > 0 {
@@ -2077,7 +2180,7 @@ func cmdRandomPunishAll(client *Client, args []string, usage string) {
 	tier := issuerTierFor(client)
 	var report string
 	for _, c := range targets {
-		pType := randompunishPool[rand.Intn(len(randompunishPool))]
+		pType := pool[rng.Intn(len(pool))]
 		c.AddPunishmentBy(pType, duration, *reason, tier)
 		var expires int64
 		if duration > 0 {
@@ -2223,7 +2326,7 @@ func cmdICWarp(client *Client, args []string, usage string) {
 		client.SendServerMessage("Duration capped at 24 hours.")
 	}
 
-	toPunish := getUidList(strings.Split(flags.Arg(0), ","))
+	toPunish := getUidList(client, strings.Split(flags.Arg(0), ","))
 	toPunish, skipped, skippedReport := partitionPunishmentSafe(toPunish)
 	targetArea := client.Area()
 	var count int
@@ -2259,7 +2362,7 @@ func cmdICWarp(client *Client, args []string, usage string) {
 
 // cmdUniCWarp removes the icwarp punishment from user(s).
 func cmdUniCWarp(client *Client, args []string, usage string) {
-	toUnpunish := getUidList(strings.Split(args[0], ","))
+	toUnpunish := getUidList(client, strings.Split(args[0], ","))
 	var count int
 	var report string
 
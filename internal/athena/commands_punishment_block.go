@@ -40,7 +40,7 @@ func isPunishmentBlocked(ipid string) bool {
 //
 //	/blockpunishment <uid1>,<uid2>,...
 func cmdBlockPunishment(client *Client, args []string, usage string) {
-	targets := getUidList(strings.Split(args[0], ","))
+	targets := getUidList(client, strings.Split(args[0], ","))
 	if len(targets) == 0 {
 		client.SendServerMessage("No valid UID(s) provided.\n" + usage)
 		return
@@ -70,7 +70,7 @@ func cmdBlockPunishment(client *Client, args []string, usage string) {
 //
 //	/unblockpunishment <uid1>,<uid2>,...
 func cmdUnblockPunishment(client *Client, args []string, usage string) {
-	targets := getUidList(strings.Split(args[0], ","))
+	targets := getUidList(client, strings.Split(args[0], ","))
 	if len(targets) == 0 {
 		client.SendServerMessage("No valid UID(s) provided.\n" + usage)
 		return
@@ -13,12 +13,13 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"math/rand"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/MangosArentLiterature/Athena/internal/db"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
 	"github.com/MangosArentLiterature/Athena/internal/permissions"
 	str2duration "github.com/xhit/go-str2duration/v2"
 )
@@ -115,13 +116,15 @@ func cmdOmnidere(client *Client, args []string, usage string) {
 
 // megamasoStackPool is the pool /megamaso draws each random punishment from.
 // Reuses the same broad transform list /maso uses so the chaos stays varied.
+// /essay blocks short messages outright rather than transforming them, so it
+// is left out.
 var megamasoStackPool = []PunishmentType{
 	PunishmentBackward, PunishmentStutterstep, PunishmentElongate, PunishmentUppercase,
 	PunishmentLowercase, PunishmentRobotic, PunishmentAlternating, PunishmentFancy,
 	PunishmentUwu, PunishmentPirate, PunishmentShakespearean, PunishmentCaveman,
 	PunishmentCensor, PunishmentConfused, PunishmentParanoid, PunishmentDrunk,
 	PunishmentHiccup, PunishmentWhistle, PunishmentMumble, PunishmentSpaghetti,
-	PunishmentRng, PunishmentEssay, PunishmentAutospell, PunishmentSubtitles,
+	PunishmentRng, PunishmentAutospell, PunishmentSubtitles,
 	PunishmentSpotlight, PunishmentTsundere, PunishmentYandere, PunishmentKuudere,
 	PunishmentDandere, PunishmentDeredere, PunishmentBakadere, PunishmentSlang,
 	PunishmentValleyGirl, PunishmentBabytalk, PunishmentUnreliableNarrator,
@@ -167,18 +170,24 @@ func cmdMegamaso(client *Client, args []string, _ string) {
 		duration = 24 * time.Hour
 	}
 
+	pool := enabledPunishments(megamasoStackPool)
+	if len(pool) == 0 {
+		client.SendServerMessage("Every punishment in the pool is disabled on this server.")
+		return
+	}
+
 	// Pick a random punishment that the player isn't already wearing, falling
 	// back to "any" if everything is somehow already applied.
 	var pick PunishmentType
 	for tries := 0; tries < 16; tries++ {
-		candidate := megamasoStackPool[rand.Intn(len(megamasoStackPool))]
+		candidate := pool[rng.Intn(len(pool))]
 		if !client.HasPunishment(candidate) {
 			pick = candidate
 			break
 		}
 	}
 	if pick == PunishmentNone {
-		pick = megamasoStackPool[rand.Intn(len(megamasoStackPool))]
+		pick = pool[rng.Intn(len(pool))]
 	}
 
 	client.AddPunishment(pick, duration, "megamaso stack")
@@ -197,6 +206,23 @@ func cmdMegamaso(client *Client, args []string, _ string) {
 	addToBuffer(client, "CMD", fmt.Sprintf("Megamaso stacked %v.", pick.String()), false)
 }
 
+// applyDataPunishment applies a punishment that carries per-instance data
+// (an SFX URL, a sprite offset) and persists it by IPID the same way
+// /forcecolor does, so it is restored when the target reconnects.
+func applyDataPunishment(issuer, c *Client, pType PunishmentType, duration time.Duration, reason, customData string) {
+	tier := issuerTierFor(issuer)
+	c.AddPunishmentWithData(pType, duration, reason, customData)
+	c.setPunishmentTier(pType, tier)
+	var expires int64
+	if duration > 0 {
+		expires = time.Now().UTC().Add(duration).Unix()
+	}
+	stored := customData + "\x1f" + reason
+	if err := db.UpsertTextPunishmentBy(c.Ipid(), int(pType), expires, stored, int(tier)); err != nil {
+		logger.LogErrorf("Failed to persist %v for %v: %v", pType, c.Ipid(), err)
+	}
+}
+
 // Handles /sfxcurse <uid> <sfx-url>
 //
 // Forces the target to emit the specified SFX file on every IC message.
@@ -266,7 +292,7 @@ func cmdSfxCurse(client *Client, args []string, usage string) {
 				notePunishmentSafeSkip(&skipped, &skippedReport, c)
 				return
 			}
-			c.AddPunishmentWithData(PunishmentSfxCurse, duration, *reason, sfx)
+			applyDataPunishment(client, c, PunishmentSfxCurse, duration, *reason, sfx)
 			if !hidden {
 				c.SendServerMessage(fmt.Sprintf("🔊 You are now SFX-cursed: every IC message will play %s", sfx))
 			}
@@ -283,12 +309,12 @@ func cmdSfxCurse(client *Client, args []string, usage string) {
 		return
 	}
 
-	toCurse := getUidList(strings.Split(uidArg, ","))
+	toCurse := getUidList(client, strings.Split(uidArg, ","))
 	toCurse, skipped, skippedReport := partitionPunishmentSafe(toCurse)
 	count := 0
 	var report string
 	for _, c := range toCurse {
-		c.AddPunishmentWithData(PunishmentSfxCurse, duration, *reason, sfx)
+		applyDataPunishment(client, c, PunishmentSfxCurse, duration, *reason, sfx)
 		if !hidden {
 			c.SendServerMessage(fmt.Sprintf("🔊 You are now SFX-cursed: every IC message will play %s", sfx))
 		}
@@ -308,13 +334,16 @@ func cmdSfxCurse(client *Client, args []string, usage string) {
 
 // Handles /unsfx <uid>
 func cmdUnSfx(client *Client, args []string, _ string) {
-	toClear := getUidList(strings.Split(args[0], ","))
+	toClear := getUidList(client, strings.Split(args[0], ","))
 	count := 0
 	for _, c := range toClear {
 		if !c.HasPunishment(PunishmentSfxCurse) {
 			continue
 		}
 		c.RemovePunishment(PunishmentSfxCurse)
+		if err := db.DeleteTextPunishment(c.Ipid(), int(PunishmentSfxCurse)); err != nil {
+			logger.LogErrorf("Failed to delete persisted sfxcurse for %v: %v", c.Ipid(), err)
+		}
 		c.SendServerMessage("Your SFX curse has been lifted.")
 		count++
 	}
@@ -363,12 +392,12 @@ func applyShrinkGrowWide(client *Client, args []string, usage string, pType Puni
 		duration = 24 * time.Hour
 	}
 
-	toCurse := getUidList(strings.Split(uidArg, ","))
+	toCurse := getUidList(client, strings.Split(uidArg, ","))
 	toCurse, skipped, skippedReport := partitionPunishmentSafe(toCurse)
 	count := 0
 	var report string
 	for _, c := range toCurse {
-		c.AddPunishmentWithData(pType, duration, *reason, strconv.Itoa(offset))
+		applyDataPunishment(client, c, pType, duration, *reason, strconv.Itoa(offset))
 		if !hidden {
 			c.SendServerMessage(fmt.Sprintf("📐 You have been %v'd. Your sprite offset is locked at %d.", pType.String(), offset))
 		}
@@ -402,13 +431,16 @@ func cmdWide(client *Client, args []string, usage string) {
 
 // removeOffsetPunishment is shared by /unshrink, /ungrow, /unwide.
 func removeOffsetPunishment(client *Client, args []string, pType PunishmentType, label string) {
-	toClear := getUidList(strings.Split(args[0], ","))
+	toClear := getUidList(client, strings.Split(args[0], ","))
 	count := 0
 	for _, c := range toClear {
 		if !c.HasPunishment(pType) {
 			continue
 		}
 		c.RemovePunishment(pType)
+		if err := db.DeleteTextPunishment(c.Ipid(), int(pType)); err != nil {
+			logger.LogErrorf("Failed to remove %s for %v: %v", label, c.Ipid(), err)
+		}
 		c.SendServerMessage(fmt.Sprintf("Your %s effect has been removed.", label))
 		count++
 	}
@@ -10,8 +10,25 @@
 
 package athena
 
+import (
+	"fmt"
+	"strconv"
+)
+
+// cmdZalgo handles /zalgo. -l sets the intensity (1-3) stored on the
+// punishment; without it the effect keeps its standard strength.
 func cmdZalgo(client *Client, args []string, usage string) {
-	cmdPunishment(client, args, usage, PunishmentZalgo)
+	args, level, ok := extractValueFlag(args, "l")
+	var customData string
+	if ok {
+		n, err := strconv.Atoi(level)
+		if err != nil || n < 1 || n > zalgoMaxIntensity {
+			client.SendServerMessage(fmt.Sprintf("Intensity must be between 1 and %v.", zalgoMaxIntensity))
+			return
+		}
+		customData = strconv.Itoa(n)
+	}
+	cmdPunishmentWithData(client, args, usage, PunishmentZalgo, customData)
 }
 
 func cmdLeetspeak(client *Client, args []string, usage string) {
@@ -5,6 +5,11 @@
                          Players inspect themselves; the uid form needs MUTE.
                          With 100+ stackable punishment types this is the
                          mod team's missing dashboard.
+     /mystatus           show your own mute, jail and punishment state with
+                         the time left on each, so players don't have to
+                         ask a moderator.
+     /find <uid|name>    show which area a player is in, by UID, OOC name,
+                         character or showname, so friends can regroup.
      /clients <uid>      list every connection sharing the target's IPID
                          (multiclient overview). Requires MUTE.
      /lfp                toggle the Looking-For-Pair flag.
@@ -18,9 +23,11 @@ package athena
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/MangosArentLiterature/Athena/internal/permissions"
 )
@@ -47,24 +54,39 @@ func cmdPunishments(client *Client, args []string, usage string) {
 	}
 
 	viewerIsMod := permissions.HasPermission(client.Perms(), permissions.PermissionField["MUTE"])
+	var lines []string
+	for _, line := range punishmentSummary(target, viewerIsMod) {
+		lines = append(lines, "  • "+line)
+	}
+
+	who := fmt.Sprintf("[%v] %v", target.Uid(), clientDisplayName(target))
+	if target == client {
+		who += " (you)"
+	}
+	if len(lines) == 0 {
+		client.SendServerMessage(fmt.Sprintf("⛓️ %v has no active punishments. Enjoy the freedom while it lasts.", who))
+		return
+	}
+	client.SendServerMessage(fmt.Sprintf("⛓️ Active punishments for %v (%d):\n%v", who, len(lines), strings.Join(lines, "\n")))
+}
+
+// punishmentSummary describes each of target's active effects, one line per
+// effect, including the ones that live outside the punishment slice (lag,
+// mute, jail). withTier appends the issuer tier, which only staff should see.
+func punishmentSummary(target *Client, withTier bool) []string {
 	active := target.GetActivePunishments()
 
 	var lines []string
 	for i := range active {
 		p := &active[i]
-		line := "  • " + p.punishmentType.String()
-		if p.expiresAt.IsZero() {
-			line += " — permanent"
-		} else {
-			line += fmt.Sprintf(" — %v left", time.Until(p.expiresAt).Round(time.Second))
-		}
+		line := p.punishmentType.String() + " — " + timeLeft(p.expiresAt)
 		if p.customData != "" {
 			line += fmt.Sprintf(" (%v)", p.customData)
 		}
 		if p.reason != "" {
 			line += " — reason: " + p.reason
 		}
-		if viewerIsMod {
+		if withTier {
 			switch p.issuerTier {
 			case IssuerMod:
 				line += " [by mod]"
@@ -79,28 +101,165 @@ func cmdPunishments(client *Client, args []string, usage string) {
 
 	// Effects living outside the punishment slice.
 	if isIPIDTormented(target.Ipid()) {
-		lines = append(lines, "  • lag (torment list — lift with /unpunish -t lag)")
+		lines = append(lines, "lag (torment list — lift with /unpunish -t lag)")
 	}
 	if target.Muted() != Unmuted {
-		line := "  • muted"
+		line := "muted"
 		if until := target.UnmuteTime(); !until.IsZero() {
-			line += fmt.Sprintf(" — %v left", time.Until(until).Round(time.Second))
+			line += " — " + timeLeft(until)
 		}
 		lines = append(lines, line)
 	}
 	if target.IsJailed() {
-		lines = append(lines, fmt.Sprintf("  • jailed — %v left", time.Until(target.JailedUntil()).Round(time.Second)))
+		lines = append(lines, "jailed — "+timeLeft(target.JailedUntil()))
 	}
+	return lines
+}
 
-	who := fmt.Sprintf("[%v] %v", target.Uid(), clientDisplayName(target))
-	if target == client {
-		who += " (you)"
+// permanentAfter marks expiry times far enough out to count as permanent;
+// /jail perma uses the end of 2099.
+var permanentAfter = time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// timeLeft describes how long remains until an expiry time, e.g. "4m left".
+// A zero time (no expiry) or one past permanentAfter reads "permanent".
+func timeLeft(until time.Time) string {
+	if until.IsZero() || !until.Before(permanentAfter) {
+		return "permanent"
 	}
-	if len(lines) == 0 {
-		client.SendServerMessage(fmt.Sprintf("⛓️ %v has no active punishments. Enjoy the freedom while it lasts.", who))
+	return formatDurationShort(time.Until(until)) + " left"
+}
+
+// formatDurationShort renders d using its two largest non-zero units, e.g.
+// "2d 3h", "1h 5m", "4m" or "30s".
+func formatDurationShort(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d <= 0 {
+		return "0s"
+	}
+	units := []struct {
+		size   time.Duration
+		suffix string
+	}{
+		{24 * time.Hour, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	}
+	var parts []string
+	for _, u := range units {
+		if n := d / u.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%v", n, u.suffix))
+			d -= n * u.size
+		} else if len(parts) > 0 {
+			break
+		}
+		if len(parts) == 2 {
+			break
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// cmdMyStatus reports the caller's own mute, jail and punishment state.
+func cmdMyStatus(client *Client, _ []string, _ string) {
+	mute := "not muted"
+	if m := client.Muted(); m != Unmuted {
+		mute = fmt.Sprintf("muted %v — %v", m.String(), timeLeft(client.UnmuteTime()))
+	}
+	jail := "not jailed"
+	if client.IsJailed() {
+		jail = "jailed — " + timeLeft(client.JailedUntil())
+		if id := client.JailAreaID(); id >= 0 && id < len(areas) {
+			jail += fmt.Sprintf(" (held in %v)", areas[id].Name())
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "📋 Your status:\n  • Mute: %v\n  • Jail: %v", mute, jail)
+	active := client.GetActivePunishments()
+	if len(active) == 0 {
+		b.WriteString("\n  • Punishments: none")
+	} else {
+		fmt.Fprintf(&b, "\n  • Punishments (%d):", len(active))
+		for i := range active {
+			p := &active[i]
+			fmt.Fprintf(&b, "\n      – %v — %v", p.punishmentType.String(), timeLeft(p.expiresAt))
+		}
+	}
+	client.SendServerMessage(b.String())
+}
+
+// maxFindResults caps how many matches /find lists for a loose name search.
+const maxFindResults = 10
+
+// cmdFind reports which area a player is in. A numeric argument is tried as a
+// UID first; otherwise OOC names, characters and shownames are matched exactly
+// (case-insensitive), falling back to a substring match when nothing matches
+// exactly. Hidden admins are only visible to other admins, mirroring /gas.
+func cmdFind(client *Client, args []string, _ string) {
+	query := strings.TrimSpace(strings.Join(args, " "))
+	seeHidden := permissions.HasPermission(client.Perms(), permissions.PermissionField["ADMIN"])
+	visible := func(c *Client) bool {
+		return c.Uid() != -1 && (seeHidden || !c.Hidden())
+	}
+
+	var matches []*Client
+	if uid, err := strconv.Atoi(query); err == nil {
+		if c, err := getClientByUid(uid); err == nil && visible(c) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		lower := strings.ToLower(query)
+		var exact, partial []*Client
+		clients.ForEach(func(c *Client) {
+			if !visible(c) {
+				return
+			}
+			names := []string{c.OOCName(), c.CurrentCharacter(), c.EffectiveShowname()}
+			for _, n := range names {
+				if n != "" && strings.EqualFold(n, query) {
+					exact = append(exact, c)
+					return
+				}
+			}
+			for _, n := range names {
+				if n != "" && strings.Contains(strings.ToLower(n), lower) {
+					partial = append(partial, c)
+					return
+				}
+			}
+		})
+		matches = exact
+		if len(matches) == 0 {
+			matches = partial
+		}
+	}
+
+	if len(matches) == 0 {
+		client.SendServerMessage(fmt.Sprintf("No player found matching \"%v\".", query))
 		return
 	}
-	client.SendServerMessage(fmt.Sprintf("⛓️ Active punishments for %v (%d):\n%v", who, len(lines), strings.Join(lines, "\n")))
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Uid() < matches[j].Uid() })
+	var lines []string
+	for i, c := range matches {
+		if i == maxFindResults {
+			lines = append(lines, fmt.Sprintf("  …and %d more; try a more specific name.", len(matches)-maxFindResults))
+			break
+		}
+		lines = append(lines, playerLocationLine(c))
+	}
+	client.SendServerMessage(fmt.Sprintf("🔎 Found %d player(s):\n%v", len(matches), strings.Join(lines, "\n")))
+}
+
+// playerLocationLine renders one player as "  [uid] Char (OOC) — area N: Name"
+// for /find and /alts.
+func playerLocationLine(c *Client) string {
+	line := fmt.Sprintf("  [%v] %v", c.Uid(), c.CurrentCharacter())
+	if ooc := c.OOCName(); ooc != "" {
+		line += fmt.Sprintf(" (%v)", ooc)
+	}
+	return line + fmt.Sprintf(" — area %d: %v", getAreaIndex(c.Area()), c.Area().Name())
 }
 
 // cmdClients lists every connection sharing the target's IPID.
@@ -163,6 +322,71 @@ func cmdLfp(client *Client, _ []string, _ string) {
 	}
 }
 
+// awayMsgMaxLen caps the /away message, in characters.
+const awayMsgMaxLen = 100
+
+// Away returns whether the client is marked /away, and its away message.
+func (client *Client) Away() (bool, string) {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	return client.away, client.awayMsg
+}
+
+// SetAway marks the client as away with the given (possibly empty) message.
+func (client *Client) SetAway(msg string) {
+	client.mu.Lock()
+	client.away = true
+	client.awayMsg = msg
+	client.mu.Unlock()
+}
+
+// ClearAway clears the /away flag, reporting whether it was set.
+func (client *Client) ClearAway() bool {
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	was := client.away
+	client.away = false
+	client.awayMsg = ""
+	return was
+}
+
+// awayLabel returns the /players suffix for an away client, or "".
+func awayLabel(c *Client) string {
+	away, msg := c.Away()
+	switch {
+	case !away:
+		return ""
+	case msg == "":
+		return " (away)"
+	default:
+		return fmt.Sprintf(" (away: %v)", msg)
+	}
+}
+
+// cmdAway marks the caller as away, with an optional message.
+func cmdAway(client *Client, args []string, _ string) {
+	msg := strings.TrimSpace(strings.Join(args, " "))
+	if utf8.RuneCountInString(msg) > awayMsgMaxLen {
+		client.SendServerMessage(fmt.Sprintf("Your away message is too long (max %d characters).", awayMsgMaxLen))
+		return
+	}
+	client.SetAway(msg)
+	if msg == "" {
+		client.SendServerMessage("💤 You are now marked as away. Use /back, or just speak IC, to return.")
+	} else {
+		client.SendServerMessage(fmt.Sprintf("💤 You are now marked as away: %v. Use /back, or just speak IC, to return.", msg))
+	}
+}
+
+// cmdBack clears the caller's away flag.
+func cmdBack(client *Client, _ []string, _ string) {
+	if !client.ClearAway() {
+		client.SendServerMessage("You are not marked as away.")
+		return
+	}
+	client.SendServerMessage("👋 Welcome back! You are no longer marked as away.")
+}
+
 // cmdPairlist lists everyone in the caller's area flagged /lfp.
 func cmdPairlist(client *Client, _ []string, _ string) {
 	a := client.Area()
@@ -190,3 +414,35 @@ func cmdPairlist(client *Client, _ []string, _ string) {
 func cmdStealthMute(client *Client, args []string, usage string) {
 	cmdPunishment(client, append(args, "-h"), usage, PunishmentStealthMute)
 }
+
+// maxClearLines caps how many of their own IC lines /clear may remove at once.
+const maxClearLines = 10
+
+// cmdClearMine removes the caller's most recent IC lines from their area's
+// in-memory log buffer so they no longer show up in /log. Already-delivered
+// messages and on-disk area logs are untouched, and a marker line plus an
+// audit entry record how many lines were removed, so modcall reports and
+// moderators still see that something was cleared.
+func cmdClearMine(client *Client, args []string, usage string) {
+	count := 1
+	if len(args) > 0 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 || n > maxClearLines {
+			client.SendServerMessage(fmt.Sprintf("Count must be between 1 and %d.\n%v", maxClearLines, usage))
+			return
+		}
+		count = n
+	}
+	ipid := client.Ipid()
+	removed := client.Area().RemoveLastBufferLines(func(line string) bool {
+		// Buffer lines are "time | action | character | ipid | ooc name | message".
+		parts := strings.SplitN(line, " | ", 5)
+		return len(parts) == 5 && parts[1] == "IC" && parts[3] == ipid
+	}, count)
+	if removed == 0 {
+		client.SendServerMessage("You have no IC messages in this area's log.")
+		return
+	}
+	addToBuffer(client, "CMD", fmt.Sprintf("Cleared %d own IC line(s) from the area log.", removed), true)
+	client.SendServerMessage(fmt.Sprintf("Removed %d of your IC message(s) from this area's log. Anyone who already saw them still did.", removed))
+}
@@ -0,0 +1,168 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestFormatDurationShort(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{30 * time.Second, "30s"},
+		{4 * time.Minute, "4m"},
+		{4*time.Minute + 30*time.Second, "4m 30s"},
+		{time.Hour + 5*time.Minute + 10*time.Second, "1h 5m"},
+		{time.Hour + 30*time.Second, "1h"},
+		{51 * time.Hour, "2d 3h"},
+	}
+	for _, tt := range tests {
+		if got := formatDurationShort(tt.d); got != tt.want {
+			t.Errorf("formatDurationShort(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestMyStatus(t *testing.T) {
+	conn := &captureConn{}
+	client := &Client{conn: conn, uid: 1, char: -1, area: makeTestArea("Courtroom"), jailAreaID: -1}
+	client.SetMuted(ICMuted)
+	client.SetUnmuteTime(time.Now().UTC().Add(4*time.Minute + 2*time.Second))
+	client.SetJailedUntil(time.Date(2099, 12, 31, 23, 59, 59, 0, time.UTC))
+
+	cmdMyStatus(client, nil, "")
+	out := conn.String()
+	for _, want := range []string{"Mute: muted IC — 4m", "Jail: jailed — permanent", "Punishments: none"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("/mystatus output missing %q: %q", want, out)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	newTestClients(t)
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	t.Cleanup(setupTestAreas([]*area.Area{lobby, court}))
+
+	newClient := func(uid int, ooc string, a *area.Area) (*Client, *captureConn) {
+		conn := &captureConn{}
+		c := &Client{conn: conn, uid: uid, ipid: "secret-ipid", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+		c.SetOocName(ooc)
+		c.SetArea(a)
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+		return c, conn
+	}
+	_, conn := newClient(1, "Seeker", lobby)
+	newClient(2, "Phoenix", court)
+	ghost, _ := newClient(3, "Ghost", court)
+	ghost.SetHidden(true)
+
+	seeker, _ := getClientByUid(1)
+	cmdFind(seeker, []string{"phoenix"}, "")
+	if out := conn.String(); !strings.Contains(out, "[2]") || !strings.Contains(out, "area 1: Court") || strings.Contains(out, "secret-ipid") {
+		t.Errorf("/find phoenix = %q, want UID 2 in Court without IPID", out)
+	}
+
+	seen := len(conn.String())
+	cmdFind(seeker, []string{"3"}, "")
+	if out := conn.String()[seen:]; !strings.Contains(out, "No player found") {
+		t.Errorf("hidden client should not be found by a player, got %q", out)
+	}
+
+	seen = len(conn.String())
+	seeker.SetPerms(permissions.PermissionField["ADMIN"])
+	cmdFind(seeker, []string{"3"}, "")
+	if out := conn.String()[seen:]; !strings.Contains(out, "[3]") {
+		t.Errorf("admin should find hidden client, got %q", out)
+	}
+}
+
+func TestAwayAndBack(t *testing.T) {
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	t.Cleanup(setupTestAreas([]*area.Area{court}))
+
+	newClient := func(uid int, ooc string) (*Client, *captureConn) {
+		conn := &captureConn{}
+		c := &Client{conn: conn, uid: uid, ipid: "ip", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+		c.SetOocName(ooc)
+		c.SetArea(court)
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+		return c, conn
+	}
+	afk, afkConn := newClient(1, "Sleepy")
+	sender, conn := newClient(2, "Sender")
+
+	cmdAway(afk, []string{"brb", "dinner"}, "")
+	cmdPlayers(sender, nil, "")
+	if out := conn.String(); !strings.Contains(out, "(away: brb dinner)") {
+		t.Errorf("/players should mark the away player, got %q", out)
+	}
+	seen := len(conn.String())
+	cmdPM(sender, []string{"1", "hello?"}, "")
+	if out := conn.String()[seen:]; !strings.Contains(out, "is away: brb dinner") {
+		t.Errorf("PM to an away player should return the away message, got %q", out)
+	}
+
+	cmdBack(afk, nil, "")
+	if away, _ := afk.Away(); away || !strings.Contains(afkConn.String(), "Welcome back") {
+		t.Errorf("/back should clear the away flag, got %q", afkConn.String())
+	}
+	seen = len(conn.String())
+	cmdPM(sender, []string{"1", "hello?"}, "")
+	if out := conn.String()[seen:]; strings.Contains(out, "is away") {
+		t.Errorf("PM after /back should not mention away, got %q", out)
+	}
+}
+
+// TestClearMineLeavesMarker verifies that /clear removes only the caller's IC
+// lines and leaves a marker so the log shows something was cleared.
+func TestClearMineLeavesMarker(t *testing.T) {
+	court := makeTestArea("Courtroom")
+	client := &Client{conn: &captureConn{}, uid: 1, ipid: "clear-ipid", char: -1, area: court}
+	other := &Client{conn: &captureConn{}, uid: 2, ipid: "other-ipid", char: -1, area: court}
+	addToBuffer(client, "IC", "\"first\"", false)
+	addToBuffer(other, "IC", "\"keep me\"", false)
+	addToBuffer(client, "IC", "\"second\"", false)
+
+	cmdClearMine(client, []string{"5"}, "")
+	log := strings.Join(court.Buffer(), "\n")
+	if strings.Contains(log, "first") || strings.Contains(log, "second") {
+		t.Errorf("the caller's IC lines should be gone: %q", log)
+	}
+	if !strings.Contains(log, "keep me") {
+		t.Errorf("other players' lines should stay: %q", log)
+	}
+	if !strings.Contains(log, "Cleared 2 own IC line(s) from the area log.") {
+		t.Errorf("expected a marker line, got %q", log)
+	}
+}
@@ -36,6 +36,7 @@ type Command struct {
 	voiceCmd   bool   // when true, command is hidden/disabled if EnableVoice is false
 	category   string // help category (e.g. "general", "casino", "punishment")
 	publicHelp bool   // when true, command is listed in /help (and /help <cmd> shows usage) for everyone, even users who lack reqPerms
+	ownsH      bool   // when true, -h is one of the command's own flags and is passed to the handler instead of printing usage
 }
 
 var Commands map[string]Command
@@ -139,6 +140,22 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "general",
 		},
+		"areapass": {
+			handler:  cmdAreaPass,
+			minArgs:  1,
+			usage:    "Usage: /areapass <password>\n       /areapass -c\n-c: Remove the password.",
+			desc:     "Sets a password that lets players invite themselves into the area with /move <area> <password>.",
+			reqPerms: permissions.PermissionField["CM"],
+			category: "area",
+		},
+		"areamotd": {
+			handler:  cmdAreaDesc,
+			minArgs:  0,
+			usage:    "Usage: /areamotd [-c] [description]\n-c: Clear the description.",
+			desc:     "Alias of /areadesc — prints or sets the area's entry description.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "area",
+		},
 		"areadesc": {
 			handler:  cmdAreaDesc,
 			minArgs:  0,
@@ -147,6 +164,14 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "area",
 		},
+		"areatemplates": {
+			handler:  cmdAreaTemplates,
+			minArgs:  0,
+			usage:    "Usage: /areatemplates [template]\nWith no template, lists the configured templates.",
+			desc:     "Lists the area templates from config.toml, or applies one to the current area.",
+			reqPerms: permissions.PermissionField["MODIFY_AREA"],
+			category: "area",
+		},
 		"arealog": {
 			handler:  cmdAreaLog,
 			minArgs:  1,
@@ -158,7 +183,7 @@ func initCommands() {
 		"ban": {
 			handler:  cmdBan,
 			minArgs:  3,
-			usage:    "Usage: /ban -u <uid1>,<uid2>... | -i <ipid1>,<ipid2>... [-d duration] <reason>\n-i supports offline IPIDs.",
+			usage:    "Usage: /ban -u <uid1>,<uid2>... | -i <ipid1>,<ipid2>... | -area <id> [-d duration] <reason>\n       /ban -n -u <uid1>,<uid2>... | -i <ipid1>,<ipid2>... | -area <id>\n-i supports offline IPIDs.\n-area bans everyone in that area except staff with BYPASS_LOCK.\n-n: dry run; lists who would be banned without banning anyone.",
 			desc:     "Bans user(s) from the server. Use -i to ban by IPID (supports offline users).",
 			reqPerms: permissions.PermissionField["BAN"],
 			category: "moderation",
@@ -174,8 +199,8 @@ func initCommands() {
 		"bglist": {
 			handler:  cmdBgList,
 			minArgs:  0,
-			usage:    "Usage: /bglist",
-			desc:     "Lists all available backgrounds.",
+			usage:    "Usage: /bglist [page]",
+			desc:     "Lists all available backgrounds, 50 per page.",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "general",
 		},
@@ -230,7 +255,7 @@ func initCommands() {
 		"doc": {
 			handler:  cmdDoc,
 			minArgs:  0,
-			usage:    "Usage: /doc [-c] [doc]\n-c: Clear the doc.",
+			usage:    "Usage: /doc [-c | -prev] [doc]\n-c: Clear the doc.\n-prev: Restore the doc that was in place before the last change.",
 			desc:     "Prints or sets the area's document.",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "area",
@@ -299,10 +324,19 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["BAN"],
 			category: "moderation",
 		},
+		"alts": {
+			handler:  cmdAlts,
+			minArgs:  1,
+			usage:    "Usage: /alts <uid>",
+			desc:     "Lists connected clients sharing the player's IPID or HDID.",
+			reqPerms: permissions.PermissionField["BAN_INFO"],
+			category: "moderation",
+		},
 		"getban": {
 			handler:  cmdGetBan,
 			minArgs:  0,
-			usage:    "Usage: /getban [-b banid | -i ipid]",
+			usage:    "Usage: /getban [-b banid | -i ipid | -h hdid]",
+			ownsH:    true,
 			desc:     "Prints ban(s) matching the search parameters, or prints the 5 most recent bans.",
 			reqPerms: permissions.PermissionField["BAN_INFO"],
 			category: "moderation",
@@ -363,6 +397,22 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["CM"],
 			category: "area",
 		},
+		"invitecode": {
+			handler:  cmdInviteCode,
+			minArgs:  0,
+			usage:    "Usage: /invitecode [-n uses] [-t minutes]",
+			desc:     "Creates a short-lived join code for the current locked area. Anyone who redeems it with /join is invited in. Single-use and valid for 10 minutes by default.",
+			reqPerms: permissions.PermissionField["CM"],
+			category: "area",
+		},
+		"join": {
+			handler:  cmdJoin,
+			minArgs:  1,
+			usage:    "Usage: /join <code>",
+			desc:     "Redeems an area join code from /invitecode, adding you to that area's invite list and moving you there.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "area",
+		},
 		"ignore": {
 			handler:  cmdIgnore,
 			minArgs:  1,
@@ -383,14 +433,14 @@ func initCommands() {
 			handler:  cmdJail,
 			minArgs:  1,
 			usage:    "Usage: /jail <uid> [area_id] [-d duration] [-r reason]",
-			desc:     "Jails a player in the given area (or their current area). They cannot leave and are returned there on reconnect.",
+			desc:     "Jails a player in the given area (or the configured jail area, else their current area). They cannot leave and are returned there on reconnect.",
 			reqPerms: permissions.PermissionField["BAN"],
 			category: "moderation",
 		},
 		"kick": {
 			handler:  cmdKick,
 			minArgs:  3,
-			usage:    "Usage: /kick -u <uid1>,<uid2>... | -i <ipid1>,<ipid2>... <reason>",
+			usage:    "Usage: /kick -u <uid1>,<uid2>... | -i <ipid1>,<ipid2>... | -area <id> <reason>\n       /kick -n -u <uid1>,<uid2>... | -i <ipid1>,<ipid2>... | -area <id>\n-area kicks everyone in that area except staff with BYPASS_LOCK.\n-n: dry run; lists who would be kicked without kicking anyone.",
 			desc:     "Kicks user(s) from the server.",
 			reqPerms: permissions.PermissionField["KICK"],
 			category: "moderation",
@@ -459,6 +509,22 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["BAN"],
 			category: "moderation",
 		},
+		"chatlockdown": {
+			handler:  cmdChatLockdown,
+			minArgs:  1,
+			usage:    "Usage: /chatlockdown <on|off|status> [ooc,global,ic|all]",
+			desc:     "Silences all non-mod chat server-wide during raids. Scope defaults to OOC and global; add 'ic' or 'all' to include IC. Moderators bypass it, and the state is broadcast to everyone.",
+			reqPerms: permissions.PermissionField["MUTE"],
+			category: "moderation",
+		},
+		"panic": {
+			handler:  cmdPanic,
+			minArgs:  1,
+			usage:    "Usage: /panic <on|off>\n/panic off lifts any /chatlockdown as well, so all chat is open again.",
+			desc:     "Silences all IC, OOC and global chat from anyone without MOD_SPEAK until turned off. The state is broadcast to everyone.",
+			reqPerms: permissions.PermissionField["MUTE"],
+			category: "moderation",
+		},
 		"botban": {
 			handler:  cmdBotBan,
 			minArgs:  0,
@@ -523,6 +589,30 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["MODIFY_AREA"],
 			category: "area",
 		},
+		"bufsize": {
+			handler:  cmdBufSize,
+			minArgs:  1,
+			usage:    "Usage: /bufsize <n|off>",
+			desc:     "Sets how many lines this area's log buffer keeps (from the server default up to 1000); the oldest lines drop off once it is full. 'off' restores the server default.",
+			reqPerms: permissions.PermissionField["CM"],
+			category: "area",
+		},
+		"maxlen": {
+			handler:  cmdMaxLen,
+			minArgs:  1,
+			usage:    "Usage: /maxlen <n|off>",
+			desc:     "Limits IC messages in this area to n characters (at most the server's max_message_length). 'off' restores the server default.",
+			reqPerms: permissions.PermissionField["CM"],
+			category: "area",
+		},
+		"capsfilter": {
+			handler:  cmdCapsFilter,
+			minArgs:  1,
+			usage:    "Usage: /capsfilter <off|lower|reject>",
+			desc:     "Sets how this area treats ALL-CAPS IC: lowercase it, reject it, or leave it alone. Short interjections are exempt.",
+			reqPerms: permissions.PermissionField["CM"],
+			category: "area",
+		},
 		"punishmentsafe": {
 			handler:  cmdPunishmentSafeArea,
 			minArgs:  1,
@@ -547,11 +637,27 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["DJ"],
 			category: "area",
 		},
+		"clear": {
+			handler:  cmdClearMine,
+			minArgs:  0,
+			usage:    "Usage: /clear [count]",
+			desc:     "Removes your last 1-10 IC messages from this area's log buffer so they don't appear in /log. Does not unsend anything, and the log and audit log note how many were cleared.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
+		"lang": {
+			handler:  cmdLang,
+			minArgs:  0,
+			usage:    "Usage: /lang [code]",
+			desc:     "Shows or sets the language used for server messages sent to you.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
 		"log": {
 			handler:  cmdLog,
 			minArgs:  1,
-			usage:    "Usage: /log <area>",
-			desc:     "Prints an area's log buffer.",
+			usage:    "Usage: /log [-e] <area> [terms...]\n-e: Treat the terms as a single regular expression.",
+			desc:     "Prints an area's log buffer, optionally keeping only lines that contain every search term (or match a regex with -e).",
 			reqPerms: permissions.PermissionField["LOG"],
 			category: "moderation",
 		},
@@ -579,6 +685,14 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["ADMIN"],
 			category: "admin",
 		},
+		"announce": {
+			handler:  cmdAnnounce,
+			minArgs:  1,
+			usage:    "Usage: /announce [-a <area ids>] <message>\n-a: Comma-separated area IDs to limit the announcement to.",
+			desc:     "Sends a framed server announcement to every area, or only the listed ones.",
+			reqPerms: permissions.PermissionField["MOD_SPEAK"],
+			category: "moderation",
+		},
 		"mod": {
 			handler:  cmdMod,
 			minArgs:  1,
@@ -606,7 +720,7 @@ func initCommands() {
 		"move": {
 			handler:  cmdMove,
 			minArgs:  1,
-			usage:    "Usage: /move [-u <uid1,<uid2>...] <area>",
+			usage:    "Usage: /move [-u <uid1,<uid2>...] <area>\n       /move <area> <password>\n       /move -f <source area> <area>\n<area> is an area number or name.\n<password>: the area's /areapass password; adds you to its invite list.\n-f: move everyone in the source area (requires MOVE_USERS).",
 			desc:     "Moves to an area.",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "general",
@@ -614,8 +728,8 @@ func initCommands() {
 		"modnote": {
 			handler:  cmdModnote,
 			minArgs:  1,
-			usage:    "Usage: /modnote add <ipid> <note> | /modnote list <ipid> | /modnote delete <id>",
-			desc:     "Manages per-IPID moderator notes.",
+			usage:    "Usage: /modnote add <uid|ipid> <note> | /modnote list <uid|ipid> | /modnote delete <id>",
+			desc:     "Manages per-IPID moderator notes; the newest show up in /players.",
 			reqPerms: permissions.PermissionField["BAN_INFO"],
 			category: "moderation",
 		},
@@ -703,6 +817,7 @@ func initCommands() {
 			handler:  cmdVoiceMute,
 			minArgs:  1,
 			usage:    "Usage: /voicemute [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Voice punishment: silently drops every one of the target's voice-chat frames.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			voiceCmd: true,
@@ -712,6 +827,7 @@ func initCommands() {
 			handler:  cmdVoiceStatic,
 			minArgs:  1,
 			usage:    "Usage: /voicestatic [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Voice punishment: drops ~60% of the target's voice frames — choppy, breaking up.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			voiceCmd: true,
@@ -721,6 +837,7 @@ func initCommands() {
 			handler:  cmdVoiceGarble,
 			minArgs:  1,
 			usage:    "Usage: /voicegarble [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Voice punishment: drops ~88% of the target's voice frames — barely intelligible.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			voiceCmd: true,
@@ -730,6 +847,7 @@ func initCommands() {
 			handler:  cmdVoiceCutout,
 			minArgs:  1,
 			usage:    "Usage: /voicecutout [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Voice punishment: gates the target's voice on a walkie-talkie on/off cycle.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			voiceCmd: true,
@@ -739,6 +857,7 @@ func initCommands() {
 			handler:  cmdVoiceStutter,
 			minArgs:  1,
 			usage:    "Usage: /voicestutter [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Voice punishment: randomly replays stale frames — a glitchy voice stutter.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			voiceCmd: true,
@@ -848,6 +967,22 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["KICK"],
 			category: "moderation",
 		},
+		"queue": {
+			handler:  cmdQueue,
+			minArgs:  0,
+			usage:    "Usage: /queue [leave] <area>\n       /queue\n<area> is an area number or name. With no arguments, lists who is queued for your area.",
+			desc:     "Joins the queue for an area that is looking for players; you are invited when its CM runs /startcase.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "area",
+		},
+		"songqueue": {
+			handler:  cmdSongQueue,
+			minArgs:  1,
+			usage:    "Usage: /songqueue <song> | list | next",
+			desc:     "Requests a song for the area's queue; CMs and DJs play the oldest request with /songqueue next.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "area",
+		},
 		"reversename": {
 			handler:  cmdReverseName,
 			minArgs:  1,
@@ -1008,7 +1143,7 @@ func initCommands() {
 		"poll": {
 			handler:  cmdPoll,
 			minArgs:  1,
-			usage:    "Usage: /poll [question]|[option1]|[option2]|[option3...]",
+			usage:    "Usage: /poll [-p] [question]|[option1]|[option2]|[option3...]\n-p: Public poll; the results show which UIDs voted for each option.",
 			desc:     "Creates a poll in the current area.",
 			reqPerms: permissions.PermissionField["CM"],
 			category: "area",
@@ -1040,11 +1175,19 @@ func initCommands() {
 		"roll": {
 			handler:  cmdRoll,
 			minArgs:  1,
-			usage:    "Usage: /roll [-p] <dice>d<sides>\n-p: Sets the roll to be private.",
+			usage:    "Usage: /roll [-p] <expression>\nAn expression adds dice groups and modifiers, e.g. d20, 2d6+3, d20-1 or 3d8+2d4.\n-p: Sets the roll to be private.",
 			desc:     "Rolls dice.",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "general",
 		},
+		"choose": {
+			handler:  cmdChoose,
+			minArgs:  1,
+			usage:    "Usage: /choose <option> | <option> [| <option>...]\nOptions may also be separated by commas, or by spaces for single words.",
+			desc:     "Picks one of the given options at random.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
 		"randomchar": {
 			handler:  cmdRandomChar,
 			minArgs:  0,
@@ -1077,11 +1220,19 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "general",
 		},
+		"leaderboard": {
+			handler:  cmdLeaderboard,
+			minArgs:  1,
+			usage:    "Usage: /leaderboard <rps|coinflip|ttt|trivia> [area] [n]\narea: Only rank players currently in this area.",
+			desc:     "Shows the top RPS, coinflip, tic-tac-toe or trivia players by wins, server-wide or in the current area.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
 		"coinflip": {
 			handler:  cmdCoinflip,
 			minArgs:  1,
-			usage:    "Usage: /coinflip <heads|tails>",
-			desc:     "Challenge another player to a coinflip.",
+			usage:    "Usage: /coinflip [-s] <heads|tails>\n-s: Flip solo against the server instead of challenging the area.",
+			desc:     "Challenge another player to a coinflip, or flip solo with -s.",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "general",
 		},
@@ -1133,10 +1284,18 @@ func initCommands() {
 			category:   "area",
 			publicHelp: true,
 		},
+		"startcase": {
+			handler:  cmdStartCase,
+			minArgs:  0,
+			usage:    "Usage: /startcase",
+			desc:     "Sets the area to casing and invites everyone waiting in its /queue.",
+			reqPerms: permissions.PermissionField["CM"],
+			category: "area",
+		},
 		"status": {
 			handler:  cmdStatus,
 			minArgs:  1,
-			usage:    "Usage: /status <idle|looking-for-players|lfp|casing|recess|rp|gaming>",
+			usage:    "Usage: /status <idle|looking-for-players|lfp|casing|recess|rp|gaming> [-t duration]\n-t: revert to idle automatically after the duration (e.g. 15m).",
 			desc:     "Sets the current area's status. \"lfp\" is a shorthand for looking-for-players.",
 			reqPerms: permissions.PermissionField["CM"],
 			category: "area",
@@ -1144,11 +1303,19 @@ func initCommands() {
 		"summon": {
 			handler:  cmdSummon,
 			minArgs:  1,
-			usage:    "Usage: /summon <area>",
+			usage:    "Usage: /summon <area>\n<area> is an area number or name.",
 			desc:     "Summons all users to the specified area.",
 			reqPerms: permissions.PermissionField["MOVE_USERS"],
 			category: "moderation",
 		},
+		"evidence": {
+			handler:  cmdEvidence,
+			minArgs:  2,
+			usage:    "Usage: /evidence who <id>",
+			desc:     "Shows the IPID (and any online UIDs) of whoever added a piece of evidence in this area.",
+			reqPerms: permissions.PermissionField["MOD_EVI"],
+			category: "moderation",
+		},
 		"swapevi": {
 			handler:  cmdSwapEvi,
 			minArgs:  2,
@@ -1160,7 +1327,7 @@ func initCommands() {
 		"testimony": {
 			handler:  cmdTestimony,
 			minArgs:  0,
-			usage:    "Usage: /testimony <record|stop|play|update|insert|delete>\nUse /testimony record to start recording. Witnesses must be in /pos wit for their IC messages to be recorded.",
+			usage:    "Usage: /testimony <record [name]|stop|play|update|insert|delete>\n       /testimony list | switch <name>\n       /testimony save <name> [-f] | load <name>\nUse /testimony record to start recording. Witnesses must be in /pos wit for their IC messages to be recorded.\nrecord <name>/switch: keep several testimonies in the area (e.g. one per witness); the unnamed one is 'default'.\nsave/load: keep a testimony on the server to prepare cross-examinations ahead of time; -f overwrites an existing save.",
 			desc:     "Manages the area's testimony recorder. Use /testimony record to start recording. Witnesses must be in /pos wit for their IC messages to be captured.",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "testimony",
@@ -1176,8 +1343,8 @@ func initCommands() {
 		"unban": {
 			handler:  cmdUnban,
 			minArgs:  1,
-			usage:    "Usage: /unban <id1>,<id2>...",
-			desc:     "Nullifies ban(s).",
+			usage:    "Usage: /unban <id1>,<id2>... | /unban -i <ipid>",
+			desc:     "Nullifies ban(s) by ID, or every active ban on an IPID with -i.",
 			reqPerms: permissions.PermissionField["BAN"],
 			category: "moderation",
 		},
@@ -1200,7 +1367,7 @@ func initCommands() {
 		"uninvite": {
 			handler:  cmdUninvite,
 			minArgs:  1,
-			usage:    "Usage: /uninvite <uid1>,<uid2>...",
+			usage:    "Usage: /uninvite [-k] <uid1>,<uid2>...\n-k: move the user(s) out even if the area is only spectatable.",
 			desc:     "Uninvites user(s) from the current area.",
 			reqPerms: permissions.PermissionField["CM"],
 			category: "area",
@@ -1265,7 +1432,7 @@ func initCommands() {
 			handler:  cmdVote,
 			minArgs:  1,
 			usage:    "Usage: /vote <option_number>",
-			desc:     "Vote on the active poll.",
+			desc:     "Vote on the active poll. Voting again changes your vote.",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "area",
 		},
@@ -1274,6 +1441,7 @@ func initCommands() {
 			handler:  cmdWhisper,
 			minArgs:  1,
 			usage:    "Usage: /whisper [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Makes messages only visible to mods and CMs.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1282,6 +1450,7 @@ func initCommands() {
 			handler:  cmdBackward,
 			minArgs:  1,
 			usage:    "Usage: /backward [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Reverses character order in messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1290,6 +1459,7 @@ func initCommands() {
 			handler:  cmdStutterstep,
 			minArgs:  1,
 			usage:    "Usage: /stutterstep [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Doubles every word in messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1298,6 +1468,7 @@ func initCommands() {
 			handler:  cmdElongate,
 			minArgs:  1,
 			usage:    "Usage: /elongate [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Repeats vowels in messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1306,6 +1477,7 @@ func initCommands() {
 			handler:  cmdUppercase,
 			minArgs:  1,
 			usage:    "Usage: /uppercase [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces messages to UPPERCASE.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1314,6 +1486,7 @@ func initCommands() {
 			handler:  cmdLowercase,
 			minArgs:  1,
 			usage:    "Usage: /lowercase [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces messages to lowercase.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1322,6 +1495,7 @@ func initCommands() {
 			handler:  cmdRobotic,
 			minArgs:  1,
 			usage:    "Usage: /robotic [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with [BEEP] [BOOP] robotic sounds.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1330,6 +1504,7 @@ func initCommands() {
 			handler:  cmdAlternating,
 			minArgs:  1,
 			usage:    "Usage: /alternating [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Makes messages AlTeRnAtInG cAsE.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1338,6 +1513,7 @@ func initCommands() {
 			handler:  cmdFancy,
 			minArgs:  1,
 			usage:    "Usage: /fancy [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts messages to Unicode fancy characters.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1346,6 +1522,7 @@ func initCommands() {
 			handler:  cmdUwu,
 			minArgs:  1,
 			usage:    "Usage: /uwu [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts messages to UwU speak.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1354,6 +1531,7 @@ func initCommands() {
 			handler:  cmdPirate,
 			minArgs:  1,
 			usage:    "Usage: /pirate [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts messages to pirate speech.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1362,6 +1540,7 @@ func initCommands() {
 			handler:  cmdShakespearean,
 			minArgs:  1,
 			usage:    "Usage: /shakespearean [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts messages to Shakespearean English.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1370,6 +1549,7 @@ func initCommands() {
 			handler:  cmdCaveman,
 			minArgs:  1,
 			usage:    "Usage: /caveman [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts messages to caveman grunts.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1379,6 +1559,7 @@ func initCommands() {
 			handler:  cmdEmoji,
 			minArgs:  1,
 			usage:    "Usage: /emoji [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces name with random emojis.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1387,6 +1568,7 @@ func initCommands() {
 			handler:  cmdInvisible,
 			minArgs:  1,
 			usage:    "Usage: /invisible [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Prevents user from seeing other players' messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1395,6 +1577,7 @@ func initCommands() {
 			handler:  cmdHideDisplay,
 			minArgs:  1,
 			usage:    "Usage: /hidedisplay [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Hides the target's own sprite from the IC viewport (their text still shows; funny on pairs).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1435,6 +1618,7 @@ func initCommands() {
 			handler:  cmdForceDisplay,
 			minArgs:  1,
 			usage:    "Usage: /forcedisplay [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Pins the target's character onto every IC message in their area; no other sprite can show.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1444,6 +1628,7 @@ func initCommands() {
 			handler:  cmdSlowpoke,
 			minArgs:  1,
 			usage:    "Usage: /slowpoke [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Delays messages before sending.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1452,6 +1637,7 @@ func initCommands() {
 			handler:  cmdFastspammer,
 			minArgs:  1,
 			usage:    "Usage: /fastspammer [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Rate limits messages heavily.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1485,6 +1671,7 @@ func initCommands() {
 			handler:  cmdSubtitles,
 			minArgs:  1,
 			usage:    "Usage: /subtitles [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Adds confusing subtitles to messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1493,7 +1680,8 @@ func initCommands() {
 			handler:  cmdRoulette,
 			minArgs:  0,
 			usage:    "Usage: /roulette join | /roulette [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
-			desc:     "Join Russian Roulette game, or apply roulette punishment to user(s) (requires MUTE permission).",
+			ownsH:    true,
+			desc:     "Join Russian Roulette game, or apply the roulette punishment, which silently drops some of a user's IC messages (requires MUTE permission).",
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "minigames",
 		},
@@ -1501,6 +1689,7 @@ func initCommands() {
 			handler:  cmdSpotlight,
 			minArgs:  1,
 			usage:    "Usage: /spotlight [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Announces all actions publicly.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1510,6 +1699,7 @@ func initCommands() {
 			handler:  cmdCensor,
 			minArgs:  1,
 			usage:    "Usage: /censor [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces words with [CENSORED].",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1518,6 +1708,7 @@ func initCommands() {
 			handler:  cmdConfused,
 			minArgs:  1,
 			usage:    "Usage: /confused [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Randomly reorders words in messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1526,6 +1717,7 @@ func initCommands() {
 			handler:  cmdParanoid,
 			minArgs:  1,
 			usage:    "Usage: /paranoid [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Adds paranoid text to messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1533,7 +1725,8 @@ func initCommands() {
 		"drunk": {
 			handler:  cmdDrunk,
 			minArgs:  1,
-			usage:    "Usage: /drunk [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			usage:    "Usage: /drunk [-d duration] [-r reason] [-i intensity] [-h] global | <uid1>,<uid2>...\n-i: 1 (tipsy) to 5 (wasted); default 2.",
+			ownsH:    true,
 			desc:     "Slurs and repeats words in messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1542,6 +1735,7 @@ func initCommands() {
 			handler:  cmdHiccup,
 			minArgs:  1,
 			usage:    "Usage: /hiccup [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Interrupts words with 'hic'.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1550,6 +1744,7 @@ func initCommands() {
 			handler:  cmdWhistle,
 			minArgs:  1,
 			usage:    "Usage: /whistle [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces letters with whistles.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1558,6 +1753,7 @@ func initCommands() {
 			handler:  cmdMumble,
 			minArgs:  1,
 			usage:    "Usage: /mumble [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Obscures message text.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1567,6 +1763,7 @@ func initCommands() {
 			handler:  cmdSpaghetti,
 			minArgs:  1,
 			usage:    "Usage: /spaghetti [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Combines multiple random effects.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1575,6 +1772,7 @@ func initCommands() {
 			handler:  cmdTorment,
 			minArgs:  1,
 			usage:    "Usage: /torment [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Cycles through different effects.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1583,6 +1781,7 @@ func initCommands() {
 			handler:  cmdRng,
 			minArgs:  1,
 			usage:    "Usage: /rng [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Applies random effect from pool each message.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1591,6 +1790,7 @@ func initCommands() {
 			handler:  cmdEssay,
 			minArgs:  1,
 			usage:    "Usage: /essay [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Requires minimum 50 characters.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1600,6 +1800,7 @@ func initCommands() {
 			handler:  cmdHaiku,
 			minArgs:  1,
 			usage:    "Usage: /haiku [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Requires 5-7-5 syllable format.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1608,6 +1809,7 @@ func initCommands() {
 			handler:  cmdAutospell,
 			minArgs:  1,
 			usage:    "Usage: /autospell [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Autocorrects to wrong words.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1617,6 +1819,7 @@ func initCommands() {
 			handler:  cmdMonkey,
 			minArgs:  1,
 			usage:    "Usage: /monkey [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with monkey noises (ook, eek, ooh ooh).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1625,6 +1828,7 @@ func initCommands() {
 			handler:  cmdSnake,
 			minArgs:  1,
 			usage:    "Usage: /snake [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Makes messages hissss like a ssssnake.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1633,6 +1837,7 @@ func initCommands() {
 			handler:  cmdDog,
 			minArgs:  1,
 			usage:    "Usage: /dog [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with dog sounds (woof, arf, grr, bork).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1641,6 +1846,7 @@ func initCommands() {
 			handler:  cmdCat,
 			minArgs:  1,
 			usage:    "Usage: /cat [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with cat sounds (meow, purrr~, mrrrow).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1649,6 +1855,7 @@ func initCommands() {
 			handler:  cmdBird,
 			minArgs:  1,
 			usage:    "Usage: /bird [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with bird sounds (tweet, chirp, squawk).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1657,6 +1864,7 @@ func initCommands() {
 			handler:  cmdCow,
 			minArgs:  1,
 			usage:    "Usage: /cow [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with cow sounds (moo, mooo, MOOO).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1665,6 +1873,7 @@ func initCommands() {
 			handler:  cmdFrog,
 			minArgs:  1,
 			usage:    "Usage: /frog [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with frog sounds (ribbit, croak).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1673,6 +1882,7 @@ func initCommands() {
 			handler:  cmdDuck,
 			minArgs:  1,
 			usage:    "Usage: /duck [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with duck sounds (quack, QUACK).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1681,6 +1891,7 @@ func initCommands() {
 			handler:  cmdHorse,
 			minArgs:  1,
 			usage:    "Usage: /horse [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with horse sounds (neigh, whinny, snort).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1689,6 +1900,7 @@ func initCommands() {
 			handler:  cmdLion,
 			minArgs:  1,
 			usage:    "Usage: /lion [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with lion sounds (ROAR, grrr, rawr).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1697,6 +1909,7 @@ func initCommands() {
 			handler:  cmdZoo,
 			minArgs:  1,
 			usage:    "Usage: /zoo [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Applies a random animal sound punishment to each message.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1705,6 +1918,7 @@ func initCommands() {
 			handler:  cmdBunny,
 			minArgs:  1,
 			usage:    "Usage: /bunny [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with bunny sounds (*thump*, *binky!*, *flops*).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1713,6 +1927,7 @@ func initCommands() {
 			handler:  cmdTsundere,
 			minArgs:  1,
 			usage:    "Usage: /tsundere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "It's not like I wanted to punish you, b-baka!! Wraps messages in tsundere denial.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1721,6 +1936,7 @@ func initCommands() {
 			handler:  cmdYandere,
 			minArgs:  1,
 			usage:    "Usage: /yandere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Hehehe~ wraps messages in obsessive yandere flavour.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1729,6 +1945,7 @@ func initCommands() {
 			handler:  cmdKuudere,
 			minArgs:  1,
 			usage:    "Usage: /kuudere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Delivers messages in cold, emotionless monotone.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1737,6 +1954,7 @@ func initCommands() {
 			handler:  cmdDandere,
 			minArgs:  1,
 			usage:    "Usage: /dandere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Makes messages extremely shy and hesitant with stutters.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1745,6 +1963,7 @@ func initCommands() {
 			handler:  cmdDeredere,
 			minArgs:  1,
 			usage:    "Usage: /deredere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps messages in over-the-top lovey-dovey sweetness.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1753,6 +1972,7 @@ func initCommands() {
 			handler:  cmdHimedere,
 			minArgs:  1,
 			usage:    "Usage: /himedere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Makes messages imperious and royalty-like, commoner.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1761,6 +1981,7 @@ func initCommands() {
 			handler:  cmdKamidere,
 			minArgs:  1,
 			usage:    "Usage: /kamidere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Delivers messages as a self-proclaimed god to unworthy mortals.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1769,6 +1990,7 @@ func initCommands() {
 			handler:  cmdUndere,
 			minArgs:  1,
 			usage:    "Usage: /undere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces messages to agree with everything unconditionally.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1777,6 +1999,7 @@ func initCommands() {
 			handler:  cmdBakadere,
 			minArgs:  1,
 			usage:    "Usage: /bakadere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Inserts clumsy, airheaded interjections into every message.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1785,6 +2008,7 @@ func initCommands() {
 			handler:  cmdMayadere,
 			minArgs:  1,
 			usage:    "Usage: /mayadere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps messages in eerie, enigmatic mystery. Kukuku~",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1793,6 +2017,7 @@ func initCommands() {
 			handler:  cmdEmoticon,
 			minArgs:  1,
 			usage:    "Usage: /emoticon [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces user to speak only in emoticons (:P, :D, :3, etc.).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1802,6 +2027,7 @@ func initCommands() {
 			handler:  cmdLovebomb,
 			minArgs:  0,
 			usage:    "Usage: /lovebomb [global [off]] | /lovebomb [-d duration] [-r reason] [-h] [uid1 [uid2]]\n  global           – love-bomb all non-moderators in the area.\n  global off       – remove lovebomb from everyone in the area.\n  -d <duration>    – duration (e.g. 10m, 1h). Default: 10m. Max: 24h.\n  -r <reason>      – optional reason for the log.\n  1 uid            – apply to that uid (random area target per message).\n  2 uids           – uid1 will love-bomb uid2 specifically.",
+			ownsH:    true,
 			desc:     "Forces IC messages to be replaced with silly love declarations. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1818,6 +2044,7 @@ func initCommands() {
 			handler:  cmdDegrade,
 			minArgs:  1,
 			usage:    "Usage: /degrade [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces IC messages to be replaced with degrading self-insults. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1834,6 +2061,7 @@ func initCommands() {
 			handler:  cmdTourettes,
 			minArgs:  1,
 			usage:    "Usage: /tourettes [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Causes random outbursts to be inserted into IC messages (swearing, random objects, nonsense, animal noises). Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1842,6 +2070,7 @@ func initCommands() {
 			handler:  cmdSlang,
 			minArgs:  1,
 			usage:    "Usage: /slang [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts messages to internet slang abbreviations (e.g. 'i don't know' -> 'idk', 'got to go' -> 'gtg').",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1858,6 +2087,7 @@ func initCommands() {
 			handler:  cmdThesaurusOverload,
 			minArgs:  1,
 			usage:    "Usage: /thesaurusoverload [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces IC messages to use comically pompous synonyms and smug parentheticals. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1874,6 +2104,7 @@ func initCommands() {
 			handler:  cmdValleyGirl,
 			minArgs:  1,
 			usage:    "Usage: /valleygirl [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Injects valley-girl filler words, vowel stretching, and dramatic tone into IC messages. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1890,6 +2121,7 @@ func initCommands() {
 			handler:  cmdBabytalk,
 			minArgs:  1,
 			usage:    "Usage: /babytalk [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts IC messages to toddler-style baby talk with phonetic substitutions and stage directions. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1906,6 +2138,7 @@ func initCommands() {
 			handler:  cmdThirdPerson,
 			minArgs:  1,
 			usage:    "Usage: /thirdperson [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces IC messages into third-person narration using the player's display name, with mood tags. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1922,6 +2155,7 @@ func initCommands() {
 			handler:  cmdUnreliableNarrator,
 			minArgs:  1,
 			usage:    "Usage: /unreliablenarrator [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Makes IC messages sound suspiciously unreliable with hedges, contradictions, and self-doubting commentary. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1938,6 +2172,7 @@ func initCommands() {
 			handler:  cmdUncannyValley,
 			minArgs:  1,
 			usage:    "Usage: /uncannyvalley [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Adds glitchy system notes to IC messages and subtly mutates the player's display name each message. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1954,6 +2189,7 @@ func initCommands() {
 			handler:  cmd51,
 			minArgs:  1,
 			usage:    "Usage: /51 [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces each IC message with a random line from the 51-messages story. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1970,6 +2206,7 @@ func initCommands() {
 			handler:  cmdPhilosopher,
 			minArgs:  1,
 			usage:    "Usage: /philosopher [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Appends a random deep philosophical question to every IC message. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -1986,6 +2223,7 @@ func initCommands() {
 			handler:  cmdPoet,
 			minArgs:  1,
 			usage:    "Usage: /poet [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps every IC message in lyrical poetic flourishes. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2002,6 +2240,7 @@ func initCommands() {
 			handler:  cmdUpsidedown,
 			minArgs:  1,
 			usage:    "Usage: /upsidedown [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Flips every IC message upside-down using Unicode characters. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2018,6 +2257,7 @@ func initCommands() {
 			handler:  cmdSarcasm,
 			minArgs:  1,
 			usage:    "Usage: /sarcasm [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Adds sarcastic parenthetical commentary to every IC message. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2034,6 +2274,7 @@ func initCommands() {
 			handler:  cmdAcademic,
 			minArgs:  1,
 			usage:    "Usage: /academic [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps every IC message in overly formal academic language. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2050,6 +2291,7 @@ func initCommands() {
 			handler:  cmdRecipe,
 			minArgs:  1,
 			usage:    "Usage: /recipe [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Reformats every IC message as a cooking recipe step. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2066,6 +2308,7 @@ func initCommands() {
 			handler:  cmdQuote,
 			minArgs:  1,
 			usage:    "Usage: /quote [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps IC messages in quotation marks with a 50% chance. Moderator only.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2180,6 +2423,7 @@ func initCommands() {
 			handler:  cmdStack,
 			minArgs:  2,
 			usage:    "Usage: /stack <punishment1> <punishment2> [<punishment3>...] [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Applies multiple punishment effects to user(s) simultaneously.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2188,6 +2432,7 @@ func initCommands() {
 			handler:  cmdRandomPunishAll,
 			minArgs:  0,
 			usage:    "Usage: /randompunishall [-d duration] [-r reason] [-h]",
+			ownsH:    true,
 			desc:     "Applies a random punishment to every player currently in the area.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2224,6 +2469,22 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "minigames",
 		},
+		"ttt": {
+			handler:  cmdTicTacToe,
+			minArgs:  1,
+			usage:    "Usage: /ttt <uid> | /ttt accept | /ttt <1-9> | /ttt resign",
+			desc:     "Challenge a player in your area to tic-tac-toe, accept a challenge, place your mark or resign.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "minigames",
+		},
+		"trivia": {
+			handler:  cmdTrivia,
+			minArgs:  1,
+			usage:    "Usage: /trivia start [questions] | /trivia stop",
+			desc:     "Run a trivia game in your area from trivia.txt. The first correct OOC answer to each question scores a point.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "minigames",
+		},
 		"giveaway": {
 			handler:  cmdGiveaway,
 			minArgs:  1,
@@ -2260,8 +2521,9 @@ func initCommands() {
 			handler: cmdHangman,
 			minArgs: 1,
 			usage: "Usage: /hangman start [animals|courtroom|nature|food|random|custom <word>]\n" +
+				"       /hangman start <word>  (CM only)\n" +
 				"       /hangman join\n" +
-				"       /hangman guess <letter|word>\n" +
+				"       /hangman guess <letter|word>  (or just /hangman <letter>)\n" +
 				"       /hangman status\n" +
 				"       /hangman stop",
 			desc:     "Play Hangman! Host starts a game with a themed or custom secret word. Players guess letters (and optionally the full word). Wrong-guessers are punished on failure.",
@@ -2350,6 +2612,24 @@ func initCommands() {
 			casinoCmd: true,
 			category:  "casino",
 		},
+		"bet": {
+			handler:   cmdBet,
+			minArgs:   2,
+			usage:     "Usage: /bet <amount> <heads|tails>",
+			desc:      "Bet chips on a coinflip against the house; a correct call pays double.",
+			reqPerms:  permissions.PermissionField["NONE"],
+			casinoCmd: true,
+			category:  "casino",
+		},
+		"daily": {
+			handler:   cmdDaily,
+			minArgs:   0,
+			usage:     "Usage: /daily",
+			desc:      "Claim your daily chip stipend (once every 24 hours).",
+			reqPerms:  permissions.PermissionField["NONE"],
+			casinoCmd: true,
+			category:  "chips",
+		},
 		"wheel": {
 			handler:   cmdWheel,
 			minArgs:   2,
@@ -2689,6 +2969,7 @@ func initCommands() {
 			handler:  cmdTimewarp,
 			minArgs:  1,
 			usage:    "Usage: /timewarp [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Shuffles the word order of the target's IC messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2697,6 +2978,7 @@ func initCommands() {
 			handler:  cmdMorse,
 			minArgs:  1,
 			usage:    "Usage: /morse [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Converts the target's IC messages to Morse code dots and dashes.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2705,6 +2987,7 @@ func initCommands() {
 			handler:  cmdRickroll,
 			minArgs:  1,
 			usage:    "Usage: /rickroll [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces the target's IC messages with meme-styled lyric-adjacent stand-in lines.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2713,6 +2996,7 @@ func initCommands() {
 			handler:  cmdPickup,
 			minArgs:  1,
 			usage:    "Usage: /pickup [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces every IC message the target sends with a catastrophically cheesy pickup line.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2721,6 +3005,7 @@ func initCommands() {
 			handler:  cmdBrainrot,
 			minArgs:  1,
 			usage:    "Usage: /brainrot [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Corrupts the target's IC messages with maximum skibidi sigma Italian brainrot energy.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2729,6 +3014,7 @@ func initCommands() {
 			handler:  cmdVowelhell,
 			minArgs:  1,
 			usage:    "Usage: /vowelhell [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces every consonant in the target's messages with a random vowel.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2737,6 +3023,7 @@ func initCommands() {
 			handler:  cmdChef,
 			minArgs:  1,
 			usage:    "Usage: /chef [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Swedish-Chef filter — bork bork bork!",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2745,6 +3032,7 @@ func initCommands() {
 			handler:  cmdKaren,
 			minArgs:  1,
 			usage:    "Usage: /karen [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps each message in escalating entitled complaints and manager demands.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2753,6 +3041,7 @@ func initCommands() {
 			handler:  cmdPassiveAggressive,
 			minArgs:  1,
 			usage:    "Usage: /passiveaggressive [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Adds chilly, performatively-polite framings and sign-offs. It's fine. Really.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2761,6 +3050,7 @@ func initCommands() {
 			handler:  cmdNervous,
 			minArgs:  1,
 			usage:    "Usage: /nervous [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Sprinkles stuttering, um/uh fillers, and jittery trailing apologies.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2769,6 +3059,7 @@ func initCommands() {
 			handler:  cmdDreamSequence,
 			minArgs:  1,
 			usage:    "Usage: /dreamsequence [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Rewrites IC messages as surreal, dreamlike fragments.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2777,6 +3068,7 @@ func initCommands() {
 			handler:  cmdGordonRamsay,
 			minArgs:  1,
 			usage:    "Usage: /gordonramsay [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces every IC line the target sends with a Gordon Ramsay kitchen tirade.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2785,6 +3077,7 @@ func initCommands() {
 			handler:  cmdCherri,
 			minArgs:  1,
 			usage:    "Usage: /cherri [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces The Target To Capitalize Every Word Like Cherri Does.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2793,6 +3086,7 @@ func initCommands() {
 			handler:  cmdAlbhed,
 			minArgs:  1,
 			usage:    "Usage: /albhed [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Transliterates the target's IC messages through the Al Bhed cipher (FFX).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2801,6 +3095,7 @@ func initCommands() {
 			handler:  cmdClown,
 			minArgs:  1,
 			usage:    "Usage: /clown [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps every IC message in clown honks and circus filler. 🤡",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2809,6 +3104,7 @@ func initCommands() {
 			handler:  cmdJester,
 			minArgs:  1,
 			usage:    "Usage: /jester [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Wraps messages in theatrical jester flourishes and bell-jingle SFX text.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2817,6 +3113,7 @@ func initCommands() {
 			handler:  cmdJoker,
 			minArgs:  1,
 			usage:    "Usage: /joker [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Peppers chaotic laughter throughout every IC message. HAHAHA!",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2825,6 +3122,7 @@ func initCommands() {
 			handler:  cmdMime,
 			minArgs:  1,
 			usage:    "Usage: /mime [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces every IC line with a silent mime action — *gestures wordlessly*.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2833,6 +3131,7 @@ func initCommands() {
 			handler:  cmdBiblebot,
 			minArgs:  1,
 			usage:    "Usage: /biblebot [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces every IC line with a random Bible verse.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2841,6 +3140,7 @@ func initCommands() {
 			handler:  cmdGrounded,
 			minArgs:  1,
 			usage:    "Usage: /grounded [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces every IC line with a GoAnimate-style 'YOU ARE GROUNDED' tirade.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2849,6 +3149,7 @@ func initCommands() {
 			handler:  cmdSmugdere,
 			minArgs:  1,
 			usage:    "Usage: /smugdere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Smug, condescending dere — looks down on everyone, obviously.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2857,6 +3158,7 @@ func initCommands() {
 			handler:  cmdDeretsun,
 			minArgs:  1,
 			usage:    "Usage: /deretsun [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Tsun-leaning dere flavour. The most popular pick.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2865,6 +3167,7 @@ func initCommands() {
 			handler:  cmdBokodere,
 			minArgs:  1,
 			usage:    "Usage: /bokodere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Violently affectionate dere — threats interleaved with care.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2873,6 +3176,7 @@ func initCommands() {
 			handler:  cmdThugdere,
 			minArgs:  1,
 			usage:    "Usage: /thugdere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Streetwise dere — every message in fam/yo/no-cap idiom.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2881,6 +3185,7 @@ func initCommands() {
 			handler:  cmdTeasedere,
 			minArgs:  1,
 			usage:    "Usage: /teasedere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Flirty teasing dere — playful pokes and winks.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2889,6 +3194,7 @@ func initCommands() {
 			handler:  cmdDorodere,
 			minArgs:  1,
 			usage:    "Usage: /dorodere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Dirt-stained, twisted dere — smiles through grime.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2897,6 +3203,7 @@ func initCommands() {
 			handler:  cmdHinedere,
 			minArgs:  1,
 			usage:    "Usage: /hinedere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Sarcastic, aloof dere — perpetually exasperated.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2905,6 +3212,7 @@ func initCommands() {
 			handler:  cmdHajidere,
 			minArgs:  1,
 			usage:    "Usage: /hajidere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Mortified, blushing dere — perpetually embarrassed.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2913,6 +3221,7 @@ func initCommands() {
 			handler:  cmdRindere,
 			minArgs:  1,
 			usage:    "Usage: /rindere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Cold, distant dere — barely speaks to you.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2921,6 +3230,7 @@ func initCommands() {
 			handler:  cmdUtsudere,
 			minArgs:  1,
 			usage:    "Usage: /utsudere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Depressive dere — everything is pointless.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2929,6 +3239,7 @@ func initCommands() {
 			handler:  cmdDarudere,
 			minArgs:  1,
 			usage:    "Usage: /darudere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Lazy, perpetually-tired dere — yawns through every line.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2937,6 +3248,7 @@ func initCommands() {
 			handler:  cmdButsudere,
 			minArgs:  1,
 			usage:    "Usage: /butsudere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Apathetic, deadpan dere — couldn't care less.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2945,6 +3257,7 @@ func initCommands() {
 			handler:  cmdSDere,
 			minArgs:  1,
 			usage:    "Usage: /sdere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Dominant dere archetype — gives orders.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2953,6 +3266,7 @@ func initCommands() {
 			handler:  cmdMDere,
 			minArgs:  1,
 			usage:    "Usage: /mdere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Submissive dere archetype — apologetic and meek.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2961,6 +3275,7 @@ func initCommands() {
 			handler:  cmdTsuyodere,
 			minArgs:  1,
 			usage:    "Usage: /tsuyodere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Strength-obsessed dere — flexes in every sentence.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2969,6 +3284,7 @@ func initCommands() {
 			handler:  cmdOmnidere,
 			minArgs:  1,
 			usage:    "Usage: /omnidere [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "All deres at once — every IC message picks a random dere flavour for maximum tonal whiplash.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -2985,6 +3301,7 @@ func initCommands() {
 			handler:  cmdSfxCurse,
 			minArgs:  2,
 			usage:    "Usage: /sfxcurse [-d duration] [-r reason] [-h] global | <uid1>,<uid2>... <sfx-url>",
+			ownsH:    true,
 			desc:     "Forces the target to emit the specified SFX on every IC message. Accepts any streamable http(s) URL (Discord CDN, custom hosting, etc.) or a /base/sounds/ path.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3001,6 +3318,7 @@ func initCommands() {
 			handler:  cmdShrink,
 			minArgs:  1,
 			usage:    "Usage: /shrink [-d duration] [-r reason] [-h] global | <uid1>,<uid2>... [offset]",
+			ownsH:    true,
 			desc:     "Locks the target's vertical sprite offset into a negative value (default -25). Persists per IC message until /unshrink.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3009,6 +3327,7 @@ func initCommands() {
 			handler:  cmdGrow,
 			minArgs:  1,
 			usage:    "Usage: /grow [-d duration] [-r reason] [-h] global | <uid1>,<uid2>... [offset]",
+			ownsH:    true,
 			desc:     "Locks the target's vertical sprite offset into a positive value (default +25). Persists per IC message until /ungrow.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3017,6 +3336,7 @@ func initCommands() {
 			handler:  cmdWide,
 			minArgs:  1,
 			usage:    "Usage: /wide [-d duration] [-r reason] [-h] global | <uid1>,<uid2>... [offset]",
+			ownsH:    true,
 			desc:     "Locks the target's horizontal sprite offset (default +50). Persists per IC message until /unwide.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3049,6 +3369,7 @@ func initCommands() {
 			handler:  cmdFromSoftware,
 			minArgs:  1,
 			usage:    "Usage: /fromsoftware [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Censors words from fromsoft.txt in the target's IC messages, replacing each occurrence (including inside larger words) with asterisks (one per letter).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3081,8 +3402,9 @@ func initCommands() {
 		"zalgo": {
 			handler:  cmdZalgo,
 			minArgs:  1,
-			usage:    "Usage: /zalgo [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
-			desc:     "C̴o̷r̶r̸u̵p̷t̶s̸ the target's text with creeping zalgo combining marks.",
+			usage:    "Usage: /zalgo [-d duration] [-r reason] [-l 1-3] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
+			desc:     "C̴o̷r̶r̸u̵p̷t̶s̸ the target's text with creeping zalgo combining marks; -l sets the intensity.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
 		},
@@ -3090,6 +3412,7 @@ func initCommands() {
 			handler:  cmdLeetspeak,
 			minArgs:  1,
 			usage:    "Usage: /leetspeak [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "F0rc35 7h3 74r937 70 5p34k 1n h4x0r 1337speak.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3098,6 +3421,7 @@ func initCommands() {
 			handler:  cmdSmallcaps,
 			minArgs:  1,
 			usage:    "Usage: /smallcaps [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "ʀᴇɴᴅᴇʀs ᴇᴠᴇʀʏᴛʜɪɴɢ ᴛʜᴇ ᴛᴀʀɢᴇᴛ sᴀʏs ɪɴ ᴛɪɴʏ ᴄᴀᴘs.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3106,6 +3430,7 @@ func initCommands() {
 			handler:  cmdPiglatin,
 			minArgs:  1,
 			usage:    "Usage: /piglatin [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Orcesfay ethay argettay otay eakspay inyay Igpay Atinlay.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3114,6 +3439,7 @@ func initCommands() {
 			handler:  cmdVaporwave,
 			minArgs:  1,
 			usage:    "Usage: /vaporwave [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Ｒｅｎｄｅｒｓ ｔｅｘｔ ｉｎ ｆｕｌｌｗｉｄｔｈ ａｅｓｔｈｅｔｉｃ (distinct from /fancy).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3122,6 +3448,7 @@ func initCommands() {
 			handler:  cmdLisp,
 			minArgs:  1,
 			usage:    "Usage: /lisp [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replatheth every 's' and 'z' with 'th'. Tho thorry.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3130,6 +3457,7 @@ func initCommands() {
 			handler:  cmdSpoonerism,
 			minArgs:  1,
 			usage:    "Usage: /spoonerism [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Swaps the starting sounds of adjacent words — shake a tower, anyone?",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3138,6 +3466,7 @@ func initCommands() {
 			handler:  cmdKeysmash,
 			minArgs:  1,
 			usage:    "Usage: /keysmash [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Injects random asdfjkl; keyboard bursts into the target's messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3146,6 +3475,7 @@ func initCommands() {
 			handler:  cmdWeeb,
 			minArgs:  1,
 			usage:    "Usage: /weeb [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Sprinkles 350+ anime romaji over everything — word swaps, honorifics, 'Nani?!' and desu~. Sugoi!",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3154,6 +3484,7 @@ func initCommands() {
 			handler:  cmdPolitician,
 			minArgs:  1,
 			usage:    "Usage: /politician [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Great question. The target never answers anything directly again.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3162,6 +3493,7 @@ func initCommands() {
 			handler:  cmdClickbait,
 			minArgs:  1,
 			usage:    "Usage: /clickbait [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Every message becomes a clickbait headline about the speaker (Number 3 Will SHOCK You).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3170,6 +3502,7 @@ func initCommands() {
 			handler:  cmdMarkov,
 			minArgs:  1,
 			usage:    "Usage: /markov [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces messages with markov-chain babble brewed from the area's own recent chat history.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3178,6 +3511,7 @@ func initCommands() {
 			handler:  cmdAlliteration,
 			minArgs:  1,
 			usage:    "Usage: /alliteration [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Boldly bends both big and bitty words into bizarrely bombastic alliteration.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3186,6 +3520,7 @@ func initCommands() {
 			handler:  cmdCipher,
 			minArgs:  1,
 			usage:    "Usage: /cipher [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Escalating encryption layers per message: ROT13 → BINARY → BASE64, then the decryption fails and it re-arms.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3194,6 +3529,7 @@ func initCommands() {
 			handler:  cmdMedieval,
 			minArgs:  1,
 			usage:    "Usage: /medieval [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Hark! Rewrites the target's text into Olde-English speak — thee/thou swaps, herald's cries and courtly flourishes (100+ combinations).",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3202,6 +3538,7 @@ func initCommands() {
 			handler:  cmdCheese,
 			minArgs:  1,
 			usage:    "Usage: /cheese [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Replaces every message with one of 100+ statements about cheese. Cheese is, technically, a sauce.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3211,6 +3548,7 @@ func initCommands() {
 			handler:  cmdTeleport,
 			minArgs:  1,
 			usage:    "Usage: /teleport [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "The target's sprite pops to a random screen position on every message.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3219,6 +3557,7 @@ func initCommands() {
 			handler:  cmdShakecurse,
 			minArgs:  1,
 			usage:    "Usage: /shakecurse [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces the screenshake flag on every one of the target's messages.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3227,6 +3566,7 @@ func initCommands() {
 			handler:  cmdRandomflip,
 			minArgs:  1,
 			usage:    "Usage: /randomflip [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Coin-flips the target's sprite facing on every message — they can't control which way they look.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3235,6 +3575,7 @@ func initCommands() {
 			handler:  cmdForceColor,
 			minArgs:  2,
 			usage:    "Usage: /forcecolor [-d duration] [-r reason] [-h] <global | uid1,uid2...> <0-9 | white|green|red|orange|blue|yellow|rainbow>",
+			ownsH:    true,
 			desc:     "Locks the target's IC text colour — all-red rage, ghostly white, or full rainbow.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3243,6 +3584,7 @@ func initCommands() {
 			handler:  cmdNoPreanim,
 			minArgs:  1,
 			usage:    "Usage: /nopreanim [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Strips preanimations from the target's messages — no more dramatic desk slams.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3251,6 +3593,7 @@ func initCommands() {
 			handler:  cmdForcePreanim,
 			minArgs:  1,
 			usage:    "Usage: /forcepreanim [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Forces the target's preanimation to play on every message that names one.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3260,6 +3603,7 @@ func initCommands() {
 			handler:  cmdLifo,
 			minArgs:  1,
 			usage:    "Usage: /lifo [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Buffers the target's IC messages and releases them in REVERSE order — say three things, they arrive backwards.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3268,6 +3612,7 @@ func initCommands() {
 			handler:  cmdContagious,
 			minArgs:  2,
 			usage:    "Usage: /contagious <type> [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Plague mode: the punishment spreads to anyone who speaks within 5s of an infected player's message. Mods are immune.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3276,6 +3621,7 @@ func initCommands() {
 			handler:  cmdMinefield,
 			minArgs:  1,
 			usage:    "Usage: /minefield [-d duration] [-r reason] [-h] global | <uid1>,<uid2>...",
+			ownsH:    true,
 			desc:     "Every message the target sends has a 1-in-6 chance to detonate a random 2-minute punishment on them.",
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "punishment",
@@ -3297,6 +3643,22 @@ func initCommands() {
 			category: "punishment",
 		},
 		// ── Wave-2 QoL ────────────────────────────────────────────────────
+		"find": {
+			handler:  cmdFind,
+			minArgs:  1,
+			usage:    "Usage: /find <uid|name>",
+			desc:     "Shows which area a player is in, by UID, OOC name, character or showname.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
+		"mystatus": {
+			handler:  cmdMyStatus,
+			minArgs:  0,
+			usage:    "Usage: /mystatus",
+			desc:     "Shows your own mute, jail and punishment state with the time left on each.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
 		"punishments": {
 			handler:  cmdPunishments,
 			minArgs:  0,
@@ -3313,6 +3675,22 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["MUTE"],
 			category: "moderation",
 		},
+		"away": {
+			handler:  cmdAway,
+			minArgs:  0,
+			usage:    "Usage: /away [message]",
+			desc:     "Marks you as away in /players; anyone who PMs you gets your message. Cleared by /back or your next IC message.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
+		"back": {
+			handler:  cmdBack,
+			minArgs:  0,
+			usage:    "Usage: /back",
+			desc:     "Clears your /away status.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
 		"lfp": {
 			handler:  cmdLfp,
 			minArgs:  0,
@@ -3337,6 +3715,22 @@ func initCommands() {
 			reqPerms: permissions.PermissionField["NONE"],
 			category: "general",
 		},
+		"modcall": {
+			handler:  cmdModcall,
+			minArgs:  0,
+			usage:    "Usage: /modcall [reason]",
+			desc:     "Calls a moderator to your area, the same as the client's Call Mod button.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
+		"calladmin": {
+			handler:  cmdModcall,
+			minArgs:  0,
+			usage:    "Usage: /calladmin [reason]   (alias of /modcall)",
+			desc:     "Alias of /modcall — calls a moderator to your area.",
+			reqPerms: permissions.PermissionField["NONE"],
+			category: "general",
+		},
 		"dc": {
 			handler:  cmdDC,
 			minArgs:  0,
@@ -3510,6 +3904,10 @@ func ParseCommand(client *Client, command string, args []string) {
 		client.SendServerMessage("Invalid command.")
 		return
 	}
+	if punishmentDisabled(command) {
+		client.SendServerMessage("That punishment is disabled on this server.")
+		return
+	}
 	// Block casino/account commands when the feature is disabled server-wide.
 	if cmd.casinoCmd && !casinoEnabled {
 		client.SendServerMessage("The casino and player account system is not enabled on this server.")
@@ -3524,11 +3922,11 @@ func ParseCommand(client *Client, command string, args []string) {
 		return
 	}
 	if clientCanUseCommand(client, cmd) {
-		// Show usage when the user passes -h, UNLESS the command's own
-		// usage string documents [-h] as a supported flag (punishment
-		// commands use -h for "hidden" — suppress the per-target
-		// notification).  In that case, let the handler receive -h.
-		if sliceutil.ContainsString(args, "-h") && !strings.Contains(cmd.usage, "[-h]") {
+		// Show usage when the user passes -h, UNLESS the command owns -h
+		// as one of its flags (punishment commands use [-h] for "hidden"
+		// — suppress the per-target notification; /getban takes
+		// "-h hdid").  In that case, let the handler receive -h.
+		if sliceutil.ContainsString(args, "-h") && !cmd.ownsH {
 			client.SendServerMessage(cmd.usage)
 			return
 		} else if len(args) < cmd.minArgs {
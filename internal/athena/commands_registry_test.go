@@ -57,6 +57,20 @@ func TestSpectatePublicHelp(t *testing.T) {
 	}
 }
 
+// TestOwnsHFlag guards that /getban and the [-h] punishment commands receive
+// -h instead of having it swallowed as a usage request.
+func TestOwnsHFlag(t *testing.T) {
+	initCommands()
+	for _, name := range []string{"getban", "whisper", "forcedisplay", "voicemute"} {
+		if !Commands[name].ownsH {
+			t.Errorf("%s must set ownsH so its -h flag reaches the handler", name)
+		}
+	}
+	if Commands["ga"].ownsH {
+		t.Error("ga does not take -h and must not set ownsH")
+	}
+}
+
 // TestRegisterCommandInstalls verifies the additive registration path.
 func TestRegisterCommandInstalls(t *testing.T) {
 	initCommands()
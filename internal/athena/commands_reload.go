@@ -29,6 +29,7 @@ import "fmt"
 //   - backgrounds.txt
 //   - parrot.txt
 //   - 8ball.txt          (optional; missing file leaves current value intact)
+//   - locales/*.toml     (message catalogs for /lang)
 //   - banned_words.txt   (only when automod is enabled)
 //   - config.toml        (motd and description only)
 //
@@ -82,7 +82,7 @@ func reverseNameTargets(client *Client, arg string) []*Client {
 		})
 		return l
 	}
-	return getUidList(strings.Split(arg, ","))
+	return getUidList(client, strings.Split(arg, ","))
 }
 
 // cmdReverseName flips the showname of one or more players, or every player in
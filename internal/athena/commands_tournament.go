@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -62,32 +63,34 @@ func cmdTournament(client *Client, args []string, usage string) {
 			return
 		}
 
-		// Determine winner
-		var winner *TournamentParticipant
-		var winnerClient *Client
-		for uid, participant := range tournamentParticipants {
-			if winner == nil || participant.messageCount > winner.messageCount {
-				winner = participant
-				winnerClient = clients.GetClientByUID(uid)
-			}
-		}
-
+		ranked, afk := tournamentResults(tournamentParticipants, config.TournamentDropAFK)
 		tournamentActive = false
 
-		if winner != nil && winnerClient != nil {
-			duration := time.Since(tournamentStartTime).Round(time.Second)
-			announcement := fmt.Sprintf("🏆 TOURNAMENT ENDED! Winner: UID %d with %d messages over %v! Congratulations!",
-				winner.uid, winner.messageCount, duration)
-			writeToAllClients("CT", "OOC", announcement)
-
-			// Remove all punishments from winner (memory and DB).
-			winnerClient.RemoveAllPunishments()
-			if err := db.DeleteAllPunishments(winnerClient.Ipid()); err != nil {
-				logger.LogErrorf("Failed to remove persistent punishments for tournament winner %v: %v", winnerClient.Ipid(), err)
+		for _, uid := range afk {
+			if c := clients.GetClientByUID(uid); c != nil {
+				c.SendServerMessage("You were dropped from the tournament results for not sending any IC messages.")
 			}
-			winnerClient.SendServerMessage("Congratulations! Your tournament punishments have been removed.")
-		} else {
+		}
+
+		duration := time.Since(tournamentStartTime).Round(time.Second)
+		switch {
+		case len(ranked) == 0:
 			writeToAllClients("CT", "OOC", "🏆 TOURNAMENT ENDED! No participants.")
+		case config.TournamentMinPlayers > 0 && len(ranked) < config.TournamentMinPlayers:
+			writeToAllClients("CT", "OOC", fmt.Sprintf("🏆 TOURNAMENT ENDED! Only %d participant(s) took part (%d needed), so no winner was named.",
+				len(ranked), config.TournamentMinPlayers))
+		default:
+			winner := ranked[0]
+			writeToAllClients("CT", "OOC", fmt.Sprintf("🏆 TOURNAMENT ENDED! Winner: UID %d with %d messages over %v! Congratulations!",
+				winner.uid, winner.messageCount, duration))
+			clearTournamentPunishments(winner.uid, "Congratulations! Your tournament punishments have been removed.")
+
+			if config.TournamentConsolation && len(ranked) > 1 {
+				last := ranked[len(ranked)-1]
+				writeToAllClients("CT", "OOC", fmt.Sprintf("🏆 Consolation prize: UID %d (last place, %d messages) also has their punishments lifted.",
+					last.uid, last.messageCount))
+				clearTournamentPunishments(last.uid, "Consolation prize! Your tournament punishments have been removed.")
+			}
 		}
 
 		tournamentParticipants = make(map[int]*TournamentParticipant)
@@ -104,7 +107,8 @@ func cmdTournament(client *Client, args []string, usage string) {
 
 		duration := time.Since(tournamentStartTime).Round(time.Second)
 		msg := fmt.Sprintf("🏆 TOURNAMENT STATUS (Running for %v)\n", duration)
-		msg += fmt.Sprintf("Participants: %d\n\n", len(tournamentParticipants))
+		msg += fmt.Sprintf("Participants: %d\n", len(tournamentParticipants))
+		msg += tournamentSettingsLine() + "\n\n"
 
 		// Build leaderboard sorted by message count
 		type leaderEntry struct {
@@ -140,6 +144,60 @@ func cmdTournament(client *Client, args []string, usage string) {
 	}
 }
 
+// tournamentResults ranks participants by IC message count, highest first,
+// breaking ties by who joined first. With dropAFK, participants who sent no
+// messages are left out of the ranking and their UIDs returned separately.
+func tournamentResults(participants map[int]*TournamentParticipant, dropAFK bool) (ranked []*TournamentParticipant, afk []int) {
+	for _, p := range participants {
+		if dropAFK && p.messageCount == 0 {
+			afk = append(afk, p.uid)
+			continue
+		}
+		ranked = append(ranked, p)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].messageCount != ranked[j].messageCount {
+			return ranked[i].messageCount > ranked[j].messageCount
+		}
+		if !ranked[i].joinedAt.Equal(ranked[j].joinedAt) {
+			return ranked[i].joinedAt.Before(ranked[j].joinedAt)
+		}
+		return ranked[i].uid < ranked[j].uid
+	})
+	sort.Ints(afk)
+	return ranked, afk
+}
+
+// clearTournamentPunishments removes every punishment (memory and DB) from
+// the participant with uid, if they are still connected, and tells them why.
+func clearTournamentPunishments(uid int, notice string) {
+	c := clients.GetClientByUID(uid)
+	if c == nil {
+		return
+	}
+	c.RemoveAllPunishments()
+	if err := db.DeleteAllPunishments(c.Ipid()); err != nil {
+		logger.LogErrorf("Failed to remove persistent punishments for tournament participant %v: %v", c.Ipid(), err)
+	}
+	c.SendServerMessage(notice)
+}
+
+// tournamentSettingsLine summarises the tournament_* config for /tournament status.
+func tournamentSettingsLine() string {
+	minimum := "none"
+	if config.TournamentMinPlayers > 0 {
+		minimum = strconv.Itoa(config.TournamentMinPlayers)
+	}
+	onOff := func(b bool) string {
+		if b {
+			return "on"
+		}
+		return "off"
+	}
+	return fmt.Sprintf("Settings: minimum participants %v, last-place consolation %v, AFK drop %v",
+		minimum, onOff(config.TournamentConsolation), onOff(config.TournamentDropAFK))
+}
+
 // cmdJoinTournament allows users to join the active tournament
 func cmdJoinTournament(client *Client, args []string, usage string) {
 	tournamentMutex.Lock()
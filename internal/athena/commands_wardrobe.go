@@ -115,6 +115,10 @@ func cmdWardrobe(client *Client, args []string, _ string) {
 			return
 		}
 
+		if client.characterSlotBlocked() {
+			return
+		}
+
 		if client.Area().IsTaken(charID) && client.CharID() != charID {
 			client.SendServerMessage(fmt.Sprintf(
 				"Character \"%v\" is already taken in this area.", canonicalName))
@@ -17,8 +17,11 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package athena
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
 )
 
 type cmdParamList struct {
@@ -39,7 +42,10 @@ func (v cmdParamList) Set(s string) error {
 }
 
 // getUidList returns a list of clients that have the given UID(s).
-func getUidList(uids []string) []*Client {
+// Entries that aren't a valid UID or don't belong to a connected client are
+// skipped; when caller is non-nil they are reported back to it as
+// "Unknown UIDs: ..." so a typo doesn't look like a silent no-op.
+func getUidList(caller *Client, uids []string) []*Client {
 	var l []*Client
 	for _, s := range uids {
 		uid, err := strconv.Atoi(s)
@@ -52,6 +58,30 @@ func getUidList(uids []string) []*Client {
 		}
 		l = append(l, c)
 	}
+	if caller != nil {
+		if unknown := unresolvedUids(uids); len(unknown) > 0 {
+			caller.SendServerMessage(fmt.Sprintf("Unknown UIDs: %v", strings.Join(unknown, ", ")))
+		}
+	}
+	return l
+}
+
+// unresolvedUids returns the entries of uids that getUidList would skip:
+// anything that isn't a valid UID or doesn't belong to a connected client.
+func unresolvedUids(uids []string) []string {
+	var l []string
+	for _, s := range uids {
+		if s == "" {
+			continue
+		}
+		uid, err := strconv.Atoi(s)
+		if err == nil && uid != -1 {
+			if _, err := getClientByUid(uid); err == nil {
+				continue
+			}
+		}
+		l = append(l, s)
+	}
 	return l
 }
 
@@ -85,6 +115,33 @@ func getCharacterID(charName string) int {
 	return -1
 }
 
+// resolveArea resolves a command or Discord argument to an area and its
+// index. The token is matched case-insensitively against area names first,
+// so an area named "2" is found by name; a number that names no area is
+// then taken as the index.
+func resolveArea(token string) (*area.Area, int, error) {
+	token = strings.TrimSpace(token)
+	for i, a := range areas {
+		if strings.EqualFold(a.Name(), token) {
+			return a, i, nil
+		}
+	}
+	if id, err := strconv.Atoi(token); err == nil && id >= 0 && id < len(areas) {
+		return areas[id], id, nil
+	}
+	return nil, -1, fmt.Errorf("area not found: %s", token)
+}
+
+// invalidAreaMessage is the in-game reply for an area argument that
+// resolveArea could not resolve; it lists every area by index and name.
+func invalidAreaMessage() string {
+	names := make([]string, len(areas))
+	for i, a := range areas {
+		names[i] = fmt.Sprintf("%d: %v", i, a.Name())
+	}
+	return "Invalid area. Valid areas are:\n" + strings.Join(names, "\n")
+}
+
 // clientDisplayName returns the client's effective showname, falling back to
 // their character name when the showname is blank.
 func clientDisplayName(c *Client) string {
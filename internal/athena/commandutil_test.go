@@ -0,0 +1,86 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+)
+
+// TestGetUidListReportsUnknown verifies that a mixed UID list resolves the
+// connected clients and reports the rest back to the caller.
+func TestGetUidListReportsUnknown(t *testing.T) {
+	newTestClients(t)
+	for uid := 1; uid <= 2; uid++ {
+		c := &Client{conn: &captureConn{}, uid: uid, char: -1}
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+	callerConn := &captureConn{}
+	caller := &Client{conn: callerConn, uid: 9, char: -1}
+
+	got := getUidList(caller, strings.Split("1,42,2,abc,", ","))
+	if len(got) != 2 || got[0].Uid() != 1 || got[1].Uid() != 2 {
+		t.Fatalf("getUidList resolved %d clients, want UIDs 1 and 2", len(got))
+	}
+	if out := callerConn.String(); !strings.Contains(out, "Unknown UIDs: 42, abc") {
+		t.Errorf("expected unresolved UIDs to be reported, got %q", out)
+	}
+
+	callerConn = &captureConn{}
+	caller.conn = callerConn
+	getUidList(caller, []string{"1", "2"})
+	if out := callerConn.String(); strings.Contains(out, "Unknown UIDs") {
+		t.Errorf("expected no report when every UID resolves, got %q", out)
+	}
+}
+
+// TestResolveArea verifies that areas resolve by case-insensitive name or by
+// index, that an exact name beats an index, and that out-of-range indices
+// and unknown names are rejected.
+func TestResolveArea(t *testing.T) {
+	lobby, court, numbered := makeTestArea("Lobby"), makeTestArea("Court Room"), makeTestArea("1")
+	t.Cleanup(setupTestAreas([]*area.Area{lobby, court, numbered}))
+
+	tests := []struct {
+		token  string
+		want   *area.Area
+		wantID int
+	}{
+		{"0", lobby, 0},
+		{"1", numbered, 2}, // an exact name wins over the index
+		{" 1 ", numbered, 2},
+		{"2", numbered, 2},
+		{"lobby", lobby, 0},
+		{"COURT ROOM", court, 1},
+		{"3", nil, -1},
+		{"-1", nil, -1},
+		{"Court", nil, -1},
+		{"", nil, -1},
+	}
+	for _, tt := range tests {
+		got, id, err := resolveArea(tt.token)
+		if got != tt.want || id != tt.wantID || (err == nil) != (tt.want != nil) {
+			t.Errorf("resolveArea(%q) = (%v, %d, %v), want index %d", tt.token, got, id, err, tt.wantID)
+		}
+	}
+	if msg := invalidAreaMessage(); !strings.Contains(msg, "1: Court Room") {
+		t.Errorf("invalidAreaMessage() = %q, want it to list area names", msg)
+	}
+}
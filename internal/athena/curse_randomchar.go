@@ -20,7 +20,6 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"time"
 
@@ -67,7 +66,7 @@ func (client *Client) disarmCurseRandomChar() {
 func (client *Client) curseRandomCharWatch() {
 	defer client.curseRandomCharWatcherStarted.Store(false)
 	for {
-		wait := time.Duration(1+rand.Intn(5)) * time.Second // 1-5 seconds, inclusive
+		wait := time.Duration(1+rng.Intn(5)) * time.Second // 1-5 seconds, inclusive
 		timer := time.NewTimer(wait)
 		select {
 		case <-client.done:
@@ -22,7 +22,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/MangosArentLiterature/Athena/internal/area"
@@ -33,12 +33,6 @@ import (
 	"github.com/MangosArentLiterature/Athena/internal/permissions"
 )
 
-// warnings is a simple in-memory warning store keyed by IPID.
-var (
-	warningsMu sync.RWMutex
-	warnings   = make(map[string][]bot.WarnRecord)
-)
-
 // ServerAdapter implements bot.ServerInterface, bridging Discord bot commands to the AO2 server.
 type ServerAdapter struct{}
 
@@ -145,21 +139,32 @@ func (a *ServerAdapter) GetAreas() []bot.AreaInfo {
 	return result
 }
 
-// FindArea finds an area by name.
+// FindArea finds an area by name or index.
 func (a *ServerAdapter) FindArea(name string) *bot.AreaInfo {
-	name = strings.ToLower(name)
-	for i, ar := range areas {
-		if strings.EqualFold(ar.Name(), name) {
-			return &bot.AreaInfo{
-				Index:       i,
-				Name:        ar.Name(),
-				PlayerCount: ar.PlayerCount(),
-				Status:      ar.Status().String(),
-				Lock:        ar.Lock().String(),
-			}
-		}
+	ar, i, err := resolveArea(name)
+	if err != nil {
+		return nil
 	}
-	return nil
+	return &bot.AreaInfo{
+		Index:       i,
+		Name:        ar.Name(),
+		PlayerCount: ar.PlayerCount(),
+		Status:      ar.Status().String(),
+		Lock:        ar.Lock().String(),
+	}
+}
+
+// GetTestimony returns the decoded statements recorded in the area at
+// areaIndex, without the title.
+func (a *ServerAdapter) GetTestimony(areaIndex int) []string {
+	if areaIndex < 0 || areaIndex >= len(areas) {
+		return nil
+	}
+	statements := areas[areaIndex].Testimony()
+	for i, s := range statements {
+		statements[i] = decode(s)
+	}
+	return statements
 }
 
 // MutePlayer mutes a player by UID.
@@ -181,7 +186,7 @@ func (a *ServerAdapter) MutePlayer(uid int, duration time.Duration, reason strin
 	if err := db.UpsertMute(c.Ipid(), int(ICOOCMuted), expires); err != nil {
 		logger.LogErrorf("Failed to persist mute for %v: %v", c.Ipid(), err)
 	}
-	c.SendServerMessage(fmt.Sprintf("You have been muted. Reason: %s", reason))
+	c.SendLocalized("muted_with_reason", reason)
 	return nil
 }
 
@@ -195,7 +200,7 @@ func (a *ServerAdapter) UnmutePlayer(uid int) error {
 	if err := db.DeleteMute(c.Ipid()); err != nil {
 		logger.LogErrorf("Failed to remove persistent mute for %v: %v", c.Ipid(), err)
 	}
-	c.SendServerMessage("You have been unmuted.")
+	c.SendLocalized("unmuted")
 	return nil
 }
 
@@ -205,7 +210,7 @@ func (a *ServerAdapter) KickPlayer(uid int, reason string) error {
 	if err != nil {
 		return fmt.Errorf("player not found: UID %d", uid)
 	}
-	c.SendServerMessage(fmt.Sprintf("You have been kicked. Reason: %s", reason))
+	c.SendLocalized("kicked_with_reason", reason)
 	c.conn.Close()
 	return nil
 }
@@ -224,7 +229,7 @@ func (a *ServerAdapter) BanPlayer(ipid string, duration time.Duration, reason st
 	}
 	// Kick all clients with this IPID.
 	for _, c := range getClientsByIpid(ipid) {
-		c.SendServerMessage(fmt.Sprintf("You have been banned. Reason: %s", reason))
+		c.SendLocalized("banned_with_reason", reason)
 		c.conn.Close()
 	}
 	logger.WriteAudit(fmt.Sprintf("%v | BAN | IPID:%v | %v | By: %v", time.Now().UTC().Format("15:04:05"), ipid, reason, moderator))
@@ -262,29 +267,33 @@ func (a *ServerAdapter) UngagPlayer(uid int) error {
 	return nil
 }
 
-// WarnPlayer issues a warning to a player (stored in memory keyed by IPID).
+// WarnPlayer issues a warning to a player (stored in the database keyed by IPID).
 func (a *ServerAdapter) WarnPlayer(uid int, reason string, moderator string) error {
 	c, err := getClientByUid(uid)
 	if err != nil {
 		return fmt.Errorf("player not found: UID %d", uid)
 	}
-	warningsMu.Lock()
-	warnings[c.Ipid()] = append(warnings[c.Ipid()], bot.WarnRecord{
-		Reason:    reason,
-		Moderator: moderator,
-		Time:      time.Now().UTC().Unix(),
-	})
-	warningsMu.Unlock()
+	if err := db.AddWarning(c.Ipid(), reason, moderator); err != nil {
+		logger.LogErrorf("Failed to record warning for %v: %v", c.Ipid(), err)
+		return fmt.Errorf("failed to record warning")
+	}
 	c.SendServerMessage(fmt.Sprintf("⚠️ Warning from moderator: %s", reason))
 	logger.WriteAudit(fmt.Sprintf("%v | WARN | IPID:%v | %v | By: %v", time.Now().UTC().Format("15:04:05"), c.Ipid(), reason, moderator))
 	return nil
 }
 
-// GetWarnings returns all warnings for a given IPID.
+// GetWarnings returns all warnings for a given IPID, newest first.
 func (a *ServerAdapter) GetWarnings(ipid string) []bot.WarnRecord {
-	warningsMu.RLock()
-	defer warningsMu.RUnlock()
-	return append([]bot.WarnRecord(nil), warnings[ipid]...)
+	entries, err := db.GetWarnings(ipid)
+	if err != nil {
+		logger.LogErrorf("Failed to load warnings for %v: %v", ipid, err)
+		return nil
+	}
+	records := make([]bot.WarnRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, bot.WarnRecord{Reason: e.Reason, Moderator: e.Moderator, Time: e.Time})
+	}
+	return records
 }
 
 // GetBanList returns all bans from the database.
@@ -324,6 +333,9 @@ func (a *ServerAdapter) ApplyPunishment(uid int, punishmentName string, duration
 	if pType == PunishmentNone {
 		return fmt.Errorf("unknown punishment: %s", punishmentName)
 	}
+	if punishmentDisabled(punishmentName) || punishmentDisabled(pType.String()) {
+		return fmt.Errorf("that punishment is disabled on this server")
+	}
 	if punishmentSafeBlocked(c) {
 		return fmt.Errorf("that player is in a punishment-safe area and cannot be punished")
 	}
@@ -359,6 +371,16 @@ func (a *ServerAdapter) RemovePunishment(uid int, punishmentName string) error {
 	return nil
 }
 
+// GetPlayerPunishments describes a player's active punishments (including lag,
+// mute and jail) with remaining durations, one entry per effect.
+func (a *ServerAdapter) GetPlayerPunishments(uid int) []string {
+	c, err := getClientByUid(uid)
+	if err != nil {
+		return nil
+	}
+	return punishmentSummary(c, true)
+}
+
 // punishmentNameToType converts a string name to a PunishmentType.
 func punishmentNameToType(name string) PunishmentType {
 	switch strings.ToLower(name) {
@@ -423,32 +445,22 @@ func (a *ServerAdapter) ForceMove(uid int, areaName string) error {
 	if err != nil {
 		return fmt.Errorf("player not found: UID %d", uid)
 	}
-	for _, ar := range areas {
-		if strings.EqualFold(ar.Name(), areaName) {
-			if !c.ChangeArea(ar) {
-				return fmt.Errorf("could not move player to %s (area may be locked)", areaName)
-			}
-			c.SendServerMessage(fmt.Sprintf("You were moved to %s by a moderator.", ar.Name()))
-			return nil
-		}
+	ar, _, err := resolveArea(areaName)
+	if err != nil {
+		return err
+	}
+	if !c.ChangeArea(ar) {
+		return fmt.Errorf("could not move player to %s (area may be locked)", ar.Name())
 	}
-	return fmt.Errorf("area not found: %s", areaName)
+	c.SendLocalized("moved_to_by_mod", ar.Name())
+	return nil
 }
 
 // ClearArea moves all players out of a named area to area 0.
 func (a *ServerAdapter) ClearArea(areaName string) error {
-	var target *area.Area
-	for _, ar := range areas {
-		if strings.EqualFold(ar.Name(), areaName) {
-			target = ar
-			break
-		}
-	}
-	if target == nil {
-		return fmt.Errorf("area not found: %s", areaName)
-	}
-	if len(areas) == 0 {
-		return fmt.Errorf("no areas configured")
+	target, _, err := resolveArea(areaName)
+	if err != nil {
+		return err
 	}
 	lobby := areas[0]
 	if target == lobby {
@@ -457,29 +469,91 @@ func (a *ServerAdapter) ClearArea(areaName string) error {
 	clients.ForEach(func(c *Client) {
 		if c.Uid() != -1 && c.Area() == target {
 			c.ChangeArea(lobby)
-			c.SendServerMessage(fmt.Sprintf("You were moved out of %s by a moderator.", areaName))
+			c.SendLocalized("moved_out_by_mod", target.Name())
 		}
 	})
 	return nil
 }
 
+// SummonAll moves every player to a named area, mirroring the in-game
+// /summon. It returns the number of players that were moved.
+func (a *ServerAdapter) SummonAll(areaName string) (int, error) {
+	target, _, err := resolveArea(areaName)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	clients.ForEach(func(c *Client) {
+		if !c.ChangeArea(target) {
+			return
+		}
+		c.SendServerMessage(fmt.Sprintf("You were summoned to %v.", target.Name()))
+		count++
+	})
+	return count, nil
+}
+
+// KickFromArea moves a player out of their current area to area 0, mirroring
+// the in-game /kickarea: the player is also dropped from the area's invite
+// list so they cannot walk straight back into a locked area.
+func (a *ServerAdapter) KickFromArea(uid int) error {
+	c, err := getClientByUid(uid)
+	if err != nil {
+		return fmt.Errorf("player not found: UID %d", uid)
+	}
+	if len(areas) == 0 {
+		return fmt.Errorf("no areas configured")
+	}
+	origin := c.Area()
+	if origin == areas[0] {
+		return fmt.Errorf("cannot kick a player from the default area")
+	}
+	origin.RemoveInvited(c.Uid())
+	if !c.ChangeArea(areas[0]) {
+		return fmt.Errorf("could not move player out of %s (player may be jailed)", origin.Name())
+	}
+	c.SendServerMessage("You were kicked from the area!")
+	return nil
+}
+
+// GetAreaPlayers returns the players currently in a named area.
+func (a *ServerAdapter) GetAreaPlayers(areaName string) ([]bot.PlayerInfo, error) {
+	target, _, err := resolveArea(areaName)
+	if err != nil {
+		return nil, err
+	}
+	var result []bot.PlayerInfo
+	clients.ForEach(func(c *Client) {
+		if c.Uid() == -1 || c.Area() != target {
+			return
+		}
+		result = append(result, bot.PlayerInfo{
+			UID:       c.Uid(),
+			Character: c.CurrentCharacter(),
+			OOCName:   c.OOCName(),
+			Area:      target.Name(),
+			IPID:      c.Ipid(),
+		})
+	})
+	return result, nil
+}
+
 // LockArea locks a named area.
 func (a *ServerAdapter) LockArea(areaName string) error {
-	for _, ar := range areas {
-		if strings.EqualFold(ar.Name(), areaName) {
-			ar.SetLock(area.LockLocked)
-			// Invite all current players.
-			clients.ForEach(func(c *Client) {
-				if c.Uid() != -1 && c.Area() == ar {
-					ar.AddInvited(c.Uid())
-				}
-			})
-			sendAreaServerMessage(ar, fmt.Sprintf("%s was locked by a Discord moderator.", ar.Name()))
-			sendLockArup()
-			return nil
-		}
+	ar, _, err := resolveArea(areaName)
+	if err != nil {
+		return err
 	}
-	return fmt.Errorf("area not found: %s", areaName)
+	ar.SetLock(area.LockLocked)
+	// Invite all current players.
+	clients.ForEach(func(c *Client) {
+		if c.Uid() != -1 && c.Area() == ar {
+			ar.AddInvited(c.Uid())
+		}
+	})
+	sendAreaServerMessage(ar, fmt.Sprintf("%s was locked by a Discord moderator.", ar.Name()))
+	sendLockArup()
+	return nil
 }
 
 // SetFirewall toggles the IPHub VPN/proxy firewall. Mirrors in-game /firewall.
@@ -543,19 +617,18 @@ func (a *ServerAdapter) WhitelistAllConnected() (int, error) {
 
 // UnlockArea unlocks a named area.
 func (a *ServerAdapter) UnlockArea(areaName string) error {
-	for _, ar := range areas {
-		if strings.EqualFold(ar.Name(), areaName) {
-			if ar.Lock() == area.LockFree {
-				return fmt.Errorf("area %s is not locked", areaName)
-			}
-			ar.SetLock(area.LockFree)
-			ar.ClearInvited()
-			sendAreaServerMessage(ar, fmt.Sprintf("%s was unlocked by a Discord moderator.", ar.Name()))
-			sendLockArup()
-			return nil
-		}
+	ar, _, err := resolveArea(areaName)
+	if err != nil {
+		return err
+	}
+	if ar.Lock() == area.LockFree {
+		return fmt.Errorf("area %s is not locked", ar.Name())
 	}
-	return fmt.Errorf("area not found: %s", areaName)
+	ar.SetLock(area.LockFree)
+	ar.ClearInvited()
+	sendAreaServerMessage(ar, fmt.Sprintf("%s was unlocked by a Discord moderator.", ar.Name()))
+	sendLockArup()
+	return nil
 }
 
 // GetPlayerLogs returns the area buffer log entries for the area the player is currently in,
@@ -572,12 +645,18 @@ func (a *ServerAdapter) GetPlayerLogs(ipid string) []string {
 	return result
 }
 
-// GetAuditLog returns the last N lines of the audit log, optionally filtered by a search string.
-func (a *ServerAdapter) GetAuditLog(filter string) []string {
+// GetAuditLog returns the last N lines of the audit log, optionally filtered.
+// The filter is a set of space-separated terms that must all match, or a
+// regular expression when regex is set; an invalid regex is returned as an error.
+func (a *ServerAdapter) GetAuditLog(filter string, regex bool) ([]string, error) {
+	match, err := compileLogFilter(filter, regex)
+	if err != nil {
+		return nil, err
+	}
 	auditPath := logger.LogPath + "/audit.log"
 	f, err := os.Open(auditPath)
 	if err != nil {
-		return nil
+		return nil, nil
 	}
 	defer f.Close()
 
@@ -585,7 +664,7 @@ func (a *ServerAdapter) GetAuditLog(filter string) []string {
 	scanner := bufio.NewScanner(f)
 	for scanner.Scan() {
 		line := scanner.Text()
-		if filter == "" || strings.Contains(strings.ToLower(line), strings.ToLower(filter)) {
+		if match(line) {
 			lines = append(lines, line)
 		}
 	}
@@ -595,7 +674,7 @@ func (a *ServerAdapter) GetAuditLog(filter string) []string {
 	if len(lines) > maxLines {
 		lines = lines[len(lines)-maxLines:]
 	}
-	return lines
+	return lines, nil
 }
 
 // GetServerName returns the server's name.
@@ -613,6 +692,56 @@ func (a *ServerAdapter) GetMaxPlayers() int {
 	return config.MaxPlayers
 }
 
+// RollDice rolls a dice expression with the same limits as in-game /roll and
+// returns the formatted rolls and total.
+func (a *ServerAdapter) RollDice(spec string) (string, error) {
+	r, err := rollDice(spec)
+	if err != nil {
+		return "", err
+	}
+	return r.String(), nil
+}
+
+// Choose splits options like in-game /choose and picks one, returning the
+// choice along with the parsed options.
+func (a *ServerAdapter) Choose(options string) (string, []string, error) {
+	parsed := parseChoices(options)
+	choice, err := pickChoice(parsed)
+	return choice, parsed, err
+}
+
+// EightBall draws an answer from the same pool as in-game /8ball.
+func (a *ServerAdapter) EightBall() string {
+	return eightBallAnswer()
+}
+
+// icRelayFunc is the Discord bot's IC relay, registered via SetICRelay; nil
+// when no relay is running.
+var icRelayFunc atomic.Pointer[func(area, character, message string)]
+
+// SetICRelay registers (or, with nil, removes) the bot's IC relay.
+func (a *ServerAdapter) SetICRelay(relay func(area, character, message string)) {
+	if relay == nil {
+		icRelayFunc.Store(nil)
+		return
+	}
+	icRelayFunc.Store(&relay)
+}
+
+// relayIC hands a broadcast IC message to the Discord relay, if one is
+// registered. The bot applies its own area allowlist and batching.
+func relayIC(client *Client, ms *packet.MSPacket) {
+	relay := icRelayFunc.Load()
+	if relay == nil || client.Area().LogSilenced() {
+		return
+	}
+	name := decode(ms.Showname)
+	if name == "" {
+		name = client.CurrentCharacter()
+	}
+	(*relay)(client.Area().Name(), name, decode(ms.Message))
+}
+
 // Restart signals the server process to restart itself.
 func (a *ServerAdapter) Restart() error {
 	go RequestRestart()
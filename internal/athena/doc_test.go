@@ -0,0 +1,70 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+func TestValidDocLink(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want bool
+	}{
+		{"Ask the CM for the case file", true},
+		{"Case doc: https://example.com/doc", true},
+		{"https://docs.google.com/document/d/abc", true},
+		{"www.example.com/case", true},
+		{"http://", false},
+		{"https//example.com", true},
+		{"ftp://example.com/case", false},
+		{"javascript://alert(1)", false},
+	}
+	for _, tt := range tests {
+		if got := validDocLink(tt.doc); got != tt.want {
+			t.Errorf("validDocLink(%q) = %v, want %v", tt.doc, got, tt.want)
+		}
+	}
+}
+
+// TestDocPrevRestoresClearedDoc verifies the "oops I cleared the doc" flow.
+func TestDocPrevRestoresClearedDoc(t *testing.T) {
+	a := area.NewArea(area.AreaData{Name: "Court"}, 1, 0, area.EviAny)
+	conn := &captureConn{}
+	cm := &Client{conn: conn, uid: 1, char: -1}
+	cm.SetArea(a)
+	cm.SetPerms(permissions.PermissionField["ADMIN"])
+
+	cmdDoc(cm, []string{"https://example.com/case"}, "")
+	cmdDoc(cm, []string{"-c"}, "")
+	if a.Doc() != "" {
+		t.Fatalf("doc after -c = %q, want empty", a.Doc())
+	}
+	cmdDoc(cm, []string{"-prev"}, "")
+	if a.Doc() != "https://example.com/case" {
+		t.Errorf("doc after -prev = %q, want the cleared link back", a.Doc())
+	}
+
+	cmdDoc(cm, []string{"https://"}, "")
+	if a.Doc() != "https://example.com/case" || !strings.Contains(conn.String(), "valid http(s) URL") {
+		t.Errorf("an invalid link should be refused; doc = %q", a.Doc())
+	}
+}
@@ -0,0 +1,102 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+const eventCooldownFile = "event_cooldowns.json"
+
+// eventCooldowns is the on-disk form of the global event cooldowns, so a
+// restart can't be used to skip them.
+type eventCooldowns struct {
+	GiveawayEnd  time.Time `json:"giveaway_end"`
+	HotPotatoEnd time.Time `json:"hot_potato_end"`
+}
+
+// eventCooldownMu serialises writes so two events ending together can't
+// interleave their temporary files.
+var eventCooldownMu sync.Mutex
+
+// eventCooldownPath returns where the event cooldown timestamps are kept.
+func eventCooldownPath() string {
+	return filepath.Join(settings.ConfigPath, eventCooldownFile)
+}
+
+// saveEventCooldowns writes the giveaway and hot potato end times to path,
+// reading each under its own mutex. Like saveAreaState it writes to a
+// temporary file and renames it into place.
+func saveEventCooldowns(path string) error {
+	giveaway.mu.Lock()
+	state := eventCooldowns{GiveawayEnd: giveaway.lastEnd}
+	giveaway.mu.Unlock()
+	hotPotato.mu.Lock()
+	state.HotPotatoEnd = hotPotato.lastGameEnd
+	hotPotato.mu.Unlock()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	eventCooldownMu.Lock()
+	defer eventCooldownMu.Unlock()
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// restoreEventCooldowns loads the end times written by saveEventCooldowns
+// into the in-memory event state. A missing file is not an error.
+func restoreEventCooldowns(path string) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	var state eventCooldowns
+	if err := json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	giveaway.mu.Lock()
+	giveaway.lastEnd = state.GiveawayEnd
+	giveaway.mu.Unlock()
+	hotPotato.mu.Lock()
+	hotPotato.lastGameEnd = state.HotPotatoEnd
+	hotPotato.mu.Unlock()
+	return nil
+}
+
+// persistEventCooldowns saves the event cooldowns, logging rather than
+// returning any failure. Call it after releasing the event's mutex.
+func persistEventCooldowns() {
+	if err := saveEventCooldowns(eventCooldownPath()); err != nil {
+		logger.LogErrorf("Failed to save event cooldowns: %v", err)
+	}
+}
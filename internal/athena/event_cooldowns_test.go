@@ -0,0 +1,76 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestEventCooldownsSaveRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), eventCooldownFile)
+	giveaway.mu.Lock()
+	origGiveaway := giveaway.lastEnd
+	giveaway.mu.Unlock()
+	hotPotato.mu.Lock()
+	origHotPotato := hotPotato.lastGameEnd
+	hotPotato.mu.Unlock()
+	t.Cleanup(func() {
+		giveaway.mu.Lock()
+		giveaway.lastEnd = origGiveaway
+		giveaway.mu.Unlock()
+		hotPotato.mu.Lock()
+		hotPotato.lastGameEnd = origHotPotato
+		hotPotato.mu.Unlock()
+	})
+
+	end := time.Now().UTC().Add(-time.Minute).Truncate(time.Second)
+	giveaway.mu.Lock()
+	giveaway.lastEnd = end
+	giveaway.mu.Unlock()
+	hotPotato.mu.Lock()
+	hotPotato.lastGameEnd = end.Add(-time.Second)
+	hotPotato.mu.Unlock()
+	if err := saveEventCooldowns(path); err != nil {
+		t.Fatalf("saveEventCooldowns: %v", err)
+	}
+
+	// Simulate a restart: the in-memory timestamps start out zero.
+	giveaway.mu.Lock()
+	giveaway.lastEnd = time.Time{}
+	giveaway.mu.Unlock()
+	hotPotato.mu.Lock()
+	hotPotato.lastGameEnd = time.Time{}
+	hotPotato.mu.Unlock()
+	if err := restoreEventCooldowns(path); err != nil {
+		t.Fatalf("restoreEventCooldowns: %v", err)
+	}
+	if cooling, _ := isGiveawayCoolingDown(); !cooling {
+		t.Error("giveaway cooldown did not survive the restart")
+	}
+	if cooling, _ := isHotPotatoCoolingDown(); !cooling {
+		t.Error("hot potato cooldown did not survive the restart")
+	}
+}
+
+func TestEventCooldownsRestoreMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), eventCooldownFile)
+	if err := restoreEventCooldowns(path); err != nil {
+		t.Errorf("a missing file should not be an error, got %v", err)
+	}
+}
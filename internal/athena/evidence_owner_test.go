@@ -0,0 +1,68 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+func TestEvidenceOwnerMode(t *testing.T) {
+	logger.LogPath = t.TempDir()
+	a := area.NewArea(area.AreaData{}, 3, 10, area.EviOwner)
+	ownerConn, otherConn := &captureConn{}, &captureConn{}
+	owner := &Client{conn: ownerConn, uid: 1, ipid: "ip-owner", char: 0, area: a}
+	other := &Client{conn: otherConn, uid: 2, ipid: "ip-other", char: 1, area: a}
+	cm := &Client{conn: &captureConn{}, uid: 3, ipid: "ip-cm", char: 2, area: a, perms: permissions.PermissionField["CM"]}
+
+	for _, c := range []*Client{owner, other} {
+		if !c.CanAlterEvidence() {
+			t.Fatalf("UID %d: expected owner mode to let anyone add evidence", c.Uid())
+		}
+	}
+	a.AddEvidenceBy("Knife&Sharp&knife.png", owner.Ipid())
+	a.AddEvidenceBy("Map&Old&map.png", "")
+
+	if !owner.CanAlterEvidenceItem("ip-owner") || other.CanAlterEvidenceItem("ip-owner") {
+		t.Error("expected only the adder to alter their own evidence")
+	}
+	if other.CanAlterEvidenceItem("") {
+		t.Error("expected evidence with no recorded owner to be restricted to CMs and mods")
+	}
+	if !cm.CanAlterEvidenceItem("ip-owner") || !cm.CanAlterEvidenceItem("") {
+		t.Error("expected CMs to alter any evidence in owner mode")
+	}
+
+	pktRemoveEvi(other, &packet.Packet{Body: []string{"0"}})
+	if len(a.Evidence()) != 2 || !strings.Contains(otherConn.String(), "only change evidence you added") {
+		t.Errorf("expected a non-owner's removal to be refused; evidence=%v", a.Evidence())
+	}
+	pktRemoveEvi(owner, &packet.Packet{Body: []string{"0"}})
+	if len(a.Evidence()) != 1 {
+		t.Errorf("expected the owner's removal to succeed; evidence=%v", a.Evidence())
+	}
+
+	a.SetEvidenceMode(area.EviAny)
+	if !other.CanAlterEvidenceItem("ip-owner") {
+		t.Error("expected per-item ownership to be ignored outside owner mode")
+	}
+}
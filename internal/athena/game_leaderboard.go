@@ -0,0 +1,173 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/sliceutil"
+)
+
+// Game keys stored in GAME_RECORDS; must be stable.
+const (
+	gameRPS       = "rps"
+	gameCoinflip  = "coinflip"
+	gameTicTacToe = "ttt"
+	gameTrivia    = "trivia"
+)
+
+// leaderboardGames lists the games /leaderboard can rank.
+var leaderboardGames = []string{gameRPS, gameCoinflip, gameTicTacToe, gameTrivia}
+
+// recordGameOutcome adds a PvP result to both players' records when
+// enable_game_leaderboards is on. Games between connections sharing an IPID
+// are not recorded, so multiclients can't farm wins against themselves.
+func recordGameOutcome(game, winnerIPID, winnerName, loserIPID, loserName string) {
+	if config == nil || !config.EnableGameLeaderboards || winnerIPID == loserIPID {
+		return
+	}
+	if err := db.RecordGameResult(winnerIPID, game, winnerName, true); err != nil {
+		logger.LogErrorf("leaderboard: failed to record %v win for %v: %v", game, winnerIPID, err)
+	}
+	if err := db.RecordGameResult(loserIPID, game, loserName, false); err != nil {
+		logger.LogErrorf("leaderboard: failed to record %v loss for %v: %v", game, loserIPID, err)
+	}
+}
+
+// gameResult is one player's outcome in a multiplayer game.
+type gameResult struct {
+	IPID string
+	Name string
+	Won  bool
+}
+
+// recordGameResults adds one result per entry when enable_game_leaderboards
+// is on, for games with more than two players. Nothing is recorded unless at
+// least two IPIDs took part, so a lone multiclient can't farm wins.
+func recordGameResults(game string, results []gameResult) {
+	if config == nil || !config.EnableGameLeaderboards {
+		return
+	}
+	ipids := make(map[string]bool, len(results))
+	for _, r := range results {
+		ipids[r.IPID] = true
+	}
+	if len(ipids) < 2 {
+		return
+	}
+	for _, r := range results {
+		if err := db.RecordGameResult(r.IPID, game, r.Name, r.Won); err != nil {
+			logger.LogErrorf("leaderboard: failed to record %v result for %v: %v", game, r.IPID, err)
+		}
+	}
+}
+
+// Handles /leaderboard <rps|coinflip|ttt|trivia> [area] [n]
+
+func cmdLeaderboard(client *Client, args []string, usage string) {
+	if !config.EnableGameLeaderboards {
+		client.SendServerMessage("Game leaderboards are not enabled on this server.")
+		return
+	}
+	game := strings.ToLower(args[0])
+	if !sliceutil.ContainsString(leaderboardGames, game) {
+		client.SendServerMessage("Unknown game.\n" + usage)
+		return
+	}
+	areaOnly := false
+	n := 10
+	for _, arg := range args[1:] {
+		if strings.EqualFold(arg, "area") {
+			areaOnly = true
+		} else if v, err := strconv.Atoi(arg); err == nil && v > 0 && v <= 50 {
+			n = v
+		} else {
+			client.SendServerMessage(usage)
+			return
+		}
+	}
+
+	var entries []db.GameRecord
+	var title string
+	if areaOnly {
+		entries = areaGameRecords(client, game)
+		title = fmt.Sprintf("%v Leaderboard — %v", strings.ToUpper(game), client.Area().Name())
+	} else {
+		var err error
+		entries, err = db.GetTopGameRecords(game, n)
+		if err != nil {
+			logger.LogErrorf("leaderboard: GetTopGameRecords failed: %v", err)
+		}
+		title = fmt.Sprintf("%v Leaderboard", strings.ToUpper(game))
+	}
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+	if len(entries) == 0 {
+		client.SendServerMessage("No results recorded yet.")
+		return
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\n🏆 %v (Top %d)\n", title, len(entries)))
+	for i, e := range entries {
+		sb.WriteString(fmt.Sprintf("  %2d. %-20v  %dW / %dL\n", i+1, e.Name, e.Wins, e.Losses))
+	}
+	client.SendServerMessage(sb.String())
+}
+
+// areaGameRecords returns the records of everyone currently in the client's
+// area who has played game, best first, labelled by account or OOC name.
+func areaGameRecords(client *Client, game string) []db.GameRecord {
+	myArea := client.Area()
+	names := make(map[string]string)
+	var ipids []string
+	clients.ForEach(func(c *Client) {
+		if c.Area() != myArea || c.Uid() == -1 {
+			return
+		}
+		if _, seen := names[c.Ipid()]; !seen {
+			ipids = append(ipids, c.Ipid())
+		}
+		names[c.Ipid()] = oocDisplayName(c)
+	})
+	records, err := db.GetGameRecordsByIPIDs(game, ipids)
+	if err != nil {
+		logger.LogErrorf("leaderboard: GetGameRecordsByIPIDs failed: %v", err)
+	}
+	accounts, _ := db.GetUsernamesByIPIDs(ipids)
+
+	entries := make([]db.GameRecord, 0, len(records))
+	for ipid, r := range records {
+		r.Name = names[ipid]
+		if u := accounts[ipid]; u != "" {
+			r.Name = u
+		}
+		entries = append(entries, r)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Wins != entries[j].Wins {
+			return entries[i].Wins > entries[j].Wins
+		}
+		return entries[i].Losses < entries[j].Losses
+	})
+	return entries
+}
@@ -0,0 +1,89 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestRecordGameOutcome(t *testing.T) {
+	setupCoinBetTestDB(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+
+	recordGameOutcome(gameRPS, "ipidA", "Phoenix", "ipidB", "Edgeworth")
+	if top, _ := db.GetTopGameRecords(gameRPS, 10); len(top) != 0 {
+		t.Fatalf("nothing should be recorded while leaderboards are off, got %+v", top)
+	}
+
+	config.EnableGameLeaderboards = true
+	recordGameOutcome(gameRPS, "ipidA", "Phoenix", "ipidB", "Edgeworth")
+	recordGameOutcome(gameRPS, "ipidA", "Phoenix", "ipidA", "Phoenix (alt)")
+	top, _ := db.GetTopGameRecords(gameRPS, 10)
+	if len(top) != 2 || top[0].IPID != "ipidA" || top[0].Wins != 1 || top[1].Losses != 1 {
+		t.Errorf("unexpected records (same-IPID games must be skipped): %+v", top)
+	}
+}
+
+func TestLeaderboardAreaOnly(t *testing.T) {
+	setupCoinBetTestDB(t)
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	config.EnableGameLeaderboards = true
+
+	here, elsewhere := makeTestArea("Courtroom"), makeTestArea("Lobby")
+	conn := &captureConn{}
+	viewer := &Client{conn: conn, uid: 1, ipid: "ipidA", area: here, oocName: "Phoenix"}
+	away := &Client{conn: &testConn{}, uid: 2, ipid: "ipidB", area: elsewhere, oocName: "Edgeworth"}
+	for _, c := range []*Client{viewer, away} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+	recordGameOutcome(gameCoinflip, "ipidB", "Edgeworth", "ipidA", "Phoenix")
+
+	cmdLeaderboard(viewer, []string{"coinflip", "area"}, "usage")
+	out := conn.String()
+	if !strings.Contains(out, "Phoenix") || strings.Contains(out, "Edgeworth") {
+		t.Errorf("area leaderboard should only list players in the area, got %q", out)
+	}
+}
+
+func TestRecordGameResults(t *testing.T) {
+	setupCoinBetTestDB(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	config.EnableGameLeaderboards = true
+
+	recordGameResults(gameTrivia, []gameResult{{"ipidA", "Phoenix", true}, {"ipidA", "Phoenix (alt)", false}})
+	if top, _ := db.GetTopGameRecords(gameTrivia, 10); len(top) != 0 {
+		t.Fatalf("a game played by one IPID must not be recorded, got %+v", top)
+	}
+
+	recordGameResults(gameTrivia, []gameResult{{"ipidA", "Phoenix", true}, {"ipidB", "Edgeworth", false}, {"ipidC", "Maya", true}})
+	top, _ := db.GetTopGameRecords(gameTrivia, 10)
+	if len(top) != 3 || top[0].Wins != 1 || top[1].Wins != 1 || top[2].IPID != "ipidB" || top[2].Losses != 1 {
+		t.Errorf("unexpected records: %+v", top)
+	}
+}
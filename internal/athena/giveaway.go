@@ -215,6 +215,7 @@ func giveawayTimer(item, hostName string) {
 		uids = append(uids, uid)
 	}
 	giveaway.mu.Unlock()
+	persistEventCooldowns()
 
 	// Filter disconnected players in-place — avoids a second heap allocation.
 	n := 0
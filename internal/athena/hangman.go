@@ -18,7 +18,6 @@ package athena
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
 	"sync"
 	"time"
@@ -27,6 +26,7 @@ import (
 
 	"github.com/MangosArentLiterature/Athena/internal/area"
 	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
 )
 
 // ── Constants ─────────────────────────────────────────────────────────────────
@@ -39,6 +39,9 @@ const (
 	hangmanPunishDuration = 10 * time.Minute // length of punishment for wrong-guessers
 )
 
+// hangmanWordsFile is the optional word list for random-theme games.
+const hangmanWordsFile = "/hangman_words.txt"
+
 // hangmanRules is the welcome text broadcast when a game opens.
 const hangmanRules = `🎲 HANGMAN STARTING! 🎲
 Type /hangman join within 30 seconds to participate.
@@ -251,7 +254,38 @@ func pickHangmanWord(theme string) string {
 	default:
 		pool = hangmanWordsAll
 	}
-	return pool[rand.Intn(len(pool))]
+	return pool[rng.Intn(len(pool))]
+}
+
+// loadHangmanWords reads the optional hangman_words.txt from the config
+// directory, keeping only lines that are valid secret words. When it yields
+// any, random-theme games draw from it instead of the built-in pools.
+func loadHangmanWords() []string {
+	lines, err := settings.LoadFile(hangmanWordsFile)
+	if err != nil {
+		return nil
+	}
+	var words []string
+	for _, line := range lines {
+		if w := strings.ToLower(strings.TrimSpace(line)); w != "" && hangmanWordError(w) == "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// hangmanWordError explains why w cannot be used as a secret word, or
+// returns "" when it can.
+func hangmanWordError(w string) string {
+	for _, ch := range w {
+		if !unicode.IsLetter(ch) {
+			return "Custom word must contain letters only (no spaces or numbers)."
+		}
+	}
+	if n := utf8.RuneCountInString(w); n < 3 || n > 30 {
+		return "Custom word must be 3–30 letters long."
+	}
+	return ""
 }
 
 // ── Command entry point ───────────────────────────────────────────────────────
@@ -278,6 +312,11 @@ func cmdHangman(client *Client, args []string, usage string) {
 	case "stop":
 		hangmanStop(client)
 	default:
+		// /hangman <letter> is shorthand for /hangman guess <letter>.
+		if len(args) == 1 && utf8.RuneCountInString(args[0]) == 1 {
+			hangmanGuess(client, args[0])
+			return
+		}
 		client.SendServerMessage(usage)
 	}
 }
@@ -290,7 +329,10 @@ func cmdHangman(client *Client, args []string, usage string) {
 //	/hangman start                        → random theme
 //	/hangman start animals|courtroom|…    → specific theme
 //	/hangman start custom <word>          → host supplies the word
+//	/hangman start <word>                 → same, for CMs
 func hangmanStart(client *Client, args []string) {
+	isCM := client.HasCMPermission()
+	fileWords := loadHangmanWords()
 	st := hangmanGetState(client.Area())
 	st.mu.Lock()
 
@@ -314,40 +356,44 @@ func hangmanStart(client *Client, args []string) {
 	theme := "random"
 	var word string
 	if len(args) >= 1 {
-		switch strings.ToLower(args[0]) {
+		var raw string
+		switch theme = strings.ToLower(args[0]); theme {
 		case "custom":
 			if len(args) < 2 {
 				st.mu.Unlock()
 				client.SendServerMessage("Usage: /hangman start custom <word>  (letters only, 3–30 chars)")
 				return
 			}
-			raw := strings.ToLower(strings.Join(args[1:], ""))
-			for _, ch := range raw {
-				if !unicode.IsLetter(ch) {
-					st.mu.Unlock()
-					client.SendServerMessage("Custom word must contain letters only (no spaces or numbers).")
-					return
-				}
+			raw = strings.Join(args[1:], "")
+		case "animals", "courtroom", "nature", "food", "random":
+		default:
+			// CMs may skip the "custom" keyword and pass the word directly.
+			if !isCM {
+				st.mu.Unlock()
+				client.SendServerMessage(
+					"Unknown theme. Choices: animals | courtroom | nature | food | random | custom <word>",
+				)
+				return
 			}
-			if len([]rune(raw)) < 3 || len([]rune(raw)) > 30 {
+			raw = strings.Join(args, "")
+		}
+		if raw != "" {
+			raw = strings.ToLower(raw)
+			if msg := hangmanWordError(raw); msg != "" {
 				st.mu.Unlock()
-				client.SendServerMessage("Custom word must be 3–30 letters long.")
+				client.SendServerMessage(msg)
 				return
 			}
 			word = raw
 			theme = "custom"
-		case "animals", "courtroom", "nature", "food", "random":
-			theme = strings.ToLower(args[0])
-		default:
-			st.mu.Unlock()
-			client.SendServerMessage(
-				"Unknown theme. Choices: animals | courtroom | nature | food | random | custom <word>",
-			)
-			return
 		}
 	}
 	if word == "" {
-		word = pickHangmanWord(theme)
+		if theme == "random" && len(fileWords) > 0 {
+			word = fileWords[rng.Intn(len(fileWords))]
+		} else {
+			word = pickHangmanWord(theme)
+		}
 	}
 
 	wordLen := utf8.RuneCountInString(word)
@@ -713,7 +759,11 @@ func hangmanPunishLosers(a *area.Area, wrongGuessers map[int]bool) {
 		if err != nil {
 			continue
 		}
-		pType := hotPotatoPunishmentPool[rand.Intn(len(hotPotatoPunishmentPool))]
+		pool := enabledPunishments(hotPotatoPunishmentPool)
+		if len(pool) == 0 {
+			continue
+		}
+		pType := pool[rng.Intn(len(pool))]
 		c.AddPunishment(pType, hangmanPunishDuration, "Hangman: too many wrong guesses")
 		c.SendServerMessage(fmt.Sprintf(
 			"💀 You made wrong guesses and failed to solve the word! Punished with '%v' for %v.",
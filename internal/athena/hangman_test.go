@@ -1,8 +1,12 @@
 package athena
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/settings"
 )
 
 // ── pickHangmanWord ───────────────────────────────────────────────────────────
@@ -193,3 +197,33 @@ func TestHangmanArtLength(t *testing.T) {
 		t.Errorf("hangmanArt has %d stages, want %d (hangmanMaxWrong+1)", len(hangmanArt), hangmanMaxWrong+1)
 	}
 }
+
+// ── Word list file ────────────────────────────────────────────────────────────
+
+func TestHangmanWordError(t *testing.T) {
+	for w, ok := range map[string]bool{"objection": true, "café": true, "ab": false, "two words": false, "r2d2": false} {
+		if got := hangmanWordError(w) == ""; got != ok {
+			t.Errorf("hangmanWordError(%q) accepted = %v, want %v", w, got, ok)
+		}
+	}
+}
+
+// TestLoadHangmanWords verifies that hangman_words.txt is optional and that
+// unusable lines are dropped.
+func TestLoadHangmanWords(t *testing.T) {
+	origPath := settings.ConfigPath
+	t.Cleanup(func() { settings.ConfigPath = origPath })
+	settings.ConfigPath = t.TempDir()
+
+	if words := loadHangmanWords(); words != nil {
+		t.Fatalf("a missing file should yield no words, got %v", words)
+	}
+	data := "Turnabout\n\nno\nnot a word\nwitness\n"
+	if err := os.WriteFile(filepath.Join(settings.ConfigPath, "hangman_words.txt"), []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	words := loadHangmanWords()
+	if len(words) != 2 || words[0] != "turnabout" || words[1] != "witness" {
+		t.Errorf("loadHangmanWords() = %v, want [turnabout witness]", words)
+	}
+}
@@ -17,6 +17,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package athena
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/MangosArentLiterature/Athena/internal/settings"
@@ -67,3 +68,37 @@ func TestHotConfigIsolatesWhitelist(t *testing.T) {
 		t.Fatalf("whitelist fields not set correctly")
 	}
 }
+
+// TestSendWelcomeOrderAndSkips verifies the join welcome sends the MOTD, rules
+// and /help nudge in order, skips blank lines and ignores clients without a UID.
+func TestSendWelcomeOrderAndSkips(t *testing.T) {
+	origConfig := config
+	t.Cleanup(func() { config = origConfig; initHotConfig(&settings.Config{}) })
+	config = &settings.Config{ServerConfig: settings.ServerConfig{
+		Motd:         "motd line",
+		WelcomeRules: "rules: https://example.com/rules",
+		WelcomeHelp:  "type /help",
+	}}
+	initHotConfig(config)
+
+	conn := &captureConn{}
+	sendWelcome(&Client{conn: conn, uid: 1})
+	out := conn.String()
+	m, r, h := strings.Index(out, "motd line"), strings.Index(out, "rules:"), strings.Index(out, "type /help")
+	if m < 0 || r < m || h < r {
+		t.Fatalf("welcome out of order or missing lines: %q", out)
+	}
+
+	config.WelcomeRules = "  "
+	conn = &captureConn{}
+	sendWelcome(&Client{conn: conn, uid: 1})
+	if out := conn.String(); strings.Contains(out, "rules:") || strings.Count(out, "CT#") != 2 {
+		t.Errorf("blank rules line should be skipped: %q", out)
+	}
+
+	conn = &captureConn{}
+	sendWelcome(&Client{conn: conn, uid: -1})
+	if out := conn.String(); out != "" {
+		t.Errorf("client without a UID got a welcome: %q", out)
+	}
+}
@@ -76,9 +76,22 @@ var hotPotatoPunishmentPool = []PunishmentType{
 	PunishmentSubtitles,
 }
 
-// randomHotPotatoPunishment returns a random punishment from the pool.
+// randomHotPotatoPunishment returns a random enabled punishment from the
+// pool, weighted by hotpotato_weights when configured, or PunishmentNone if
+// every one is disabled (hotPotatoStart refuses to start then).
 func randomHotPotatoPunishment() PunishmentType {
-	return hotPotatoPunishmentPool[rand.Intn(len(hotPotatoPunishmentPool))]
+	pool := enabledPunishments(hotPotatoPunishmentPool)
+	if len(pool) == 0 {
+		return PunishmentNone
+	}
+	var weights map[string]float64
+	if config != nil {
+		weights = config.HotPotatoWeights
+	}
+	i := weightedIndex(len(pool), func(i int) string {
+		return pool[i].String()
+	}, weights)
+	return pool[i]
 }
 
 // ── State ────────────────────────────────────────────────────────────────────
@@ -144,6 +157,10 @@ func cmdHotPotato(client *Client, args []string, _ string) {
 // hotPotatoStart validates preconditions and opens the opt-in window.
 // State is mutated under the lock; all I/O follows after the lock is released.
 func hotPotatoStart(client *Client) {
+	if len(enabledPunishments(hotPotatoPunishmentPool)) == 0 {
+		client.SendServerMessage("Every punishment in the pool is disabled on this server.")
+		return
+	}
 	hotPotato.mu.Lock()
 
 	if hotPotato.optInActive || hotPotato.gameActive {
@@ -306,6 +323,7 @@ func hotPotatoOptInTimer() {
 		hotPotato.mu.Lock()
 		hotPotato.lastGameEnd = time.Now().UTC()
 		hotPotato.mu.Unlock()
+		persistEventCooldowns()
 		sendGlobalServerMessage(fmt.Sprintf(
 			"🥔 Hot Potato cancelled — not enough participants (%d/%d required).",
 			len(validUIDs), hotPotatoMinParticipants,
@@ -359,6 +377,7 @@ func hotPotatoGameTimer() {
 		participantUIDs = append(participantUIDs, uid)
 	}
 	hotPotato.mu.Unlock()
+	persistEventCooldowns()
 
 	hotPotatoResolve(currentCarrierUID, participantUIDs)
 }
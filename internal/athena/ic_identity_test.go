@@ -0,0 +1,89 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+)
+
+func setupIdentityTest(t *testing.T, iniswap bool) *Client {
+	t.Helper()
+	origChars := getCharacters()
+	t.Cleanup(func() { setCharacters(origChars) })
+	setCharacters([]string{"Phoenix Wright", "Miles Edgeworth", "Maya Fey"})
+
+	a := area.NewArea(area.AreaData{}, len(getCharacters()), 10, area.EviAny)
+	a.SetIniswapAllowed(iniswap)
+	client := &Client{conn: &testConn{}, uid: 1, possessing: -1, pair: ClientPairInfo{wanted_id: -1}, charStuckCharID: -1}
+	client.SetArea(a)
+	client.SetCharID(0)
+	return client
+}
+
+func TestICIdentityRejectsSpoofedCharID(t *testing.T) {
+	client := setupIdentityTest(t, true)
+	ms := &packet.MSPacket{CharID: "1", Character: "Phoenix Wright"}
+	if icIdentityValid(client, ms) {
+		t.Error("expected an MS claiming another character slot to be rejected")
+	}
+}
+
+func TestICIdentityAllowsIniswap(t *testing.T) {
+	client := setupIdentityTest(t, true)
+	ms := &packet.MSPacket{CharID: "0", Character: "Miles Edgeworth"}
+	if !icIdentityValid(client, ms) {
+		t.Error("expected an iniswap on the sender's own slot to be accepted")
+	}
+}
+
+func TestICIdentityRejectsIniswapWhenDisallowed(t *testing.T) {
+	client := setupIdentityTest(t, false)
+	if !icIdentityValid(client, &packet.MSPacket{CharID: "0", Character: "phoenix wright"}) {
+		t.Error("expected the sender's own character name to be accepted")
+	}
+	if icIdentityValid(client, &packet.MSPacket{CharID: "0", Character: "Miles Edgeworth"}) {
+		t.Error("expected an iniswap to be rejected when the area disallows it")
+	}
+}
+
+func TestParsePairCharID(t *testing.T) {
+	client := setupIdentityTest(t, true)
+	tests := []struct {
+		in   string
+		want int
+		ok   bool
+	}{
+		{"1", 1, true},
+		{"2^0", 2, true},
+		{"2^1", 2, true},
+		{"0", 0, false},  // own character
+		{"3", 0, false},  // one past the character list
+		{"-2", 0, false}, // negative
+		{"1^2", 0, false},
+		{"1^", 0, false},
+		{"abc", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePairCharID(client, tt.in)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("parsePairCharID(%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
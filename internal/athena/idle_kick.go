@@ -0,0 +1,84 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+// idleKickInterval is how often the idle sweep runs. A kick therefore lands
+// up to this long after the configured timeout.
+const idleKickInterval = time.Minute
+
+// TouchActivity records that the client just sent an IC or OOC message,
+// restarting its idle_kick_minutes countdown.
+func (client *Client) TouchActivity() {
+	client.lastActivityNano.Store(time.Now().UnixNano())
+}
+
+// LastActivity returns when the client last sent an IC or OOC message, or the
+// zero time if it has not joined yet.
+func (client *Client) LastActivity() time.Time {
+	if n := client.lastActivityNano.Load(); n != 0 {
+		return time.Unix(0, n)
+	}
+	return time.Time{}
+}
+
+// startIdleKickLoop sweeps for idle players every idleKickInterval. Runs for
+// the lifetime of the server process.
+func startIdleKickLoop(timeout time.Duration) {
+	ticker := time.NewTicker(idleKickInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		sweepIdleClients(timeout, time.Now())
+	}
+}
+
+// sweepIdleClients moves every player outside the first area who has been
+// idle for at least timeout back to it and to character select. CMs,
+// moderators and jailed players are left alone.
+func sweepIdleClients(timeout time.Duration, now time.Time) {
+	lobby := areas[0]
+	var idle []*Client
+	clients.ForEach(func(c *Client) {
+		if c.Uid() == -1 || c.Area() == lobby || c.HasCMPermission() || permissions.IsModerator(c.Perms()) {
+			return
+		}
+		last := c.LastActivity()
+		if last.IsZero() || now.Sub(last) < timeout {
+			return
+		}
+		if jail := c.JailedUntil(); !jail.IsZero() && now.Before(jail) {
+			return
+		}
+		idle = append(idle, c)
+	})
+	for _, c := range idle {
+		from := c.Area()
+		c.ChangeCharacter(-1)
+		c.forceChangeArea(lobby)
+		c.TouchActivity()
+		c.SendServerMessage(fmt.Sprintf("You were idle for %d minute(s), so you were moved to %v and back to character select to free your character.",
+			int(timeout.Minutes()), lobby.Name()))
+		logger.LogInfof("Client (IPID:%v UID:%v) idle-kicked from %v to %v", c.Ipid(), c.Uid(), from.Name(), lobby.Name())
+	}
+}
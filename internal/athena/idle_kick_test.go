@@ -0,0 +1,74 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// TestSweepIdleClients checks that only idle, non-exempt players outside the
+// lobby are sent back to it and to character select.
+func TestSweepIdleClients(t *testing.T) {
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	origChars := getCharacters()
+	t.Cleanup(func() { setCharacters(origChars) })
+	setCharacters([]string{"Phoenix", "Maya", "Edgeworth", "Gumshoe"})
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	cleanup := setupTestAreas([]*area.Area{lobby, court})
+	defer cleanup()
+
+	now := time.Now()
+	newClient := func(uid int, idle time.Duration) (*Client, *captureConn) {
+		conn := &captureConn{}
+		c := &Client{conn: conn, uid: uid, ipid: "ip", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+		c.JoinArea(court)
+		c.ChangeCharacter(uid)
+		c.lastActivityNano.Store(now.Add(-idle).UnixNano())
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+		return c, conn
+	}
+	afk, afkConn := newClient(1, time.Hour)
+	active, _ := newClient(2, time.Minute)
+	mod, _ := newClient(3, time.Hour)
+	mod.SetPerms(permissions.PermissionField["KICK"])
+
+	sweepIdleClients(30*time.Minute, now)
+
+	if afk.Area() != lobby || afk.CharID() != -1 || court.IsTaken(1) {
+		t.Errorf("idle player should be in the lobby at character select, got area %v char %d", afk.Area().Name(), afk.CharID())
+	}
+	if !strings.Contains(afkConn.String(), "You were idle for 30 minute(s)") {
+		t.Errorf("idle player was not told why they were moved: %q", afkConn.String())
+	}
+	if active.Area() != court || active.CharID() != 2 {
+		t.Error("a recently active player should not be moved")
+	}
+	if mod.Area() != court || mod.CharID() != 3 {
+		t.Error("moderators should be exempt from the idle kick")
+	}
+}
@@ -0,0 +1,90 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestInviteReportsAlreadyInvitedAndUnknownUids(t *testing.T) {
+	origClients := clients
+	t.Cleanup(func() { clients = origClients })
+	clients = &ClientList{list: make(map[*Client]struct{}), uidIndex: make(map[int]*Client), ipidCounts: make(map[string]int)}
+
+	room := makeTestArea("Courtroom")
+	room.SetLock(area.LockLocked)
+	cmConn := &captureConn{}
+	cm := &Client{conn: cmConn, uid: 1, ipid: "ip-cm", char: -1, area: room, perms: permissions.PermissionField["CM"]}
+	guest := &Client{conn: &captureConn{}, uid: 2, ipid: "ip-guest", char: -1, area: room}
+	for _, c := range []*Client{cm, guest} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+	room.AddInvited(guest.Uid())
+
+	cmdInvite(cm, []string{"2,99"}, "")
+	out := cmConn.String()
+	if !strings.Contains(out, "Invited 0 users.") {
+		t.Errorf("no new invites expected; got %q", out)
+	}
+	if !strings.Contains(out, "Already invited: 2.") {
+		t.Errorf("UID 2 should be reported as already invited; got %q", out)
+	}
+	if !strings.Contains(out, "No player found with UID: 99.") {
+		t.Errorf("UID 99 should be reported as not found; got %q", out)
+	}
+}
+
+func TestUninviteForceMovesSpectator(t *testing.T) {
+	newTestClients(t)
+	origAreas, origConfig := areas, config
+	t.Cleanup(func() { areas = origAreas; config = origConfig })
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	stage := area.NewArea(area.AreaData{Name: "Stage"}, 5, 10, area.EviAny)
+	areas = []*area.Area{lobby, stage}
+	config = &settings.Config{}
+	stage.SetLock(area.LockSpectatable)
+
+	cmConn := &captureConn{}
+	cm := &Client{conn: cmConn, uid: 1, ipid: "ip-cm", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+	cm.SetPerms(permissions.PermissionField["CM"])
+	cm.SetArea(stage)
+	spectator := &Client{conn: &testConn{}, uid: 2, ipid: "ip-spec", char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+	spectator.SetArea(stage)
+	for _, c := range []*Client{cm, spectator} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdUninvite(cm, []string{"2"}, "")
+	if spectator.Area() != stage {
+		t.Fatal("without -k a spectatable area must not eject anyone")
+	}
+
+	cmdUninvite(cm, []string{"-k", "2"}, "")
+	if spectator.Area() != lobby {
+		t.Fatalf("-k should move the spectator to area 0, still in %q", spectator.Area().Name())
+	}
+	if !strings.Contains(cmConn.String(), "Moved out of the area: 2.") {
+		t.Errorf("CM should be told who was moved; got %q", cmConn.String())
+	}
+}
@@ -0,0 +1,128 @@
+/* Athena - A server for Attorney Online 2 written in Go
+   Nyathena fork additions: /invitecode and /join.
+
+   A lighter alternative to inviting each UID into a locked area: a CM mints a
+   short code that can be shared in OOC, and anyone who redeems it with /join is
+   added to the area's invite list and moved in. Codes live on the area, expire
+   on their own, and are dropped whenever the invite list is cleared (unlock,
+   admin-lock lift). */
+
+package athena
+
+import (
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+)
+
+const (
+	joinCodeLength     = 6
+	joinCodeAlphabet   = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789" // no 0/O or 1/I lookalikes
+	joinCodeDefaultTTL = 10                                 // minutes
+	joinCodeMaxTTL     = 24 * 60                            // minutes
+	joinCodeMaxUses    = 100
+)
+
+// generateJoinCode returns a random code that is not already outstanding in any area.
+func generateJoinCode() (string, error) {
+	for {
+		var b [joinCodeLength]byte
+		if _, err := rand.Read(b[:]); err != nil {
+			return "", err
+		}
+		for i := range b {
+			b[i] = joinCodeAlphabet[int(b[i])%len(joinCodeAlphabet)]
+		}
+		code := string(b[:])
+		if findJoinCodeArea(code) == nil {
+			return code, nil
+		}
+	}
+}
+
+// findJoinCodeArea returns the area holding code, or nil if none does.
+func findJoinCodeArea(code string) *area.Area {
+	for _, a := range areas {
+		if a.HasJoinCode(code) {
+			return a
+		}
+	}
+	return nil
+}
+
+// startJoinCodeCleanup periodically drops expired join codes so areas whose
+// codes are never redeemed don't hold on to them. Runs for the lifetime of the
+// server process.
+func startJoinCodeCleanup() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for range ticker.C {
+		now := time.Now()
+		for _, a := range areas {
+			a.PruneJoinCodes(now)
+		}
+	}
+}
+
+// Handles /invitecode
+
+func cmdInviteCode(client *Client, args []string, usage string) {
+	flags := flag.NewFlagSet("", 0)
+	flags.SetOutput(io.Discard)
+	uses := flags.Int("n", 1, "")
+	minutes := flags.Int("t", joinCodeDefaultTTL, "")
+	if err := flags.Parse(args); err != nil || flags.NArg() != 0 {
+		client.SendServerMessage("Invalid arguments:\n" + usage)
+		return
+	}
+	if *uses < 1 || *uses > joinCodeMaxUses {
+		client.SendServerMessage(fmt.Sprintf("Uses must be between 1 and %d.", joinCodeMaxUses))
+		return
+	}
+	if *minutes < 1 || *minutes > joinCodeMaxTTL {
+		client.SendServerMessage(fmt.Sprintf("Duration must be between 1 and %d minutes.", joinCodeMaxTTL))
+		return
+	}
+	a := client.Area()
+	if a.Lock() == area.LockFree {
+		client.SendServerMessage("This area is unlocked, so anyone can already join. Lock it with /lock first.")
+		return
+	}
+	code, err := generateJoinCode()
+	if err != nil {
+		logger.LogErrorf("Failed to generate join code: %v", err)
+		client.SendServerMessage("Failed to generate a join code.")
+		return
+	}
+	a.AddJoinCode(code, *uses, time.Now().Add(time.Duration(*minutes)*time.Minute))
+	client.SendServerMessage(fmt.Sprintf("Join code for %v: %v (%d use(s), expires in %d minute(s)). Players can redeem it with /join %v.",
+		a.Name(), code, *uses, *minutes, code))
+	addToBuffer(client, "CMD", fmt.Sprintf("Created a join code with %d use(s) for %d minute(s).", *uses, *minutes), false)
+}
+
+// Handles /join
+
+func cmdJoin(client *Client, args []string, _ string) {
+	code := strings.ToUpper(strings.TrimSpace(args[0]))
+	a := findJoinCodeArea(code)
+	if a == nil || !a.RedeemJoinCode(code, client.Uid(), time.Now()) {
+		client.SendServerMessage("That join code is invalid or has expired.")
+		return
+	}
+	addToBuffer(client, "CMD", fmt.Sprintf("Redeemed a join code for %v.", a.Name()), false)
+	if client.Area() == a {
+		client.SendServerMessage(fmt.Sprintf("You are now on the invite list for %v.", a.Name()))
+		return
+	}
+	if !client.ChangeArea(a) {
+		client.SendServerMessage(fmt.Sprintf("You are now on the invite list for %v, but could not be moved there.", a.Name()))
+		return
+	}
+	client.SendServerMessage(fmt.Sprintf("Moved to %v.", a.Name()))
+}
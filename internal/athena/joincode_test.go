@@ -0,0 +1,59 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+func TestInviteCodeRedeem(t *testing.T) {
+	newTestClients(t)
+	room := makeTestArea("Courtroom")
+	restore := setupTestAreas([]*area.Area{room})
+	t.Cleanup(restore)
+
+	cmConn := &captureConn{}
+	cm := &Client{conn: cmConn, uid: 1, char: -1, area: room, perms: permissions.PermissionField["CM"]}
+	guestConn := &captureConn{}
+	guest := &Client{conn: guestConn, uid: 2, char: -1, area: room}
+
+	cmdInviteCode(cm, nil, "")
+	if !strings.Contains(cmConn.String(), "This area is unlocked") {
+		t.Fatalf("expected /invitecode to refuse an unlocked area; got %q", cmConn.String())
+	}
+
+	room.SetLock(area.LockLocked)
+	cmdInviteCode(cm, nil, "")
+	code := regexp.MustCompile(`/join ([A-Z0-9]+)`).FindStringSubmatch(cmConn.String())
+	if code == nil {
+		t.Fatalf("no join code in /invitecode output: %q", cmConn.String())
+	}
+
+	cmdJoin(guest, []string{strings.ToLower(code[1])}, "")
+	if !room.HasInvited(guest.Uid()) {
+		t.Errorf("expected /join to add the guest to the invite list; got %q", guestConn.String())
+	}
+	cmdJoin(guest, []string{code[1]}, "")
+	if !strings.Contains(guestConn.String(), "invalid or has expired") {
+		t.Errorf("expected a single-use code to be refused the second time; got %q", guestConn.String())
+	}
+}
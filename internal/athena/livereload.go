@@ -19,6 +19,7 @@ package athena
 import (
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -296,6 +297,14 @@ func ReloadConfig() (string, error) {
 		havePunishNames = true
 	}
 
+	var newLocales map[string]map[string]string
+	if config != nil {
+		newLocales, err = settings.LoadLocales(config.Locales)
+		if err != nil {
+			return "", fmt.Errorf("locales: %w", err)
+		}
+	}
+
 	// --- Phase 2: publish. These are atomic stores; readers see old-or-new, never
 	// a torn value.
 	var changes []string
@@ -339,6 +348,11 @@ func ReloadConfig() (string, error) {
 		changes = append(changes, "8ball.txt")
 	}
 
+	if config != nil && !reflect.DeepEqual(getLocales(), newLocales) {
+		setLocales(newLocales)
+		changes = append(changes, "locales")
+	}
+
 	if haveBanned && !equalStrSlices(getBannedWords(), newBanned) {
 		setBannedWords(newBanned)
 		changes = append(changes, "banned_words.txt")
@@ -17,6 +17,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package athena
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 )
@@ -125,3 +126,40 @@ func TestSetBackgroundsUpdatesListStr(t *testing.T) {
 		}
 	}
 }
+
+// TestBgListPages checks /bglist pages long lists and rejects pages past the
+// end, while a short list is still served from the cached string.
+func TestBgListPages(t *testing.T) {
+	origBg := getBackgrounds()
+	t.Cleanup(func() { setBackgrounds(origBg) })
+	a := newTestArea()
+	conn := &captureConn{}
+	client := &Client{conn: conn, uid: 1, area: a}
+
+	setBackgrounds([]string{"court", "forest"})
+	cmdBgList(client, nil, "usage")
+	if out := conn.String(); !strings.Contains(out, "court") || strings.Contains(out, "Page") {
+		t.Errorf("short list should be sent whole, got %q", out)
+	}
+
+	bgs := make([]string, bgListPageSize+5)
+	for i := range bgs {
+		bgs[i] = fmt.Sprintf("bg%03d", i)
+	}
+	setBackgrounds(bgs)
+	before := len(conn.String())
+	cmdBgList(client, nil, "usage")
+	if out := conn.String()[before:]; !strings.Contains(out, "Page 1/2") || !strings.Contains(out, "bg000") || strings.Contains(out, "bg050") {
+		t.Errorf("page 1 = %q, want only the first %d backgrounds", out, bgListPageSize)
+	}
+	before = len(conn.String())
+	cmdBgList(client, []string{"2"}, "usage")
+	if out := conn.String()[before:]; !strings.Contains(out, "bg054") || strings.Contains(out, "bg049") {
+		t.Errorf("page 2 = %q, want the remaining backgrounds", out)
+	}
+	before = len(conn.String())
+	cmdBgList(client, []string{"3"}, "usage")
+	if out := conn.String()[before:]; !strings.Contains(out, "No page 3") {
+		t.Errorf("page past the end = %q, want a refusal", out)
+	}
+}
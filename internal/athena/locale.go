@@ -0,0 +1,140 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// defaultMessages is the built-in English catalog. Every localized message
+// must have an entry here; it is the fallback whenever a catalog lacks a
+// translation.
+var defaultMessages = map[string]string{
+	"muted":               "You have been muted from %v",
+	"muted_for_seconds":   " for %v seconds",
+	"muted_reason":        " for reason: %v",
+	"muted_with_reason":   "You have been muted. Reason: %v",
+	"muted_automod":       "You have been muted for prohibited language.",
+	"unmuted":             "You have been unmuted.",
+	"moved_to":            "You were moved to %v.",
+	"moved_to_by_mod":     "You were moved to %v by a moderator.",
+	"moved_out_by_mod":    "You were moved out of %v by a moderator.",
+	"moved_to_charselect": "You were moved back to character select.",
+	"ban_notice":          "%v\nUntil: %v\nID: %v",
+	"ban_notice_no_id":    "%v\nUntil: %v",
+	"banned_with_reason":  "You have been banned. Reason: %v",
+	"banned_flood":        "You have been banned for packet flooding.",
+	"kicked_with_reason":  "You have been kicked. Reason: %v",
+	"kicked_spam":         "You have been kicked for spamming.",
+	"lang_current":        "Your language is %v. Available: %v.",
+	"lang_set":            "Language set to %v.",
+	"lang_unknown":        "Unknown language %v. Available: %v.",
+}
+
+// localesPtr holds the catalogs loaded from the locales directory, keyed by
+// locale code. Like the other reloadable lists it is swapped atomically.
+var localesPtr atomic.Pointer[map[string]map[string]string]
+
+func getLocales() map[string]map[string]string {
+	if v := localesPtr.Load(); v != nil {
+		return *v
+	}
+	return nil
+}
+
+func setLocales(l map[string]map[string]string) { localesPtr.Store(&l) }
+
+// defaultLocale returns the configured default_locale.
+func defaultLocale() string {
+	if config == nil || config.DefaultLocale == "" {
+		return "en"
+	}
+	return strings.ToLower(config.DefaultLocale)
+}
+
+// availableLocales lists English plus every loaded catalog, sorted.
+func availableLocales() []string {
+	codes := []string{"en"}
+	for code := range getLocales() {
+		if code != "en" {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// lookupMessage finds the template for key in the given locale, or in
+// default_locale when the player has not picked one, falling back to the
+// built-in English. Unknown keys return the key itself so a missing entry is
+// visible rather than silent.
+func lookupMessage(locale, key string) string {
+	if locale == "" {
+		locale = defaultLocale()
+	}
+	if t, ok := getLocales()[locale][key]; ok {
+		return t
+	}
+	if t, ok := defaultMessages[key]; ok {
+		return t
+	}
+	return key
+}
+
+// localize renders the message for key in the given locale.
+func localize(locale, key string, args ...interface{}) string {
+	t := lookupMessage(locale, key)
+	if len(args) == 0 {
+		return t
+	}
+	return fmt.Sprintf(t, args...)
+}
+
+// Localize renders the message for key in the client's locale.
+func (client *Client) Localize(key string, args ...interface{}) string {
+	return localize(client.Locale(), key, args...)
+}
+
+// SendLocalized sends the message for key as a server message in the
+// client's locale.
+func (client *Client) SendLocalized(key string, args ...interface{}) {
+	client.SendServerMessage(client.Localize(key, args...))
+}
+
+// Handles /lang
+
+func cmdLang(client *Client, args []string, _ string) {
+	available := strings.Join(availableLocales(), ", ")
+	if len(args) == 0 {
+		current := client.Locale()
+		if current == "" {
+			current = defaultLocale()
+		}
+		client.SendLocalized("lang_current", current, available)
+		return
+	}
+	code := strings.ToLower(args[0])
+	if _, ok := getLocales()[code]; !ok && code != "en" {
+		client.SendLocalized("lang_unknown", code, available)
+		return
+	}
+	client.SetLocale(code)
+	client.SendLocalized("lang_set", code)
+}
@@ -0,0 +1,118 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func setTestLocales(t *testing.T, l map[string]map[string]string, def string) {
+	t.Helper()
+	origLocales := getLocales()
+	origConfig := config
+	t.Cleanup(func() {
+		setLocales(origLocales)
+		config = origConfig
+	})
+	setLocales(l)
+	config = &settings.Config{}
+	config.DefaultLocale = def
+}
+
+func TestLocalizeFallback(t *testing.T) {
+	setTestLocales(t, map[string]map[string]string{
+		"es": {"unmuted": "Ya no estás silenciado.", "moved_to": "Has sido movido a %v."},
+	}, "en")
+
+	c := &Client{conn: &testConn{}}
+	if got := c.Localize("unmuted"); got != "You have been unmuted." {
+		t.Errorf("default locale: got %q", got)
+	}
+
+	c.SetLocale("es")
+	if got := c.Localize("moved_to", "Lobby"); got != "Has sido movido a Lobby." {
+		t.Errorf("translated message: got %q", got)
+	}
+	if got := c.Localize("kicked_spam"); got != defaultMessages["kicked_spam"] {
+		t.Errorf("key missing from catalog should fall back to English, got %q", got)
+	}
+	if got := c.Localize("no_such_key"); got != "no_such_key" {
+		t.Errorf("unknown key: got %q", got)
+	}
+}
+
+func TestLocalizeDefaultLocale(t *testing.T) {
+	setTestLocales(t, map[string]map[string]string{
+		"es": {"unmuted": "Ya no estás silenciado."},
+	}, "es")
+
+	c := &Client{conn: &testConn{}}
+	if got := c.Localize("unmuted"); got != "Ya no estás silenciado." {
+		t.Errorf("players without /lang should get default_locale, got %q", got)
+	}
+	c.SetLocale("en")
+	if got := c.Localize("unmuted"); got != "You have been unmuted." {
+		t.Errorf("/lang en should override default_locale, got %q", got)
+	}
+}
+
+func TestCmdLang(t *testing.T) {
+	setTestLocales(t, map[string]map[string]string{"es": {"lang_set": "Idioma cambiado a %v."}}, "en")
+
+	conn := &captureConn{}
+	c := &Client{conn: conn}
+	cmdLang(c, []string{"xx"}, "")
+	if c.Locale() != "" || !strings.Contains(conn.String(), "Unknown language xx") {
+		t.Fatalf("unknown locale should be rejected, locale=%q out=%q", c.Locale(), conn.String())
+	}
+	cmdLang(c, []string{"ES"}, "")
+	if c.Locale() != "es" || !strings.Contains(conn.String(), "Idioma cambiado a es.") {
+		t.Fatalf("/lang ES should switch to es, locale=%q out=%q", c.Locale(), conn.String())
+	}
+}
+
+// TestSampleLocales checks that the shipped catalogs only use known keys and
+// keep the same number of placeholders as the English templates.
+func TestSampleLocales(t *testing.T) {
+	dir, err := filepath.Abs("../../config_sample/locales")
+	if err != nil {
+		t.Fatal(err)
+	}
+	catalogs, err := settings.LoadLocales(dir)
+	if err != nil {
+		t.Fatalf("LoadLocales: %v", err)
+	}
+	if len(catalogs) < 2 {
+		t.Fatalf("expected at least two sample catalogs, got %d", len(catalogs))
+	}
+	for code, catalog := range catalogs {
+		for key, tmpl := range catalog {
+			en, ok := defaultMessages[key]
+			if !ok {
+				t.Errorf("%v: unknown key %q", code, key)
+				continue
+			}
+			if strings.Count(tmpl, "%v") != strings.Count(en, "%v") {
+				t.Errorf("%v: %q has a different number of placeholders than English", code, key)
+			}
+		}
+	}
+}
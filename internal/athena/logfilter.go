@@ -0,0 +1,76 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+)
+
+// Limits for regex log searches. Go's regexp engine already runs in linear
+// time, but an unbounded pattern (e.g. nested counted repeats) can still
+// compile into a huge program, so both the pattern and the compiled program
+// are capped.
+const (
+	maxLogRegexLen   = 256
+	maxLogRegexInsts = 5000
+)
+
+// compileLogFilter builds the matcher used by /log and the audit-log search.
+// By default the query is split on whitespace and a line matches only if it
+// contains every term, case-insensitively. With useRegex the whole query is a
+// case-insensitive regular expression instead. An empty query matches
+// everything.
+func compileLogFilter(query string, useRegex bool) (func(string) bool, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return func(string) bool { return true }, nil
+	}
+	if !useRegex {
+		terms := strings.Fields(strings.ToLower(query))
+		return func(line string) bool {
+			line = strings.ToLower(line)
+			for _, term := range terms {
+				if !strings.Contains(line, term) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	}
+	if len(query) > maxLogRegexLen {
+		return nil, fmt.Errorf("regex is too long (max %d characters)", maxLogRegexLen)
+	}
+	parsed, err := syntax.Parse(query, syntax.Perl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %v", err)
+	}
+	prog, err := syntax.Compile(parsed.Simplify())
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %v", err)
+	}
+	if len(prog.Inst) > maxLogRegexInsts {
+		return nil, fmt.Errorf("regex is too complex")
+	}
+	re, err := regexp.Compile("(?i)" + query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %v", err)
+	}
+	return re.MatchString, nil
+}
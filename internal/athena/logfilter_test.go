@@ -0,0 +1,63 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompileLogFilterTerms(t *testing.T) {
+	match, err := compileLogFilter("ban ModX", false)
+	if err != nil {
+		t.Fatalf("compileLogFilter: %v", err)
+	}
+	tests := []struct {
+		line string
+		want bool
+	}{
+		{"[CMD] modx: Banned 3 (Courtroom)", true},
+		{"[CMD] ModX: Kicked 3 (Courtroom)", false},
+		{"[CMD] ModY: Banned 3 (Courtroom)", false},
+	}
+	for _, tt := range tests {
+		if got := match(tt.line); got != tt.want {
+			t.Errorf("match(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+
+	all, err := compileLogFilter("  ", false)
+	if err != nil || !all("anything") {
+		t.Error("expected an empty filter to match every line")
+	}
+}
+
+func TestCompileLogFilterRegex(t *testing.T) {
+	match, err := compileLogFilter(`banned \d+ \(court`, true)
+	if err != nil {
+		t.Fatalf("compileLogFilter: %v", err)
+	}
+	if !match("[CMD] ModX: Banned 3 (Courtroom)") || match("[CMD] ModX: Banned all (Courtroom)") {
+		t.Error("regex filter did not match as expected")
+	}
+
+	for _, bad := range []string{"ban(", strings.Repeat("a", maxLogRegexLen+1), "((a{100}){100}){100}"} {
+		if _, err := compileLogFilter(bad, true); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
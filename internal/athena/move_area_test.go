@@ -0,0 +1,63 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestMoveFromAreaConsolidates(t *testing.T) {
+	newTestClients(t)
+	origAreas, origConfig := areas, config
+	t.Cleanup(func() { areas = origAreas; config = origConfig })
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	courtA := area.NewArea(area.AreaData{Name: "Court A"}, 5, 10, area.EviAny)
+	courtB := area.NewArea(area.AreaData{Name: "Court B"}, 5, 10, area.EviAny)
+	areas = []*area.Area{lobby, courtA, courtB}
+	config = &settings.Config{}
+	courtB.SetLock(area.LockLocked)
+
+	newClient := func(uid int, a *area.Area) *Client {
+		c := &Client{conn: &captureConn{}, uid: uid, ipid: "ip-" + strconv.Itoa(uid), char: -1, forcePairUID: -1, possessing: -1, jailAreaID: -1, pair: ClientPairInfo{wanted_id: -1}}
+		c.SetArea(a)
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+		return c
+	}
+	mod := newClient(1, lobby)
+	mod.SetPerms(permissions.PermissionField["MOVE_USERS"])
+	p1, p2 := newClient(2, courtA), newClient(3, courtA)
+	bystander := newClient(4, lobby)
+
+	cmdMove(mod, []string{"-f", "1", "2"}, "")
+	if p1.Area() != courtB || p2.Area() != courtB {
+		t.Fatal("everyone in the source area should land in the locked destination")
+	}
+	if bystander.Area() != lobby || mod.Area() != lobby {
+		t.Error("clients outside the source area must not move")
+	}
+
+	cmdMove(mod, []string{"-f", "0", "1"}, "")
+	if bystander.Area() != lobby {
+		t.Error("area 0 must not be usable as a bulk-move source")
+	}
+}
@@ -19,6 +19,9 @@ package athena
 import (
 	"strings"
 	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
 )
 
 // TestBuildSMPacketEncodesMusicNames verifies that music names with AO2-special
@@ -116,3 +119,43 @@ func TestMusicSpecialCharsInSMPacket(t *testing.T) {
 		t.Errorf("expected %d fields in SM packet, got %d; packet = %q", want, len(fields), pkt)
 	}
 }
+
+// TestAreaMusicListLayersOverGlobal verifies that an area's own tracks are
+// appended to the global list without duplicates, that areas without a list
+// use the global one, and that jukebox plays are checked against the union.
+func TestAreaMusicListLayersOverGlobal(t *testing.T) {
+	origMusic := getMusicList()
+	t.Cleanup(func() { setMusicList(origMusic) })
+	setMusicList([]string{"Global", "shared.opus"})
+
+	plain := area.NewArea(area.AreaData{Name: "Plain"}, 4, 50, area.EviAny)
+	if got := areaMusicList(plain); strings.Join(got, "|") != "Global|shared.opus" {
+		t.Errorf("an area without its own music should use the global list, got %v", got)
+	}
+	casing := area.NewArea(area.AreaData{Name: "Casing", Music: []string{"Case 3", "shared.opus", "cornered.opus"}}, 4, 50, area.EviAny)
+	if got := areaMusicList(casing); strings.Join(got, "|") != "Global|shared.opus|Case 3|cornered.opus" {
+		t.Errorf("areaMusicList = %v, want the global list followed by the area's new tracks", got)
+	}
+
+	client, conn := newMusicTestClient(t)
+	pktAM(client, &packet.Packet{Header: "MC", Body: []string{"cornered.opus", "0"}})
+	if strings.Contains(conn.String(), "MC#cornered.opus") {
+		t.Fatalf("an area-only track should not play outside its area, got %q", conn.String())
+	}
+
+	sendAreaMusic(client, plain, casing)
+	if !strings.Contains(conn.String(), "FM#Global#shared.opus#Case 3#cornered.opus#%") {
+		t.Errorf("entering the area should send its combined list, got %q", conn.String())
+	}
+	client.SetArea(casing)
+	pktAM(client, &packet.Packet{Header: "MC", Body: []string{"cornered.opus", "0"}})
+	if !strings.Contains(conn.String(), "MC#cornered.opus") {
+		t.Errorf("an area-only track should play in its area, got %q", conn.String())
+	}
+
+	prev := len(conn.String())
+	sendAreaMusic(client, plain, plain)
+	if conn.String()[prev:] != "" {
+		t.Errorf("moving between areas without their own music should not resend the list, got %q", conn.String()[prev:])
+	}
+}
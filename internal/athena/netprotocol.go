@@ -26,6 +26,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/MangosArentLiterature/Athena/internal/area"
@@ -180,7 +181,7 @@ func pktId(client *Client, _ *packet.Packet) {
 	// about the wire format.
 	client.Send(&packet.PN{
 		PlayerCount:       players.GetPlayerCount(),
-		MaxPlayers:        config.MaxPlayers,
+		MaxPlayers:        serverCapacity(config.MaxPlayers, config.MaxSpectators),
 		ServerDescription: encode(GetServerDesc()),
 	})
 	client.Send(&packet.FL{Features: []string{
@@ -201,7 +202,7 @@ func pktResCount(client *Client, _ *packet.Packet) {
 	if client.Uid() != -1 || client.Hdid() == "" {
 		return
 	}
-	if players.GetPlayerCount() >= config.MaxPlayers {
+	if players.GetPlayerCount() >= serverCapacity(config.MaxPlayers, config.MaxSpectators) {
 		logger.LogInfo("Player limit reached")
 		client.SendSync(&packet.BD{Reason: "This server is currently full."})
 		client.conn.Close()
@@ -267,11 +268,13 @@ func pktReqDone(client *Client, _ *packet.Packet) {
 	clients.RegisterUID(client)
 	client.SetConnectedAt(time.Now())
 	client.lastPingNano.Store(time.Now().UnixNano()) // seed so the ping timeout window starts from join time
+	client.TouchActivity()
 	players.AddPlayer()
 	if config.Advertise {
 		updatePlayers <- players.GetPlayerCount()
 	}
 	client.JoinArea(areas[0])
+	sendAreaMusic(client, nil, areas[0])
 	client.Send(&packet.DONE{})
 	// Send BN after DONE so WebAO's viewport is fully initialized before the
 	// background and desk-overlay images are loaded.  Akashi follows the same
@@ -293,8 +296,9 @@ func pktReqDone(client *Client, _ *packet.Packet) {
 	client.Send(&packet.IDClient{PlayerNumber: client.Uid(), Software: "Nyathena", Version: encode(version)})
 	sendPlayerListToClient(client)
 	broadcastPlayerJoin(client)
-	if motd := GetMotd(); motd != "" {
-		client.SendMotd(motd)
+	sendWelcome(client)
+	if characterSlotsFull() && !permissions.IsModerator(client.Perms()) {
+		client.SendServerMessage(fmt.Sprintf("All %d player slots are taken — you've joined as a spectator. You can pick a character once a slot frees up.", config.MaxPlayers))
 	}
 	client.restorePunishments()
 	client.restoreRandomCharCurse()
@@ -328,6 +332,24 @@ func pktReqDone(client *Client, _ *packet.Packet) {
 	}
 }
 
+// sendWelcome sends the onboarding sequence to a player who has just finished
+// joining: the MOTD, then the configured rules link and /help nudge. Blank
+// entries are skipped. Clients without a UID have not joined yet and get
+// nothing.
+func sendWelcome(client *Client) {
+	if client.Uid() == -1 {
+		return
+	}
+	if motd := GetMotd(); motd != "" {
+		client.SendMotd(motd)
+	}
+	for _, line := range []string{config.WelcomeRules, config.WelcomeHelp} {
+		if line = strings.TrimSpace(line); line != "" {
+			client.SendServerMessage(line)
+		}
+	}
+}
+
 // getRandomFreeChar returns a random free character ID in the client's area,
 // or -1 if no characters are available.
 func getRandomFreeChar(client *Client) int {
@@ -373,7 +395,120 @@ func pktChangeChar(client *Client, p *packet.Packet) {
 		client.SendServerMessage("You have been tunged and cannot change characters until the effect is removed.")
 		return
 	}
+	if client.characterSlotBlocked() {
+		return
+	}
 	client.ChangeCharacter(newid)
+	if client.CharID() == newid {
+		reportJoin(client)
+	}
+}
+
+// interjectionAllowed applies interjection_cooldown to IC messages carrying a
+// shout (objection != 0). Messages without a shout are always allowed and do
+// not touch the cooldown.
+func interjectionAllowed(client *Client, objection int) bool {
+	if objection == 0 || config.InterjectionCooldown <= 0 {
+		return true
+	}
+	ok, _ := client.CheckAndUpdateInterjectionCooldown(time.Duration(config.InterjectionCooldown) * time.Second)
+	return ok
+}
+
+// maxOOCNameLen is the longest OOC name, in bytes, the server accepts.
+const maxOOCNameLen = 30
+
+// oocNameError validates an OOC name and returns the message to send back
+// when it is rejected, or "" when it is acceptable. Names must be non-empty,
+// at most maxOOCNameLen bytes, free of brackets (which would fake the [UID]
+// prefix) and of control or invisible formatting characters, and must not
+// match the server name, "Server" or any entry in reserved_ooc_names.
+func oocNameError(name string) string {
+	if name == "" || strings.ContainsAny(name, "[]") {
+		return "Invalid username."
+	}
+	if len(name) > maxOOCNameLen {
+		return fmt.Sprintf("Your OOC name must be at most %d characters.", maxOOCNameLen)
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) || unicode.Is(unicode.Cf, r) {
+			return "Your OOC name cannot contain control or invisible characters."
+		}
+	}
+	reserved := append([]string{config.Name, "Server"}, config.ReservedOOCNames...)
+	for _, r := range reserved {
+		if r != "" && strings.EqualFold(name, strings.TrimSpace(r)) {
+			return "That OOC name is reserved."
+		}
+	}
+	return ""
+}
+
+// oocRenameAllowed applies ooc_name_cooldown when a client switches to a
+// different OOC name. Picking a first name, or sending under the current
+// one, is always allowed and does not touch the cooldown.
+func oocRenameAllowed(client *Client, name string) (bool, time.Duration) {
+	current := client.OOCName()
+	if current == "" || current == name || config.OOCNameCooldown <= 0 {
+		return true, 0
+	}
+	return client.CheckAndUpdateOOCRenameCooldown(time.Duration(config.OOCNameCooldown) * time.Second)
+}
+
+// throttleScreenEffects applies effect_cooldown to IC messages that shake or
+// flash the screen (screenshake, realization). Inside the window the effects
+// are stripped and the message is delivered as plain text, so rapid-fire
+// flashing can't be used to strobe an area while ordinary talking is never
+// held up. It returns true when effects were stripped.
+func throttleScreenEffects(client *Client, ms *packet.MSPacket) bool {
+	if config.EffectCooldown <= 0 || (ms.Screenshake != "1" && ms.Realization != "1") {
+		return false
+	}
+	if client.CheckAndUpdateEffectCooldown(time.Duration(config.EffectCooldown) * time.Second) {
+		return false
+	}
+	ms.Screenshake = "0"
+	ms.Realization = "0"
+	return true
+}
+
+// icIdentityValid checks that an IC message speaks as the sender's own
+// character slot. char_id must match the sender's slot exactly; a different
+// character name is only accepted as an iniswap, which the area must allow and
+// /charstuck must not forbid. Offending messages are dropped with a notice to
+// the sender. Possession and forced iniswap rewrite these fields server-side
+// and do not go through this check.
+func icIdentityValid(client *Client, ms *packet.MSPacket) bool {
+	ownChar := getCharacters()[client.CharID()]
+	if !strings.EqualFold(ownChar, ms.Character) && !client.Area().IniswapAllowed() {
+		client.SendServerMessage("Iniswapping is not allowed in this area.")
+		return false
+	}
+	if stuck := client.charStuckID(); stuck >= 0 && !strings.EqualFold(getCharacters()[stuck], ms.Character) {
+		client.SendServerMessage(fmt.Sprintf("You are character stuck as %v and cannot iniswap.", getCharacters()[stuck]))
+		return false
+	}
+	if ms.CharID != client.CharIDStr() {
+		logger.LogWarningf("dropped MS from IPID:%v UID:%v — CharID mismatch; packet=%q client=%q", client.Ipid(), client.Uid(), ms.CharID, client.CharIDStr())
+		return false
+	}
+	return true
+}
+
+// parsePairCharID validates an MS other_charid field of the form "<id>" or
+// "<id>^<order>" and returns the pair character ID. The ID must name an
+// existing character other than the sender's own, and the optional order
+// suffix may only be "0" (in front) or "1" (behind).
+func parsePairCharID(client *Client, otherCharID string) (int, bool) {
+	pidStr, order, hasOrder := strings.Cut(otherCharID, "^")
+	if hasOrder && order != "0" && order != "1" {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(pidStr)
+	if err != nil || pid < 0 || pid >= len(getCharacters()) || pid == client.CharID() {
+		return 0, false
+	}
+	return pid, true
 }
 
 // Handles MS#%
@@ -390,9 +525,18 @@ func pktIC(client *Client, p *packet.Packet) {
 		client.SendServerMessage("You are not allowed to speak in this area.")
 		return
 	}
+	if chatLockdownBlocks(client, chatIC) {
+		client.SendServerMessage(chatLockdownNotice("IC chat is locked down by the moderators."))
+		return
+	}
 
-	// Sending an IC message counts as activity for the opt-in /dc idle timer.
+	// Sending an IC message counts as activity for the opt-in /dc idle timer
+	// and the server-wide idle kick, and brings an /away player back.
 	client.dcTouchActivity()
+	client.TouchActivity()
+	if client.ClearAway() {
+		client.SendServerMessage("👋 Welcome back! You are no longer marked as away.")
+	}
 
 	// Decode the wire-form client packet body into the structured MSPacket
 	// type exactly once. From this point on the IC pipeline operates on named
@@ -400,6 +544,12 @@ func pktIC(client *Client, p *packet.Packet) {
 	// The reverse encode happens once at the bottom via ms.ServerArgs().
 	ms := packet.ParseMSClient(p.Body)
 
+	// Strip or reject raw control bytes and invalid UTF-8 before anything else
+	// reads the text (see chat_sanitize.go).
+	if !sanitizeChatFields(client, &ms.Message, &ms.Showname) {
+		return
+	}
+
 	// /truepossess silences its target: their own IC is echoed back to them but
 	// reaches nobody, and their showname is frozen so they can't expose the
 	// possession. Gated by the atomic counter so servers not using it pay only a
@@ -536,12 +686,56 @@ func pktIC(client *Client, p *packet.Packet) {
 		client.SetPos(ms.Side)
 	}
 
+	// Caps filter: an area rule (set with /capsfilter) that lowercases or
+	// refuses mostly-uppercase IC. Runs on the speaker's own text, before any
+	// punishment transforms, so /uppercase and friends still work as usual.
+	if ms.Message != "" {
+		if f := client.Area().CapsFilter(); f != area.CapsOff && isShouting(decode(ms.Message), config.CapsFilterRatio, config.CapsFilterMinLength) {
+			if f == area.CapsReject {
+				client.SendServerMessage("Please don't shout — this area filters ALL-CAPS messages.")
+				return
+			}
+			ms.Message = encode(strings.ToLower(decode(ms.Message)))
+		}
+	}
+
+	// Per-area length cap (/maxlen). Checked against the speaker's own text,
+	// before punishment transforms can lengthen it; the server-wide
+	// max_message_length is still enforced on the final text further down.
+	if limit := client.Area().MaxMsgLen(); limit > 0 && ms.Message != "" {
+		if n := utf8.RuneCountInString(decode(ms.Message)); n > limit {
+			client.SendServerMessage(fmt.Sprintf("Your message is %d characters; this area allows at most %d.", n, limit))
+			return
+		}
+	}
+
 	// Check for expired punishments and collect the still-active ones in a single
 	// lock acquisition (avoids a second mutex cycle + second time.Now() call).
 	expired, punishments := client.CheckExpiredAndGetPunishments()
 	if expired {
 		client.SendServerMessage("One or more punishments have expired.")
 	}
+	if !punishmentTimingAllows(client, punishments, time.Now()) {
+		return
+	}
+	// /roulette drops the message without telling the sender, so they are
+	// left wondering whether it went through.
+	if hasPunishmentType(punishments, PunishmentRoulette) && rouletteDrops() {
+		return
+	}
+	if ms.Message != "" && hasPunishmentType(punishments, PunishmentEssay) {
+		if text, suppress := applyEssay(decode(ms.Message)); suppress {
+			client.SendServerMessage(fmt.Sprintf("Your message must be at least %d characters (you sent %d).",
+				essayMinLength, utf8.RuneCountInString(text)))
+			return
+		}
+	}
+	if ms.Message != "" && hasPunishmentType(punishments, PunishmentHaiku) {
+		if ok, counts := validateHaiku(decode(ms.Message)); !ok {
+			client.SendServerMessage(haikuRejection(counts))
+			return
+		}
+	}
 
 	// Capture the original decoded message before any punishment transforms so
 	// it can be (a) used for icwarp backlog history recording and (b) skipped
@@ -572,6 +766,12 @@ func pktIC(client *Client, p *packet.Packet) {
 			} else if p.punishmentType == PunishmentClickbait {
 				// Clickbait headlines star the speaker by name.
 				modifiedMsg = applyClickbaitWithName(decodedMsg, clientDisplayName(client))
+			} else if p.punishmentType == PunishmentDrunk {
+				// /drunk -i stores its intensity on the punishment.
+				modifiedMsg = applyDrunkIntensity(decodedMsg, drunkIntensity(p.customData))
+			} else if p.punishmentType == PunishmentZalgo {
+				// /zalgo -l stores its intensity on the punishment.
+				modifiedMsg = applyZalgo(decodedMsg, zalgoIntensity(p.customData))
 			} else if p.punishmentType == PunishmentMarkov {
 				// Markov babble is generated from this area's recent chat history.
 				modifiedMsg = applyMarkov(decodedMsg, client.Area())
@@ -685,7 +885,7 @@ func pktIC(client *Client, p *packet.Packet) {
 
 		// Handle name modifications
 		if p.punishmentType == PunishmentEmoji {
-			ms.Message = GetRandomEmoji()
+			ms.Showname = encode(emojiShowname(client))
 		}
 		if p.punishmentType == PunishmentUncannyValley {
 			name := ms.Showname
@@ -801,10 +1001,6 @@ func pktIC(client *Client, p *packet.Packet) {
 	// Decode the message text once; reused for length validation, testimony navigation, and automod.
 	msgText := decode(ms.Message)
 
-	// Single lock to obtain the stuck character ID; -1 means not stuck.
-	// Used in both iniswap cases below to avoid redundant mutex acquisitions.
-	stuckCharID := client.charStuckID()
-
 	// DeskMod "chat" is a legacy alias for "1"; rewrite to match Akashi.
 	if ms.DeskMod == "chat" {
 		ms.DeskMod = "1"
@@ -814,11 +1010,7 @@ func pktIC(client *Client, p *packet.Packet) {
 	case !sliceutil.ContainsString(validDeskMods, ms.DeskMod):
 		logger.LogWarningf("dropped MS from IPID:%v UID:%v — DeskMod not in validDeskMods; value=%q", client.Ipid(), client.Uid(), ms.DeskMod)
 		return
-	case !isPossessing && !hasForcedIniswap && !strings.EqualFold(getCharacters()[client.CharID()], ms.Character) && !client.Area().IniswapAllowed(): // character name (skip check when possessing or forced iniswap)
-		client.SendServerMessage("Iniswapping is not allowed in this area.")
-		return
-	case !isPossessing && !hasForcedIniswap && stuckCharID >= 0 && !strings.EqualFold(getCharacters()[stuckCharID], ms.Character): // block iniswap when charstuck unless forced iniswap
-		client.SendServerMessage(fmt.Sprintf("You are character stuck as %v and cannot iniswap.", getCharacters()[stuckCharID]))
+	case !isPossessing && !hasForcedIniswap && !icIdentityValid(client, ms): // skip check when possessing or forced iniswap
 		return
 	case utf8.RuneCountInString(msgText) > config.MaxMsg:
 		// Count characters (runes), not bytes. len() returns the UTF-8 byte
@@ -831,9 +1023,6 @@ func pktIC(client *Client, p *packet.Packet) {
 	case ms.Message == client.LastMsg():
 		logger.LogWarningf("dropped MS from IPID:%v UID:%v — duplicate of LastMsg", client.Ipid(), client.Uid())
 		return
-	case !isPossessing && !hasForcedIniswap && ms.CharID != client.CharIDStr(): // skip check when possessing or forced iniswap
-		logger.LogWarningf("dropped MS from IPID:%v UID:%v — CharID mismatch; packet=%q client=%q", client.Ipid(), client.Uid(), ms.CharID, client.CharIDStr())
-		return
 	case objection < 0 || objection > 4:
 		logger.LogWarningf("dropped MS from IPID:%v UID:%v — ShoutModifier out of [0,4]; value=%d", client.Ipid(), client.Uid(), objection)
 		return
@@ -866,6 +1055,13 @@ func pktIC(client *Client, p *packet.Packet) {
 		return
 	}
 
+	// Interjections inside interjection_cooldown are dropped without a notice,
+	// so a shout spammer gets no feedback to time their next attempt against.
+	if !interjectionAllowed(client, objection) {
+		return
+	}
+	throttleScreenEffects(client, ms)
+
 	// During possession the pair fields are resolved from the *target's* state,
 	// not the possessor's, so the target's partner renders exactly as it would on
 	// the target's own messages (no "the pair vanished" possess tell). Applies to
@@ -901,12 +1097,9 @@ func pktIC(client *Client, p *packet.Packet) {
 
 		// Pairing validation
 		if ms.OtherCharID != "" && ms.OtherCharID != "-1" {
-			pidStr, _, _ := strings.Cut(ms.OtherCharID, "^")
-			pid, err := strconv.Atoi(pidStr)
-			if err != nil {
-				return
-			}
-			if pid < 0 || pid > len(getCharacters()) || pid == client.CharID() {
+			pid, ok := parsePairCharID(client, ms.OtherCharID)
+			if !ok {
+				logger.LogWarningf("dropped MS from IPID:%v UID:%v — invalid other_charid; value=%q", client.Ipid(), client.Uid(), ms.OtherCharID)
 				return
 			}
 			client.SetPairWantedID(pid)
@@ -1153,10 +1346,11 @@ func pktIC(client *Client, p *packet.Packet) {
 	// hand it to the broadcaster. MSPacket implements packet.Outgoing, so
 	// Args() is invoked once inside broadcastToAreaFrom.
 	//
-	// Two delivery punishments intercept here:
+	// Three delivery punishments intercept here:
 	//   - stealthmute: the packet echoes back to ONLY the sender — they see
 	//     their message appear normally while the room hears nothing.
 	//   - lifo: the packet is buffered and released in reverse arrival order.
+	//   - lag: the packet is held and released with the rest of its batch.
 	//
 	// A censor trip (banned word or censored showname, checked above) is
 	// folded in the same way: the triggering message is echoed back to the
@@ -1180,9 +1374,14 @@ func pktIC(client *Client, p *packet.Packet) {
 		client.Send(ms)
 	case hasPunishmentType(punishments, PunishmentLifo):
 		lifoEnqueueIC(client, ms)
+	case hasPunishmentType(punishments, PunishmentLag):
+		lagEnqueueIC(client, ms)
 	default:
 		broadcastToAreaFrom(client.Ipid(), senderBypassesIgnore(client.Perms()), client.Area(), ms)
 	}
+	if !silenced && hasPunishmentType(punishments, PunishmentSpotlight) {
+		spotlightBroadcast(client, ms)
+	}
 	// SFX curse MC fallback: for external http(s) URLs the sfx_name field alone
 	// is not enough because standard AO2 desktop clients look for a local file
 	// and WebAO concatenates the asset URL with the sound name (producing a
@@ -1207,6 +1406,7 @@ func pktIC(client *Client, p *packet.Packet) {
 	// stealthmuted message never triggers them.
 	if !silenced {
 		punishmentMechanicsOnIC(client, punishments)
+		relayIC(client, ms)
 	}
 	// Log suppressed /truepossess IC with a marker so staff can audit what the
 	// silenced target tried to say (e.g. an attempt to expose the possession).
@@ -1259,7 +1459,7 @@ func pktAM(client *Client, p *packet.Packet) {
 	// In areas with fewer than musicBanQuietAreaThreshold players the ban is
 	// bypassed so banned players can still set the mood in quiet rooms.
 	if !permissions.IsModerator(client.Perms()) && isMusicBanned(client.Ipid()) {
-		if isMusicURL(decodedSong) || sliceutil.ContainsString(getMusicList(), decodedSong) {
+		if isMusicURL(decodedSong) || sliceutil.ContainsString(areaMusicList(client.Area()), decodedSong) {
 			if client.Area().PlayerCount() >= musicBanQuietAreaThreshold {
 				client.SendServerMessage(fmt.Sprintf(
 					"You are music-banned. (In areas with fewer than %d people you may still play music.)",
@@ -1304,7 +1504,7 @@ func pktAM(client *Client, p *packet.Packet) {
 		})
 		return
 	}
-	if sliceutil.ContainsString(getMusicList(), decodedSong) {
+	if sliceutil.ContainsString(areaMusicList(client.Area()), decodedSong) {
 		if !client.CanChangeMusic() {
 			client.SendServerMessage("You are not allowed to change the music in this area.")
 			return
@@ -1436,6 +1636,9 @@ func pktOOC(client *Client, p *packet.Packet) {
 	if err != nil {
 		return
 	}
+	if !sanitizeChatFields(client, &ct.Message, &ct.Name) {
+		return
+	}
 
 	// /truepossess silences the target's OOC entirely (see pktIC for the IC side).
 	// Gated by the atomic counter so servers not using it pay only one atomic load.
@@ -1460,12 +1663,14 @@ func pktOOC(client *Client, p *packet.Packet) {
 		return
 	}
 
-	// A real (non-command) OOC message counts as activity for the /dc idle timer.
+	// A real (non-command) OOC message counts as activity for the /dc idle timer
+	// and the server-wide idle kick.
 	client.dcTouchActivity()
+	client.TouchActivity()
 
 	username := decode(strings.TrimSpace(ct.Name))
-	if username == "" || username == config.Name || len(username) > 30 || strings.ContainsAny(username, "[]") {
-		client.SendServerMessage("Invalid username.")
+	if reason := oocNameError(username); reason != "" {
+		client.SendServerMessage(reason)
 		return
 	}
 	// Automod check on the OOC username itself — slurs in display names are
@@ -1523,6 +1728,10 @@ func pktOOC(client *Client, p *packet.Packet) {
 		client.SendServerMessage("That username is already taken.")
 		return
 	}
+	if ok, remaining := oocRenameAllowed(client, username); !ok {
+		client.SendServerMessage(fmt.Sprintf("You changed your OOC name too recently. Try again in %v.", formatDurationShort(remaining)))
+		return
+	}
 	client.SetOocName(username)
 
 	// Build OOC display name: [Tag] [UID] OOCName.
@@ -1544,6 +1753,10 @@ func pktOOC(client *Client, p *packet.Packet) {
 		client.SendServerMessage("You are muted from speaking in OOC.")
 		return
 	}
+	if chatLockdownBlocks(client, chatOOC) {
+		client.SendServerMessage(chatLockdownNotice("OOC chat is locked down by the moderators."))
+		return
+	}
 	// Check new-IPID OOC cooldown; commands are exempt so new users can still interact with the server.
 	if limited, remaining := checkNewIPIDOOCCooldown(client.Ipid()); limited {
 		unit := "seconds"
@@ -1592,9 +1805,16 @@ func pktOOC(client *Client, p *packet.Packet) {
 		addToBuffer(client, "OOC", "\""+msg+"\" (stealthmuted)", false)
 		return
 	}
+	if client.HasAnyPunishment() {
+		decoded := decode(msg)
+		if out := applyOOCPunishments(client, decoded); out != decoded {
+			msg = encode(out)
+		}
+	}
 	broadcastToAreaFrom(client.Ipid(), senderBypassesIgnore(client.Perms()), client.Area(),
 		&packet.CTToClient{Name: encode(displayUsername), Message: msg, IsFromServer: "0"})
 	addToBuffer(client, "OOC", "\""+msg+"\"", false)
+	triviaOnOOC(client, decode(ct.Message))
 }
 
 // Handles PE#%
@@ -1607,9 +1827,10 @@ func pktAddEvi(client *Client, p *packet.Packet) {
 	if err != nil {
 		return
 	}
-	client.Area().AddEvidence(pe.Name + "&" + pe.Description + "&" + pe.Image)
+	client.Area().AddEvidenceBy(pe.Name+"&"+pe.Description+"&"+pe.Image, client.Ipid())
 	broadcastToArea(client.Area(), &packet.LE{Items: client.Area().Evidence()})
-	addToBuffer(client, "EVI", fmt.Sprintf("Added evidence: %v | %v", pe.Name, pe.Description), false)
+	addToBuffer(client, "EVI", fmt.Sprintf("Added evidence: %v | %v", pe.Name, pe.Description), true)
+	saveEvidenceChange(client.Area())
 }
 
 // Handles DE#%
@@ -1622,9 +1843,18 @@ func pktRemoveEvi(client *Client, p *packet.Packet) {
 	if err != nil {
 		return
 	}
+	evi, owner, ok := client.Area().EvidenceAt(de.ID)
+	if !ok {
+		return
+	}
+	if !client.CanAlterEvidenceItem(owner) {
+		client.SendServerMessage("You can only change evidence you added in this area.")
+		return
+	}
 	client.Area().RemoveEvidence(de.ID)
 	broadcastToArea(client.Area(), &packet.LE{Items: client.Area().Evidence()})
-	addToBuffer(client, "EVI", fmt.Sprintf("Removed evidence %v.", de.ID), false)
+	addToBuffer(client, "EVI", fmt.Sprintf("Removed evidence %v (%v, added by %v).", de.ID, evidenceName(evi), evidenceOwnerLabel(owner)), true)
+	saveEvidenceChange(client.Area())
 }
 
 // Handles EE#%
@@ -1637,9 +1867,18 @@ func pktEditEvi(client *Client, p *packet.Packet) {
 	if err != nil {
 		return
 	}
+	evi, owner, ok := client.Area().EvidenceAt(ee.ID)
+	if !ok {
+		return
+	}
+	if !client.CanAlterEvidenceItem(owner) {
+		client.SendServerMessage("You can only change evidence you added in this area.")
+		return
+	}
 	client.Area().EditEvidence(ee.ID, ee.Name+"&"+ee.Description+"&"+ee.Image)
 	broadcastToArea(client.Area(), &packet.LE{Items: client.Area().Evidence()})
-	addToBuffer(client, "EVI", fmt.Sprintf("Updated evidence %v to %v | %v", ee.ID, ee.Name, ee.Description), false)
+	addToBuffer(client, "EVI", fmt.Sprintf("Updated evidence %v (%v, added by %v) to %v | %v", ee.ID, evidenceName(evi), evidenceOwnerLabel(owner), ee.Name, ee.Description), true)
+	saveEvidenceChange(client.Area())
 }
 
 // Handles CH#%
@@ -1656,30 +1895,42 @@ func pktPing(client *Client, _ *packet.Packet) {
 
 // Handles ZZ#%
 func pktModcall(client *Client, p *packet.Packet) {
+	zz, _ := packet.ParseZZ(p.Body)
+	sendModcall(client, zz.Reason)
+}
+
+// sendModcall alerts online moderators (and Discord, when enabled) that client
+// needs help, subject to the new-IPID and modcall cooldowns. It backs both the
+// ZZ packet and /modcall, and reports whether the modcall went out.
+func sendModcall(client *Client, reason string) bool {
 	if limited, remaining := checkNewIPIDModcallCooldown(client.Ipid()); limited {
 		unit := "seconds"
 		if remaining == 1 {
 			unit = "second"
 		}
 		client.SendServerMessage(fmt.Sprintf("New users must wait %d %s before sending a modcall.", remaining, unit))
-		return
+		return false
+	}
+	limited, remaining := client.CheckModcallCooldown()
+	if !limited {
+		limited, remaining = checkIPModcallCooldown(client.Ipid())
 	}
-	if limited, remaining := checkIPModcallCooldown(client.Ipid()); limited {
+	if limited {
 		unit := "seconds"
 		if remaining == 1 {
 			unit = "second"
 		}
 		client.SendServerMessage(fmt.Sprintf("You must wait %d %s before sending another modcall.", remaining, unit))
-		return
+		return false
 	}
+	client.SetLastModcallTime()
 	setIPModcallTime(client.Ipid())
-	zz, _ := packet.ParseZZ(p.Body)
-	addToBuffer(client, "MOD", fmt.Sprintf("Called moderator for reason: %v", zz.Reason), false)
+	addToBuffer(client, "MOD", fmt.Sprintf("Called moderator for reason: %v", reason), false)
 	if client.Area().LogSilenced() {
-		return
+		return true
 	}
 	modcallMsg := fmt.Sprintf("MODCALL\n----------\nArea: %v\nUser: [%v] %v\nShowname: %v\nOOC Name: %v\nIPID: %v\nReason: %v",
-		client.Area().Name(), client.Uid(), client.CurrentCharacter(), client.EffectiveShowname(), client.OOCName(), client.Ipid(), zz.Reason)
+		client.Area().Name(), client.Uid(), client.CurrentCharacter(), client.EffectiveShowname(), client.OOCName(), client.Ipid(), reason)
 	out := &packet.ZZ{Reason: modcallMsg}
 	clients.ForEach(func(c *Client) {
 		if c.Authenticated() && permissions.IsModerator(c.Perms()) {
@@ -1687,12 +1938,13 @@ func pktModcall(client *Client, p *packet.Packet) {
 		}
 	})
 	if enableDiscord {
-		err := webhook.PostModcall(client.CurrentCharacter(), client.EffectiveShowname(), client.OOCName(), client.Ipid(), client.Area().Name(), zz.Reason, client.Uid())
+		err := webhook.PostModcall(client.CurrentCharacter(), client.EffectiveShowname(), client.OOCName(), client.Ipid(), client.Area().Name(), reason, client.Uid())
 		if err != nil {
 			logger.LogError(err.Error())
 		}
 	}
 	logger.WriteReport(client.Area().Name(), client.Area().Buffer())
+	return true
 }
 
 // Handles SETCASE#%
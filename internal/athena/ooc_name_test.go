@@ -0,0 +1,82 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestOOCNameError(t *testing.T) {
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{ServerConfig: settings.ServerConfig{
+		Name:             "My Server",
+		ReservedOOCNames: []string{"Admin"},
+	}}
+
+	tests := []struct {
+		name string
+		ok   bool
+	}{
+		{"Phoenix", true},
+		{"フェニックス", true},
+		{"", false},
+		{"[1] Phoenix", false},
+		{"abcdefghijklmnopqrstuvwxyzabcde", false},
+		{"Pho\u200benix", false},
+		{"Pho\tenix", false},
+		{"my server", false},
+		{"SERVER", false},
+		{"admin", false},
+	}
+	for _, tt := range tests {
+		if got := oocNameError(tt.name); (got == "") != tt.ok {
+			t.Errorf("oocNameError(%q) = %q, want ok=%v", tt.name, got, tt.ok)
+		}
+	}
+}
+
+func TestOOCRenameCooldown(t *testing.T) {
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{ServerConfig: settings.ServerConfig{OOCNameCooldown: 10}}
+
+	client := &Client{}
+	if ok, _ := oocRenameAllowed(client, "First"); !ok {
+		t.Fatal("picking a first name should always be allowed")
+	}
+	client.SetOocName("First")
+	if ok, _ := oocRenameAllowed(client, "First"); !ok {
+		t.Fatal("keeping the same name should always be allowed")
+	}
+	if ok, _ := oocRenameAllowed(client, "Second"); !ok {
+		t.Fatal("first rename should be allowed")
+	}
+	client.SetOocName("Second")
+	ok, remaining := oocRenameAllowed(client, "Third")
+	if ok || remaining <= 0 || remaining > 10*time.Second {
+		t.Fatalf("rename inside cooldown: ok=%v remaining=%v, want rejected", ok, remaining)
+	}
+
+	config.OOCNameCooldown = 0
+	if ok, _ := oocRenameAllowed(client, "Third"); !ok {
+		t.Fatal("cooldown 0 should disable the check")
+	}
+}
@@ -0,0 +1,148 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// TestKickDryRunLeavesTargetsConnected verifies that /kick -n lists every
+// client sharing the IPID and disconnects nobody.
+func TestKickDryRunLeavesTargetsConnected(t *testing.T) {
+	newTestClients(t)
+	modConn := &captureConn{}
+	mod := &Client{conn: modConn, uid: 1, ipid: "mod-ipid", char: -1}
+	clients.AddClient(mod)
+	clients.RegisterUID(mod)
+
+	var targetConns []*captureConn
+	for uid := 2; uid <= 3; uid++ {
+		conn := &captureConn{}
+		c := &Client{conn: conn, uid: uid, ipid: "shared-ipid", char: -1}
+		c.SetOocName("Household")
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+		targetConns = append(targetConns, conn)
+	}
+
+	cmdKick(mod, []string{"-n", "-i", "shared-ipid"}, "usage")
+	out := modConn.String()
+	for _, want := range []string{"would affect 2 connected client(s)", "[2]", "[3]", "IPID shared-ipid", "Nothing was done"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry-run output missing %q: %q", want, out)
+		}
+	}
+	for i, conn := range targetConns {
+		if conn.String() != "" {
+			t.Errorf("target %d received packets during a dry run: %q", i+2, conn.String())
+		}
+	}
+}
+
+// TestPunishPreviewListsOfflineIPIDs verifies that a ban dry run notes IPIDs
+// with nobody online.
+func TestPunishPreviewListsOfflineIPIDs(t *testing.T) {
+	out := punishPreview("ban", nil, []string{"gone-ipid"})
+	if !strings.Contains(out, "0 connected client(s)") || !strings.Contains(out, "IPID gone-ipid — offline") {
+		t.Errorf("punishPreview = %q, want the offline IPID listed", out)
+	}
+}
+
+// TestSharedIPIDWarning verifies that a ban target whose IPID is used by
+// several connected UIDs produces a warning naming each of them, and that a
+// ban dry run includes it.
+func TestSharedIPIDWarning(t *testing.T) {
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	modConn := &captureConn{}
+	mod := &Client{conn: modConn, uid: 1, ipid: "mod-ipid", char: -1}
+	clients.AddClient(mod)
+	clients.RegisterUID(mod)
+	for uid := 2; uid <= 3; uid++ {
+		c := &Client{conn: &captureConn{}, uid: uid, ipid: "shared-ipid", char: -1}
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	if got := sharedIPIDWarning([]string{"mod-ipid", "unknown-ipid"}); got != "" {
+		t.Errorf("unshared IPIDs should not warn, got %q", got)
+	}
+	got := sharedIPIDWarning([]string{"shared-ipid", "shared-ipid"})
+	if strings.Count(got, "⚠️") != 1 || !strings.Contains(got, "shared by 2 connected clients: [2] Spectator, [3] Spectator") {
+		t.Errorf("sharedIPIDWarning = %q, want one warning listing UIDs 2 and 3", got)
+	}
+
+	cmdBan(mod, []string{"-n", "-u", "2"}, "usage")
+	if out := modConn.String(); !strings.Contains(out, "IPID shared-ipid is shared by 2") {
+		t.Errorf("ban dry run should carry the shared-IPID warning: %q", out)
+	}
+}
+
+// TestKickAreaTargetsEveryoneButStaff verifies that /kick -area selects every
+// client in the area except the caller and BYPASS_LOCK staff, and refuses to
+// be combined with -u.
+func TestKickAreaTargetsEveryoneButStaff(t *testing.T) {
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	raided := area.NewArea(area.AreaData{Name: "Raided"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{lobby, raided})()
+
+	modConn := &captureConn{}
+	mod := &Client{conn: modConn, uid: 1, ipid: "mod-ipid", char: -1, area: raided}
+	staff := &Client{conn: &captureConn{}, uid: 2, ipid: "staff-ipid", char: -1, area: raided,
+		perms: permissions.PermissionField["BYPASS_LOCK"]}
+	raider1 := &Client{conn: &captureConn{}, uid: 3, ipid: "raider-1", char: -1, area: raided}
+	raider2 := &Client{conn: &captureConn{}, uid: 4, ipid: "raider-2", char: -1, area: raided}
+	bystander := &Client{conn: &captureConn{}, uid: 5, ipid: "bystander", char: -1, area: lobby}
+	for _, c := range []*Client{mod, staff, raider1, raider2, bystander} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdKick(mod, []string{"-n", "-area", "1"}, "usage")
+	out := modConn.String()
+	if !strings.Contains(out, "would affect 2 connected client(s)") || !strings.Contains(out, "IPID raider-1") || !strings.Contains(out, "IPID raider-2") {
+		t.Errorf("area dry run should list both raiders: %q", out)
+	}
+	for _, skipped := range []string{"mod-ipid", "staff-ipid", "bystander"} {
+		if strings.Contains(out, skipped) {
+			t.Errorf("area dry run should not list %v: %q", skipped, out)
+		}
+	}
+
+	prev := len(modConn.String())
+	cmdKick(mod, []string{"-n", "-area", "1", "-u", "3"}, "usage")
+	if out := modConn.String()[prev:]; !strings.Contains(out, "cannot be combined") {
+		t.Errorf("-area with -u should be rejected: %q", out)
+	}
+
+	prev = len(modConn.String())
+	cmdBan(mod, []string{"-n", "-area", "nowhere"}, "usage")
+	if out := modConn.String()[prev:]; !strings.Contains(out, "Invalid area") {
+		t.Errorf("an unknown area should be rejected: %q", out)
+	}
+}
@@ -104,6 +104,17 @@ func alertPunishmentIssued(issuer *Client, punishmentLabel, targetReport string,
 	})
 }
 
+// announcePunishment tells the issuer's area that a moderator applied
+// punishmentLabel to the listed UIDs, when announce_punishments is on. The
+// issuer stays anonymous so shadow moderators aren't exposed, and -h keeps
+// the punishment out of the announcement as well as the target's notice.
+func announcePunishment(issuer *Client, punishmentLabel, targetReport string, targetCount int, hidden bool) {
+	if config == nil || !config.AnnouncePunishments || hidden || targetCount == 0 || issuer.Area() == nil {
+		return
+	}
+	sendAreaServerMessage(issuer.Area(), fmt.Sprintf("A moderator applied '%s' to UID %s.", punishmentLabel, targetReport))
+}
+
 // cmdPunishAudit handles /punishaudit <on|off>. With no argument it reports
 // the caller's current setting. The toggle is per-session: alerts default
 // back to on for every fresh connection.
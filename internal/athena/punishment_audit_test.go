@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
 )
 
 // Every test below builds struct-literal Clients backed by a captureConn.
@@ -170,3 +171,42 @@ func TestNewAdminCommandsRequireAdminPermission(t *testing.T) {
 		}
 	}
 }
+
+func TestAnnouncePunishment(t *testing.T) {
+	pf := permissions.PermissionField
+	testArea := makeTestArea("Courtroom")
+
+	origClients, origConfig := clients, config
+	t.Cleanup(func() { clients, config = origClients, origConfig })
+	clients = &ClientList{list: make(map[*Client]struct{}), uidIndex: make(map[int]*Client), ipidCounts: make(map[string]int)}
+	config = &settings.Config{}
+
+	issuer := &Client{conn: &captureConn{}, uid: 1, ipid: "ip-issuer", char: -1,
+		area: testArea, perms: pf["MUTE"], mod_name: "SecretMod"}
+	playerConn := &captureConn{}
+	player := &Client{conn: playerConn, uid: 2, ipid: "ip-player", char: -1, area: testArea}
+	for _, c := range []*Client{issuer, player} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	announcePunishment(issuer, "drunk", "2", 1, false)
+	if strings.Contains(playerConn.String(), "drunk") {
+		t.Fatal("announce_punishments off must keep punishments quiet")
+	}
+
+	config.AnnouncePunishments = true
+	announcePunishment(issuer, "drunk", "2", 1, true)
+	if strings.Contains(playerConn.String(), "drunk") {
+		t.Fatal("-h punishments must not be announced")
+	}
+
+	announcePunishment(issuer, "drunk", "2", 1, false)
+	out := playerConn.String()
+	if !strings.Contains(out, "drunk") || !strings.Contains(out, "UID 2") {
+		t.Errorf("area should be told about the punishment; got %q", out)
+	}
+	if strings.Contains(out, "SecretMod") {
+		t.Errorf("announcement must not name the issuing moderator; got %q", out)
+	}
+}
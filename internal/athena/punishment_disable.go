@@ -0,0 +1,72 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// disabledPunishments is the set of punishment names turned off by the
+// disabled_punishments config key, built once at server init by
+// initDisabledPunishments. Lookups are O(1) with no allocations.
+var disabledPunishments map[string]struct{}
+
+// initDisabledPunishments pre-builds disabledPunishments from the server
+// config. Entries may carry a leading "/" and are matched case-insensitively;
+// names that are neither a punishment effect nor a punishment command are
+// logged and ignored so a typo can't silently disable something else.
+func initDisabledPunishments(conf *settings.Config) {
+	m := make(map[string]struct{}, len(conf.DisabledPunishments))
+	for _, s := range conf.DisabledPunishments {
+		name := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "/"))
+		if name == "" {
+			continue
+		}
+		if parsePunishmentType(name) == PunishmentNone && Commands[name].category != "punishment" {
+			logger.LogWarningf("disabled_punishments: %q is not a punishment, ignoring", s)
+			continue
+		}
+		m[name] = struct{}{}
+	}
+	disabledPunishments = m
+}
+
+// punishmentDisabled reports whether the named punishment command or effect
+// has been disabled on this server.
+func punishmentDisabled(name string) bool {
+	_, ok := disabledPunishments[strings.ToLower(name)]
+	return ok
+}
+
+// enabledPunishments returns pool without the punishments disabled on this
+// server, for the commands that pick an effect at random. pool itself is
+// returned when nothing is disabled.
+func enabledPunishments(pool []PunishmentType) []PunishmentType {
+	if len(disabledPunishments) == 0 {
+		return pool
+	}
+	enabled := make([]PunishmentType, 0, len(pool))
+	for _, p := range pool {
+		if !punishmentDisabled(p.String()) {
+			enabled = append(enabled, p)
+		}
+	}
+	return enabled
+}
@@ -0,0 +1,177 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestDisabledPunishments(t *testing.T) {
+	initCommands()
+	orig := disabledPunishments
+	t.Cleanup(func() { disabledPunishments = orig })
+	initDisabledPunishments(&settings.Config{PunishmentConfig: settings.PunishmentConfig{
+		DisabledPunishments: []string{"/Torment", "sfxcurse", "drunk", "notathing", "help", ""},
+	}})
+
+	for name, want := range map[string]bool{
+		"torment": true, "TORMENT": true, "sfxcurse": true,
+		"notathing": false, "help": false, "uwu": false,
+	} {
+		if got := punishmentDisabled(name); got != want {
+			t.Errorf("punishmentDisabled(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	newTestClients(t)
+	conn := &captureConn{}
+	c := &Client{conn: conn, uid: 1, ipid: "ip-disabled", char: -1}
+	clients.AddClient(c)
+	clients.RegisterUID(c)
+
+	ParseCommand(c, "torment", []string{"1"})
+	if !strings.Contains(conn.String(), "That punishment is disabled on this server.") {
+		t.Errorf("expected disabled notice, got %q", conn.String())
+	}
+	if err := NewServerAdapter().ApplyPunishment(1, "drunk", time.Minute); err == nil || !strings.Contains(err.Error(), "disabled") {
+		t.Error("Discord ApplyPunishment should refuse a disabled punishment")
+	}
+	if c.HasPunishment(PunishmentTorment) || c.HasPunishment(PunishmentDrunk) {
+		t.Error("disabled punishment was applied")
+	}
+}
+
+// TestDisabledPunishmentsStackAndPools verifies that /stack, the direct
+// punishment path and the random pools never apply a disabled effect.
+func TestDisabledPunishmentsStackAndPools(t *testing.T) {
+	initCommands()
+	orig := disabledPunishments
+	t.Cleanup(func() { disabledPunishments = orig })
+	initDisabledPunishments(&settings.Config{PunishmentConfig: settings.PunishmentConfig{
+		DisabledPunishments: []string{"torment", "uwu"},
+	}})
+
+	newTestClients(t)
+	modConn := &captureConn{}
+	mod := &Client{conn: modConn, uid: 0, ipid: "ip-mod", char: -1}
+	target := &Client{conn: &captureConn{}, uid: 1, ipid: "ip-target", char: -1}
+	for _, c := range []*Client{mod, target} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdStack(mod, []string{"backward", "torment", "1"}, "usage")
+	if !strings.Contains(modConn.String(), "The torment punishment is disabled on this server.") {
+		t.Errorf("expected /stack to refuse a disabled effect, got %q", modConn.String())
+	}
+	if target.HasPunishment(PunishmentTorment) || target.HasPunishment(PunishmentBackward) {
+		t.Error("/stack with a disabled effect should apply nothing")
+	}
+	cmdPunishment(mod, []string{"1"}, "usage", PunishmentUwu)
+	if target.HasPunishment(PunishmentUwu) {
+		t.Error("a disabled effect was applied through cmdPunishment")
+	}
+
+	for _, p := range enabledPunishments(megamasoStackPool) {
+		if p == PunishmentTorment || p == PunishmentUwu {
+			t.Errorf("enabledPunishments kept disabled %v", p)
+		}
+	}
+	for i := 0; i < 200; i++ {
+		if e := pickPoolEffect(rngEffects, nil); e.pType == PunishmentUwu {
+			t.Fatal("pickPoolEffect drew a disabled effect")
+		}
+		if p := pickAreaRandomPunishment(); p == PunishmentTorment || p == PunishmentUwu {
+			t.Fatalf("pickAreaRandomPunishment drew disabled %v", p)
+		}
+	}
+}
+
+// TestDisabledPunishmentsBellAndShownameStain verifies that the silence bell
+// and the showname stain apply nothing once every pool entry is disabled.
+func TestDisabledPunishmentsBellAndShownameStain(t *testing.T) {
+	defer setupShownameCensorTestDB(t)()
+	initCommands()
+	orig := disabledPunishments
+	t.Cleanup(func() { disabledPunishments = orig })
+	var names []string
+	for _, p := range megamasoStackPool {
+		names = append(names, p.String())
+	}
+	initDisabledPunishments(&settings.Config{PunishmentConfig: settings.PunishmentConfig{DisabledPunishments: names}})
+
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	t.Cleanup(setupTestAreas([]*area.Area{court}))
+	modConn := &captureConn{}
+	mod := &Client{conn: modConn, uid: 0, ipid: "ip-mod", char: -1}
+	target := &Client{conn: &captureConn{}, uid: 1, ipid: "ip-bell-target", char: -1}
+	for _, c := range []*Client{mod, target} {
+		c.SetArea(court)
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+	t.Cleanup(func() {
+		mechanicsMu.Lock()
+		delete(areaBellTrap, court)
+		mechanicsMu.Unlock()
+	})
+
+	cmdSilencebell(mod, []string{"uwu"}, "usage")
+	if !strings.Contains(modConn.String(), "The uwu punishment is disabled on this server.") {
+		t.Errorf("expected the bell to refuse a disabled type, got %q", modConn.String())
+	}
+	cmdSilencebell(mod, nil, "usage")
+	if !strings.Contains(modConn.String(), "Every punishment in the pool is disabled on this server.") {
+		t.Errorf("expected the random bell to refuse an empty pool, got %q", modConn.String())
+	}
+	mechanicsMu.Lock()
+	_, armed := areaBellTrap[court]
+	mechanicsMu.Unlock()
+	if armed {
+		t.Error("the bell was armed with every pool entry disabled")
+	}
+
+	// A bell armed before the effects were disabled rings harmlessly.
+	for _, pType := range []PunishmentType{PunishmentNone, PunishmentUwu} {
+		trap := bellTrap{pType: pType, duration: time.Minute, armedBy: mod.Uid()}
+		mechanicsMu.Lock()
+		areaBellTrap[court] = trap
+		mechanicsMu.Unlock()
+		bellTriggerOnIC(target, court, trap)
+	}
+	if got := countMegamasoPoolPunishments(target); got != 0 {
+		t.Errorf("the bell applied %d disabled punishments", got)
+	}
+
+	origNames := getPunishmentNames()
+	t.Cleanup(func() { setPunishmentNames(origNames) })
+	setPunishmentNames([]string{"blacklistname"})
+	t.Cleanup(func() { unstainShownamePunish(target.Ipid()) })
+	checkPunishmentShowname(target, "blacklistname")
+	if !isShownamePunishStained(target.Ipid()) {
+		t.Fatal("expected the IPID to be stained")
+	}
+	if got := countMegamasoPoolPunishments(target); got != 0 {
+		t.Errorf("the showname stain applied %d disabled punishments", got)
+	}
+}
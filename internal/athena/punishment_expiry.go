@@ -0,0 +1,82 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+)
+
+// punishmentExpiryInterval is how often the background worker sweeps connected
+// clients for timed punishments that have run out.
+const punishmentExpiryInterval = 15 * time.Second
+
+var (
+	punishExpiryDone     = make(chan struct{}) // Signals the punishment expiry worker to stop.
+	punishExpiryStopOnce sync.Once
+)
+
+// startPunishmentExpiry lifts timed punishments once their duration elapses,
+// instead of waiting for the punished client to speak. Runs until done is
+// closed.
+func startPunishmentExpiry(done <-chan struct{}) {
+	ticker := time.NewTicker(punishmentExpiryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			expireTimedPunishments()
+		}
+	}
+}
+
+// stopPunishmentExpiry stops the expiry worker. Safe to call more than once.
+func stopPunishmentExpiry() {
+	punishExpiryStopOnce.Do(func() { close(punishExpiryDone) })
+}
+
+// expireTimedPunishments removes every elapsed punishment from connected
+// clients, deletes the persisted rows and tells each client what was lifted.
+// The database work happens after the client sweep so a slow write does not
+// hold up the client list.
+func expireTimedPunishments() {
+	type expiredPunishment struct {
+		client *Client
+		ipid   string
+		pType  PunishmentType
+	}
+	var expired []expiredPunishment
+	clients.ForEach(func(c *Client) {
+		for _, pType := range c.ExpirePunishments() {
+			expired = append(expired, expiredPunishment{c, c.Ipid(), pType})
+		}
+	})
+	for _, e := range expired {
+		if err := db.DeleteTextPunishment(e.ipid, int(e.pType)); err != nil {
+			logger.LogErrorf("Failed to remove expired punishment from DB for %v: %v", e.ipid, err)
+		}
+		if e.client.Uid() != -1 {
+			e.client.SendServerMessage(fmt.Sprintf("Your '%v' punishment has expired.", e.pType))
+		}
+	}
+}
@@ -0,0 +1,110 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+)
+
+// TestExpireTimedPunishments verifies the background sweep lifts elapsed
+// punishments, keeps permanent ones, drops the persisted row and notifies
+// the client.
+func TestExpireTimedPunishments(t *testing.T) {
+	tmp, err := os.CreateTemp("", "athena-expiry-*.db")
+	if err != nil {
+		t.Fatalf("failed to create temp db: %v", err)
+	}
+	tmp.Close()
+	db.DBPath = tmp.Name()
+	if err := db.Open(); err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		os.Remove(tmp.Name())
+	})
+	newTestClients(t)
+
+	conn := &captureConn{}
+	c := &Client{conn: conn, uid: 1, ipid: "ip-expiry", char: -1}
+	clients.AddClient(c)
+	clients.RegisterUID(c)
+
+	c.AddPunishment(PunishmentWhisper, time.Nanosecond, "short")
+	c.AddPunishment(PunishmentBackward, 0, "permanent")
+	// A future expiry keeps the row visible to GetPunishments, so the check
+	// below proves the sweep deleted it rather than the query filtering it.
+	expires := time.Now().UTC().Add(time.Hour).Unix()
+	if err := db.UpsertTextPunishmentBy(c.Ipid(), int(PunishmentWhisper), expires, "short", 0); err != nil {
+		t.Fatalf("failed to persist punishment: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+
+	expireTimedPunishments()
+
+	if c.HasPunishment(PunishmentWhisper) {
+		t.Error("expired whisper punishment was not removed")
+	}
+	if !c.HasPunishment(PunishmentBackward) {
+		t.Error("permanent punishment was removed")
+	}
+	if !strings.Contains(conn.String(), "Your 'whisper' punishment has expired.") {
+		t.Errorf("expected expiry notice, got %q", conn.String())
+	}
+	rows, err := db.GetPunishments(c.Ipid())
+	if err != nil {
+		t.Fatalf("GetPunishments: %v", err)
+	}
+	for _, r := range rows {
+		if r.Kind == db.PunishKindText && r.Subtype == int(PunishmentWhisper) {
+			t.Error("expired punishment row was not deleted")
+		}
+	}
+
+	before := len(conn.String())
+	expireTimedPunishments()
+	if len(conn.String()) != before {
+		t.Error("second sweep should not notify again")
+	}
+}
+
+// TestExpiryPathsReleaseForceDisplay verifies both expiry entry points keep the
+// force-display gate counter in step when a /forcedisplay punishment lapses.
+func TestExpiryPathsReleaseForceDisplay(t *testing.T) {
+	for name, expire := range map[string]func(c *Client){
+		"ExpirePunishments":        func(c *Client) { c.ExpirePunishments() },
+		"CheckExpiredPunishments":  func(c *Client) { c.CheckExpiredPunishments() },
+		"CheckExpiredAndGetActive": func(c *Client) { c.CheckExpiredAndGetPunishments() },
+	} {
+		c := &Client{ipid: "ip-forcedisplay", char: -1}
+		before := activeForceDisplay.Load()
+		c.AddPunishment(PunishmentForceDisplay, time.Nanosecond, "short")
+		time.Sleep(time.Millisecond)
+		expire(c)
+		if c.HasPunishment(PunishmentForceDisplay) {
+			t.Errorf("%s: expired forcedisplay was not removed", name)
+		}
+		if got := activeForceDisplay.Load(); got != before {
+			t.Errorf("%s: activeForceDisplay = %d after expiry, want %d", name, got, before)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/db"
+)
+
+// TestUnshrinkClearsPersistedRow verifies that /unshrink deletes the stored
+// punishment, so the effect is not restored when the target reconnects.
+func TestUnshrinkClearsPersistedRow(t *testing.T) {
+	tmp, err := os.CreateTemp("", "athena-unshrink-*.db")
+	if err != nil {
+		t.Fatalf("failed to create temp db: %v", err)
+	}
+	tmp.Close()
+	db.DBPath = tmp.Name()
+	if err := db.Open(); err != nil {
+		t.Fatalf("failed to open test db: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Close()
+		os.Remove(tmp.Name())
+	})
+	newTestClients(t)
+
+	mod := &Client{conn: &captureConn{}, uid: 0, ipid: "ip-mod", char: -1}
+	target := &Client{conn: &captureConn{}, uid: 1, ipid: "ip-shrink", char: -1}
+	clients.AddClient(mod)
+	clients.RegisterUID(mod)
+	clients.AddClient(target)
+	clients.RegisterUID(target)
+
+	applyDataPunishment(mod, target, PunishmentShrink, time.Hour, "shrink", "-25")
+	rejoined := &Client{conn: &captureConn{}, uid: 2, ipid: "ip-shrink", char: -1}
+	rejoined.restorePunishments()
+	if !rejoined.HasPunishment(PunishmentShrink) {
+		t.Fatal("shrink should be restored on reconnect before it is removed")
+	}
+
+	cmdUnshrink(mod, []string{"1"}, "")
+	if target.HasPunishment(PunishmentShrink) {
+		t.Error("/unshrink did not remove the effect")
+	}
+	rejoined = &Client{conn: &captureConn{}, uid: 2, ipid: "ip-shrink", char: -1}
+	rejoined.restorePunishments()
+	if rejoined.HasPunishment(PunishmentShrink) {
+		t.Error("shrink was restored on reconnect after /unshrink")
+	}
+}
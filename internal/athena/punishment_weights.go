@@ -0,0 +1,119 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// poolEffect is one entry of a random text-effect pool (/rng, /spaghetti).
+// The punishment type names the entry for the *_weights config keys.
+type poolEffect struct {
+	pType PunishmentType
+	apply func(string) string
+}
+
+// pickPoolEffect draws one effect from pool using weights, skipping effects
+// disabled on this server. If every effect is disabled the text is left
+// alone.
+func pickPoolEffect(pool []poolEffect, weights map[string]float64) poolEffect {
+	if len(disabledPunishments) != 0 {
+		enabled := make([]poolEffect, 0, len(pool))
+		for _, e := range pool {
+			if !punishmentDisabled(e.pType.String()) {
+				enabled = append(enabled, e)
+			}
+		}
+		if len(enabled) == 0 {
+			return poolEffect{pType: PunishmentNone, apply: func(s string) string { return s }}
+		}
+		pool = enabled
+	}
+	return pool[weightedIndex(len(pool), func(i int) string { return pool[i].pType.String() }, weights)]
+}
+
+// weightedIndex picks an index in [0, n), drawing entry i with probability
+// proportional to weights[name(i)]. Entries missing from weights weigh 1 and
+// non-positive weights exclude an entry. With no weights configured, or if
+// every entry is excluded, the pick is uniform.
+func weightedIndex(n int, name func(int) string, weights map[string]float64) int {
+	if len(weights) == 0 {
+		return rng.Intn(n)
+	}
+	w := make([]float64, n)
+	var total float64
+	for i := range w {
+		w[i] = 1
+		if v, ok := weights[name(i)]; ok {
+			w[i] = v
+		}
+		if w[i] < 0 {
+			w[i] = 0
+		}
+		total += w[i]
+	}
+	if total <= 0 {
+		return rng.Intn(n)
+	}
+	r := rng.Float64() * total
+	for i, v := range w {
+		if r < v {
+			return i
+		}
+		r -= v
+	}
+	// Rounding can leave r just past the last bucket; fall back to the last
+	// entry that can actually be drawn.
+	for i := n - 1; i > 0; i-- {
+		if w[i] > 0 {
+			return i
+		}
+	}
+	return 0
+}
+
+// checkPunishmentWeights warns about *_weights keys that name no effect in
+// their pool, since a typo there would otherwise be silently ignored.
+func checkPunishmentWeights(conf *settings.Config) {
+	names := func(pool []poolEffect) map[string]bool {
+		m := make(map[string]bool, len(pool))
+		for _, e := range pool {
+			m[e.pType.String()] = true
+		}
+		return m
+	}
+	hotPotato := make(map[string]bool, len(hotPotatoPunishmentPool))
+	for _, p := range hotPotatoPunishmentPool {
+		hotPotato[p.String()] = true
+	}
+	for _, c := range []struct {
+		key     string
+		weights map[string]float64
+		known   map[string]bool
+	}{
+		{"rng_weights", conf.RngWeights, names(rngEffects)},
+		{"spaghetti_weights", conf.SpaghettiWeights, names(spaghettiEffects)},
+		{"hotpotato_weights", conf.HotPotatoWeights, hotPotato},
+	} {
+		for name := range c.weights {
+			if !c.known[name] {
+				logger.LogWarningf("%v: %q is not in that pool and will be ignored.", c.key, name)
+			}
+		}
+	}
+}
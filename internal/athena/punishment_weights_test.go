@@ -0,0 +1,64 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import "testing"
+
+func TestWeightedIndexBiasesSelection(t *testing.T) {
+	names := []string{"a", "b", "c"}
+	name := func(i int) string { return names[i] }
+
+	const trials = 3000
+	counts := make([]int, len(names))
+	for i := 0; i < trials; i++ {
+		counts[weightedIndex(len(names), name, map[string]float64{"a": 8, "c": 0})]++
+	}
+	if counts[2] != 0 {
+		t.Errorf("a zero-weight entry was picked %d times", counts[2])
+	}
+	// "a" weighs 8 against "b"'s default 1, so it should take ~89% of picks.
+	if counts[0] < trials*3/4 {
+		t.Errorf("weighted entry picked %d/%d times, want a clear majority", counts[0], trials)
+	}
+
+	counts = make([]int, len(names))
+	for i := 0; i < trials; i++ {
+		counts[weightedIndex(len(names), name, nil)]++
+	}
+	for i, c := range counts {
+		if c < trials/6 {
+			t.Errorf("unweighted pick of %q = %d/%d, want roughly uniform", names[i], c, trials)
+		}
+	}
+
+	if i := weightedIndex(len(names), name, map[string]float64{"a": 0, "b": 0, "c": 0}); i < 0 || i >= len(names) {
+		t.Errorf("all-zero weights should fall back to a uniform pick, got %d", i)
+	}
+}
+
+func TestPickPoolEffectHonoursWeights(t *testing.T) {
+	weights := make(map[string]float64, len(rngEffects))
+	for _, e := range rngEffects {
+		weights[e.pType.String()] = 0
+	}
+	weights["uwu"] = 1
+	for i := 0; i < 20; i++ {
+		if e := pickPoolEffect(rngEffects, weights); e.pType != PunishmentUwu {
+			t.Fatalf("picked %v, want uwu as the only weighted effect", e.pType)
+		}
+	}
+}
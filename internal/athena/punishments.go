@@ -18,16 +18,21 @@ package athena
 
 import (
 	"fmt"
-	"math/rand"
 	"regexp"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/MangosArentLiterature/Athena/internal/packet"
 )
 
 // confusedSplitter splits on any sequence of non-letter, non-digit characters
 var confusedSplitter = regexp.MustCompile(`[^\p{L}\p{N}]+`)
 
+// confusedSentence matches one sentence: its body and its closing .?! run.
+var confusedSentence = regexp.MustCompile(`(?s)(.*?)([.?!]+(?:\s+|$)|$)`)
+
 const maxTextLength = 2000
 
 // Package-level tables — allocated once at program start, reused on every IC
@@ -204,21 +209,21 @@ var (
 		"it's":  "its",
 	}
 
-	spaghettiEffects = []func(string) string{
-		applyUppercase,
-		applyBackward,
-		applyElongate,
-		applyConfused,
-		applyDrunk,
+	spaghettiEffects = []poolEffect{
+		{PunishmentUppercase, applyUppercase},
+		{PunishmentBackward, applyBackward},
+		{PunishmentElongate, applyElongate},
+		{PunishmentConfused, applyConfused},
+		{PunishmentDrunk, applyDrunk},
 	}
-	rngEffects = []func(string) string{
-		applyBackward,
-		applyUppercase,
-		applyLowercase,
-		applyUwu,
-		applyPirate,
-		applyRobotic,
-		applyAlternating,
+	rngEffects = []poolEffect{
+		{PunishmentBackward, applyBackward},
+		{PunishmentUppercase, applyUppercase},
+		{PunishmentLowercase, applyLowercase},
+		{PunishmentUwu, applyUwu},
+		{PunishmentPirate, applyPirate},
+		{PunishmentRobotic, applyRobotic},
+		{PunishmentAlternating, applyAlternating},
 	}
 	tormentEffects = []func(string) string{
 		applyUppercase,
@@ -500,7 +505,7 @@ var (
 	}
 
 	// tourettesAllVariants bundles the four outburst categories so applyTourettes
-	// can pick one with a single rand.Intn call instead of allocating a new slice.
+	// can pick one with a single rng.Intn call instead of allocating a new slice.
 	tourettesAllVariants = [][]string{
 		tourettesSwearing,
 		tourettesRandom,
@@ -846,8 +851,8 @@ func applyUwu(text string) string {
 	text = strings.ReplaceAll(text, "Na", "Nya")
 
 	// Add random UwU expressions
-	if rand.Float32() < 0.3 {
-		text += uwuSuffixes[rand.Intn(len(uwuSuffixes))]
+	if rng.Float32() < 0.3 {
+		text += uwuSuffixes[rng.Intn(len(uwuSuffixes))]
 	}
 	return truncateText(text)
 }
@@ -860,8 +865,8 @@ func applyPirate(text string) string {
 	}
 
 	// Add pirate expressions
-	if rand.Float32() < 0.3 {
-		lower += pirateSuffixes[rand.Intn(len(pirateSuffixes))]
+	if rng.Float32() < 0.3 {
+		lower += pirateSuffixes[rng.Intn(len(pirateSuffixes))]
 	}
 	return truncateText(lower)
 }
@@ -895,12 +900,12 @@ func applyShakespearean(text string) string {
 
 	result := strings.Join(words, " ")
 
-	if rand.Float32() < 0.4 {
-		result = shakespeareanPrefixes[rand.Intn(len(shakespeareanPrefixes))] + result
+	if rng.Float32() < 0.4 {
+		result = shakespeareanPrefixes[rng.Intn(len(shakespeareanPrefixes))] + result
 	}
 
-	if rand.Float32() < 0.3 {
-		result = result + shakespeareanSuffixes[rand.Intn(len(shakespeareanSuffixes))]
+	if rng.Float32() < 0.3 {
+		result = result + shakespeareanSuffixes[rng.Intn(len(shakespeareanSuffixes))]
 	}
 
 	return truncateText(result)
@@ -918,7 +923,7 @@ func applyCaveman(text string) string {
 		if i > 0 {
 			result.WriteString(" ")
 		}
-		result.WriteString(cavemanWords[rand.Intn(len(cavemanWords))])
+		result.WriteString(cavemanWords[rng.Intn(len(cavemanWords))])
 	}
 	return truncateText(result.String())
 }
@@ -927,43 +932,105 @@ func applyCaveman(text string) string {
 func applyCensor(text string) string {
 	words := strings.Fields(text)
 	for i, word := range words {
-		if len(word) > 3 && rand.Float32() < 0.4 {
+		if len(word) > 3 && rng.Float32() < 0.4 {
 			words[i] = "[CENSORED]"
 		}
 	}
 	return truncateText(strings.Join(words, " "))
 }
 
-// applyConfused reorders words randomly
+// applyConfused reorders words randomly, across the whole message or, with
+// confused_mode = "sentence", only within each sentence.
 func applyConfused(text string) string {
-	// Split on any non-letter, non-digit characters to prevent bypass via dots, hyphens, etc.
-	parts := confusedSplitter.Split(text, -1)
-	var words []string
-	for _, w := range parts {
-		if w != "" {
-			words = append(words, w)
+	if config != nil && strings.EqualFold(config.ConfusedMode, "sentence") {
+		if out, ok := confuseSentences(text); ok {
+			return truncateText(out)
 		}
 	}
+	words := confusedWords(text)
 	if len(words) <= 1 {
 		return text
 	}
+	shuffleWords(words)
+	return truncateText(strings.Join(words, " "))
+}
+
+// confusedWords splits on any non-letter, non-digit characters to prevent
+// bypass via dots, hyphens, etc.
+func confusedWords(text string) []string {
+	var words []string
+	for _, w := range confusedSplitter.Split(text, -1) {
+		if w != "" {
+			words = append(words, w)
+		}
+	}
+	return words
+}
 
-	// Shuffle words
+func shuffleWords(words []string) {
 	for i := range words {
-		j := rand.Intn(len(words))
+		j := rng.Intn(len(words))
 		words[i], words[j] = words[j], words[i]
 	}
-	return truncateText(strings.Join(words, " "))
+}
+
+// confuseSentences shuffles words within each sentence, keeping every
+// sentence's closing punctuation in place. A sentence ends at a run of .?!
+// followed by whitespace or the end of the text, so "a.b" stays one sentence.
+// ok is false when no sentence has two words to swap — e.g. "a. b. c." — so
+// the caller falls back to a full shuffle instead of leaving it readable.
+func confuseSentences(text string) (out string, ok bool) {
+	var b strings.Builder
+	for _, m := range confusedSentence.FindAllStringSubmatch(text, -1) {
+		words := confusedWords(m[1])
+		if len(words) == 0 {
+			continue
+		}
+		if len(words) > 1 {
+			ok = true
+			shuffleWords(words)
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(strings.Join(words, " "))
+		b.WriteString(strings.TrimSpace(m[2]))
+	}
+	return b.String(), ok
 }
 
 // applyParanoid adds paranoid text
 func applyParanoid(text string) string {
-	phrase := paranoidPhrases[rand.Intn(len(paranoidPhrases))]
+	phrase := paranoidPhrases[rng.Intn(len(paranoidPhrases))]
 	return truncateText(text + phrase)
 }
 
+// drunkDefaultIntensity is the strength /drunk has without -i; the chances in
+// applyDrunkIntensity scale linearly from it up to drunkMaxIntensity.
+const (
+	drunkDefaultIntensity = 2
+	drunkMaxIntensity     = 5
+)
+
 // applyDrunk slurs and repeats words
 func applyDrunk(text string) string {
+	return applyDrunkIntensity(text, drunkDefaultIntensity)
+}
+
+// drunkIntensity parses the intensity stored in a /drunk punishment's
+// customData, falling back to the default.
+func drunkIntensity(customData string) int {
+	n, err := strconv.Atoi(customData)
+	if err != nil || n < 1 || n > drunkMaxIntensity {
+		return drunkDefaultIntensity
+	}
+	return n
+}
+
+// applyDrunkIntensity is applyDrunk at a given intensity.
+func applyDrunkIntensity(text string, level int) string {
+	scale := float32(level) / drunkDefaultIntensity
+	repeatChance, slurChance, hicChance := 0.3*scale, 0.2*scale, 0.3*scale
 	words := strings.Fields(text)
 	var result strings.Builder
 
@@ -973,7 +1040,7 @@ func applyDrunk(text string) string {
 		}
 
 		// Randomly repeat words
-		if rand.Float32() < 0.3 {
+		if rng.Float32() < repeatChance {
 			result.WriteString(word)
 			result.WriteString(" ")
 		}
@@ -982,14 +1049,14 @@ func applyDrunk(text string) string {
 		runes := []rune(word)
 		for j, r := range runes {
 			result.WriteRune(r)
-			if j > 0 && rand.Float32() < 0.2 {
+			if j > 0 && rng.Float32() < slurChance {
 				result.WriteRune(r)
 			}
 		}
 	}
 
 	// Add hiccups
-	if rand.Float32() < 0.3 {
+	if rng.Float32() < hicChance {
 		result.WriteString(" *hic*")
 	}
 	return truncateText(result.String())
@@ -1006,7 +1073,7 @@ func applyHiccup(text string) string {
 		}
 		result.WriteString(word)
 
-		if rand.Float32() < 0.4 {
+		if rng.Float32() < 0.4 {
 			result.WriteString(" *hic*")
 		}
 	}
@@ -1023,7 +1090,7 @@ func applyWhistle(text string) string {
 			result.WriteString(" ")
 		}
 		for range word {
-			result.WriteString(whistleSounds[rand.Intn(len(whistleSounds))])
+			result.WriteString(whistleSounds[rng.Intn(len(whistleSounds))])
 		}
 	}
 	return truncateText(result.String())
@@ -1056,24 +1123,100 @@ func applyMumble(text string) string {
 // applySpaghetti combines multiple random effects
 func applySpaghetti(text string) string {
 	// Apply 2-3 random effects
-	numEffects := 2 + rand.Intn(2)
+	numEffects := 2 + rng.Intn(2)
+	var weights map[string]float64
+	if config != nil {
+		weights = config.SpaghettiWeights
+	}
 	for i := 0; i < numEffects; i++ {
-		text = spaghettiEffects[rand.Intn(len(spaghettiEffects))](text)
+		text = pickPoolEffect(spaghettiEffects, weights).apply(text)
 	}
 	return text
 }
 
 // applyRng applies random effect from pool
 func applyRng(text string) string {
-	return rngEffects[rand.Intn(len(rngEffects))](text)
+	var weights map[string]float64
+	if config != nil {
+		weights = config.RngWeights
+	}
+	return pickPoolEffect(rngEffects, weights).apply(text)
 }
 
-// applyEssay ensures minimum character count
-func applyEssay(text string) string {
-	if len(text) < 50 {
-		return text + " [MESSAGE TOO SHORT - MINIMUM 50 CHARACTERS REQUIRED]"
+// essayMinLength is the shortest IC message, in characters, a player under
+// /essay may send.
+const essayMinLength = 50
+
+// applyEssay enforces the /essay minimum length. It never rewrites the text;
+// suppress is true when the message is too short and must not be sent.
+func applyEssay(text string) (string, bool) {
+	return text, utf8.RuneCountInString(text) < essayMinLength
+}
+
+// validateHaiku reports whether text is a 5-7-5 haiku. Lines are separated
+// by "/" or newlines; blank lines are ignored. counts holds the estimated
+// syllables of each line so the sender can see what went wrong.
+func validateHaiku(text string) (ok bool, counts []int) {
+	lines := strings.FieldsFunc(text, func(r rune) bool { return r == '/' || r == '\n' })
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		n := 0
+		for _, word := range strings.Fields(line) {
+			n += countSyllables(word)
+		}
+		counts = append(counts, n)
 	}
-	return text
+	ok = len(counts) == 3 && counts[0] == 5 && counts[1] == 7 && counts[2] == 5
+	return ok, counts
+}
+
+// haikuRejection tells a /haiku-punished sender why their message was dropped.
+func haikuRejection(counts []int) string {
+	detected := "no syllables"
+	if len(counts) > 0 {
+		parts := make([]string, len(counts))
+		for i, n := range counts {
+			parts[i] = strconv.Itoa(n)
+		}
+		detected = strings.Join(parts, "-") + " syllables"
+	}
+	return fmt.Sprintf("Your message must be a 5-7-5 haiku (detected %v). Separate the three lines with / or new lines.", detected)
+}
+
+// countSyllables estimates the syllables in an English word by counting vowel
+// groups, discounting a silent trailing 'e' ("make") but not a consonant + "le"
+// ending ("table"). Words with letters always count at least one syllable;
+// words without any count none.
+func countSyllables(word string) int {
+	var letters []byte
+	for _, r := range strings.ToLower(word) {
+		if r >= 'a' && r <= 'z' {
+			letters = append(letters, byte(r))
+		}
+	}
+	if len(letters) == 0 {
+		return 0
+	}
+	isVowel := func(b byte) bool { return strings.IndexByte("aeiouy", b) >= 0 }
+	n := 0
+	prevVowel := false
+	for _, b := range letters {
+		v := isVowel(b)
+		if v && !prevVowel {
+			n++
+		}
+		prevVowel = v
+	}
+	if l := len(letters); l >= 2 && letters[l-1] == 'e' && !isVowel(letters[l-2]) &&
+		!(letters[l-2] == 'l' && l >= 3 && !isVowel(letters[l-3])) {
+		n--
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
 }
 
 // applyAutospell intentionally misspells words
@@ -1093,9 +1236,57 @@ func applyTorment(text string, cycleIndex int) string {
 	return tormentEffects[cycleIndex%len(tormentEffects)](text)
 }
 
-// applySubtitles adds confusing annotations
+// applyOOCPunishments runs the sender's -ooc text punishments over an OOC
+// message. Punishments issued without -ooc leave OOC chat alone.
+func applyOOCPunishments(client *Client, text string) string {
+	applied := false
+	for _, p := range client.GetActivePunishments() {
+		if p.oocScope {
+			text = ApplyPunishmentToText(text, p.punishmentType)
+			applied = true
+		}
+	}
+	if applied {
+		text = fitICBudget(text)
+	}
+	return text
+}
+
+// rouletteDrops reports whether a /roulette target's message should be
+// dropped, with the odds set by roulette_chance.
+func rouletteDrops() bool {
+	chance := 0.25
+	if config != nil {
+		chance = config.RouletteChance
+	}
+	return chance > 0 && rng.Float64() < chance
+}
+
+// applySubtitles adds a confusing caption, with the chance and placement set
+// by subtitles_chance and subtitles_placement. A chance of 0 or less never
+// captions; without a config every message is captioned.
 func applySubtitles(text string) string {
-	return text + subtitleLines[rand.Intn(len(subtitleLines))]
+	chance, placement := 1.0, "end"
+	if config != nil {
+		chance, placement = config.SubtitlesChance, strings.ToLower(config.SubtitlesPlacement)
+	}
+	if chance <= 0 || (chance < 1 && rng.Float64() >= chance) {
+		return text
+	}
+	line := subtitleLines[rng.Intn(len(subtitleLines))]
+	if placement == "random" {
+		placement = []string{"end", "middle"}[rng.Intn(2)]
+	}
+	if placement != "middle" {
+		return text + line
+	}
+	// Captions carry a leading space, so splice one in between two words.
+	words := strings.Fields(text)
+	if len(words) < 2 {
+		return text + line
+	}
+	i := 1 + rng.Intn(len(words)-1)
+	return strings.Join(words[:i], " ") + line + " " + strings.Join(words[i:], " ")
 }
 
 // applySpotlight adds an announcement prefix
@@ -1103,6 +1294,25 @@ func applySpotlight(text string) string {
 	return "📣 EVERYONE LOOK: " + text
 }
 
+// spotlightBroadcast mirrors a /spotlight target's IC message to every joined
+// client outside their area as a server message when spotlight_global is on.
+// The speaker's own area already received the real IC packet.
+func spotlightBroadcast(client *Client, ms *packet.MSPacket) {
+	if config == nil || !config.SpotlightGlobal || ms.Message == "" {
+		return
+	}
+	name := clientDisplayName(client)
+	if ms.Showname != "" {
+		name = decode(ms.Showname)
+	}
+	msg := fmt.Sprintf("[Spotlight] %v in %v: %v", name, client.Area().Name(), decode(ms.Message))
+	clients.ForEach(func(c *Client) {
+		if c.Uid() != -1 && c.Area() != client.Area() {
+			c.SendServerMessage(msg)
+		}
+	})
+}
+
 // ── ThesaurusOverload ────────────────────────────────────────────────────────
 
 // applyThesaurusOverload replaces common words with absurdly pompous synonyms.
@@ -1130,8 +1340,8 @@ func applyThesaurusOverload(text string) string {
 		}
 	}
 	result := strings.Join(words, " ")
-	if rand.Float32() < 0.4 {
-		result += thesaurusSuffixes[rand.Intn(len(thesaurusSuffixes))]
+	if rng.Float32() < 0.4 {
+		result += thesaurusSuffixes[rng.Intn(len(thesaurusSuffixes))]
 	}
 	return truncateText(result)
 }
@@ -1141,15 +1351,15 @@ func applyThesaurusOverload(text string) string {
 // applyValleyGirl injects valley-girl filler words and stretches vowels.
 func applyValleyGirl(text string) string {
 	// Inject a filler at the start ~60% of the time
-	if rand.Float32() < 0.6 {
-		text = valleygirlFillers[rand.Intn(len(valleygirlFillers))] + text
+	if rng.Float32() < 0.6 {
+		text = valleygirlFillers[rng.Intn(len(valleygirlFillers))] + text
 	}
 	// Stretch some vowels for drama. Compute lower once and keep it in sync
 	// with text so subsequent searches use accurate positions without
 	// redundant ToLower calls.
 	lower := strings.ToLower(text)
 	for _, ch := range []string{"o", "e", "a"} {
-		if rand.Float32() < 0.3 {
+		if rng.Float32() < 0.3 {
 			if idx := strings.Index(lower, ch); idx >= 0 {
 				text = text[:idx+1] + ch + ch + text[idx+1:]
 				lower = lower[:idx+1] + ch + ch + lower[idx+1:]
@@ -1160,8 +1370,8 @@ func applyValleyGirl(text string) string {
 	text = strings.ReplaceAll(text, " no ", " nooo ")
 	text = strings.ReplaceAll(text, " yes ", " yesss ")
 	// Append a dramatic suffix ~50% of the time
-	if rand.Float32() < 0.5 {
-		text += valleygirlSuffixes[rand.Intn(len(valleygirlSuffixes))]
+	if rng.Float32() < 0.5 {
+		text += valleygirlSuffixes[rng.Intn(len(valleygirlSuffixes))]
 	}
 	return truncateText(text)
 }
@@ -1174,8 +1384,8 @@ func applyBabytalk(text string) string {
 	// softening) in a single O(n) pass instead of 22 sequential ReplaceAll calls.
 	lower := babytalkReplacer.Replace(strings.ToLower(text))
 	// Add a stage direction ~40% of the time
-	if rand.Float32() < 0.4 {
-		lower += babytalkStageDirections[rand.Intn(len(babytalkStageDirections))]
+	if rng.Float32() < 0.4 {
+		lower += babytalkStageDirections[rng.Intn(len(babytalkStageDirections))]
 	}
 	return truncateText(lower)
 }
@@ -1217,10 +1427,10 @@ func applyThirdPersonWithName(text, showname string) string {
 		moodTag = " [dramatic]"
 	case hasQuestion:
 		moodTag = " [confused]"
-	case rand.Float32() < 0.25:
-		moodTag = thirdPersonMoodTags[rand.Intn(len(thirdPersonMoodTags))]
+	case rng.Float32() < 0.25:
+		moodTag = thirdPersonMoodTags[rng.Intn(len(thirdPersonMoodTags))]
 	}
-	template := thirdPersonTemplates[rand.Intn(len(thirdPersonTemplates))]
+	template := thirdPersonTemplates[rng.Intn(len(thirdPersonTemplates))]
 	result := fmt.Sprintf(template, showname, text) + moodTag
 	return truncateText(result)
 }
@@ -1237,15 +1447,15 @@ func applyUnreliableNarrator(text string) string {
 	// Insert a hedge word after the first word ~60% of the time.
 	// Use in-place grow-and-shift to avoid allocating two temporary slices.
 	words := strings.Fields(text)
-	if len(words) >= 2 && rand.Float32() < 0.6 {
-		hedge := unreliableHedges[rand.Intn(len(unreliableHedges))]
+	if len(words) >= 2 && rng.Float32() < 0.6 {
+		hedge := unreliableHedges[rng.Intn(len(unreliableHedges))]
 		words = append(words, "")  // grow by one
 		copy(words[2:], words[1:]) // shift [1:] one position right
 		words[1] = hedge
 		text = strings.Join(words, " ")
 	}
 	// Append a suspicious suffix
-	text += unreliableSuffixes[rand.Intn(len(unreliableSuffixes))]
+	text += unreliableSuffixes[rng.Intn(len(unreliableSuffixes))]
 	return truncateText(text)
 }
 
@@ -1260,8 +1470,8 @@ func applyUncannyValley(text string) string {
 	// which is cheaper than pre-checking with ToLower + Contains.
 	text = uncannyFineReplacer.Replace(text)
 	// ~60% chance to append a glitch tag
-	if rand.Float32() < 0.6 {
-		text += uncannyGlitchTags[rand.Intn(len(uncannyGlitchTags))]
+	if rng.Float32() < 0.6 {
+		text += uncannyGlitchTags[rng.Intn(len(uncannyGlitchTags))]
 	}
 	return truncateText(text)
 }
@@ -1297,7 +1507,7 @@ var messages51 = []string{
 
 // apply51 replaces the message with a random line from the 51-messages story.
 func apply51(_ string) string {
-	return messages51[rand.Intn(len(messages51))]
+	return messages51[rng.Intn(len(messages51))]
 }
 
 // MutateShowname applies a mild per-message display-name glitch for
@@ -1307,7 +1517,7 @@ func MutateShowname(name string) string {
 		return name
 	}
 	runes := []rune(name)
-	choice := rand.Intn(4)
+	choice := rng.Intn(4)
 	switch choice {
 	case 0:
 		// Replace one vowel with a lookalike homoglyph
@@ -1318,9 +1528,9 @@ func MutateShowname(name string) string {
 			}
 		}
 		if len(vowelIndices) > 0 {
-			idx := vowelIndices[rand.Intn(len(vowelIndices))]
+			idx := vowelIndices[rng.Intn(len(vowelIndices))]
 			options := uncannyVowelSwaps[runes[idx]]
-			runes[idx] = options[rand.Intn(len(options))]
+			runes[idx] = options[rng.Intn(len(options))]
 			return string(runes)
 		}
 		// Fallback: add underscore suffix
@@ -1331,7 +1541,7 @@ func MutateShowname(name string) string {
 	case 2:
 		// Swap two adjacent letters (skip first char to keep capital intact)
 		if len(runes) >= 3 {
-			idx := 1 + rand.Intn(len(runes)-2)
+			idx := 1 + rng.Intn(len(runes)-2)
 			runes[idx], runes[idx+1] = runes[idx+1], runes[idx]
 		} else {
 			return string(runes) + "."
@@ -1341,7 +1551,7 @@ func MutateShowname(name string) string {
 		// Duplicate a random character (only if within length budget).
 		// len(runes) is the rune count — the correct comparison for maxShownameLength.
 		if len(runes) < maxShownameLength-1 {
-			idx := rand.Intn(len(runes))
+			idx := rng.Intn(len(runes))
 			newRunes := make([]rune, len(runes)+1)
 			copy(newRunes, runes[:idx+1])
 			newRunes[idx+1] = runes[idx]
@@ -1397,8 +1607,6 @@ func ApplyPunishmentToText(text string, pType PunishmentType) string {
 		return applySpaghetti(text)
 	case PunishmentRng:
 		return applyRng(text)
-	case PunishmentEssay:
-		return applyEssay(text)
 	case PunishmentAutospell:
 		return applyAutospell(text)
 	case PunishmentSubtitles:
@@ -1560,7 +1768,7 @@ func ApplyPunishmentToText(text string, pType PunishmentType) string {
 	case PunishmentGrounded:
 		return applyGrounded(text)
 	case PunishmentZalgo:
-		return applyZalgo(text)
+		return applyZalgo(text, zalgoDefaultIntensity)
 	case PunishmentLeetspeak:
 		return applyLeetspeak(text)
 	case PunishmentSmallcaps:
@@ -1627,7 +1835,7 @@ func applyAnimalSounds(text, defaultSound string, sounds []string) string {
 		if i > 0 {
 			result.WriteString(" ")
 		}
-		result.WriteString(sounds[rand.Intn(len(sounds))])
+		result.WriteString(sounds[rng.Intn(len(sounds))])
 	}
 	return truncateText(result.String())
 }
@@ -1641,8 +1849,8 @@ func applyMonkey(text string) string {
 func applySnake(text string) string {
 	text = strings.ReplaceAll(text, "s", "sss")
 	text = strings.ReplaceAll(text, "S", "SSS")
-	if rand.Float32() < 0.5 {
-		text += snakeSuffixes[rand.Intn(len(snakeSuffixes))]
+	if rng.Float32() < 0.5 {
+		text += snakeSuffixes[rng.Intn(len(snakeSuffixes))]
 	}
 	return truncateText(text)
 }
@@ -1689,7 +1897,7 @@ func applyLion(text string) string {
 
 // applyZoo applies a random animal punishment from the full zoo
 func applyZoo(text string) string {
-	return zooEffects[rand.Intn(len(zooEffects))](text)
+	return zooEffects[rng.Intn(len(zooEffects))](text)
 }
 
 // applyBunny replaces text with bunny sounds
@@ -1699,13 +1907,45 @@ func applyBunny(text string) string {
 
 // GetRandomEmoji returns a random emoji string
 func GetRandomEmoji() string {
-	return emojiTable[rand.Intn(len(emojiTable))]
+	return emojiTable[rng.Intn(len(emojiTable))]
+}
+
+// emojiNameLength is how many emojis make up an /emoji showname.
+const emojiNameLength = 3
+
+// randomEmojiName returns a showname built from emojiNameLength random emojis.
+func randomEmojiName() string {
+	var b strings.Builder
+	for i := 0; i < emojiNameLength; i++ {
+		b.WriteString(GetRandomEmoji())
+	}
+	return b.String()
+}
+
+// emojiShowname returns the showname an /emoji target speaks under. With
+// emoji_name_reroll off, the first generated name is kept in the punishment's
+// customData so it stays the same for the rest of the punishment.
+func emojiShowname(client *Client) string {
+	if config == nil || config.EmojiNameReroll {
+		return randomEmojiName()
+	}
+	var name string
+	client.UpdatePunishmentState(PunishmentEmoji, func(ps *PunishmentState) {
+		if ps.customData == "" {
+			ps.customData = randomEmojiName()
+		}
+		name = ps.customData
+	})
+	if name == "" {
+		name = randomEmojiName()
+	}
+	return name
 }
 
 // ── Dere-type punishments ────────────────────────────────────────────────────
 // All phrase tables are package-level vars — allocated once at startup, never
 // on the hot-path (every IC message). Each archetype has 8 entries so a
-// single rand.Intn(8) selects uniformly without an extra len() call.
+// single rng.Intn(8) selects uniformly without an extra len() call.
 
 var (
 	tsunderePfx = []string{
@@ -1904,7 +2144,7 @@ var (
 // applyPrefixSuffix wraps text with a random prefix from pfx and a random suffix from sfx.
 // Both pfx and sfx must be non-empty slices.
 func applyPrefixSuffix(text string, pfx, sfx []string) string {
-	return pfx[rand.Intn(len(pfx))] + text + sfx[rand.Intn(len(sfx))]
+	return pfx[rng.Intn(len(pfx))] + text + sfx[rng.Intn(len(sfx))]
 }
 
 // applyTsundere wraps text in classic tsundere denial and blush reactions.
@@ -1946,16 +2186,16 @@ func applyDandere(text string) string {
 			sb.WriteByte(' ')
 		}
 		if i%3 == 0 && i != 0 {
-			sb.WriteString(dandereSttrs[rand.Intn(len(dandereSttrs))])
+			sb.WriteString(dandereSttrs[rng.Intn(len(dandereSttrs))])
 		}
 		// Stutter first ASCII letter of the word for extra shyness.
-		if len(w) > 0 && w[0] >= 'a' && w[0] <= 'z' && rand.Intn(3) == 0 {
+		if len(w) > 0 && w[0] >= 'a' && w[0] <= 'z' && rng.Intn(3) == 0 {
 			sb.WriteByte(w[0])
 			sb.WriteByte('-')
 		}
 		sb.WriteString(w)
 	}
-	sb.WriteString(dandereSfx[rand.Intn(len(dandereSfx))])
+	sb.WriteString(dandereSfx[rng.Intn(len(dandereSfx))])
 	return truncateText(sb.String())
 }
 
@@ -1991,13 +2231,13 @@ func applyBakadere(text string) string {
 		if i > 0 {
 			sb.WriteByte(' ')
 		}
-		if rand.Intn(4) == 0 {
-			sb.WriteString(bakadereIntj[rand.Intn(len(bakadereIntj))])
+		if rng.Intn(4) == 0 {
+			sb.WriteString(bakadereIntj[rng.Intn(len(bakadereIntj))])
 			sb.WriteByte(' ')
 		}
 		sb.WriteString(w)
 	}
-	sb.WriteString(bakadereEnd[rand.Intn(len(bakadereEnd))])
+	sb.WriteString(bakadereEnd[rng.Intn(len(bakadereEnd))])
 	return truncateText(sb.String())
 }
 
@@ -2014,7 +2254,7 @@ var emoticons = []string{
 
 // applyEmoticon replaces the message with a random emoticon.
 func applyEmoticon(text string) string {
-	return emoticons[rand.Intn(len(emoticons))]
+	return emoticons[rng.Intn(len(emoticons))]
 }
 
 // degradeMessages are first-person degrading statements used by the degrade punishment.
@@ -2035,7 +2275,7 @@ var degradeMessages = []string{
 
 // applyDegrade replaces the message with a random degrading first-person statement.
 func applyDegrade(text string) string {
-	return degradeMessages[rand.Intn(len(degradeMessages))]
+	return degradeMessages[rng.Intn(len(degradeMessages))]
 }
 
 // tourettesSwearing contains censored-style swear outbursts for the tourettes effect.
@@ -2087,9 +2327,9 @@ func applyTourettes(text string) string {
 		result.WriteString(word)
 
 		// ~35% chance of an outburst after each word
-		if rand.Float32() < 0.35 {
-			category := tourettesAllVariants[rand.Intn(len(tourettesAllVariants))]
-			outburst := category[rand.Intn(len(category))]
+		if rng.Float32() < 0.35 {
+			category := tourettesAllVariants[rng.Intn(len(tourettesAllVariants))]
+			outburst := category[rng.Intn(len(category))]
 			result.WriteString(" ")
 			result.WriteString(outburst)
 		}
@@ -2193,7 +2433,7 @@ func applyLovebombMessage(targetShowname string) string {
 	if targetShowname == "" {
 		return "I LOVE EVERYONE HERE SO MUCH!! ♥"
 	}
-	return fmt.Sprintf(lovebombTemplates[rand.Intn(len(lovebombTemplates))], targetShowname)
+	return fmt.Sprintf(lovebombTemplates[rng.Intn(len(lovebombTemplates))], targetShowname)
 }
 
 // --- Philosophical / Literary Punishments ---
@@ -2230,7 +2470,7 @@ var philosopherQuestions = []string{
 
 // applyPhilosopher appends a random deep philosophical question to the text.
 func applyPhilosopher(text string) string {
-	q := philosopherQuestions[rand.Intn(len(philosopherQuestions))]
+	q := philosopherQuestions[rng.Intn(len(philosopherQuestions))]
 	return truncateText(text + " " + q)
 }
 
@@ -2264,8 +2504,8 @@ var poeticPrefixes = []string{
 
 // applyPoet wraps the text in poetic flourishes.
 func applyPoet(text string) string {
-	prefix := poeticPrefixes[rand.Intn(len(poeticPrefixes))]
-	suffix := poeticSuffixes[rand.Intn(len(poeticSuffixes))]
+	prefix := poeticPrefixes[rng.Intn(len(poeticPrefixes))]
+	suffix := poeticSuffixes[rng.Intn(len(poeticSuffixes))]
 	return truncateText(prefix + text + " " + suffix)
 }
 
@@ -2473,7 +2713,7 @@ var sarcasmCommentaries = []string{
 
 // applySarcasm appends a sarcastic parenthetical remark to the text.
 func applySarcasm(text string) string {
-	comment := sarcasmCommentaries[rand.Intn(len(sarcasmCommentaries))]
+	comment := sarcasmCommentaries[rng.Intn(len(sarcasmCommentaries))]
 	return truncateText(text + " " + comment)
 }
 
@@ -2507,8 +2747,8 @@ var academicSuffixes = []string{
 
 // applyAcademic wraps the text in overly formal academic language.
 func applyAcademic(text string) string {
-	prefix := academicPrefixes[rand.Intn(len(academicPrefixes))]
-	suffix := academicSuffixes[rand.Intn(len(academicSuffixes))]
+	prefix := academicPrefixes[rng.Intn(len(academicPrefixes))]
+	suffix := academicSuffixes[rng.Intn(len(academicSuffixes))]
 	return truncateText(prefix + text + suffix)
 }
 
@@ -2578,22 +2818,22 @@ var recipeEndings = []string{
 // chosen per-message so a stream of /recipe lines reads like a real
 // recipe: Step 1, Step 3, Step 2, Step 4...
 func applyRecipe(text string) string {
-	stepIdx := rand.Intn(len(recipeStepLabels))
+	stepIdx := rng.Intn(len(recipeStepLabels))
 	label := recipeStepLabels[stepIdx]
 	var verb string
 	switch stepIdx {
 	case 0:
-		verb = recipeStep1Verbs[rand.Intn(len(recipeStep1Verbs))]
+		verb = recipeStep1Verbs[rng.Intn(len(recipeStep1Verbs))]
 	case 1:
-		verb = recipeStep2Verbs[rand.Intn(len(recipeStep2Verbs))]
+		verb = recipeStep2Verbs[rng.Intn(len(recipeStep2Verbs))]
 	case 2:
-		verb = recipeStep3Verbs[rand.Intn(len(recipeStep3Verbs))]
+		verb = recipeStep3Verbs[rng.Intn(len(recipeStep3Verbs))]
 	case 3:
-		verb = recipeStep4Verbs[rand.Intn(len(recipeStep4Verbs))]
+		verb = recipeStep4Verbs[rng.Intn(len(recipeStep4Verbs))]
 	default:
-		verb = recipeStepVerbs[rand.Intn(len(recipeStepVerbs))]
+		verb = recipeStepVerbs[rng.Intn(len(recipeStepVerbs))]
 	}
-	ending := recipeEndings[rand.Intn(len(recipeEndings))]
+	ending := recipeEndings[rng.Intn(len(recipeEndings))]
 	var b strings.Builder
 	b.Grow(len(label) + len(": ") + len(verb) + len(" \"") + len(text) + len("\". ") + len(ending))
 	b.WriteString(label)
@@ -2610,7 +2850,7 @@ func applyRecipe(text string) string {
 func applyQuote(text string) string {
 	words := strings.Fields(text)
 	for i, word := range words {
-		if rand.Float32() < 0.2 {
+		if rng.Float32() < 0.2 {
 			words[i] = "\"" + word + "\""
 		}
 	}
@@ -2645,7 +2885,6 @@ var areaRandomPunishments = []PunishmentType{
 	PunishmentMumble,
 	PunishmentSpaghetti,
 	PunishmentRng,
-	PunishmentEssay,
 	PunishmentAutospell,
 	PunishmentSubtitles,
 	PunishmentSpotlight,
@@ -2701,10 +2940,15 @@ var areaRandomPunishments = []PunishmentType{
 	PunishmentGrounded,
 }
 
-// pickAreaRandomPunishment returns a random punishment type from the pool.
+// pickAreaRandomPunishment returns a random enabled punishment type from the
+// pool, or PunishmentNone (which leaves text alone) if every one is disabled.
 // Exported as a var so the punishment-area tests can stub it if needed.
 var pickAreaRandomPunishment = func() PunishmentType {
-	return areaRandomPunishments[rand.Intn(len(areaRandomPunishments))]
+	pool := enabledPunishments(areaRandomPunishments)
+	if len(pool) == 0 {
+		return PunishmentNone
+	}
+	return pool[rng.Intn(len(pool))]
 }
 
 // applyAreaRandomPunishmentText applies ONE random stateless punishment to
@@ -2717,7 +2961,7 @@ var pickAreaRandomPunishment = func() PunishmentType {
 func applyAreaRandomPunishmentText(text string, includeTranslator bool) (string, PunishmentType) {
 	// When translator is live, give it a real chance to be picked so the
 	// area feels unpredictable rather than just "same list of filters".
-	if includeTranslator && rand.Intn(len(areaRandomPunishments)+1) == 0 {
+	if includeTranslator && !punishmentDisabled(PunishmentTranslator.String()) && rng.Intn(len(areaRandomPunishments)+1) == 0 {
 		return applyTranslator(text, "random"), PunishmentTranslator
 	}
 	pType := pickAreaRandomPunishment()
@@ -2730,7 +2974,7 @@ func applyTimewarp(text string) string {
 	if len(words) < 2 {
 		return text
 	}
-	rand.Shuffle(len(words), func(i, j int) { words[i], words[j] = words[j], words[i] })
+	rng.Shuffle(len(words), func(i, j int) { words[i], words[j] = words[j], words[i] })
 	return truncateText(strings.Join(words, " "))
 }
 
@@ -2763,7 +3007,7 @@ var rickrollLines = []string{
 // applyRickroll replaces the message with a meme-styled placeholder line.
 // Lyrics-adjacent only — no copyrighted content is reproduced.
 func applyRickroll(_ string) string {
-	return rickrollLines[rand.Intn(len(rickrollLines))]
+	return rickrollLines[rng.Intn(len(rickrollLines))]
 }
 
 // pickupLines is a deliberately enormous catalogue of the cheesiest, most
@@ -2852,7 +3096,7 @@ var pickupLines = []string{
 // The original text is intentionally discarded — the punishment IS the
 // substitution. Each delivery is a fresh, public, deeply preventable disaster.
 func applyPickup(_ string) string {
-	return pickupLines[rand.Intn(len(pickupLines))]
+	return pickupLines[rng.Intn(len(pickupLines))]
 }
 
 // karenPrefixes are opening escalations prepended to the original message.
@@ -2886,11 +3130,11 @@ var karenSuffixes = []string{
 // applyKaren wraps the message in escalating entitlement. Picks a random
 // opener and a random closer so it varies every IC message.
 func applyKaren(text string) string {
-	prefix := karenPrefixes[rand.Intn(len(karenPrefixes))]
-	suffix := karenSuffixes[rand.Intn(len(karenSuffixes))]
+	prefix := karenPrefixes[rng.Intn(len(karenPrefixes))]
+	suffix := karenSuffixes[rng.Intn(len(karenSuffixes))]
 	// About 1 in 5 messages go full-tantrum with two suffixes.
-	if rand.Float32() < 0.2 {
-		suffix += " " + karenSuffixes[rand.Intn(len(karenSuffixes))]
+	if rng.Float32() < 0.2 {
+		suffix += " " + karenSuffixes[rng.Intn(len(karenSuffixes))]
 	}
 	return truncateText(prefix + text + suffix)
 }
@@ -2928,11 +3172,11 @@ var passiveAggressiveClosers = []string{
 // applyPassiveAggressive wraps a message with chilly politeness framings and
 // emoticon smileys that absolutely do not mean the sender is happy.
 func applyPassiveAggressive(text string) string {
-	opener := passiveAggressiveOpeners[rand.Intn(len(passiveAggressiveOpeners))]
-	closer := passiveAggressiveClosers[rand.Intn(len(passiveAggressiveClosers))]
+	opener := passiveAggressiveOpeners[rng.Intn(len(passiveAggressiveOpeners))]
+	closer := passiveAggressiveClosers[rng.Intn(len(passiveAggressiveClosers))]
 	// 30% of the time, add a second closer to amp up the chill.
-	if rand.Float32() < 0.3 {
-		closer += passiveAggressiveClosers[rand.Intn(len(passiveAggressiveClosers))]
+	if rng.Float32() < 0.3 {
+		closer += passiveAggressiveClosers[rng.Intn(len(passiveAggressiveClosers))]
 	}
 	return truncateText(opener + text + closer)
 }
@@ -2971,23 +3215,23 @@ func applyNervous(text string) string {
 	var out []string
 	for i, w := range words {
 		// 25% chance to insert a filler token before this word.
-		if rand.Float32() < 0.25 {
-			out = append(out, nervousFillers[rand.Intn(len(nervousFillers))])
+		if rng.Float32() < 0.25 {
+			out = append(out, nervousFillers[rng.Intn(len(nervousFillers))])
 		}
 		// 35% chance to stutter the word itself.
-		if rand.Float32() < 0.35 {
+		if rng.Float32() < 0.35 {
 			out = append(out, stutterFirst(w))
 		} else {
 			out = append(out, w)
 		}
 		// Small chance to trail off mid-sentence.
-		if i == len(words)/2 && rand.Float32() < 0.2 {
+		if i == len(words)/2 && rng.Float32() < 0.2 {
 			out = append(out, "...")
 		}
 	}
 	result := strings.Join(out, " ")
-	if rand.Float32() < 0.6 {
-		result += nervousTails[rand.Intn(len(nervousTails))]
+	if rng.Float32() < 0.6 {
+		result += nervousTails[rng.Intn(len(nervousTails))]
 	}
 	return truncateText(result)
 }
@@ -3017,9 +3261,9 @@ var dreamVerbs = []string{
 // applyDreamSequence rewrites the message as a dreamlike gloss. Intensity
 // ramps up based on message length — longer messages get more surreal.
 func applyDreamSequence(text string) string {
-	adj := dreamAdjectives[rand.Intn(len(dreamAdjectives))]
-	noun := dreamNouns[rand.Intn(len(dreamNouns))]
-	verb := dreamVerbs[rand.Intn(len(dreamVerbs))]
+	adj := dreamAdjectives[rng.Intn(len(dreamAdjectives))]
+	noun := dreamNouns[rng.Intn(len(dreamNouns))]
+	verb := dreamVerbs[rng.Intn(len(dreamVerbs))]
 
 	// Short messages become pure dreamlogic. Longer messages keep a trace of
 	// the original text, filtered through softly shimmering framing.
@@ -3116,7 +3360,7 @@ func applyVowelhell(text string) string {
 			if isVowel {
 				b.WriteRune(r)
 			} else {
-				v := vowels[rand.Intn(len(vowels))]
+				v := vowels[rng.Intn(len(vowels))]
 				if unicode.IsUpper(r) {
 					v = unicode.ToUpper(v)
 				}
@@ -3326,26 +3570,26 @@ var brainrotWordMap = map[string]string{
 //  2. Wrap — keep the original text but slam a brainrot prefix and suffix around it.
 //  3. Inject — scatter brainrot keywords between words AND word-map common terms.
 func applyBrainrot(text string) string {
-	r := rand.Float32()
+	r := rng.Float32()
 
 	switch {
 	case r < 0.25:
 		// Full Italian-brainrot replacement.
-		entity := brainrotItalian[rand.Intn(len(brainrotItalian))]
-		suffix := brainrotSuffixes[rand.Intn(len(brainrotSuffixes))]
+		entity := brainrotItalian[rng.Intn(len(brainrotItalian))]
+		suffix := brainrotSuffixes[rng.Intn(len(brainrotSuffixes))]
 		return truncateText(entity + " " + suffix)
 
 	case r < 0.50:
 		// Skibidi entity + original text + suffix.
-		skib := brainrotSkibidi[rand.Intn(len(brainrotSkibidi))]
-		suffix := brainrotSuffixes[rand.Intn(len(brainrotSuffixes))]
+		skib := brainrotSkibidi[rng.Intn(len(brainrotSkibidi))]
+		suffix := brainrotSuffixes[rng.Intn(len(brainrotSuffixes))]
 		return truncateText(strings.ToUpper(skib) + " " + text + " " + suffix)
 
 	case r < 0.75:
 		// Prefix + text + double suffix for maximum chaos.
-		prefix := brainrotPrefixes[rand.Intn(len(brainrotPrefixes))]
-		suffix1 := brainrotSuffixes[rand.Intn(len(brainrotSuffixes))]
-		suffix2 := brainrotSuffixes[rand.Intn(len(brainrotSuffixes))]
+		prefix := brainrotPrefixes[rng.Intn(len(brainrotPrefixes))]
+		suffix1 := brainrotSuffixes[rng.Intn(len(brainrotSuffixes))]
+		suffix2 := brainrotSuffixes[rng.Intn(len(brainrotSuffixes))]
 		return truncateText(prefix + " " + text + " " + suffix1 + " " + suffix2)
 
 	default:
@@ -3376,13 +3620,13 @@ func applyBrainrot(text string) string {
 				b.WriteByte(punct)
 			}
 			// ~30% chance to inject a random brainrot word after this token.
-			if rand.Float32() < 0.30 {
+			if rng.Float32() < 0.30 {
 				b.WriteByte(' ')
-				b.WriteString(brainrotInserts[rand.Intn(len(brainrotInserts))])
+				b.WriteString(brainrotInserts[rng.Intn(len(brainrotInserts))])
 			}
 		}
 		// Always cap with a suffix so it never just looks like a word-swap.
-		suffix := brainrotSuffixes[rand.Intn(len(brainrotSuffixes))]
+		suffix := brainrotSuffixes[rng.Intn(len(brainrotSuffixes))]
 		b.WriteByte(' ')
 		b.WriteString(suffix)
 		return truncateText(b.String())
@@ -3460,7 +3704,7 @@ var gordonRamsayQuotes = []string{
 // Picks a quote at random from gordonRamsayQuotes; the original text is
 // discarded (the punishment is meant to silence the speaker behind the meme).
 func applyGordonRamsay(_ string) string {
-	return truncateText(gordonRamsayQuotes[rand.Intn(len(gordonRamsayQuotes))])
+	return truncateText(gordonRamsayQuotes[rng.Intn(len(gordonRamsayQuotes))])
 }
 
 // groundedQuotes is a pool of GoAnimate-style "grounded" tirades. Each entry
@@ -3671,5 +3915,5 @@ var groundedQuotes = []string{
 
 // applyGrounded replaces the IC text with a GoAnimate-style grounding tirade.
 func applyGrounded(_ string) string {
-	return truncateText(groundedQuotes[rand.Intn(len(groundedQuotes))])
+	return truncateText(groundedQuotes[rng.Intn(len(groundedQuotes))])
 }
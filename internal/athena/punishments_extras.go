@@ -10,7 +10,6 @@
 package athena
 
 import (
-	"math/rand"
 	"strings"
 	"unicode"
 )
@@ -117,24 +116,24 @@ var jokerLaughs = []string{
 func applyJoker(text string) string {
 	words := strings.Fields(text)
 	if len(words) == 0 {
-		return jokerLaughs[rand.Intn(len(jokerLaughs))] + "!"
+		return jokerLaughs[rng.Intn(len(jokerLaughs))] + "!"
 	}
 	var sb strings.Builder
 	sb.Grow(len(text) + 32)
-	sb.WriteString(jokerLaughs[rand.Intn(len(jokerLaughs))])
+	sb.WriteString(jokerLaughs[rng.Intn(len(jokerLaughs))])
 	sb.WriteString("! ")
 	for i, w := range words {
 		if i > 0 {
 			sb.WriteByte(' ')
 		}
 		sb.WriteString(w)
-		if rand.Intn(4) == 0 {
+		if rng.Intn(4) == 0 {
 			sb.WriteByte(' ')
-			sb.WriteString(jokerLaughs[rand.Intn(len(jokerLaughs))])
+			sb.WriteString(jokerLaughs[rng.Intn(len(jokerLaughs))])
 		}
 	}
 	sb.WriteString(" ")
-	sb.WriteString(jokerLaughs[rand.Intn(len(jokerLaughs))])
+	sb.WriteString(jokerLaughs[rng.Intn(len(jokerLaughs))])
 	sb.WriteString("!")
 	return truncateText(sb.String())
 }
@@ -154,7 +153,7 @@ var mimeActions = []string{
 
 // applyMime drops the actual text entirely and emits a silent action.
 func applyMime(_ string) string {
-	return mimeActions[rand.Intn(len(mimeActions))]
+	return mimeActions[rng.Intn(len(mimeActions))]
 }
 
 var bibleVerses = []string{
@@ -182,7 +181,7 @@ var bibleVerses = []string{
 
 // applyBiblebot replaces the message with a random Bible verse.
 func applyBiblebot(_ string) string {
-	return truncateText(bibleVerses[rand.Intn(len(bibleVerses))])
+	return truncateText(bibleVerses[rng.Intn(len(bibleVerses))])
 }
 
 // ───────────────────────── Additional dere archetypes ────────────────────────
@@ -478,6 +477,6 @@ var omnidereTypes = []PunishmentType{
 // applyOmnidere is recursion-safe because none of the targets it dispatches
 // to call ApplyPunishmentToText again (each is a leaf transform).
 func applyOmnidere(text string) string {
-	pick := omnidereTypes[rand.Intn(len(omnidereTypes))]
+	pick := omnidereTypes[rng.Intn(len(omnidereTypes))]
 	return ApplyPunishmentToText(text, pick)
 }
@@ -30,7 +30,6 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"math/rand"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -277,7 +276,7 @@ func cmdContagious(client *Client, args []string, usage string) {
 			report += fmt.Sprintf("%v, ", c.Uid())
 		})
 	} else {
-		for _, c := range getUidList(strings.Split(uidArg, ",")) {
+		for _, c := range getUidList(client, strings.Split(uidArg, ",")) {
 			if punishmentSafeBlocked(c) {
 				notePunishmentSafeSkip(&skipped, &skippedReport, c)
 				continue
@@ -305,23 +304,27 @@ func cmdContagious(client *Client, args []string, usage string) {
 // ── Minefield ─────────────────────────────────────────────────────────────
 
 func minefieldRoll(client *Client) {
-	if rand.Intn(6) != 0 {
+	if rng.Intn(6) != 0 {
 		return
 	}
 	if client.Area().PunishmentSafe() {
 		return
 	}
+	pool := enabledPunishments(megamasoStackPool)
+	if len(pool) == 0 {
+		return
+	}
 	// Pick a mine the speaker isn't already wearing, like /megamaso does.
 	var pick PunishmentType
 	for tries := 0; tries < 16; tries++ {
-		candidate := megamasoStackPool[rand.Intn(len(megamasoStackPool))]
+		candidate := pool[rng.Intn(len(pool))]
 		if !client.HasPunishment(candidate) {
 			pick = candidate
 			break
 		}
 	}
 	if pick == PunishmentNone {
-		pick = megamasoStackPool[rand.Intn(len(megamasoStackPool))]
+		pick = pool[rng.Intn(len(pool))]
 	}
 
 	client.AddPunishment(pick, minefieldDetonationDuration, "minefield detonation")
@@ -362,7 +365,13 @@ func bellTriggerOnIC(client *Client, a *area.Area, trap bellTrap) {
 
 	pick := trap.pType
 	if pick == PunishmentNone {
-		pick = megamasoStackPool[rand.Intn(len(megamasoStackPool))]
+		pool := enabledPunishments(megamasoStackPool)
+		if len(pool) == 0 {
+			return
+		}
+		pick = pool[rng.Intn(len(pool))]
+	} else if punishmentDisabled(pick.String()) {
+		return
 	}
 
 	client.AddPunishmentBy(pick, trap.duration, "the bell tolled", trap.tier)
@@ -434,6 +443,13 @@ func cmdSilencebell(client *Client, args []string, usage string) {
 			client.SendServerMessage(fmt.Sprintf("'%v' cannot be loaded into the bell.", pick.String()))
 			return
 		}
+		if punishmentDisabled(pick.String()) {
+			client.SendServerMessage(fmt.Sprintf("The %v punishment is disabled on this server.", pick.String()))
+			return
+		}
+	} else if len(enabledPunishments(megamasoStackPool)) == 0 {
+		client.SendServerMessage("Every punishment in the pool is disabled on this server.")
+		return
 	}
 
 	duration, err := str2duration.ParseDuration(*durationStr)
@@ -22,7 +22,6 @@
 package athena
 
 import (
-	"math/rand"
 	"strings"
 	"unicode"
 )
@@ -127,14 +126,14 @@ func applyMedieval(text string) string {
 		}
 	}
 	out := strings.Join(words, " ")
-	if rand.Intn(3) < 2 { // ~2/3 of messages gain a herald's cry
-		out = strings.TrimSpace(medievalHeralds[rand.Intn(len(medievalHeralds))] + " " + out)
+	if rng.Intn(3) < 2 { // ~2/3 of messages gain a herald's cry
+		out = strings.TrimSpace(medievalHeralds[rng.Intn(len(medievalHeralds))] + " " + out)
 	}
-	if rand.Intn(3) < 2 { // ~2/3 gain a courtly flourish
-		out = strings.TrimRight(strings.TrimSpace(out), ".!?,") + ", " + medievalFlourishes[rand.Intn(len(medievalFlourishes))]
+	if rng.Intn(3) < 2 { // ~2/3 gain a courtly flourish
+		out = strings.TrimRight(strings.TrimSpace(out), ".!?,") + ", " + medievalFlourishes[rng.Intn(len(medievalFlourishes))]
 	}
 	if strings.TrimSpace(out) == "" {
-		out = medievalHeralds[rand.Intn(len(medievalHeralds))]
+		out = medievalHeralds[rng.Intn(len(medievalHeralds))]
 	}
 	return fitICBudget(out)
 }
@@ -262,5 +261,5 @@ func cheeseLineCount() int { return len(cheeseStatements) }
 
 // applyCheese discards the input and returns a random cheese statement.
 func applyCheese(_ string) string {
-	return fitICBudget(cheeseStatements[rand.Intn(len(cheeseStatements))])
+	return fitICBudget(cheeseStatements[rng.Intn(len(cheeseStatements))])
 }
@@ -27,7 +27,6 @@ import (
 	"flag"
 	"fmt"
 	"io"
-	"math/rand"
 	"strconv"
 	"strings"
 	"time"
@@ -54,13 +53,13 @@ func applyProtocolPunishments(ms *packet.MSPacket, punishments []PunishmentState
 		p := &punishments[i]
 		switch p.punishmentType {
 		case PunishmentTeleport:
-			x := rand.Intn(2*teleportMaxX+1) - teleportMaxX
-			y := rand.Intn(2*teleportMaxY+1) - teleportMaxY
+			x := rng.Intn(2*teleportMaxX+1) - teleportMaxX
+			y := rng.Intn(2*teleportMaxY+1) - teleportMaxY
 			ms.SelfOffset = encode(fmt.Sprintf("%d&%d", x, y))
 		case PunishmentShakecurse:
 			ms.Screenshake = "1"
 		case PunishmentRandomflip:
-			if rand.Intn(2) == 0 {
+			if rng.Intn(2) == 0 {
 				ms.Flip = "1"
 			} else {
 				ms.Flip = "0"
@@ -209,7 +208,7 @@ func cmdForceColor(client *Client, args []string, usage string) {
 			report += fmt.Sprintf("%v, ", c.Uid())
 		})
 	} else {
-		for _, c := range getUidList(strings.Split(uidArg, ",")) {
+		for _, c := range getUidList(client, strings.Split(uidArg, ",")) {
 			if punishmentSafeBlocked(c) {
 				notePunishmentSafeSkip(&skipped, &skippedReport, c)
 				continue
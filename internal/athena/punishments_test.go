@@ -19,6 +19,12 @@ package athena
 import (
 	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
 )
 
 func TestApplyShakespearean(t *testing.T) {
@@ -1467,3 +1473,282 @@ func TestReverseRunes(t *testing.T) {
 		}
 	}
 }
+
+// TestEmojiShownameStable verifies emoji_name_reroll = false keeps one emoji
+// name for the whole punishment instead of rolling a new one per message.
+func TestEmojiShownameStable(t *testing.T) {
+	oldConfig := config
+	t.Cleanup(func() { config = oldConfig })
+	config = &settings.Config{PunishmentConfig: settings.PunishmentConfig{EmojiNameReroll: false}}
+
+	c := &Client{conn: &testConn{}, uid: 1, ipid: "ip-emoji"}
+	c.AddPunishment(PunishmentEmoji, time.Minute, "test")
+
+	first := emojiShowname(c)
+	if utf8.RuneCountInString(first) < emojiNameLength {
+		t.Fatalf("emojiShowname = %q, want at least %d emojis", first, emojiNameLength)
+	}
+	for i := 0; i < 5; i++ {
+		if got := emojiShowname(c); got != first {
+			t.Fatalf("stable emoji name changed from %q to %q", first, got)
+		}
+	}
+}
+
+func TestApplySubtitlesPlacement(t *testing.T) {
+	oldConfig := config
+	t.Cleanup(func() { config = oldConfig })
+	hasCaption := func(s string) bool {
+		for _, l := range subtitleLines {
+			if strings.Contains(s, l) {
+				return true
+			}
+		}
+		return false
+	}
+
+	config = &settings.Config{PunishmentConfig: settings.PunishmentConfig{SubtitlesChance: 1, SubtitlesPlacement: "end"}}
+	if got := applySubtitles("hello there"); !strings.HasPrefix(got, "hello there [") {
+		t.Errorf("end placement: got %q", got)
+	}
+
+	config.SubtitlesPlacement = "middle"
+	for i := 0; i < 20; i++ {
+		got := applySubtitles("one two three")
+		if !hasCaption(got) || !strings.HasPrefix(got, "one") || !strings.HasSuffix(got, "three") {
+			t.Fatalf("middle placement: got %q", got)
+		}
+	}
+	// Too few words to split falls back to trailing.
+	if got := applySubtitles("alone"); !strings.HasPrefix(got, "alone [") {
+		t.Errorf("single-word middle placement: got %q", got)
+	}
+
+	config.SubtitlesChance = 0.000001
+	captioned := 0
+	for i := 0; i < 200; i++ {
+		if hasCaption(applySubtitles("quiet please")) {
+			captioned++
+		}
+	}
+	if captioned > 5 {
+		t.Errorf("near-zero chance captioned %d of 200 messages", captioned)
+	}
+
+	for _, chance := range []float64{0, -1} {
+		config.SubtitlesChance = chance
+		for i := 0; i < 50; i++ {
+			if got := applySubtitles("quiet please"); got != "quiet please" {
+				t.Fatalf("chance %v captioned %q", chance, got)
+			}
+		}
+	}
+	config = nil
+	if !hasCaption(applySubtitles("hello there")) {
+		t.Error("without a config every message should be captioned")
+	}
+}
+
+func TestSpotlightBroadcast(t *testing.T) {
+	origConfig, origClients := config, clients
+	t.Cleanup(func() { config, clients = origConfig, origClients })
+	clients = &ClientList{list: make(map[*Client]struct{}), uidIndex: make(map[int]*Client), ipidCounts: make(map[string]int)}
+	config = &settings.Config{}
+
+	stage := area.NewArea(area.AreaData{Name: "Stage"}, 0, 10, area.EviAny)
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 0, 10, area.EviAny)
+	speakerConn, localConn, remoteConn := &captureConn{}, &captureConn{}, &captureConn{}
+	speaker := &Client{conn: speakerConn, uid: 1, ipid: "ip-1", char: -1, area: stage}
+	local := &Client{conn: localConn, uid: 2, ipid: "ip-2", char: -1, area: stage}
+	remote := &Client{conn: remoteConn, uid: 3, ipid: "ip-3", char: -1, area: lobby}
+	for _, c := range []*Client{speaker, local, remote} {
+		clients.AddClient(c)
+	}
+	ms := &packet.MSPacket{Message: encode(applySpotlight("look at me")), Showname: "Star"}
+
+	spotlightBroadcast(speaker, ms)
+	if strings.Contains(remoteConn.String(), "[Spotlight]") {
+		t.Fatal("spotlight_global off must keep the message area-local")
+	}
+
+	config.SpotlightGlobal = true
+	spotlightBroadcast(speaker, ms)
+	if !strings.Contains(remoteConn.String(), "[Spotlight] Star in Stage") {
+		t.Errorf("other areas should get the mirrored message, got %q", remoteConn.String())
+	}
+	if strings.Contains(localConn.String(), "[Spotlight]") || strings.Contains(speakerConn.String(), "[Spotlight]") {
+		t.Error("the speaker's own area already saw the IC message and must not get a duplicate")
+	}
+}
+
+func TestApplyConfusedSentenceMode(t *testing.T) {
+	oldConfig := config
+	t.Cleanup(func() { config = oldConfig })
+	config = &settings.Config{PunishmentConfig: settings.PunishmentConfig{ConfusedMode: "sentence"}}
+
+	input := "one two three. four five six? seven eight!"
+	for i := 0; i < 20; i++ {
+		result := applyConfused(input)
+		parts := splitSentences(result)
+		want := [][]string{{"one", "two", "three"}, {"four", "five", "six"}, {"seven", "eight"}}
+		if len(parts) != len(want) {
+			t.Fatalf("applyConfused(%q) = %q: %d sentences, want %d", input, result, len(parts), len(want))
+		}
+		for k, words := range want {
+			for _, w := range words {
+				if !strings.Contains(parts[k], w) {
+					t.Fatalf("applyConfused(%q) = %q: %q moved out of sentence %d", input, result, w, k+1)
+				}
+			}
+		}
+		if !strings.Contains(result, ".") || !strings.Contains(result, "?") || !strings.HasSuffix(result, "!") {
+			t.Fatalf("applyConfused(%q) = %q: sentence punctuation lost", input, result)
+		}
+	}
+
+	// Dots without following whitespace don't split a sentence.
+	if out, ok := confuseSentences("a.b.c"); !ok || len(confusedWords(out)) != 3 {
+		t.Errorf("confuseSentences(%q) = %q, %v; want one three-word sentence", "a.b.c", out, ok)
+	}
+	// One word per sentence can't be confused in place, so it falls back.
+	if _, ok := confuseSentences("a. b. c."); ok {
+		t.Error("single-word sentences should report nothing to shuffle")
+	}
+}
+
+// splitSentences splits s after each sentence-ending punctuation mark.
+func splitSentences(s string) []string {
+	var out []string
+	start := 0
+	for i, r := range s {
+		if strings.ContainsRune(".?!", r) {
+			out = append(out, s[start:i+1])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(s[start:]) != "" {
+		out = append(out, s[start:])
+	}
+	return out
+}
+
+func TestExtractIntensityFlag(t *testing.T) {
+	out, level, ok := extractIntensityFlag([]string{"-d", "5m", "7", "-i", "4"})
+	if !ok || level != "4" || strings.Join(out, " ") != "-d 5m 7" {
+		t.Errorf("extractIntensityFlag = %v, %q, %v", out, level, ok)
+	}
+	// A "-i" used as the reason text is left alone.
+	out, _, ok = extractIntensityFlag([]string{"-r", "-i", "7"})
+	if ok || strings.Join(out, " ") != "-r -i 7" {
+		t.Errorf("reason value mistaken for -i: %v, %v", out, ok)
+	}
+}
+
+func TestDrunkIntensity(t *testing.T) {
+	for data, want := range map[string]int{"": drunkDefaultIntensity, "1": 1, "5": 5, "9": drunkDefaultIntensity, "x": drunkDefaultIntensity} {
+		if got := drunkIntensity(data); got != want {
+			t.Errorf("drunkIntensity(%q) = %d, want %d", data, got, want)
+		}
+	}
+
+	// Higher intensity should mangle text noticeably more on average.
+	input := "the quick brown fox jumps over the lazy dog again and again"
+	var mild, wasted int
+	for i := 0; i < 200; i++ {
+		mild += len(applyDrunkIntensity(input, 1))
+		wasted += len(applyDrunkIntensity(input, drunkMaxIntensity))
+	}
+	if wasted <= mild {
+		t.Errorf("intensity %d output (%d bytes) should be longer than intensity 1 (%d bytes)", drunkMaxIntensity, wasted, mild)
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	for word, want := range map[string]int{
+		"syllable": 3,
+		"make":     1, // silent trailing e
+		"code":     1,
+		"table":    2, // consonant + "le" is voiced
+		"apple":    2,
+		"free":     1,
+		"the":      1,
+		"be":       1,
+		"Pond!":    1,
+		"rhythm":   1,
+		"123":      0,
+	} {
+		if got := countSyllables(word); got != want {
+			t.Errorf("countSyllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestValidateHaiku(t *testing.T) {
+	ok, counts := validateHaiku("An old silent pond / A frog jumps into the pond / Splash! Silence again")
+	if !ok {
+		t.Errorf("expected valid haiku, got counts %v", counts)
+	}
+	if ok, _ := validateHaiku("An old silent pond\nA frog jumps into the pond\nSplash! Silence again"); !ok {
+		t.Error("newline-separated haiku should be valid")
+	}
+	ok, counts = validateHaiku("hello there / objection")
+	if ok || len(counts) != 2 || counts[0] != 3 || counts[1] != 3 {
+		t.Errorf("validateHaiku(two lines) = %v, %v", ok, counts)
+	}
+	if ok, counts := validateHaiku(" / "); ok || len(counts) != 0 {
+		t.Errorf("blank message should be rejected with no counts, got %v, %v", ok, counts)
+	}
+	if msg := haikuRejection([]int{3, 3}); !strings.Contains(msg, "3-3 syllables") {
+		t.Errorf("haikuRejection = %q", msg)
+	}
+}
+
+func TestApplyEssay(t *testing.T) {
+	short := "too short"
+	if text, suppress := applyEssay(short); !suppress || text != short {
+		t.Errorf("applyEssay(%q) = %q, %v; want unchanged text and suppress", short, text, suppress)
+	}
+	long := strings.Repeat("a", essayMinLength)
+	if text, suppress := applyEssay(long); suppress || text != long {
+		t.Errorf("applyEssay(%d chars) should pass unchanged", essayMinLength)
+	}
+	// Length is counted in characters, not bytes.
+	if _, suppress := applyEssay(strings.Repeat("é", essayMinLength-1)); !suppress {
+		t.Error("multi-byte message one character short should be suppressed")
+	}
+}
+
+func TestRouletteDrops(t *testing.T) {
+	orig := config
+	t.Cleanup(func() { config = orig })
+
+	config = &settings.Config{PunishmentConfig: settings.PunishmentConfig{RouletteChance: 0}}
+	for i := 0; i < 50; i++ {
+		if rouletteDrops() {
+			t.Fatal("roulette_chance 0 should never drop")
+		}
+	}
+	config.RouletteChance = 1
+	for i := 0; i < 50; i++ {
+		if !rouletteDrops() {
+			t.Fatal("roulette_chance 1 should always drop")
+		}
+	}
+}
+
+func TestApplyOOCPunishments(t *testing.T) {
+	out, ooc := extractOOCFlag([]string{"-r", "-ooc", "7", "-ooc", "-d", "5m"})
+	if !ooc || strings.Join(out, " ") != "-r -ooc 7 -d 5m" {
+		t.Errorf("extractOOCFlag = %v, %v", out, ooc)
+	}
+
+	c := &Client{uid: 1, char: -1}
+	c.AddPunishment(PunishmentUppercase, time.Minute, "")
+	if got := applyOOCPunishments(c, "hello"); got != "hello" {
+		t.Errorf("IC-only punishment changed OOC text: %q", got)
+	}
+	c.UpdatePunishmentState(PunishmentUppercase, func(ps *PunishmentState) { ps.oocScope = true })
+	if got := applyOOCPunishments(c, "hello"); got != "HELLO" {
+		t.Errorf("applyOOCPunishments = %q, want HELLO", got)
+	}
+}
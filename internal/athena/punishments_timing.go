@@ -0,0 +1,135 @@
+/* Athena - A server for Attorney Online 2 written in Go
+   Nyathena fork additions: timing punishments.
+
+   These leave the text alone and change WHEN a message gets through:
+
+     /fastspammer   a much tighter IC rate limit than message_rate_limit:
+                    at most fastspammerLimit messages per fastspammerWindow.
+                    Messages over the limit are rejected.
+     pause          enforces pauseMinGap between the target's IC messages;
+                    anything sent early is rejected with the time left.
+     lag            holds the target's finished IC packets and releases them
+                    together lagFlushDelay after the first one, so a burst
+                    of lines lands all at once, late.
+
+   The fastspammer and pause checks run in pktIC right after the active
+   punishments are collected, before any text transform, and keep their
+   counters in the punishment's own PunishmentState (lastMsgTime/msgCount)
+   so they vanish with the punishment. The lag queue mirrors /lifo's: keyed
+   by *Client, released through the normal area broadcaster, and removed on
+   flush so a disconnect mid-batch leaks nothing. */
+
+package athena
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+)
+
+const (
+	fastspammerLimit  = 2
+	fastspammerWindow = 15 * time.Second
+	pauseMinGap       = 8 * time.Second
+	lagFlushDelay     = 5 * time.Second
+)
+
+// fastspammerAllows reports whether a /fastspammer target may send another
+// message at now, counting it against the current window if so.
+func fastspammerAllows(ps *PunishmentState, now time.Time) bool {
+	if ps.lastMsgTime.IsZero() || now.Sub(ps.lastMsgTime) >= fastspammerWindow {
+		ps.lastMsgTime = now
+		ps.msgCount = 0
+	}
+	if ps.msgCount >= fastspammerLimit {
+		return false
+	}
+	ps.msgCount++
+	return true
+}
+
+// pauseWait returns how much longer a pause target must wait before speaking
+// at now. A zero result means the message is allowed and starts a new gap.
+func pauseWait(ps *PunishmentState, now time.Time) time.Duration {
+	if !ps.lastMsgTime.IsZero() {
+		if elapsed := now.Sub(ps.lastMsgTime); elapsed < pauseMinGap {
+			return pauseMinGap - elapsed
+		}
+	}
+	ps.lastMsgTime = now
+	return 0
+}
+
+// punishmentTimingAllows applies the /fastspammer and pause gates to an IC
+// message. It returns false, after telling the sender, when the message must
+// be dropped.
+func punishmentTimingAllows(client *Client, punishments []PunishmentState, now time.Time) bool {
+	if hasPunishmentType(punishments, PunishmentPause) {
+		var wait time.Duration
+		client.UpdatePunishmentState(PunishmentPause, func(ps *PunishmentState) {
+			wait = pauseWait(ps, now)
+		})
+		if wait > 0 {
+			client.SendServerMessage(fmt.Sprintf("You must pause between messages. Try again in %v.", wait.Round(time.Second)))
+			return false
+		}
+	}
+	if hasPunishmentType(punishments, PunishmentFastspammer) {
+		allowed := true
+		client.UpdatePunishmentState(PunishmentFastspammer, func(ps *PunishmentState) {
+			allowed = fastspammerAllows(ps, now)
+		})
+		if !allowed {
+			client.SendServerMessage("You are sending messages too quickly.")
+			return false
+		}
+	}
+	return true
+}
+
+type lagQueue struct {
+	entries []lifoPending
+}
+
+var (
+	lagMu     sync.Mutex
+	lagQueues = map[*Client]*lagQueue{}
+)
+
+// lagEnqueueIC holds a finished outgoing IC packet until the client's batch
+// is released. The first message of a batch arms the release timer; later
+// ones simply join it.
+func lagEnqueueIC(client *Client, ms *packet.MSPacket) {
+	entry := lifoPending{
+		ipid:  client.Ipid(),
+		isMod: senderBypassesIgnore(client.Perms()),
+		a:     client.Area(),
+		ms:    ms,
+	}
+
+	lagMu.Lock()
+	q := lagQueues[client]
+	if q == nil {
+		q = &lagQueue{}
+		lagQueues[client] = q
+		time.AfterFunc(lagFlushDelay, func() { lagFlushClient(client) })
+	}
+	q.entries = append(q.entries, entry)
+	lagMu.Unlock()
+}
+
+// lagFlushClient releases a client's held messages in arrival order.
+func lagFlushClient(client *Client) {
+	lagMu.Lock()
+	q := lagQueues[client]
+	delete(lagQueues, client)
+	lagMu.Unlock()
+	if q == nil {
+		return
+	}
+	for _, e := range q.entries {
+		lifoBroadcastFn(e)
+	}
+}
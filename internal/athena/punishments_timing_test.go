@@ -0,0 +1,99 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+)
+
+func TestPauseWaitEnforcesGap(t *testing.T) {
+	var ps PunishmentState
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if w := pauseWait(&ps, start); w != 0 {
+		t.Fatalf("first message should pass, got wait %v", w)
+	}
+	if w := pauseWait(&ps, start.Add(3*time.Second)); w != pauseMinGap-3*time.Second {
+		t.Errorf("early message: wait = %v, want %v", w, pauseMinGap-3*time.Second)
+	}
+	// A rejected message must not restart the gap.
+	if w := pauseWait(&ps, start.Add(pauseMinGap)); w != 0 {
+		t.Errorf("message after the full gap should pass, got wait %v", w)
+	}
+	if w := pauseWait(&ps, start.Add(pauseMinGap+time.Second)); w == 0 {
+		t.Error("gap should restart from the last accepted message")
+	}
+}
+
+func TestPunishmentTimingAllowsPause(t *testing.T) {
+	client := &Client{conn: &testConn{}, uid: 1}
+	client.AddPunishment(PunishmentPause, time.Minute, "test")
+	now := time.Now()
+
+	if !punishmentTimingAllows(client, client.Punishments(), now) {
+		t.Fatal("first message under pause should be allowed")
+	}
+	if punishmentTimingAllows(client, client.Punishments(), now.Add(time.Second)) {
+		t.Error("second message inside the gap should be rejected")
+	}
+	if !punishmentTimingAllows(client, client.Punishments(), now.Add(pauseMinGap)) {
+		t.Error("message after the gap should be allowed")
+	}
+}
+
+func TestFastspammerAllows(t *testing.T) {
+	var ps PunishmentState
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < fastspammerLimit; i++ {
+		if !fastspammerAllows(&ps, start.Add(time.Duration(i)*time.Second)) {
+			t.Fatalf("message %d should fit within the limit", i+1)
+		}
+	}
+	if fastspammerAllows(&ps, start.Add(5*time.Second)) {
+		t.Error("message over the limit inside the window should be rejected")
+	}
+	if !fastspammerAllows(&ps, start.Add(fastspammerWindow)) {
+		t.Error("a new window should reset the count")
+	}
+}
+
+func TestLagReleasesBatchInOrder(t *testing.T) {
+	var released []string
+	orig := lifoBroadcastFn
+	lifoBroadcastFn = func(e lifoPending) { released = append(released, e.ms.Message) }
+	defer func() { lifoBroadcastFn = orig }()
+
+	client := &Client{uid: 43, area: newTestArea()}
+	for _, m := range []string{"first", "second", "third"} {
+		lagEnqueueIC(client, &packet.MSPacket{Message: m})
+	}
+	if len(released) != 0 {
+		t.Fatalf("lag should hold messages until the batch is released, got %v", released)
+	}
+	lagFlushClient(client)
+	if len(released) != 3 || released[0] != "first" || released[2] != "third" {
+		t.Errorf("released = %v, want arrival order", released)
+	}
+	lagFlushClient(client)
+	if len(released) != 3 {
+		t.Error("a drained queue must not release anything twice")
+	}
+}
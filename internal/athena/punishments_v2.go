@@ -17,7 +17,6 @@ package athena
 import (
 	"encoding/base64"
 	"fmt"
-	"math/rand"
 	"strconv"
 	"strings"
 	"unicode"
@@ -124,15 +123,31 @@ func capitalizeFirst(s string) string {
 
 // ── /zalgo ────────────────────────────────────────────────────────────────
 
+// zalgoDefaultIntensity is the strength /zalgo has without -l.
+const (
+	zalgoDefaultIntensity = 2
+	zalgoMaxIntensity     = 3
+)
+
+// zalgoIntensity parses the intensity stored in a /zalgo punishment's
+// customData, falling back to the default.
+func zalgoIntensity(customData string) int {
+	n, err := strconv.Atoi(customData)
+	if err != nil || n < 1 || n > zalgoMaxIntensity {
+		return zalgoDefaultIntensity
+	}
+	return n
+}
+
 // applyZalgo corrupts the text with combining marks, reusing the doki-area
-// zalgoify engine at a readable-ish intensity. Marks are ~2 bytes each so the
-// input is pre-trimmed to keep the output inside the IC budget.
-func applyZalgo(text string) string {
+// zalgoify engine with up to intensity marks per letter. Marks are ~2 bytes
+// each so the input is pre-trimmed to keep the output inside the IC budget.
+func applyZalgo(text string, intensity int) string {
 	if strings.TrimSpace(text) == "" {
 		return text
 	}
-	t := truncBytes(text, icBudget()/5)
-	return fitICBudget(dokiZalgoify(t, 2))
+	t := truncBytes(text, icBudget()/(1+2*intensity))
+	return fitICBudget(dokiZalgoify(t, intensity))
 }
 
 // ── /leetspeak ────────────────────────────────────────────────────────────
@@ -175,8 +190,8 @@ func applyLeetspeak(text string) string {
 		}, w)
 	}
 	out := strings.Join(words, " ")
-	if rand.Intn(3) == 0 {
-		out += leetSuffixes[rand.Intn(len(leetSuffixes))]
+	if rng.Intn(3) == 0 {
+		out += leetSuffixes[rng.Intn(len(leetSuffixes))]
 	}
 	return fitICBudget(out)
 }
@@ -264,8 +279,8 @@ func applyVaporwave(text string) string {
 		}
 	}
 	out := b.String()
-	if rand.Intn(4) == 0 {
-		out += vaporwaveSuffixes[rand.Intn(len(vaporwaveSuffixes))]
+	if rng.Intn(4) == 0 {
+		out += vaporwaveSuffixes[rng.Intn(len(vaporwaveSuffixes))]
 	}
 	return fitICBudget(out)
 }
@@ -305,8 +320,8 @@ func applyLisp(text string) string {
 		}
 	}
 	out := b.String()
-	if rand.Intn(5) == 0 {
-		out += lispSuffixes[rand.Intn(len(lispSuffixes))]
+	if rng.Intn(5) == 0 {
+		out += lispSuffixes[rng.Intn(len(lispSuffixes))]
 	}
 	return fitICBudget(out)
 }
@@ -354,10 +369,10 @@ func applySpoonerism(text string) string {
 var keysmashRow = []rune("asdfghjkl;")
 
 func keysmashBurst() string {
-	n := 5 + rand.Intn(5)
+	n := 5 + rng.Intn(5)
 	runes := make([]rune, n)
 	for i := range runes {
-		runes[i] = keysmashRow[rand.Intn(len(keysmashRow))]
+		runes[i] = keysmashRow[rng.Intn(len(keysmashRow))]
 	}
 	return string(runes)
 }
@@ -367,12 +382,12 @@ func applyKeysmash(text string) string {
 	if len(words) == 0 {
 		return keysmashBurst()
 	}
-	bursts := 1 + rand.Intn(2)
+	bursts := 1 + rng.Intn(2)
 	for k := 0; k < bursts; k++ {
-		pos := rand.Intn(len(words) + 1)
+		pos := rng.Intn(len(words) + 1)
 		words = append(words[:pos], append([]string{keysmashBurst()}, words[pos:]...)...)
 	}
-	if rand.Intn(4) == 0 {
+	if rng.Intn(4) == 0 {
 		words = append(words, keysmashBurst())
 	}
 	return fitICBudget(strings.Join(words, " "))
@@ -422,10 +437,10 @@ func applyPolitician(text string) string {
 		topic = "the economy"
 	}
 	out := fmt.Sprintf("%s %s the matter of \"%s…\", %s",
-		politicianOpeners[rand.Intn(len(politicianOpeners))],
-		politicianPivots[rand.Intn(len(politicianPivots))],
+		politicianOpeners[rng.Intn(len(politicianOpeners))],
+		politicianPivots[rng.Intn(len(politicianPivots))],
 		topic,
-		politicianClosers[rand.Intn(len(politicianClosers))])
+		politicianClosers[rng.Intn(len(politicianClosers))])
 	return fitICBudget(out)
 }
 
@@ -455,14 +470,14 @@ func applyClickbaitWithName(text, name string) string {
 	if snippet == "" {
 		snippet = "…"
 	}
-	n := 3 + rand.Intn(7)
-	m := 1 + rand.Intn(n)
+	n := 3 + rng.Intn(7)
+	m := 1 + rng.Intn(n)
 	out := strings.NewReplacer(
 		"{name}", name,
 		"{snippet}", snippet,
 		"{n}", strconv.Itoa(n),
 		"{m}", strconv.Itoa(m),
-	).Replace(clickbaitTemplates[rand.Intn(len(clickbaitTemplates))])
+	).Replace(clickbaitTemplates[rng.Intn(len(clickbaitTemplates))])
 	return fitICBudget(out)
 }
 
@@ -482,7 +497,7 @@ func applyAlliteration(text string) string {
 	if len(words) == 0 {
 		return text
 	}
-	letter := alliterationLetters[rand.Intn(len(alliterationLetters))]
+	letter := alliterationLetters[rng.Intn(len(alliterationLetters))]
 	for i, w := range words {
 		pre, core, post := splitWordCore(w)
 		runes := []rune(core)
@@ -496,7 +511,7 @@ func applyAlliteration(text string) string {
 		on := onsetLen(core)
 		rest := strings.ToLower(string(runes[on:])) // on==0 → whole word, letter prefixes it
 		newCore := string(letter) + rest
-		if rand.Intn(7) == 0 {
+		if rng.Intn(7) == 0 {
 			newCore = string(letter) + "-" + newCore // b-banter stutter
 		}
 		if wasCap {
@@ -551,7 +566,7 @@ func applyCipherTier(text string, tier int) string {
 
 // applyCipher is the stateless fallback (random pools): random layer per message.
 func applyCipher(text string) string {
-	return fitICBudget(applyCipherTier(text, rand.Intn(3)))
+	return fitICBudget(applyCipherTier(text, rng.Intn(3)))
 }
 
 // applyCipherWithState escalates one layer per message. Completing all three
@@ -595,7 +610,7 @@ func applyMarkov(text string, a *area.Area) string {
 	if total < 12 || len(starters) == 0 {
 		return applyTimewarp(text)
 	}
-	want := len(strings.Fields(text)) + rand.Intn(5)
+	want := len(strings.Fields(text)) + rng.Intn(5)
 	if want < 5 {
 		want = 5
 	}
@@ -603,14 +618,14 @@ func applyMarkov(text string, a *area.Area) string {
 		want = 26
 	}
 	out := make([]string, 0, want)
-	cur := starters[rand.Intn(len(starters))]
+	cur := starters[rng.Intn(len(starters))]
 	out = append(out, cur)
 	for len(out) < want {
 		nexts := chain[strings.ToLower(cur)]
 		if len(nexts) == 0 {
-			cur = starters[rand.Intn(len(starters))]
+			cur = starters[rng.Intn(len(starters))]
 		} else {
-			cur = nexts[rand.Intn(len(nexts))]
+			cur = nexts[rng.Intn(len(nexts))]
 		}
 		out = append(out, cur)
 	}
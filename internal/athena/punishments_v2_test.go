@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 	"time"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/MangosArentLiterature/Athena/internal/area"
@@ -68,7 +69,7 @@ func TestWave2PunishmentTypeRoundTrip(t *testing.T) {
 func TestWave2TransformsProduceOutput(t *testing.T) {
 	input := "Hello there partner, this is a perfectly normal test sentence about justice."
 	transforms := map[string]func(string) string{
-		"zalgo":        applyZalgo,
+		"zalgo":        func(s string) string { return applyZalgo(s, zalgoMaxIntensity) },
 		"leetspeak":    applyLeetspeak,
 		"smallcaps":    applySmallcaps,
 		"piglatin":     applyPiglatin,
@@ -422,5 +423,41 @@ func TestMegamasoPoolHasNoPlaceholders(t *testing.T) {
 		if parsePunishmentType(name) != p {
 			t.Errorf("pool entry %q does not round-trip", name)
 		}
+		// /essay drops short messages; a random roll must not silence anyone.
+		if p == PunishmentEssay {
+			t.Error("megamasoStackPool must not contain the essay message blocker")
+		}
+	}
+}
+
+// TestZalgoPreservesBaseLetters strips the combining marks back out and checks
+// the original text is what remains, at every intensity.
+func TestZalgoPreservesBaseLetters(t *testing.T) {
+	input := "Objection, your honor!"
+	strip := func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	for level := 1; level <= zalgoMaxIntensity; level++ {
+		out := applyZalgo(input, level)
+		if got := strip(out); got != input {
+			t.Errorf("intensity %d: stripped output %q, want %q", level, got, input)
+		}
+		if out == input {
+			t.Errorf("intensity %d: no marks were added", level)
+		}
+	}
+	for data, want := range map[string]int{"": zalgoDefaultIntensity, "1": 1, "3": 3, "4": zalgoDefaultIntensity, "x": zalgoDefaultIntensity} {
+		if got := zalgoIntensity(data); got != want {
+			t.Errorf("zalgoIntensity(%q) = %d, want %d", data, got, want)
+		}
+	}
+	out, level, ok := extractValueFlag([]string{"-d", "5m", "-l", "3", "7"}, "l")
+	if !ok || level != "3" || strings.Join(out, " ") != "-d 5m 7" {
+		t.Errorf("extractValueFlag = %v, %q, %v", out, level, ok)
 	}
 }
@@ -12,7 +12,6 @@
 package athena
 
 import (
-	"math/rand"
 	"strings"
 	"unicode"
 )
@@ -200,19 +199,19 @@ func applyWeeb(text string) string {
 			continue
 		}
 		// Capitalized mid-sentence words read as names — gift them an honorific.
-		if i > 0 && len([]rune(core)) > 2 && unicode.IsUpper(firstRuneOf(core)) && rand.Intn(5) < 2 {
-			words[i] = pre + core + "-" + weebHonorifics[rand.Intn(len(weebHonorifics))] + post
+		if i > 0 && len([]rune(core)) > 2 && unicode.IsUpper(firstRuneOf(core)) && rng.Intn(5) < 2 {
+			words[i] = pre + core + "-" + weebHonorifics[rng.Intn(len(weebHonorifics))] + post
 		}
 	}
 	out := strings.Join(words, " ")
-	if rand.Intn(2) == 0 {
-		out = strings.TrimSpace(weebInterjections[rand.Intn(len(weebInterjections))] + " " + out)
+	if rng.Intn(2) == 0 {
+		out = strings.TrimSpace(weebInterjections[rng.Intn(len(weebInterjections))] + " " + out)
 	}
-	if rand.Intn(5) < 3 {
-		out = strings.TrimSpace(out + " " + weebParticles[rand.Intn(len(weebParticles))])
+	if rng.Intn(5) < 3 {
+		out = strings.TrimSpace(out + " " + weebParticles[rng.Intn(len(weebParticles))])
 	}
 	if out == "" {
-		out = weebInterjections[rand.Intn(len(weebInterjections))]
+		out = weebInterjections[rng.Intn(len(weebInterjections))]
 	}
 	return fitICBudget(out)
 }
@@ -20,7 +20,6 @@ import (
 	"crypto/rand"
 	"fmt"
 	"math/big"
-	mrand "math/rand"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -35,9 +34,15 @@ const (
 	quickdrawPunishDuration   = 10 * time.Minute // how long the loser's punishment lasts
 )
 
-// randomQuickdrawPunishment picks a random punishment from the shared pool.
+// randomQuickdrawPunishment picks a random enabled punishment from the shared
+// pool, or PunishmentNone if every one is disabled (quickdrawChallenge
+// refuses to start then).
 func randomQuickdrawPunishment() PunishmentType {
-	return hotPotatoPunishmentPool[mrand.Intn(len(hotPotatoPunishmentPool))]
+	pool := enabledPunishments(hotPotatoPunishmentPool)
+	if len(pool) == 0 {
+		return PunishmentNone
+	}
+	return pool[rng.Intn(len(pool))]
 }
 
 // quickdrawWords is the large, varied pool of words players must type after "DRAW!".
@@ -88,8 +93,8 @@ var quickdrawWords = []string{
 func quickdrawPickWord() string {
 	n, err := rand.Int(rand.Reader, quickdrawWordCount)
 	if err != nil {
-		// crypto/rand failure is extraordinarily rare; fall back to math/rand.
-		return quickdrawWords[mrand.Intn(len(quickdrawWords))]
+		// crypto/rand failure is extraordinarily rare; fall back to the shared rng.
+		return quickdrawWords[rng.Intn(len(quickdrawWords))]
 	}
 	return quickdrawWords[n.Int64()]
 }
@@ -154,6 +159,10 @@ func cmdQuickdraw(client *Client, args []string, usage string) {
 // quickdrawChallenge sends a duel challenge from client to the player with targetUID.
 // bulletMode=true starts a bullet duel where the first player to send ANY IC message wins.
 func quickdrawChallenge(client *Client, targetUID int, bulletMode bool) {
+	if len(enabledPunishments(hotPotatoPunishmentPool)) == 0 {
+		client.SendServerMessage("Every punishment in the pool is disabled on this server.")
+		return
+	}
 	challengerUID := client.Uid()
 
 	if challengerUID == targetUID {
@@ -18,11 +18,15 @@ package athena
 
 import (
 	"fmt"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
 	"github.com/MangosArentLiterature/Athena/internal/settings"
 )
 
@@ -233,6 +237,67 @@ func TestModcallCooldownExpires(t *testing.T) {
 	}
 }
 
+// TestInterjectionCooldown tests that a second rapid objection is throttled
+// while normal messages in between are not.
+func TestInterjectionCooldown(t *testing.T) {
+	oldConfig := config
+	defer func() { config = oldConfig }()
+
+	config = &settings.Config{}
+	config.InterjectionCooldown = 60
+
+	client := &Client{}
+
+	if !interjectionAllowed(client, 1) {
+		t.Fatal("first objection was blocked unexpectedly")
+	}
+	if interjectionAllowed(client, 1) {
+		t.Error("second rapid objection was not throttled")
+	}
+	if interjectionAllowed(client, 2) {
+		t.Error("a different shout within the cooldown was not throttled")
+	}
+	if !interjectionAllowed(client, 0) {
+		t.Error("a normal message was throttled by the interjection cooldown")
+	}
+
+	config.InterjectionCooldown = 0
+	if !interjectionAllowed(client, 1) {
+		t.Error("objection was throttled with the cooldown disabled")
+	}
+}
+
+// TestScreenEffectCooldown tests that a second rapid screenshake/realization
+// message has its effects stripped while plain messages pass untouched.
+func TestScreenEffectCooldown(t *testing.T) {
+	oldConfig := config
+	defer func() { config = oldConfig }()
+
+	config = &settings.Config{}
+	config.EffectCooldown = 60
+
+	client := &Client{}
+	flash := func() *packet.MSPacket { return &packet.MSPacket{Screenshake: "1", Realization: "1"} }
+
+	first := flash()
+	if throttleScreenEffects(client, first) || first.Screenshake != "1" || first.Realization != "1" {
+		t.Fatal("first flashing message was throttled unexpectedly")
+	}
+	second := flash()
+	if !throttleScreenEffects(client, second) || second.Screenshake != "0" || second.Realization != "0" {
+		t.Error("second rapid flashing message kept its effects")
+	}
+	plain := &packet.MSPacket{Screenshake: "0", Realization: "0"}
+	if throttleScreenEffects(client, plain) {
+		t.Error("a plain message was throttled by the effect cooldown")
+	}
+
+	config.EffectCooldown = 0
+	if third := flash(); throttleScreenEffects(client, third) {
+		t.Error("flashing message was throttled with the cooldown disabled")
+	}
+}
+
 // TestRateLimitMemoryEfficiency tests that old timestamps are cleaned up
 func TestRateLimitMemoryEfficiency(t *testing.T) {
 	// Backup original config
@@ -1403,3 +1468,47 @@ func TestRawPacketRateLimitIndependentFromMessage(t *testing.T) {
 		t.Errorf("Raw packet rate limit should not be exceeded just because message rate limit was")
 	}
 }
+
+// TestModcallCommand checks /modcall reaches moderators with its reason and
+// is then held back by the modcall cooldown.
+func TestModcallCommand(t *testing.T) {
+	newTestClients(t)
+	oldConfig, oldLogPath := config, logger.LogPath
+	t.Cleanup(func() {
+		config, logger.LogPath = oldConfig, oldLogPath
+		ipModcallTracker.mu.Lock()
+		delete(ipModcallTracker.times, "modcall-ipid")
+		ipModcallTracker.mu.Unlock()
+	})
+	config = &settings.Config{}
+	config.ModcallCooldown = 60
+	logger.LogPath = t.TempDir()
+
+	a := makeTestArea("Courtroom")
+	callerConn, modConn := &captureConn{}, &captureConn{}
+	caller := &Client{conn: callerConn, uid: 1, ipid: "modcall-ipid", char: -1, area: a}
+	mod := &Client{conn: modConn, uid: 2, ipid: "mod-ipid", char: -1, area: a}
+	mod.SetAuthenticated(true)
+	mod.SetPerms(permissions.PermissionField["KICK"])
+	for _, c := range []*Client{caller, mod} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdModcall(caller, []string{"spam", "in", "court"}, "")
+	if out := modConn.String(); !strings.Contains(out, "ZZ#") || !strings.Contains(out, "spam in court") {
+		t.Errorf("moderator should receive the modcall with its reason, got %q", out)
+	}
+	if out := callerConn.String(); !strings.Contains(out, "modcall was sent") {
+		t.Errorf("caller should be told the modcall went out, got %q", out)
+	}
+
+	seen := len(modConn.String())
+	cmdModcall(caller, nil, "")
+	if out := callerConn.String(); !strings.Contains(out, "You must wait") {
+		t.Errorf("second modcall should hit the cooldown, got %q", out)
+	}
+	if len(modConn.String()) != seen {
+		t.Error("a modcall blocked by the cooldown should not reach moderators")
+	}
+}
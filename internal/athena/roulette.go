@@ -18,7 +18,6 @@ package athena
 
 import (
 	"fmt"
-	"math/rand"
 	"sync"
 	"time"
 
@@ -143,12 +142,22 @@ var rrCursePunishmentPool = []PunishmentType{
 	PunishmentWhisper,
 }
 
+// randomRRPunishment returns a random enabled punishment from the pool, or
+// PunishmentNone if every one is disabled (rrStart refuses to start then).
 func randomRRPunishment() PunishmentType {
-	return rrPunishmentPool[rand.Intn(len(rrPunishmentPool))]
+	pool := enabledPunishments(rrPunishmentPool)
+	if len(pool) == 0 {
+		return PunishmentNone
+	}
+	return pool[rng.Intn(len(pool))]
 }
 
-// randomRRPunishmentExcluding returns a random punishment that differs from the excluded one.
+// randomRRPunishmentExcluding returns a random punishment that differs from
+// the excluded one, unless it is the only one left enabled.
 func randomRRPunishmentExcluding(exclude PunishmentType) PunishmentType {
+	if len(enabledPunishments(rrPunishmentPool)) < 2 {
+		return randomRRPunishment()
+	}
 	for {
 		p := randomRRPunishment()
 		if p != exclude {
@@ -157,8 +166,14 @@ func randomRRPunishmentExcluding(exclude PunishmentType) PunishmentType {
 	}
 }
 
+// randomRRCursePunishment returns a random enabled survivor curse, or
+// PunishmentNone if every one is disabled.
 func randomRRCursePunishment() PunishmentType {
-	return rrCursePunishmentPool[rand.Intn(len(rrCursePunishmentPool))]
+	pool := enabledPunishments(rrCursePunishmentPool)
+	if len(pool) == 0 {
+		return PunishmentNone
+	}
+	return pool[rng.Intn(len(pool))]
 }
 
 // ── Flavour text ─────────────────────────────────────────────────────────────
@@ -318,6 +333,10 @@ func cmdRussianRoulette(client *Client, args []string, usage string) {
 
 // rrStart opens the join window for a new Russian Roulette game.
 func rrStart(client *Client) {
+	if len(enabledPunishments(rrPunishmentPool)) == 0 {
+		client.SendServerMessage("Every punishment in the pool is disabled on this server.")
+		return
+	}
 	st := rrGetState(client.Area())
 	st.mu.Lock()
 
@@ -410,7 +429,7 @@ func rrJoinTimer(st *rrState, starterName string) {
 	}
 
 	// Shuffle player order and load the cylinder.
-	rand.Shuffle(n, func(i, j int) { st.players[i], st.players[j] = st.players[j], st.players[i] })
+	rng.Shuffle(n, func(i, j int) { st.players[i], st.players[j] = st.players[j], st.players[i] })
 
 	bullets := rrInitialBullets()
 	st.joinActive = false
@@ -419,7 +438,7 @@ func rrJoinTimer(st *rrState, starterName string) {
 	copy(players, st.players)
 	st.mu.Unlock()
 
-	gunName := rrGunNames[rand.Intn(len(rrGunNames))]
+	gunName := rrGunNames[rng.Intn(len(rrGunNames))]
 	bulletWord := "bullet"
 	if bullets > 1 {
 		bulletWord = "bullets"
@@ -427,7 +446,7 @@ func rrJoinTimer(st *rrState, starterName string) {
 	sendAreaServerMessage(st.area, fmt.Sprintf(
 		"🔫 %v raises '%v' — %d %s loaded into %d chambers. The cylinder spins...\n%s",
 		starterName, gunName, bullets, bulletWord, rrChambers,
-		rrTensionMessages[rand.Intn(len(rrTensionMessages))],
+		rrTensionMessages[rng.Intn(len(rrTensionMessages))],
 	))
 
 	go rrRun(st, players, bullets)
@@ -437,7 +456,7 @@ func rrJoinTimer(st *rrState, starterName string) {
 
 // rrInitialBullets returns the number of bullets to load for a new cylinder spin.
 func rrInitialBullets() int {
-	if rand.Intn(100) < rrDoubleBulletP {
+	if rng.Intn(100) < rrDoubleBulletP {
 		return 2
 	}
 	return 1
@@ -464,28 +483,28 @@ func rrRun(st *rrState, players []int, bullets int) {
 		// Tension flavour: regular tension every other round; critical messages when ≤2 remain.
 		if i > 0 {
 			if remaining <= 2 {
-				sendAreaServerMessage(st.area, rrCriticalMessages[rand.Intn(len(rrCriticalMessages))])
+				sendAreaServerMessage(st.area, rrCriticalMessages[rng.Intn(len(rrCriticalMessages))])
 				time.Sleep(time.Second)
 			} else if i%2 == 0 {
-				sendAreaServerMessage(st.area, rrTensionMessages[rand.Intn(len(rrTensionMessages))])
+				sendAreaServerMessage(st.area, rrTensionMessages[rng.Intn(len(rrTensionMessages))])
 				time.Sleep(time.Second)
 			}
 		}
 
 		// Probability of hitting a bullet this chamber.
-		hit := rand.Intn(remaining) < alive
+		hit := rng.Intn(remaining) < alive
 
 		// Ricochet: rare chance — redirect to a random OTHER player.
 		victim := shooterUID
 		victimName := shooterName
-		if hit && rand.Intn(100) < rrRicochetP && len(players) > 1 {
+		if hit && rng.Intn(100) < rrRicochetP && len(players) > 1 {
 			eligible := make([]int, 0, len(players)-1)
 			for _, p := range players {
 				if p != shooterUID {
 					eligible = append(eligible, p)
 				}
 			}
-			victim = eligible[rand.Intn(len(eligible))]
+			victim = eligible[rng.Intn(len(eligible))]
 			if vc, verr := getClientByUid(victim); verr == nil {
 				victimName = vc.OOCName()
 			}
@@ -498,17 +517,17 @@ func rrRun(st *rrState, players []int, bullets int) {
 
 		if hit {
 			// ── BANG ──────────────────────────────────────────────────────────
-			bangMsg := rrBangMessages[rand.Intn(len(rrBangMessages))]
+			bangMsg := rrBangMessages[rng.Intn(len(rrBangMessages))]
 			sendAreaServerMessage(st.area, fmt.Sprintf("%s\n%v takes the hit!", bangMsg, victimName))
 
 			pType := randomRRPunishment()
 
 			// Double Punishment: victim earns two punishments at once.
-			doubleHit := rand.Intn(100) < rrDoublePunishP
+			doubleHit := rng.Intn(100) < rrDoublePunishP
 			var pType2 PunishmentType
 			if doubleHit {
 				pType2 = randomRRPunishmentExcluding(pType)
-				sendAreaServerMessage(st.area, rrDoublePunishMessages[rand.Intn(len(rrDoublePunishMessages))])
+				sendAreaServerMessage(st.area, rrDoublePunishMessages[rng.Intn(len(rrDoublePunishMessages))])
 				time.Sleep(time.Second)
 			}
 
@@ -537,8 +556,8 @@ func rrRun(st *rrState, players []int, bullets int) {
 			}
 
 			// Chain Shot: a second random player also takes a (different) punishment.
-			if rand.Intn(100) < rrChainShotP && len(players) > 1 {
-				chainMsg := rrChainMessages[rand.Intn(len(rrChainMessages))]
+			if rng.Intn(100) < rrChainShotP && len(players) > 1 {
+				chainMsg := rrChainMessages[rng.Intn(len(rrChainMessages))]
 				sendAreaServerMessage(st.area, chainMsg)
 				time.Sleep(time.Second)
 				// Build an explicit list of eligible players (everyone except the current victim).
@@ -549,7 +568,7 @@ func rrRun(st *rrState, players []int, bullets int) {
 					}
 				}
 				if len(eligible) > 0 {
-					chainUID := eligible[rand.Intn(len(eligible))]
+					chainUID := eligible[rng.Intn(len(eligible))]
 					chainPType := randomRRPunishmentExcluding(pType)
 					if chainC, cerr := getClientByUid(chainUID); cerr == nil {
 						chainC.AddPunishment(chainPType, rrPunishDuration, "Russian Roulette: chain shot")
@@ -588,13 +607,16 @@ func rrRun(st *rrState, players []int, bullets int) {
 			}
 
 			// Survivor Curse: rare chance all survivors also get a minor punishment.
-			if len(survivorUIDs) > 0 && rand.Intn(100) < rrSurvivorCurseP {
+			if len(survivorUIDs) > 0 && rng.Intn(100) < rrSurvivorCurseP {
 				time.Sleep(time.Second)
-				sendAreaServerMessage(st.area, rrSurvivorCurseMessages[rand.Intn(len(rrSurvivorCurseMessages))])
+				sendAreaServerMessage(st.area, rrSurvivorCurseMessages[rng.Intn(len(rrSurvivorCurseMessages))])
 				time.Sleep(time.Second)
 				for _, sUID := range survivorUIDs {
 					if sc, scerr := getClientByUid(sUID); scerr == nil {
 						cursePType := randomRRCursePunishment()
+						if cursePType == PunishmentNone {
+							continue
+						}
 						sc.AddPunishment(cursePType, rrCurseDuration, "Russian Roulette: survivor curse")
 						sc.SendServerMessage(fmt.Sprintf(
 							"👻 Survivor curse! Punished with '%v' for %v.", cursePType, rrCurseDuration))
@@ -622,14 +644,14 @@ func rrRun(st *rrState, players []int, bullets int) {
 		sendAreaServerMessage(st.area, fmt.Sprintf(
 			"%v's turn — %s (%d/%d chambers remain)",
 			shooterName,
-			rrClickMessages[rand.Intn(len(rrClickMessages))],
+			rrClickMessages[rng.Intn(len(rrClickMessages))],
 			remaining, rrChambers,
 		))
 
 		// Cylinder Re-Spin: rare chance the cylinder resets mid-game.
-		if remaining > 0 && rand.Intn(100) < rrReSpinP {
+		if remaining > 0 && rng.Intn(100) < rrReSpinP {
 			time.Sleep(time.Second)
-			sendAreaServerMessage(st.area, rrReSpinMessages[rand.Intn(len(rrReSpinMessages))])
+			sendAreaServerMessage(st.area, rrReSpinMessages[rng.Intn(len(rrReSpinMessages))])
 			remaining = rrChambers
 			alive = rrInitialBullets()
 			time.Sleep(time.Second)
@@ -641,7 +663,7 @@ func rrRun(st *rrState, players []int, bullets int) {
 		// remaining after decrement), pick a random victim anyway.
 		if remaining == 0 {
 			time.Sleep(rrShotPause)
-			victim = players[rand.Intn(len(players))]
+			victim = players[rng.Intn(len(players))]
 			pType := randomRRPunishment()
 			vc, verr := getClientByUid(victim)
 			if verr == nil {
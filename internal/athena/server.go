@@ -73,6 +73,15 @@ var (
 	connRateLimitWindowDur   time.Duration
 )
 
+// Minigame timings. The initial values are the defaults; NewServer replaces
+// them from config so the handlers never multiply on each call.
+var (
+	rpsCooldown    = 30 * time.Second
+	coinflipExpiry = 30 * time.Second
+	pollCooldown   = 5 * time.Minute
+	pollDuration   = 2 * time.Minute
+)
+
 var (
 	config *settings.Config
 	// The character list, music list, background list, parrot list, 8-ball
@@ -204,6 +213,7 @@ type Server struct {
 	backgrounds            []string
 	parrot                 []string
 	eightBall              []string
+	locales                map[string]map[string]string
 	cdns                   []string
 	areas                  []*area.Area
 	areaNames              string
@@ -300,7 +310,7 @@ func NewServer(conf *settings.Config) (*Server, error) {
 		tournamentParticipants: make(map[int]*TournamentParticipant),
 	}
 
-	s.uids.InitHeap(conf.MaxPlayers)
+	s.uids.InitHeap(serverCapacity(conf.MaxPlayers, conf.MaxSpectators))
 
 	// Load server data.
 	var err error
@@ -359,6 +369,10 @@ func NewServer(conf *settings.Config) (*Server, error) {
 	if loaded, eerr := settings.LoadFile("/8ball.txt"); eerr == nil {
 		s.eightBall = loaded
 	}
+	s.locales, err = settings.LoadLocales(conf.Locales)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load locales: %v", err)
+	}
 	s.cdns = settings.LoadCDNs()
 	_, err = str2duration.ParseDuration(conf.BanLen)
 	if err != nil {
@@ -379,6 +393,8 @@ func NewServer(conf *settings.Config) (*Server, error) {
 	if conf.PunishmentWebhookURL != "" {
 		webhook.PunishmentWebhookURL = conf.PunishmentWebhookURL
 	}
+	webhook.KickNotices = conf.WebhookKicks
+	webhook.BanNotices = conf.WebhookBans
 
 	// Load areas.
 	s.areas = make([]*area.Area, 0, len(areaData))
@@ -396,6 +412,8 @@ func NewServer(conf *settings.Config) (*Server, error) {
 			evi_mode = area.EviCMs
 		case "mods":
 			evi_mode = area.EviMods
+		case "owner":
+			evi_mode = area.EviOwner
 		default:
 			logger.LogWarningf("Area %v has an invalid or undefined evidence mode, defaulting to 'cms'.", a.Name)
 			evi_mode = area.EviCMs
@@ -406,6 +424,7 @@ func NewServer(conf *settings.Config) (*Server, error) {
 		}
 		s.areas = append(s.areas, area.NewAreaWithVoiceDefault(a, len(s.characters), conf.BufSize, evi_mode, conf.DefaultAreaVoiceAllowed))
 	}
+	checkAreaTemplateBackgrounds(conf.AreaTemplates, bgSet)
 	s.areaNames = areaNameBuilder.String()
 
 	// Build O(1) area-index lookup map.
@@ -414,6 +433,29 @@ func NewServer(conf *settings.Config) (*Server, error) {
 		s.areaIndexMap[a] = i
 	}
 
+	// Bring back runtime area settings and evidence saved before the last
+	// shutdown. Saved evidence is kept when an area empties out.
+	if conf.PersistAreaEvidence {
+		if err := loadAreaEvidence(conf.EvidenceDir, s.areas); err != nil {
+			logger.LogErrorf("Evidence persistence disabled: %v", err)
+			conf.PersistAreaEvidence = false
+		} else {
+			for _, a := range s.areas {
+				a.SetKeepEvidence(true)
+			}
+		}
+	}
+	if conf.PersistAreaState {
+		if err := restoreAreaState(areaStatePath(), s.areas, bgSet, conf.PersistAreaTestimony, conf.PersistAreaLocks); err != nil {
+			logger.LogErrorf("Failed to restore area state: %v", err)
+		}
+	}
+
+	// Restore the giveaway / hot potato cooldowns so a restart can't skip them.
+	if err := restoreEventCooldowns(eventCooldownPath()); err != nil {
+		logger.LogErrorf("Failed to restore event cooldowns: %v", err)
+	}
+
 	// Initialize area logging if enabled.
 	logger.EnableAreaLogging = conf.EnableAreaLogging
 	if logger.EnableAreaLogging {
@@ -467,6 +509,14 @@ func NewServer(conf *settings.Config) (*Server, error) {
 	oocRateLimitWindowDur = time.Duration(config.OOCRateLimitWindow) * time.Second
 	rawPktRateLimitWindowDur = time.Duration(float64(time.Second) * config.RawPacketRateLimitWindow)
 	connRateLimitWindowDur = time.Duration(config.ConnRateLimitWindow) * time.Second
+	rpsCooldown = time.Duration(config.RpsCooldown) * time.Second
+	pollCooldown = time.Duration(config.PollCooldown) * time.Second
+	if config.CoinflipExpiry > 0 {
+		coinflipExpiry = time.Duration(config.CoinflipExpiry) * time.Second
+	}
+	if config.PollDuration > 0 {
+		pollDuration = time.Duration(config.PollDuration) * time.Second
+	}
 	// Publish the hot-reloadable data behind their atomic.Pointers. setCharacters
 	// derives the name→ID index and setBackgrounds derives the /bglist string, so
 	// neither needs to be assigned separately here. These are read at runtime via
@@ -476,6 +526,7 @@ func NewServer(conf *settings.Config) (*Server, error) {
 	setBackgrounds(s.backgrounds)
 	setParrotList(s.parrot)
 	setEightBall(s.eightBall)
+	setLocales(s.locales)
 	setCDNs(s.cdns)
 	areas = s.areas
 	areaNames = s.areaNames
@@ -499,10 +550,12 @@ func NewServer(conf *settings.Config) (*Server, error) {
 	initCommands()
 	validateCommands()
 	initAutoMod(conf)
+	checkPunishmentWeights(conf)
 	initShownameCensor()
 	initShownamePunisher()
 	initFromSoftWords()
 	initCvote(conf)
+	initDisabledPunishments(conf)
 	initHotConfig(conf)
 	initMusicBans()
 	// Initialise the goroutine pool if a limit is configured.
@@ -514,6 +567,8 @@ func NewServer(conf *settings.Config) (*Server, error) {
 	// Initialize the player-capacity lockdown threshold from config.
 	playerLockdownThreshold.Store(int32(conf.PlayerLockdownThreshold))
 	go startConnTrackerCleanup()
+	go startJoinCodeCleanup()
+	go startPunishmentExpiry(punishExpiryDone)
 	if conf.EnableCasino {
 		go startHourlyChipAward()
 		go startUnscrambleLoop()
@@ -521,6 +576,15 @@ func NewServer(conf *settings.Config) (*Server, error) {
 	if conf.EnableNewspaper {
 		go startNewspaperLoop()
 	}
+	if conf.PersistAreaState && conf.AreaStateSaveInterval > 0 {
+		go startAreaStateLoop(time.Duration(conf.AreaStateSaveInterval) * time.Second)
+	}
+	if conf.BanPurgeInterval > 0 {
+		go startBanPurgeLoop(time.Duration(conf.BanPurgeInterval) * time.Second)
+	}
+	if conf.IdleKickMinutes > 0 {
+		go startIdleKickLoop(time.Duration(conf.IdleKickMinutes) * time.Minute)
+	}
 	return s, nil
 }
 
@@ -540,9 +604,11 @@ func (s *Server) StartDiscordBot() {
 		return
 	}
 	cfg := discordbot.Config{
-		Token:     s.config.BotToken,
-		GuildID:   s.config.GuildID,
-		ModRoleID: s.config.ModRoleID,
+		Token:          s.config.BotToken,
+		GuildID:        s.config.GuildID,
+		ModRoleID:      s.config.ModRoleID,
+		ICRelayChannel: s.config.ICRelayChannel,
+		ICRelayAreas:   s.config.ICRelayAreas,
 	}
 	b, err := discordbot.New(cfg, NewServerAdapter())
 	if err != nil {
@@ -783,8 +849,17 @@ func HandleWS(w http.ResponseWriter, r *http.Request) {
 	go client.HandleClient()
 }
 
-// CleanupServer closes all connections to the server and closes the database.
+// CleanupServer saves area state and evidence if enabled, stops the
+// punishment expiry worker, closes all connections to the server and closes
+// the database.
 func (s *Server) CleanupServer() {
+	if s.config.PersistAreaState {
+		persistAreaState(s.areas)
+	}
+	if s.config.PersistAreaEvidence {
+		saveAreaEvidence(s.config.EvidenceDir, s.areas)
+	}
+	stopPunishmentExpiry()
 	clients.ForEach(func(client *Client) {
 		client.conn.Close()
 	})
@@ -1058,6 +1133,11 @@ func getClientsByIpid(ipid string) []*Client {
 	return clients.GetByIPID(ipid)
 }
 
+// getClientsByHdid returns all clients with the given hdid.
+func getClientsByHdid(hdid string) []*Client {
+	return clients.GetByHDID(hdid)
+}
+
 // sendAreaServerMessage sends a server OOC message to all clients in an area.
 func sendAreaServerMessage(area *area.Area, message string) {
 	broadcastToArea(area, &packet.CTToClient{Name: encodedServerName, Message: encode(message), IsFromServer: "1"})
@@ -18,7 +18,6 @@ package athena
 
 import (
 	"fmt"
-	"math/rand"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -179,16 +178,21 @@ func dripShownamePunishment(client *Client) {
 	// Pick a punishment the target isn't already wearing, falling back to
 	// "any" if the whole pool is somehow already applied — same roll /megamaso
 	// and /minefield use.
+	// Disabled punishments are left out; with none left the drip is skipped.
+	pool := enabledPunishments(megamasoStackPool)
+	if len(pool) == 0 {
+		return
+	}
 	var pick PunishmentType
 	for tries := 0; tries < 16; tries++ {
-		candidate := megamasoStackPool[rand.Intn(len(megamasoStackPool))]
+		candidate := pool[rng.Intn(len(pool))]
 		if !client.HasPunishment(candidate) {
 			pick = candidate
 			break
 		}
 	}
 	if pick == PunishmentNone {
-		pick = megamasoStackPool[rand.Intn(len(megamasoStackPool))]
+		pick = pool[rng.Intn(len(pool))]
 	}
 
 	reason := fmt.Sprintf("Punished showname (matched %q)", matched)
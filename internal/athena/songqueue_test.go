@@ -0,0 +1,110 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// TestSongQueue runs a request through /songqueue: players queue songs, a
+// second identical request and one past the cap are refused, and only a CM
+// can play the oldest request with /songqueue next.
+func TestSongQueue(t *testing.T) {
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	config.SongQueueMax = 2
+
+	a := newTestArea()
+	cleanup := setupTestAreas([]*area.Area{a})
+	defer cleanup()
+	conn := &captureConn{}
+	player := &Client{conn: conn, uid: 1, ipid: "p", area: a, char: 0, oocName: "Player"}
+	cm := &Client{conn: &captureConn{}, uid: 2, ipid: "c", area: a, char: 1, oocName: "CM"}
+	for _, c := range []*Client{player, cm} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+	a.AddCM(cm.Uid())
+
+	cmdSongQueue(player, []string{"Trial.opus"}, "usage")
+	cmdSongQueue(player, []string{"trial.opus"}, "usage")
+	if !strings.Contains(conn.String(), "already have that song queued") {
+		t.Errorf("expected a repeated request to be refused, got %q", conn.String())
+	}
+	cmdSongQueue(cm, []string{"Cross.opus"}, "usage")
+	before := len(conn.String())
+	cmdSongQueue(player, []string{"Lobby.opus"}, "usage")
+	if out := conn.String()[before:]; !strings.Contains(out, "queue is full") {
+		t.Errorf("expected a request past the cap to be refused, got %q", out)
+	}
+
+	before = len(conn.String())
+	cmdSongQueue(player, []string{"next"}, "usage")
+	if out := conn.String()[before:]; !strings.Contains(out, "must be a CM") || len(a.SongQueue()) != 2 {
+		t.Errorf("a player should not be able to advance the queue, got %q", out)
+	}
+	before = len(conn.String())
+	cmdSongQueue(cm, []string{"next"}, "usage")
+	if out := conn.String()[before:]; !strings.Contains(out, "MC#Trial.opus#1#") {
+		t.Errorf("expected the oldest request to be played to the area, got %q", out)
+	}
+	if q := a.SongQueue(); len(q) != 1 || q[0].Song != "Cross.opus" {
+		t.Errorf("SongQueue() = %+v, want only Cross.opus left", q)
+	}
+}
+
+// TestSongQueueChecksURL verifies that a URL from a CDN that is not
+// whitelisted is refused when queued, and that a queued song /play would
+// refuse is never announced as playing.
+func TestSongQueueChecksURL(t *testing.T) {
+	newTestClients(t)
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{}
+	config.SongQueueMax = 5
+	origCDNs := getCDNs()
+	t.Cleanup(func() { setCDNs(origCDNs) })
+	setCDNs([]string{"trusted.example"})
+
+	a := newTestArea()
+	cleanup := setupTestAreas([]*area.Area{a})
+	defer cleanup()
+	conn := &captureConn{}
+	cm := &Client{conn: conn, uid: 1, ipid: "c", area: a, char: 0, oocName: "CM"}
+	clients.AddClient(cm)
+	clients.RegisterUID(cm)
+	a.AddCM(cm.Uid())
+
+	cmdSongQueue(cm, []string{"https://evil.example/track.opus"}, "usage")
+	if !strings.Contains(conn.String(), "not from a whitelisted CDN") || len(a.SongQueue()) != 0 {
+		t.Fatalf("expected a non-whitelisted URL to be refused, got %q", conn.String())
+	}
+
+	// A request queued before the CDN list changed.
+	a.RequestSong(area.SongRequest{Song: "https://evil.example/track.opus", UID: 1, Name: "CM"}, 5)
+	before := len(conn.String())
+	cmdSongQueue(cm, []string{"next"}, "usage")
+	if out := conn.String()[before:]; strings.Contains(out, "Now playing") || strings.Contains(out, "MC#") {
+		t.Errorf("a refused track should not be announced or played, got %q", out)
+	}
+}
@@ -0,0 +1,65 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+)
+
+// serverCapacity returns how many clients may be connected at once:
+// max_players plus any spectator-only slots from max_spectators.
+func serverCapacity(maxPlayers, maxSpectators int) int {
+	if maxSpectators < 0 {
+		maxSpectators = 0
+	}
+	return maxPlayers + maxSpectators
+}
+
+// characterSlotsFull reports whether max_spectators is in effect and every one
+// of the max_players character slots is already held by a joined client.
+func characterSlotsFull() bool {
+	if config.MaxSpectators <= 0 {
+		return false
+	}
+	held := 0
+	clients.ForEach(func(c *Client) {
+		if c.Uid() != -1 && c.CharID() != -1 {
+			held++
+		}
+	})
+	return held >= config.MaxPlayers
+}
+
+// characterSlotDenied reports whether a spectator must stay one because all
+// character slots are taken. Clients already holding a character (switching
+// between characters) and moderators are never denied. ChangeCharacter
+// enforces this for every caller.
+func (client *Client) characterSlotDenied() bool {
+	return client.CharID() == -1 && !permissions.IsModerator(client.Perms()) && characterSlotsFull()
+}
+
+// characterSlotBlocked is characterSlotDenied for paths the player started
+// themselves, telling them why they have to keep spectating.
+func (client *Client) characterSlotBlocked() bool {
+	if !client.characterSlotDenied() {
+		return false
+	}
+	client.SendServerMessage(fmt.Sprintf("All %d player slots are taken, so you can only spectate for now. Try again when someone leaves or goes back to spectating.", config.MaxPlayers))
+	return true
+}
@@ -0,0 +1,77 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestSpectatorCapBlocksCharacterSelection(t *testing.T) {
+	origConfig, origClients := config, clients
+	t.Cleanup(func() { config, clients = origConfig, origClients })
+	clients = &ClientList{list: make(map[*Client]struct{}), uidIndex: make(map[int]*Client), ipidCounts: make(map[string]int)}
+	config = &settings.Config{ServerConfig: settings.ServerConfig{MaxPlayers: 2, MaxSpectators: 3}}
+
+	if got := serverCapacity(config.MaxPlayers, config.MaxSpectators); got != 5 {
+		t.Fatalf("serverCapacity = %d, want 5", got)
+	}
+
+	p1 := &Client{conn: &testConn{}, uid: 0, ipid: "ip-1", char: 0}
+	p2 := &Client{conn: &testConn{}, uid: 1, ipid: "ip-2", char: -1}
+	spec := &Client{conn: &testConn{}, uid: 2, ipid: "ip-3", char: -1}
+	mod := &Client{conn: &testConn{}, uid: 3, ipid: "ip-4", char: -1, perms: permissions.PermissionField["MUTE"]}
+	for _, c := range []*Client{p1, p2, spec, mod} {
+		clients.AddClient(c)
+	}
+
+	// One slot is still free: nobody is blocked.
+	if spec.characterSlotBlocked() {
+		t.Fatal("spectator blocked while a player slot is free")
+	}
+
+	p2.SetCharID(1)
+	if !spec.characterSlotBlocked() {
+		t.Error("spectator should be blocked once every player slot is held")
+	}
+	// Potions, curses and forced swaps all go through ChangeCharacter.
+	spec.ChangeCharacter(5)
+	if spec.CharID() != -1 {
+		t.Error("ChangeCharacter gave a spectator a character past the cap")
+	}
+	if p1.characterSlotBlocked() {
+		t.Error("a client already holding a character must be able to switch")
+	}
+	if mod.characterSlotBlocked() {
+		t.Error("moderators should bypass the spectator cap")
+	}
+
+	// A player going back to spectating frees the slot again.
+	p2.SetCharID(-1)
+	if spec.characterSlotBlocked() {
+		t.Error("spectator still blocked after a slot freed up")
+	}
+
+	// Without max_spectators the cap is not in effect at all.
+	config.MaxSpectators = 0
+	p2.SetCharID(1)
+	if spec.characterSlotBlocked() {
+		t.Error("max_spectators = 0 must not restrict character selection")
+	}
+}
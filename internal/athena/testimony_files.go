@@ -0,0 +1,116 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// testimonyDir is the directory, relative to the config directory, that
+// /testimony save and load use.
+const testimonyDir = "testimonies"
+
+// maxSavedTestimonies caps how many testimonies can be saved on the server.
+const maxSavedTestimonies = 100
+
+var (
+	errTestimonyExists = errors.New("a testimony with that name is already saved")
+	errTestimonyLimit  = errors.New("too many testimonies are saved")
+)
+
+// validTestimonyNameRe limits saved testimony names to characters that are
+// safe in a file name, so a name can never escape testimonyDir.
+var validTestimonyNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// validTestimonyStatement reports whether s is an IC message in the server
+// wire form the recorder stores, so playback can broadcast it as-is.
+func validTestimonyStatement(s string) bool {
+	n := len(strings.Split(s, "#"))
+	return n == 30 || n == 31
+}
+
+// testimonyPath returns the file a testimony called name is saved to.
+func testimonyPath(name string) string {
+	return filepath.Join(settings.ConfigPath, testimonyDir, name+".json")
+}
+
+// saveTestimony writes the area's recorded testimony to the file for name.
+// Saved testimonies are shared by every area, so an existing file is only
+// replaced when force is set, and a new file is refused once
+// maxSavedTestimonies are saved.
+func saveTestimony(a *area.Area, name string, force bool) error {
+	data, err := json.MarshalIndent(a.TstStatements(), "", "  ")
+	if err != nil {
+		return err
+	}
+	path := testimonyPath(name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); err == nil {
+		if !force {
+			return errTestimonyExists
+		}
+	} else if errors.Is(err, fs.ErrNotExist) {
+		saved, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.json"))
+		if err != nil {
+			return err
+		}
+		if len(saved) >= maxSavedTestimonies {
+			return errTestimonyLimit
+		}
+	} else {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// loadTestimony reads the testimony saved as name into the area's recorder.
+// A file without any statements after the title, or with an entry that is
+// not a recorded IC message, is refused.
+func loadTestimony(a *area.Area, name string) (int, error) {
+	data, err := os.ReadFile(testimonyPath(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, fmt.Errorf("no saved testimony named %q", name)
+	} else if err != nil {
+		return 0, err
+	}
+	var statements []string
+	if err := json.Unmarshal(data, &statements); err != nil {
+		return 0, fmt.Errorf("saved testimony %q is corrupt", name)
+	}
+	if len(statements) < 2 {
+		return 0, fmt.Errorf("saved testimony %q has no statements", name)
+	}
+	for _, s := range statements {
+		if !validTestimonyStatement(s) {
+			return 0, fmt.Errorf("saved testimony %q is corrupt", name)
+		}
+	}
+	a.TstLoad(statements)
+	return len(statements) - 1, nil
+}
@@ -0,0 +1,199 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// recordedStatement returns an IC message in the wire form the testimony
+// recorder stores.
+func recordedStatement(msg string) string {
+	return (&packet.MSPacket{Character: "Phoenix", Message: msg}).ServerString()
+}
+
+// TestTestimonySaveLoad verifies that /testimony save and load round-trip a
+// recording, reject unsafe names and refuse files without statements.
+func TestTestimonySaveLoad(t *testing.T) {
+	origPath := settings.ConfigPath
+	t.Cleanup(func() { settings.ConfigPath = origPath })
+	settings.ConfigPath = t.TempDir()
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court})()
+
+	conn := &captureConn{}
+	cm := &Client{conn: conn, uid: 1, ipid: "cm", char: -1, area: court}
+	clients.AddClient(cm)
+	clients.RegisterUID(cm)
+	court.AddCM(cm.Uid())
+
+	statements := []string{recordedStatement("title"), recordedStatement("first"), recordedStatement("second")}
+	court.TstLoad(statements)
+	cmdTestimony(cm, []string{"save", "case_3"}, "")
+	if !strings.Contains(conn.String(), "Saved the testimony as 'case_3'.") {
+		t.Fatalf("save failed: %q", conn.String())
+	}
+
+	court.TstClear()
+	cmdTestimony(cm, []string{"load", "case_3"}, "")
+	if !strings.Contains(conn.String(), "Loaded testimony 'case_3' (2 statement(s))") {
+		t.Fatalf("load failed: %q", conn.String())
+	}
+	if got := court.TstStatements(); strings.Join(got, "|") != strings.Join(statements, "|") {
+		t.Errorf("loaded statements = %v, want %v", got, statements)
+	}
+
+	prev := len(conn.String())
+	cmdTestimony(cm, []string{"load", "../area_state"}, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "Names may use") {
+		t.Errorf("an unsafe name should be rejected: %q", out)
+	}
+
+	if err := os.WriteFile(filepath.Join(settings.ConfigPath, testimonyDir, "empty.json"), []byte(`["MS#title#"]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prev = len(conn.String())
+	cmdTestimony(cm, []string{"load", "empty"}, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "has no statements") {
+		t.Errorf("a testimony without statements should be refused: %q", out)
+	}
+	if court.TstLen() != 3 {
+		t.Errorf("a refused load should leave the recorder alone, got %d entries", court.TstLen())
+	}
+
+	plain := `["Cross-exam 1","The defendant was at home","He never left"]`
+	if err := os.WriteFile(filepath.Join(settings.ConfigPath, testimonyDir, "plain.json"), []byte(plain), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prev = len(conn.String())
+	cmdTestimony(cm, []string{"load", "plain"}, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "is corrupt") {
+		t.Errorf("a file of plain strings should be refused: %q", out)
+	}
+	if got := court.TstStatements(); strings.Join(got, "|") != strings.Join(statements, "|") {
+		t.Errorf("a refused load replaced the testimony with %v", got)
+	}
+}
+
+// TestGetTestimonyDecodes verifies that the Discord adapter returns the
+// decoded statement text and nothing for an unknown area.
+func TestGetTestimonyDecodes(t *testing.T) {
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court})()
+	court.TstLoad([]string{recordedStatement("title"), recordedStatement("I saw <num>1 car<percent>")})
+
+	got := (&ServerAdapter{}).GetTestimony(0)
+	if len(got) != 1 || got[0] != "I saw #1 car%" {
+		t.Errorf("GetTestimony(0) = %q, want [\"I saw #1 car%%\"]", got)
+	}
+	if got := (&ServerAdapter{}).GetTestimony(1); got != nil {
+		t.Errorf("GetTestimony(1) = %q, want nil", got)
+	}
+}
+
+// TestTestimonySaveOverwriteAndCap verifies that saving over an existing
+// testimony needs -f, and that new saves stop at maxSavedTestimonies.
+func TestTestimonySaveOverwriteAndCap(t *testing.T) {
+	origPath := settings.ConfigPath
+	t.Cleanup(func() { settings.ConfigPath = origPath })
+	settings.ConfigPath = t.TempDir()
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court})()
+
+	conn := &captureConn{}
+	cm := &Client{conn: conn, uid: 1, ipid: "cm", char: -1, area: court}
+	clients.AddClient(cm)
+	clients.RegisterUID(cm)
+	court.AddCM(cm.Uid())
+
+	court.TstLoad([]string{recordedStatement("title"), recordedStatement("first")})
+	cmdTestimony(cm, []string{"save", "case_4"}, "")
+	court.TstLoad([]string{recordedStatement("title"), recordedStatement("second")})
+	prev := len(conn.String())
+	cmdTestimony(cm, []string{"save", "case_4"}, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "already saved") {
+		t.Errorf("saving over an existing testimony without -f should be refused: %q", out)
+	}
+	prev = len(conn.String())
+	cmdTestimony(cm, []string{"save", "case_4", "-f"}, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "Saved the testimony as 'case_4'.") {
+		t.Errorf("-f should overwrite the saved testimony: %q", out)
+	}
+
+	dir := filepath.Join(settings.ConfigPath, testimonyDir)
+	for i := 1; i < maxSavedTestimonies; i++ {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("filler_%d.json", i)), []byte("[]"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	prev = len(conn.String())
+	cmdTestimony(cm, []string{"save", "case_5"}, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "already holds") {
+		t.Errorf("a new save past the cap should be refused: %q", out)
+	}
+	prev = len(conn.String())
+	cmdTestimony(cm, []string{"save", "case_4", "-f"}, "")
+	if out := conn.String()[prev:]; !strings.Contains(out, "Saved the testimony") {
+		t.Errorf("overwriting should still work at the cap: %q", out)
+	}
+}
+
+// TestTestimonyBareRecordKeepsSwitchedTestimony verifies that a bare
+// /testimony record after a switch records into the default testimony
+// instead of wiping the one switched to.
+func TestTestimonyBareRecordKeepsSwitchedTestimony(t *testing.T) {
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court})()
+
+	conn := &captureConn{}
+	cm := &Client{conn: conn, uid: 1, ipid: "cm", char: -1, area: court}
+	clients.AddClient(cm)
+	clients.RegisterUID(cm)
+	court.AddCM(cm.Uid())
+
+	court.TstSwitch("Witness B", true)
+	court.TstLoad([]string{recordedStatement("title"), recordedStatement("first"), recordedStatement("second")})
+	court.TstSwitch(area.DefaultTestimony, true)
+
+	cmdTestimony(cm, []string{"switch", "Witness", "B"}, "")
+	cmdTestimony(cm, []string{"record"}, "")
+	if court.TstName() != area.DefaultTestimony {
+		t.Errorf("a bare record should use %q, got %q", area.DefaultTestimony, court.TstName())
+	}
+	cmdTestimony(cm, []string{"stop"}, "")
+
+	prev := len(conn.String())
+	cmdTestimony(cm, []string{"list"}, "")
+	out := conn.String()[prev:]
+	if !strings.Contains(out, "Witness B: 2 statement(s)") || strings.Contains(out, "Witness B: 2 statement(s) (active)") {
+		t.Errorf("Witness B should keep its statements, list = %q", out)
+	}
+	if !strings.Contains(out, "default: 0 statement(s) (active)") {
+		t.Errorf("the bare record should be the active default testimony, list = %q", out)
+	}
+}
@@ -0,0 +1,295 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+)
+
+// tttTimeout is how long a challenge waits to be accepted, and how long a
+// player has to make each move, before the game is called off.
+const tttTimeout = 2 * time.Minute
+
+// tttLines are the board's winning rows, columns and diagonals.
+var tttLines = [8][3]int{
+	{0, 1, 2}, {3, 4, 5}, {6, 7, 8},
+	{0, 3, 6}, {1, 4, 7}, {2, 5, 8},
+	{0, 4, 8}, {2, 4, 6},
+}
+
+// tttMu guards every area's TicTacToe game. Both players' commands and the
+// timeout timers run on their own goroutines.
+var tttMu sync.Mutex
+
+// tttMarks maps Turn to the mark that player places.
+var tttMarks = [2]byte{'X', 'O'}
+
+// tttWinner returns the mark that owns a full line, or 0 if nobody does.
+func tttWinner(board [9]byte) byte {
+	for _, l := range tttLines {
+		if m := board[l[0]]; m != 0 && m == board[l[1]] && m == board[l[2]] {
+			return m
+		}
+	}
+	return 0
+}
+
+// tttRender draws the board, numbering the empty cells so players can see
+// which moves are left.
+func tttRender(board [9]byte) string {
+	var sb strings.Builder
+	for row := 0; row < 3; row++ {
+		if row > 0 {
+			sb.WriteString("\n---+---+---\n")
+		}
+		for col := 0; col < 3; col++ {
+			if col > 0 {
+				sb.WriteString("|")
+			}
+			cell := board[row*3+col]
+			if cell == 0 {
+				cell = byte('1' + row*3 + col)
+			}
+			sb.WriteString(" " + string(cell) + " ")
+		}
+	}
+	return sb.String()
+}
+
+// tttSeat returns the caller's index in g.Players, or -1.
+func tttSeat(g *area.TicTacToe, uid int) int {
+	for i, p := range g.Players {
+		if p == uid {
+			return i
+		}
+	}
+	return -1
+}
+
+// Handles /ttt
+
+func cmdTicTacToe(client *Client, args []string, usage string) {
+	switch strings.ToLower(args[0]) {
+	case "accept":
+		tttAccept(client)
+		return
+	case "resign":
+		tttResign(client)
+		return
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		client.SendServerMessage(usage)
+		return
+	}
+	// A number is a move for someone already playing in this area, and a
+	// challenge target for everyone else.
+	tttMu.Lock()
+	g := client.Area().ActiveTicTacToe()
+	playing := g != nil && g.Accepted && tttSeat(g, client.Uid()) != -1
+	tttMu.Unlock()
+	if playing {
+		tttMove(client, n)
+	} else {
+		tttChallenge(client, n)
+	}
+}
+
+// tttChallenge opens a game against the player with the given UID.
+func tttChallenge(client *Client, uid int) {
+	target, err := getClientByUid(uid)
+	if err != nil || target.Area() != client.Area() {
+		client.SendServerMessage("That player is not in this area.")
+		return
+	}
+	if target == client {
+		client.SendServerMessage("You cannot challenge yourself.")
+		return
+	}
+	a := client.Area()
+
+	tttMu.Lock()
+	if a.ActiveTicTacToe() != nil {
+		tttMu.Unlock()
+		client.SendServerMessage("A tic-tac-toe game is already running in this area.")
+		return
+	}
+	g := &area.TicTacToe{
+		Players: [2]int{client.Uid(), target.Uid()},
+		Names:   [2]string{oocDisplayName(client), oocDisplayName(target)},
+		IPIDs:   [2]string{client.Ipid(), target.Ipid()},
+	}
+	a.SetActiveTicTacToe(g)
+	tttMu.Unlock()
+
+	sendAreaServerMessage(a, fmt.Sprintf("❌⭕ %v challenged %v to tic-tac-toe! %v, type /ttt accept within %d minutes to play.",
+		g.Names[0], g.Names[1], g.Names[1], int(tttTimeout.Minutes())))
+	addToBuffer(client, "GAME", fmt.Sprintf("Challenged UID %v to tic-tac-toe", target.Uid()), false)
+	tttArmTimeout(a, g, false, 0)
+}
+
+// tttAccept starts the game for the challenged player.
+func tttAccept(client *Client) {
+	a := client.Area()
+
+	tttMu.Lock()
+	g := a.ActiveTicTacToe()
+	if g == nil || g.Accepted || g.Players[1] != client.Uid() {
+		tttMu.Unlock()
+		client.SendServerMessage("You have no tic-tac-toe challenge to accept.")
+		return
+	}
+	g.Accepted = true
+	board := tttRender(g.Board)
+	msg := fmt.Sprintf("❌⭕ %v (X) vs %v (O)\n%v\n%v (X) moves first: /ttt <1-9>", g.Names[0], g.Names[1], board, g.Names[0])
+	tttMu.Unlock()
+
+	sendAreaServerMessage(a, msg)
+	addToBuffer(client, "GAME", "Accepted a tic-tac-toe challenge", false)
+	tttArmTimeout(a, g, true, 0)
+}
+
+// tttMove places the caller's mark in cell (1-9) and resolves the game on a
+// win or a full board.
+func tttMove(client *Client, cell int) {
+	a := client.Area()
+
+	tttMu.Lock()
+	g := a.ActiveTicTacToe()
+	if g == nil || !g.Accepted {
+		tttMu.Unlock()
+		return
+	}
+	seat := tttSeat(g, client.Uid())
+	if seat != g.Turn {
+		tttMu.Unlock()
+		client.SendServerMessage("It is not your turn.")
+		return
+	}
+	if cell < 1 || cell > 9 {
+		tttMu.Unlock()
+		client.SendServerMessage("Pick a cell from 1 to 9.")
+		return
+	}
+	if g.Board[cell-1] != 0 {
+		tttMu.Unlock()
+		client.SendServerMessage("That cell is already taken.")
+		return
+	}
+	g.Board[cell-1] = tttMarks[seat]
+	g.Moves++
+	board := tttRender(g.Board)
+	var msg string
+	done, winner := true, -1
+	switch {
+	case tttWinner(g.Board) != 0:
+		msg = fmt.Sprintf("❌⭕ %v\n🎉 %v wins tic-tac-toe against %v!", board, g.Names[seat], g.Names[1-seat])
+		winner = seat
+	case g.Moves == len(g.Board):
+		msg = fmt.Sprintf("❌⭕ %v\nIt's a draw between %v and %v!", board, g.Names[0], g.Names[1])
+	default:
+		done = false
+		g.Turn = 1 - seat
+		msg = fmt.Sprintf("❌⭕ %v\n%v (%c) to move.", board, g.Names[g.Turn], tttMarks[g.Turn])
+	}
+	if done {
+		a.SetActiveTicTacToe(nil)
+	}
+	moves := g.Moves
+	tttMu.Unlock()
+
+	sendAreaServerMessage(a, msg)
+	addToBuffer(client, "GAME", fmt.Sprintf("Tic-tac-toe move: %c at %d", tttMarks[seat], cell), false)
+	if winner != -1 {
+		recordGameOutcome(gameTicTacToe, g.IPIDs[winner], g.Names[winner], g.IPIDs[1-winner], g.Names[1-winner])
+	}
+	if !done {
+		tttArmTimeout(a, g, true, moves)
+	}
+}
+
+// tttResign ends the caller's game, handing the win to the other player.
+func tttResign(client *Client) {
+	a := client.Area()
+
+	tttMu.Lock()
+	g := a.ActiveTicTacToe()
+	seat := -1
+	if g != nil {
+		seat = tttSeat(g, client.Uid())
+	}
+	if seat == -1 {
+		tttMu.Unlock()
+		client.SendServerMessage("You are not in a tic-tac-toe game.")
+		return
+	}
+	a.SetActiveTicTacToe(nil)
+	accepted := g.Accepted
+	tttMu.Unlock()
+
+	if accepted {
+		sendAreaServerMessage(a, fmt.Sprintf("❌⭕ %v resigned. %v wins tic-tac-toe!", g.Names[seat], g.Names[1-seat]))
+		recordGameOutcome(gameTicTacToe, g.IPIDs[1-seat], g.Names[1-seat], g.IPIDs[seat], g.Names[seat])
+	} else {
+		sendAreaServerMessage(a, fmt.Sprintf("❌⭕ The tic-tac-toe challenge between %v and %v was withdrawn.", g.Names[0], g.Names[1]))
+	}
+	addToBuffer(client, "GAME", "Resigned from tic-tac-toe", false)
+}
+
+// tttArmTimeout calls the game off if it is still waiting on the same move
+// once tttTimeout has passed.
+func tttArmTimeout(a *area.Area, g *area.TicTacToe, accepted bool, moves int) {
+	time.AfterFunc(tttTimeout, func() {
+		tttMu.Lock()
+		if a.ActiveTicTacToe() != g || g.Accepted != accepted || g.Moves != moves {
+			tttMu.Unlock()
+			return
+		}
+		a.SetActiveTicTacToe(nil)
+		waiting := g.Names[g.Turn]
+		if !accepted {
+			waiting = g.Names[1]
+		}
+		tttMu.Unlock()
+		sendAreaServerMessage(a, fmt.Sprintf("⌛ Tic-tac-toe between %v and %v timed out waiting on %v.", g.Names[0], g.Names[1], waiting))
+	})
+}
+
+// tttLeave ends any game the client is part of in area a. It is called when
+// the client leaves the area or disconnects.
+func tttLeave(client *Client, a *area.Area) {
+	if a == nil {
+		return
+	}
+	tttMu.Lock()
+	g := a.ActiveTicTacToe()
+	if g == nil || tttSeat(g, client.Uid()) == -1 {
+		tttMu.Unlock()
+		return
+	}
+	a.SetActiveTicTacToe(nil)
+	tttMu.Unlock()
+
+	sendAreaServerMessage(a, fmt.Sprintf("❌⭕ Tic-tac-toe between %v and %v ended because %v left.",
+		g.Names[0], g.Names[1], g.Names[tttSeat(g, client.Uid())]))
+}
@@ -0,0 +1,124 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+)
+
+func TestTttWinner(t *testing.T) {
+	tests := []struct {
+		board string
+		want  byte
+	}{
+		{"XXX......", 'X'},
+		{"O..O..O..", 'O'},
+		{"X...X...X", 'X'},
+		{"..O.O.O..", 'O'},
+		{"XOXXOOOXX", 0},
+		{".........", 0},
+	}
+	for _, tt := range tests {
+		var b [9]byte
+		for i, c := range []byte(tt.board) {
+			if c != '.' {
+				b[i] = c
+			}
+		}
+		if got := tttWinner(b); got != tt.want {
+			t.Errorf("tttWinner(%q) = %q, want %q", tt.board, got, tt.want)
+		}
+	}
+}
+
+// TestTicTacToeGame plays a game to a win and checks that only the player
+// whose turn it is may move.
+func TestTicTacToeGame(t *testing.T) {
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court})()
+
+	xConn, oConn, watchConn := &captureConn{}, &captureConn{}, &captureConn{}
+	x := &Client{conn: xConn, uid: 1, ipid: "x", char: -1, area: court, oocName: "Phoenix"}
+	o := &Client{conn: oConn, uid: 2, ipid: "o", char: -1, area: court, oocName: "Edgeworth"}
+	watcher := &Client{conn: watchConn, uid: 3, ipid: "w", char: -1, area: court, oocName: "Maya"}
+	for _, c := range []*Client{x, o, watcher} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdTicTacToe(x, []string{"2"}, "usage")
+	cmdTicTacToe(x, []string{"accept"}, "usage")
+	if !strings.Contains(xConn.String(), "no tic-tac-toe challenge") {
+		t.Error("the challenger should not be able to accept their own challenge")
+	}
+	cmdTicTacToe(o, []string{"accept"}, "usage")
+
+	before := len(oConn.String())
+	cmdTicTacToe(o, []string{"5"}, "usage")
+	if !strings.Contains(oConn.String()[before:], "not your turn") {
+		t.Error("O should not be able to move first")
+	}
+	cmdTicTacToe(watcher, []string{"5"}, "usage")
+	if g := court.ActiveTicTacToe(); g == nil || g.Board[4] != 0 {
+		t.Fatal("a bystander's number should not place a mark")
+	}
+
+	for _, m := range []struct {
+		c    *Client
+		cell string
+	}{{x, "1"}, {o, "4"}, {x, "2"}, {o, "1"}, {o, "5"}, {x, "3"}} {
+		cmdTicTacToe(m.c, []string{m.cell}, "usage")
+	}
+	if !strings.Contains(oConn.String(), "already taken") {
+		t.Error("playing on an occupied cell should be refused")
+	}
+	if court.ActiveTicTacToe() != nil {
+		t.Fatal("the game should be cleared after a win")
+	}
+	if out := watchConn.String(); !strings.Contains(out, "Phoenix wins tic-tac-toe") || !strings.Contains(out, " X | X | X ") {
+		t.Errorf("bystanders should see the final board and result, got %q", out)
+	}
+}
+
+// TestTicTacToeLeave verifies a game is dropped when a player leaves.
+func TestTicTacToeLeave(t *testing.T) {
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court})()
+
+	conn := &captureConn{}
+	x := &Client{conn: &captureConn{}, uid: 1, ipid: "x", char: -1, area: court, oocName: "Phoenix"}
+	o := &Client{conn: conn, uid: 2, ipid: "o", char: -1, area: court, oocName: "Edgeworth"}
+	for _, c := range []*Client{x, o} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdTicTacToe(x, []string{"2"}, "usage")
+	cmdTicTacToe(o, []string{"accept"}, "usage")
+	tttLeave(x, court)
+	if court.ActiveTicTacToe() != nil {
+		t.Fatal("the game should end when a player leaves the area")
+	}
+	if !strings.Contains(conn.String(), "ended because Phoenix left") {
+		t.Errorf("the remaining player should be told why, got %q", conn.String())
+	}
+}
@@ -0,0 +1,61 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestTournamentResults(t *testing.T) {
+	start := time.Now()
+	participants := map[int]*TournamentParticipant{
+		1: {uid: 1, messageCount: 5, joinedAt: start.Add(time.Second)},
+		2: {uid: 2, messageCount: 9, joinedAt: start},
+		3: {uid: 3, messageCount: 5, joinedAt: start},
+		4: {uid: 4, messageCount: 0, joinedAt: start},
+	}
+
+	ranked, afk := tournamentResults(participants, false)
+	if len(afk) != 0 || len(ranked) != 4 {
+		t.Fatalf("without AFK drop: ranked %d, afk %v; want 4 ranked, none dropped", len(ranked), afk)
+	}
+	want := []int{2, 3, 1, 4}
+	for i, p := range ranked {
+		if p.uid != want[i] {
+			t.Fatalf("rank %d = UID %d, want %d (ties go to whoever joined first)", i+1, p.uid, want[i])
+		}
+	}
+
+	ranked, afk = tournamentResults(participants, true)
+	if len(afk) != 1 || afk[0] != 4 || len(ranked) != 3 || ranked[len(ranked)-1].uid != 1 {
+		t.Errorf("with AFK drop: afk %v, last place UID %d; want UID 4 dropped and UID 1 last", afk, ranked[len(ranked)-1].uid)
+	}
+}
+
+func TestTournamentSettingsLine(t *testing.T) {
+	origConfig := config
+	t.Cleanup(func() { config = origConfig })
+	config = &settings.Config{ServerConfig: settings.ServerConfig{TournamentMinPlayers: 3, TournamentDropAFK: true}}
+
+	want := "Settings: minimum participants 3, last-place consolation off, AFK drop on"
+	if got := tournamentSettingsLine(); got != want {
+		t.Errorf("tournamentSettingsLine() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,354 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/permissions"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// ── Constants ────────────────────────────────────────────────────────────────
+
+const (
+	triviaFile             = "/trivia.txt"
+	triviaDefaultQuestions = 5                // questions per game when no count is given
+	triviaMaxQuestions     = 20               // upper bound for /trivia start <n>
+	triviaAnswerWindow     = 30 * time.Second // time to answer before the question expires
+	triviaQuestionGap      = 5 * time.Second  // pause between one question and the next
+	triviaCooldown         = 3 * time.Minute  // global delay between games
+)
+
+// ── State ────────────────────────────────────────────────────────────────────
+
+// triviaQuestion is one question|answer line from trivia.txt.
+type triviaQuestion struct {
+	Question string
+	Answer   string
+}
+
+// triviaState holds the mutex-protected lifecycle state of the trivia game.
+// Only one game runs at a time; it is played in the host's area. State
+// mutation happens under the mutex; all I/O is performed after release.
+type triviaState struct {
+	mu        sync.Mutex
+	active    bool
+	area      *area.Area
+	hostUID   int
+	questions []triviaQuestion
+	index     int            // index of the question currently being asked
+	open      bool           // true while the current question accepts answers
+	seq       int            // bumped per question so stale timers can tell they lost
+	scores    map[int]int    // correct answers per UID
+	names     map[int]string // display name per UID at the time they scored
+	ipids     map[int]string // IPID per UID, for win/loss records
+	lastEnd   time.Time      // when the last game ended (drives the cooldown)
+}
+
+var trivia = triviaState{hostUID: -1}
+
+// ── Helpers ──────────────────────────────────────────────────────────────────
+
+// parseTrivia turns question|answer lines into questions, skipping blank or
+// malformed lines.
+func parseTrivia(lines []string) []triviaQuestion {
+	var qs []triviaQuestion
+	for _, line := range lines {
+		q, a, ok := strings.Cut(line, "|")
+		q, a = strings.TrimSpace(q), strings.TrimSpace(a)
+		if !ok || q == "" || a == "" {
+			continue
+		}
+		qs = append(qs, triviaQuestion{Question: q, Answer: a})
+	}
+	return qs
+}
+
+// normalizeTriviaAnswer folds an answer for comparison: case-insensitive,
+// ignoring surrounding whitespace.
+func normalizeTriviaAnswer(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// pickTrivia returns n questions from pool in random order.
+func pickTrivia(pool []triviaQuestion, n int) []triviaQuestion {
+	if n > len(pool) {
+		n = len(pool)
+	}
+	picked := make([]triviaQuestion, n)
+	for i, j := range rng.Perm(len(pool))[:n] {
+		picked[i] = pool[j]
+	}
+	return picked
+}
+
+// triviaLeaderboard formats the final scores, highest first. Must be called
+// with trivia.mu held.
+func triviaLeaderboard() string {
+	type entry struct {
+		uid   int
+		score int
+	}
+	entries := make([]entry, 0, len(trivia.scores))
+	for uid, score := range trivia.scores {
+		entries = append(entries, entry{uid, score})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].score != entries[j].score {
+			return entries[i].score > entries[j].score
+		}
+		return entries[i].uid < entries[j].uid
+	})
+	if len(entries) == 0 {
+		return "Nobody answered correctly this time."
+	}
+	var sb strings.Builder
+	sb.WriteString("LEADERBOARD:")
+	for i, e := range entries {
+		sb.WriteString(fmt.Sprintf("\n%d. %v (UID %d) - %d point(s)", i+1, trivia.names[e.uid], e.uid, e.score))
+	}
+	return sb.String()
+}
+
+// ── Command entry point ──────────────────────────────────────────────────────
+
+// cmdTrivia is the entry point for /trivia start [n] and /trivia stop.
+func cmdTrivia(client *Client, args []string, usage string) {
+	switch args[0] {
+	case "start":
+		n := triviaDefaultQuestions
+		if len(args) > 1 {
+			v, err := strconv.Atoi(args[1])
+			if err != nil || v < 1 || v > triviaMaxQuestions {
+				client.SendServerMessage(fmt.Sprintf("The question count must be between 1 and %d.", triviaMaxQuestions))
+				return
+			}
+			n = v
+		}
+		triviaStart(client, n)
+	case "stop":
+		triviaStop(client)
+	default:
+		client.SendServerMessage(usage)
+	}
+}
+
+// ── Start / stop ─────────────────────────────────────────────────────────────
+
+// triviaStart loads trivia.txt and opens a game of n questions in the
+// caller's area.
+func triviaStart(client *Client, n int) {
+	lines, err := settings.LoadFile(triviaFile)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		client.SendServerMessage("Failed to read trivia.txt.")
+		return
+	}
+	pool := parseTrivia(lines)
+	if len(pool) == 0 {
+		client.SendServerMessage("No trivia questions are configured.")
+		return
+	}
+
+	a := client.Area()
+	uid := client.Uid()
+
+	trivia.mu.Lock()
+	if trivia.active {
+		trivia.mu.Unlock()
+		client.SendServerMessage("A trivia game is already in progress.")
+		return
+	}
+	if !trivia.lastEnd.IsZero() {
+		if remaining := triviaCooldown - time.Since(trivia.lastEnd); remaining > 0 {
+			trivia.mu.Unlock()
+			client.SendServerMessage(fmt.Sprintf("Trivia is on cooldown. Please wait %d seconds.", int(remaining.Seconds())+1))
+			return
+		}
+	}
+	trivia.active = true
+	trivia.area = a
+	trivia.hostUID = uid
+	trivia.questions = pickTrivia(pool, n)
+	trivia.index = -1
+	trivia.open = false
+	trivia.scores = make(map[int]int)
+	trivia.names = make(map[int]string)
+	trivia.ipids = make(map[int]string)
+	count := len(trivia.questions)
+	trivia.mu.Unlock()
+
+	sendAreaServerMessage(a, fmt.Sprintf(
+		"❓ TRIVIA started by %v! %d question(s) coming up.\n"+
+			"Type your answer in OOC chat — the first correct answer scores a point.",
+		oocDisplayName(client), count,
+	))
+	addToBuffer(client, "GAME", fmt.Sprintf("Started trivia with %d question(s)", count), false)
+	triviaNext()
+}
+
+// triviaStop ends the game early. Only the host, CMs, and moderators may stop.
+func triviaStop(client *Client) {
+	uid := client.Uid()
+
+	trivia.mu.Lock()
+	if !trivia.active {
+		trivia.mu.Unlock()
+		client.SendServerMessage("No trivia game is active.")
+		return
+	}
+	if trivia.hostUID != uid && !permissions.IsModerator(client.Perms()) && !trivia.area.HasCM(uid) {
+		trivia.mu.Unlock()
+		client.SendServerMessage("Only the game host, CMs, or moderators can stop trivia.")
+		return
+	}
+	a, board, _ := triviaFinish()
+	trivia.mu.Unlock()
+
+	sendAreaServerMessage(a, fmt.Sprintf("❓ TRIVIA stopped by %v.\n%v", oocDisplayName(client), board))
+	addToBuffer(client, "GAME", "Stopped trivia", false)
+}
+
+// triviaFinish closes the game and returns its area, final leaderboard and
+// each scorer's result: the top score wins, everyone else who scored loses.
+// Results are only recorded for games that reach their last question.
+// Must be called with trivia.mu held.
+func triviaFinish() (*area.Area, string, []gameResult) {
+	a := trivia.area
+	board := triviaLeaderboard()
+	best := 0
+	for _, score := range trivia.scores {
+		if score > best {
+			best = score
+		}
+	}
+	results := make([]gameResult, 0, len(trivia.scores))
+	for uid, score := range trivia.scores {
+		results = append(results, gameResult{IPID: trivia.ipids[uid], Name: trivia.names[uid], Won: score == best})
+	}
+	trivia.active = false
+	trivia.open = false
+	trivia.seq++
+	trivia.area = nil
+	trivia.hostUID = -1
+	trivia.lastEnd = time.Now().UTC()
+	return a, board, results
+}
+
+// ── Question flow ────────────────────────────────────────────────────────────
+
+// triviaNext posts the next question, or ends the game with the leaderboard
+// once every question has been asked.
+func triviaNext() {
+	trivia.mu.Lock()
+	if !trivia.active {
+		trivia.mu.Unlock()
+		return
+	}
+	trivia.index++
+	if trivia.index >= len(trivia.questions) {
+		a, board, results := triviaFinish()
+		trivia.mu.Unlock()
+		recordGameResults(gameTrivia, results)
+		sendAreaServerMessage(a, "🏁 TRIVIA OVER!\n"+board)
+		return
+	}
+	trivia.open = true
+	trivia.seq++
+	seq := trivia.seq
+	a := trivia.area
+	q := trivia.questions[trivia.index]
+	msg := fmt.Sprintf("❓ Question %d/%d: %v\nYou have %d seconds!",
+		trivia.index+1, len(trivia.questions), q.Question, int(triviaAnswerWindow.Seconds()))
+	trivia.mu.Unlock()
+
+	sendAreaServerMessage(a, msg)
+	time.AfterFunc(triviaAnswerWindow, func() { triviaExpire(seq) })
+}
+
+// triviaExpire reveals the answer when nobody got question seq in time.
+func triviaExpire(seq int) {
+	trivia.mu.Lock()
+	if !trivia.active || !trivia.open || trivia.seq != seq {
+		trivia.mu.Unlock()
+		return
+	}
+	trivia.open = false
+	a := trivia.area
+	answer := trivia.questions[trivia.index].Answer
+	trivia.mu.Unlock()
+
+	sendAreaServerMessage(a, "⌛ Time's up! The answer was: "+answer)
+	time.AfterFunc(triviaQuestionGap, triviaNext)
+}
+
+// ── Hooks ────────────────────────────────────────────────────────────────────
+
+// triviaOnOOC is called from pktOOC for every chat message. The first
+// correct answer from a player in the game's area scores a point.
+func triviaOnOOC(client *Client, msgText string) {
+	guess := normalizeTriviaAnswer(msgText)
+	if guess == "" {
+		return
+	}
+	uid, ipid := client.Uid(), client.Ipid()
+	name := oocDisplayName(client)
+
+	trivia.mu.Lock()
+	if !trivia.active || !trivia.open || client.Area() != trivia.area {
+		trivia.mu.Unlock()
+		return
+	}
+	answer := trivia.questions[trivia.index].Answer
+	if guess != normalizeTriviaAnswer(answer) {
+		trivia.mu.Unlock()
+		return
+	}
+	trivia.open = false
+	trivia.scores[uid]++
+	trivia.names[uid] = name
+	trivia.ipids[uid] = ipid
+	score := trivia.scores[uid]
+	a := trivia.area
+	trivia.mu.Unlock()
+
+	sendAreaServerMessage(a, fmt.Sprintf("✅ %v got it! The answer was: %v (%d point(s))", name, answer, score))
+	addToBuffer(client, "GAME", "Answered trivia: "+answer, false)
+	time.AfterFunc(triviaQuestionGap, triviaNext)
+}
+
+// handleTriviaDisconnect ends the game when its host leaves the server, so
+// nobody is left waiting on a game that can no longer be stopped by its host.
+func handleTriviaDisconnect(client *Client) {
+	trivia.mu.Lock()
+	if !trivia.active || trivia.hostUID != client.Uid() {
+		trivia.mu.Unlock()
+		return
+	}
+	a, board, _ := triviaFinish()
+	trivia.mu.Unlock()
+
+	sendAreaServerMessage(a, "❓ TRIVIA ended because the host disconnected.\n"+board)
+}
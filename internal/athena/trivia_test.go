@@ -0,0 +1,151 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+// resetTriviaState clears the global trivia game between tests.
+func resetTriviaState() {
+	trivia.mu.Lock()
+	trivia.active = false
+	trivia.open = false
+	trivia.area = nil
+	trivia.hostUID = -1
+	trivia.lastEnd = time.Time{}
+	trivia.mu.Unlock()
+}
+
+func TestParseTrivia(t *testing.T) {
+	qs := parseTrivia([]string{
+		"Capital of France? | Paris",
+		"no separator here",
+		" | missing question",
+		"Missing answer? |",
+		"",
+		"2 + 2? |4",
+	})
+	if len(qs) != 2 {
+		t.Fatalf("parseTrivia kept %d questions, want 2: %+v", len(qs), qs)
+	}
+	if qs[0].Question != "Capital of France?" || qs[0].Answer != "Paris" || qs[1].Answer != "4" {
+		t.Errorf("unexpected questions %+v", qs)
+	}
+	if got := len(pickTrivia(qs, 10)); got != 2 {
+		t.Errorf("pickTrivia should cap at the pool size, got %d", got)
+	}
+}
+
+// TestTriviaGame plays a one-question game: answers from other areas are
+// ignored, a correct answer is matched case-insensitively, and the game ends
+// with a leaderboard and a cooldown.
+func TestTriviaGame(t *testing.T) {
+	origPath := settings.ConfigPath
+	t.Cleanup(func() { settings.ConfigPath = origPath })
+	settings.ConfigPath = t.TempDir()
+	if err := os.WriteFile(filepath.Join(settings.ConfigPath, "trivia.txt"), []byte("Capital of France?|Paris\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resetTriviaState()
+	t.Cleanup(resetTriviaState)
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	lobby := area.NewArea(area.AreaData{Name: "Lobby"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court, lobby})()
+
+	hostConn, outConn := &captureConn{}, &captureConn{}
+	host := &Client{conn: hostConn, uid: 1, ipid: "host", char: -1, area: court, oocName: "Host"}
+	player := &Client{conn: &captureConn{}, uid: 2, ipid: "player", char: -1, area: court, oocName: "Player"}
+	outsider := &Client{conn: outConn, uid: 3, ipid: "out", char: -1, area: lobby, oocName: "Outsider"}
+	for _, c := range []*Client{host, player, outsider} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdTrivia(host, []string{"start", "1"}, "usage")
+	if !strings.Contains(hostConn.String(), "Question 1/1: Capital of France?") {
+		t.Fatalf("the first question was not posted, got %q", hostConn.String())
+	}
+
+	triviaOnOOC(outsider, "paris")
+	triviaOnOOC(player, "London")
+	triviaOnOOC(player, "  PARIS ")
+	trivia.mu.Lock()
+	score, outsiderScore := trivia.scores[2], trivia.scores[3]
+	trivia.mu.Unlock()
+	if score != 1 || outsiderScore != 0 {
+		t.Fatalf("scores: player %d, outsider %d; want 1 and 0", score, outsiderScore)
+	}
+
+	triviaNext()
+	out := hostConn.String()
+	if !strings.Contains(out, "TRIVIA OVER") || !strings.Contains(out, "1. Player (UID 2) - 1 point(s)") {
+		t.Errorf("the leaderboard was not announced, got %q", out)
+	}
+
+	before := len(hostConn.String())
+	cmdTrivia(host, []string{"start"}, "usage")
+	if !strings.Contains(hostConn.String()[before:], "on cooldown") {
+		t.Error("a new game right after the last one should be refused")
+	}
+}
+
+// TestTriviaHostDisconnect verifies the game ends when its host leaves.
+func TestTriviaHostDisconnect(t *testing.T) {
+	origPath := settings.ConfigPath
+	t.Cleanup(func() { settings.ConfigPath = origPath })
+	settings.ConfigPath = t.TempDir()
+	if err := os.WriteFile(filepath.Join(settings.ConfigPath, "trivia.txt"), []byte("Q1?|a\nQ2?|b\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resetTriviaState()
+	t.Cleanup(resetTriviaState)
+	newTestClients(t)
+	court := area.NewArea(area.AreaData{Name: "Court"}, 5, 10, area.EviAny)
+	defer setupTestAreas([]*area.Area{court})()
+
+	conn := &captureConn{}
+	host := &Client{conn: &captureConn{}, uid: 1, ipid: "host", char: -1, area: court, oocName: "Host"}
+	player := &Client{conn: conn, uid: 2, ipid: "player", char: -1, area: court, oocName: "Player"}
+	for _, c := range []*Client{host, player} {
+		clients.AddClient(c)
+		clients.RegisterUID(c)
+	}
+
+	cmdTrivia(host, []string{"start"}, "usage")
+	before := len(conn.String())
+	cmdTrivia(player, []string{"stop"}, "usage")
+	if !strings.Contains(conn.String()[before:], "Only the game host") {
+		t.Error("a regular player should not be able to stop someone else's game")
+	}
+
+	handleTriviaDisconnect(host)
+	trivia.mu.Lock()
+	active := trivia.active
+	trivia.mu.Unlock()
+	if active || !strings.Contains(conn.String(), "host disconnected") {
+		t.Errorf("the game should end when the host disconnects, got %q", conn.String())
+	}
+}
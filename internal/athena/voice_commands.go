@@ -178,7 +178,7 @@ func cmdVkick(client *Client, args []string, usage string) {
 			client.SendServerMessage("Not enough arguments:\n" + usage)
 			return
 		}
-		for _, c := range getUidList(strings.Split(flags.Arg(0), ",")) {
+		for _, c := range getUidList(client, strings.Split(flags.Arg(0), ",")) {
 			if c.Area() != nil && inVoiceRoom(c.Area(), c.Uid()) {
 				leaveVoiceForClient(c)
 				kicked++
@@ -43,7 +43,6 @@ package athena
 // rate-limit check and the broadcast — the hook the relay always documented.
 
 import (
-	"math/rand"
 	"sync"
 	"time"
 )
@@ -146,7 +145,7 @@ func applyVoiceFramePunishments(client *Client, uid int, frame string) (payload
 	if set.garble && voiceGarbleDropChance > dropChance {
 		dropChance = voiceGarbleDropChance
 	}
-	if dropChance > 0 && rand.Float64() < dropChance {
+	if dropChance > 0 && rng.Float64() < dropChance {
 		return "", false
 	}
 
@@ -161,7 +160,7 @@ func applyVoiceFramePunishments(client *Client, uid int, frame string) (payload
 	// drifts; the artefact is a ~20ms repeat-then-skip glitch.
 	if set.stutter {
 		out := frame
-		if held, ok := getStutterFrame(uid); ok && rand.Float64() < voiceStutterChance {
+		if held, ok := getStutterFrame(uid); ok && rng.Float64() < voiceStutterChance {
 			out = held
 		}
 		setStutterFrame(uid, frame)
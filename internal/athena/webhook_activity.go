@@ -0,0 +1,152 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/area"
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/MangosArentLiterature/Athena/internal/webhook"
+)
+
+// maxActivityLines caps a single activity post; anything past it is summarised
+// as a count so a flood of distinct events still fits in one embed.
+const maxActivityLines = 20
+
+// activityFeed batches player activity lines for the Discord webhook. The
+// first event in a quiet period starts a timer; everything that arrives before
+// it fires is posted together, with identical lines merged into a count. The
+// post runs on the timer's goroutine, so callers never wait on Discord.
+type activityFeed struct {
+	mu     sync.Mutex
+	order  []string
+	counts map[string]int
+	timer  *time.Timer
+	post   func([]string) error
+}
+
+var playerActivity = &activityFeed{post: func(lines []string) error {
+	return webhook.PostActivity(lines, players.GetPlayerCount())
+}}
+
+// add queues a line, starting the batch timer if none is running.
+func (f *activityFeed) add(line string, interval time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.counts == nil {
+		f.counts = make(map[string]int)
+	}
+	if f.counts[line] == 0 {
+		f.order = append(f.order, line)
+	}
+	f.counts[line]++
+	if f.timer == nil {
+		f.timer = time.AfterFunc(interval, f.flush)
+	}
+}
+
+// flush posts and clears the pending batch.
+func (f *activityFeed) flush() {
+	f.mu.Lock()
+	order, counts := f.order, f.counts
+	f.order, f.counts, f.timer = nil, nil, nil
+	f.mu.Unlock()
+	if err := f.post(summarizeActivity(order, counts)); err != nil {
+		logger.LogErrorf("while posting activity webhook: %v", err)
+	}
+}
+
+// summarizeActivity renders the batch in arrival order, marking repeats and
+// truncating past maxActivityLines.
+func summarizeActivity(order []string, counts map[string]int) []string {
+	lines := make([]string, 0, len(order))
+	for i, line := range order {
+		if i == maxActivityLines {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(order)-maxActivityLines))
+			break
+		}
+		if n := counts[line]; n > 1 {
+			line = fmt.Sprintf("%v (×%d)", line, n)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// activityInterval returns the configured batching window.
+func activityInterval() time.Duration {
+	return time.Duration(config.WebhookActivityInterval) * time.Second
+}
+
+// activityName identifies a client in activity lines. It is keyed on IPID
+// rather than UID so repeated reconnects merge into one counted line.
+func activityName(client *Client) string {
+	if name := client.OOCName(); name != "" {
+		return fmt.Sprintf("`%v` (%v)", client.Ipid(), name)
+	}
+	return fmt.Sprintf("`%v`", client.Ipid())
+}
+
+// reportJoin queues a join event when webhook_joins is on. It is called on
+// every character pick but only reports the first, so connections that never
+// get past character select (bots, reconnect storms) stay out of the feed.
+func reportJoin(client *Client) {
+	if !client.joinReported.CompareAndSwap(false, true) {
+		return
+	}
+	if !enableDiscord || !config.WebhookJoins || client.Hidden() {
+		return
+	}
+	playerActivity.add(fmt.Sprintf("➡️ %v joined as %v [UID %d]", activityName(client), client.CurrentCharacter(), client.Uid()), activityInterval())
+}
+
+// reportLeave queues a leave event when webhook_leaves is on, for clients
+// whose join was reported.
+func reportLeave(client *Client) {
+	if !client.joinReported.Load() || !enableDiscord || !config.WebhookLeaves || client.Hidden() {
+		return
+	}
+	playerActivity.add(fmt.Sprintf("⬅️ %v left [UID %d]", activityName(client), client.Uid()), activityInterval())
+}
+
+// reportMove queues an area move when webhook_moves is on and the move
+// touches one of webhook_move_areas (or that list is empty).
+func reportMove(client *Client, from, to *area.Area) {
+	if !enableDiscord || !config.WebhookMoves || client.Hidden() || from == nil || from == to {
+		return
+	}
+	if !notableMove(from, to, config.WebhookMoveAreas) {
+		return
+	}
+	playerActivity.add(fmt.Sprintf("🚪 %v moved %v → %v", activityName(client), from.Name(), to.Name()), activityInterval())
+}
+
+// notableMove reports whether a move between from and to should be posted.
+func notableMove(from, to *area.Area, watched []string) bool {
+	if len(watched) == 0 {
+		return true
+	}
+	for _, name := range watched {
+		if name == from.Name() || name == to.Name() {
+			return true
+		}
+	}
+	return false
+}
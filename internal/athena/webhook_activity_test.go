@@ -0,0 +1,141 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package athena
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/packet"
+	"github.com/MangosArentLiterature/Athena/internal/settings"
+)
+
+func TestActivityFeedBatchesAndMerges(t *testing.T) {
+	posted := make(chan []string, 2)
+	f := &activityFeed{post: func(lines []string) error {
+		posted <- lines
+		return nil
+	}}
+
+	for i := 0; i < 3; i++ {
+		f.add("➡️ `ip1` joined", 20*time.Millisecond)
+		f.add("⬅️ `ip1` left", 20*time.Millisecond)
+	}
+	f.add("➡️ `ip2` joined", 20*time.Millisecond)
+
+	select {
+	case got := <-posted:
+		want := []string{"➡️ `ip1` joined (×3)", "⬅️ `ip1` left (×3)", "➡️ `ip2` joined"}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("posted %q, want %q", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("batch was never posted")
+	}
+	select {
+	case extra := <-posted:
+		t.Fatalf("one batch should produce one post, got a second: %q", extra)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSummarizeActivityTruncates(t *testing.T) {
+	var order []string
+	counts := make(map[string]int)
+	for i := 0; i < maxActivityLines+5; i++ {
+		line := fmt.Sprintf("line %d", i)
+		order = append(order, line)
+		counts[line] = 1
+	}
+	got := summarizeActivity(order, counts)
+	if len(got) != maxActivityLines+1 || got[maxActivityLines] != "…and 5 more" {
+		t.Fatalf("unexpected summary tail: %q", got[len(got)-1])
+	}
+}
+
+func TestNotableMove(t *testing.T) {
+	lobby, court, basement := makeTestArea("Lobby"), makeTestArea("Courtroom"), makeTestArea("Basement")
+	if !notableMove(lobby, court, nil) {
+		t.Error("every move is notable when no areas are watched")
+	}
+	watched := []string{"Courtroom"}
+	if !notableMove(lobby, court, watched) || !notableMove(court, basement, watched) {
+		t.Error("moves into or out of a watched area should be notable")
+	}
+	if notableMove(lobby, basement, watched) {
+		t.Error("moves between unwatched areas should be skipped")
+	}
+}
+
+// TestRelayIC checks IC messages reach a registered Discord relay with the
+// showname and decoded text, and that log-silenced areas are skipped.
+func TestRelayIC(t *testing.T) {
+	var got []string
+	(&ServerAdapter{}).SetICRelay(func(area, character, message string) {
+		got = append(got, fmt.Sprintf("%v|%v|%v", area, character, message))
+	})
+	t.Cleanup(func() { (&ServerAdapter{}).SetICRelay(nil) })
+
+	a := makeTestArea("Courtroom")
+	client := &Client{uid: 1, char: -1, area: a}
+	relayIC(client, &packet.MSPacket{Showname: "Phoenix", Message: encode("Hold it%")})
+	a.SetLogSilenced(true)
+	relayIC(client, &packet.MSPacket{Showname: "Phoenix", Message: "hidden"})
+
+	if want := []string{"Courtroom|Phoenix|Hold it%"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("relayed %q, want %q", got, want)
+	}
+}
+
+// TestReportJoinOnFirstCharacter checks a join is queued once, on the first
+// character pick, and that leaves are only reported for reported joins.
+func TestReportJoinOnFirstCharacter(t *testing.T) {
+	origFeed, origConfig, origDiscord := playerActivity, config, enableDiscord
+	t.Cleanup(func() {
+		playerActivity.mu.Lock()
+		if playerActivity.timer != nil {
+			playerActivity.timer.Stop()
+		}
+		playerActivity.mu.Unlock()
+		playerActivity, config, enableDiscord = origFeed, origConfig, origDiscord
+	})
+	playerActivity = &activityFeed{post: func([]string) error { return nil }}
+	config = &settings.Config{}
+	config.WebhookJoins, config.WebhookLeaves = true, true
+	config.WebhookActivityInterval = 60
+	enableDiscord = true
+	origChars := getCharacters()
+	t.Cleanup(func() { setCharacters(origChars) })
+	setCharacters([]string{"Phoenix"})
+
+	spectator := &Client{uid: 1, ipid: "ip-spec", char: -1, area: makeTestArea("Lobby")}
+	reportLeave(spectator)
+	player := &Client{uid: 2, ipid: "ip-player", char: 0, area: makeTestArea("Lobby")}
+	reportJoin(player)
+	reportJoin(player)
+	reportLeave(player)
+
+	playerActivity.mu.Lock()
+	got := append([]string(nil), playerActivity.order...)
+	playerActivity.mu.Unlock()
+	want := []string{"➡️ `ip-player` joined as Phoenix [UID 2]", "⬅️ `ip-player` left [UID 2]"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("queued %q, want %q", got, want)
+	}
+}
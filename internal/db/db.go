@@ -136,7 +136,7 @@ const MaxChipBalance = 10_000_000
 
 // Database version.
 // This should be incremented whenever changes are made to the DB that require existing databases to upgrade.
-const ver = 23
+const ver = 25
 
 // MaxFavourites is the maximum number of favourite characters a player can save.
 const MaxFavourites = 100
@@ -344,6 +344,27 @@ func Open() error {
 	if err != nil {
 		return err
 	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS GAME_RECORDS(
+		IPID   TEXT    NOT NULL,
+		GAME   TEXT    NOT NULL,
+		NAME   TEXT    NOT NULL DEFAULT '',
+		WINS   INTEGER NOT NULL DEFAULT 0,
+		LOSSES INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY(IPID, GAME)
+	)`)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS WARNINGS(
+		ID        INTEGER PRIMARY KEY AUTOINCREMENT,
+		IPID      TEXT    NOT NULL,
+		REASON    TEXT    NOT NULL DEFAULT '',
+		MODERATOR TEXT    NOT NULL DEFAULT '',
+		TIME      INTEGER NOT NULL DEFAULT 0
+	)`)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -709,6 +730,40 @@ func upgradeDB(v int) error {
 		if _, err := db.Exec("PRAGMA user_version = 23"); err != nil {
 			return err
 		}
+		fallthrough
+	case 23:
+		// GAME_RECORDS holds per-IPID win/loss counts for /leaderboard
+		// (RPS and coinflip battles). NAME is the player's OOC name at their
+		// last recorded game, shown for players without an account.
+		if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS GAME_RECORDS(
+			IPID   TEXT    NOT NULL,
+			GAME   TEXT    NOT NULL,
+			NAME   TEXT    NOT NULL DEFAULT '',
+			WINS   INTEGER NOT NULL DEFAULT 0,
+			LOSSES INTEGER NOT NULL DEFAULT 0,
+			PRIMARY KEY(IPID, GAME)
+		)`); err != nil {
+			return err
+		}
+		if _, err := db.Exec("PRAGMA user_version = 24"); err != nil {
+			return err
+		}
+		fallthrough
+	case 24:
+		// WARNINGS persists the Discord bot's /warn records per IPID; they
+		// were previously held in memory and lost on restart.
+		if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS WARNINGS(
+			ID        INTEGER PRIMARY KEY AUTOINCREMENT,
+			IPID      TEXT    NOT NULL,
+			REASON    TEXT    NOT NULL DEFAULT '',
+			MODERATOR TEXT    NOT NULL DEFAULT '',
+			TIME      INTEGER NOT NULL DEFAULT 0
+		)`); err != nil {
+			return err
+		}
+		if _, err := db.Exec("PRAGMA user_version = 25"); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1078,6 +1133,79 @@ func UnBan(id int) error {
 	return nil
 }
 
+// UnbanByIPID nullifies every active ban on an IPID and returns the bans
+// that were nullified, newest first. The lookup and the update run in one
+// transaction so a ban added in between is not cleared without being listed.
+func UnbanByIPID(ipid string) ([]BanInfo, error) {
+	if db == nil {
+		return nil, nil
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	now := time.Now().UTC().Unix()
+	rows, err := tx.Query("SELECT * FROM BANS WHERE IPID = ? AND (DURATION = -1 OR DURATION > ?) ORDER BY TIME DESC", ipid, now)
+	if err != nil {
+		return nil, err
+	}
+	var active []BanInfo
+	for rows.Next() {
+		var b BanInfo
+		if err := rows.Scan(&b.Id, &b.Ipid, &b.Hdid, &b.Time, &b.Duration, &b.Reason, &b.Moderator); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		active = append(active, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(active) == 0 {
+		return nil, nil
+	}
+	if _, err := tx.Exec("UPDATE BANS SET DURATION = 0 WHERE IPID = ? AND (DURATION = -1 OR DURATION > ?)", ipid, now); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return active, nil
+}
+
+// PurgeExpiredBans nullifies every timed ban whose duration has passed and
+// returns the bans that were nullified.
+func PurgeExpiredBans() ([]BanInfo, error) {
+	if db == nil {
+		return nil, nil
+	}
+	now := time.Now().UTC().Unix()
+	result, err := db.Query("SELECT * FROM BANS WHERE DURATION > 0 AND DURATION <= ?", now)
+	if err != nil {
+		return nil, err
+	}
+	var bans []BanInfo
+	for result.Next() {
+		var b BanInfo
+		if err := result.Scan(&b.Id, &b.Ipid, &b.Hdid, &b.Time, &b.Duration, &b.Reason, &b.Moderator); err != nil {
+			continue
+		}
+		bans = append(bans, b)
+	}
+	result.Close()
+	if len(bans) == 0 {
+		return nil, nil
+	}
+	_, err = db.Exec("UPDATE BANS SET DURATION = 0 WHERE DURATION > 0 AND DURATION <= ?", now)
+	if err != nil {
+		return nil, err
+	}
+	return bans, nil
+}
+
 // GetBan returns a list of bans matching a given value.
 func GetBan(by BanLookup, value any) ([]BanInfo, error) {
 	var stmt *sql.Stmt
@@ -1087,6 +1215,10 @@ func GetBan(by BanLookup, value any) ([]BanInfo, error) {
 		stmt, err = db.Prepare("SELECT * FROM BANS WHERE ID = ?")
 	case IPID:
 		stmt, err = db.Prepare("SELECT * FROM BANS WHERE IPID = ? ORDER BY TIME DESC")
+	case HDID:
+		stmt, err = db.Prepare("SELECT * FROM BANS WHERE HDID = ? ORDER BY TIME DESC")
+	default:
+		return []BanInfo{}, fmt.Errorf("unknown ban lookup %v", by)
 	}
 	if err != nil {
 		return []BanInfo{}, err
@@ -1106,12 +1238,12 @@ func GetBan(by BanLookup, value any) ([]BanInfo, error) {
 	return bans, nil
 }
 
-// GetRecentBans returns the 5 most recent bans.
+// GetRecentBans returns the 5 most recent bans that have not been nullified.
 func GetRecentBans() ([]BanInfo, error) {
 	if db == nil {
 		return nil, nil
 	}
-	result, err := db.Query("SELECT * FROM BANS ORDER BY TIME DESC LIMIT 5")
+	result, err := db.Query("SELECT * FROM BANS WHERE DURATION != 0 ORDER BY TIME DESC LIMIT 5")
 	if err != nil {
 		return []BanInfo{}, err
 	}
@@ -2096,6 +2228,16 @@ ON CONFLICT(IPID, JOB) DO UPDATE SET LAST_AT = excluded.LAST_AT`, ipid, job, now
 return false, 0, err
 }
 
+// ClearJobCooldown removes the last-use timestamp of a job for an IPID, so
+// the job can be used again straight away.
+func ClearJobCooldown(ipid, job string) error {
+	if db == nil {
+		return nil
+	}
+	_, err := db.Exec("DELETE FROM JOB_COOLDOWNS WHERE IPID = ? AND JOB = ?", ipid, job)
+	return err
+}
+
 // JobEarningsEntry holds one row from the job earnings leaderboard query.
 type JobEarningsEntry struct {
 	// Username is the registered account name, or empty for anonymous players.
@@ -2519,6 +2661,46 @@ func SetGambleHide(username string, hide bool) error {
 	return err
 }
 
+// WarningEntry holds a single warning issued through the Discord bot.
+type WarningEntry struct {
+	Reason    string
+	Moderator string
+	Time      int64
+}
+
+// AddWarning records a warning against the given IPID.
+func AddWarning(ipid, reason, moderator string) error {
+	if db == nil {
+		return nil
+	}
+	_, err := db.Exec(
+		"INSERT INTO WARNINGS(IPID, REASON, MODERATOR, TIME) VALUES(?, ?, ?, ?)",
+		ipid, reason, moderator, time.Now().UTC().Unix(),
+	)
+	return err
+}
+
+// GetWarnings returns all warnings for the given IPID, newest first.
+func GetWarnings(ipid string) ([]WarningEntry, error) {
+	if db == nil {
+		return nil, nil
+	}
+	rows, err := db.Query("SELECT REASON, MODERATOR, TIME FROM WARNINGS WHERE IPID = ? ORDER BY TIME DESC, ID DESC", ipid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var entries []WarningEntry
+	for rows.Next() {
+		var e WarningEntry
+		if err := rows.Scan(&e.Reason, &e.Moderator, &e.Time); err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
 // ModnoteEntry holds a single moderator note retrieved from the database.
 type ModnoteEntry struct {
 	ID      int64
@@ -2722,3 +2904,92 @@ func IsRandomCharCursed(ipid string) (bool, error) {
 	}
 	return n > 0, nil
 }
+
+// ── Game records ──────────────────────────────────────────────────────────────
+
+// GameRecord is one player's win/loss tally for a single game.
+type GameRecord struct {
+	// Name is the account name when one is linked, otherwise the OOC name
+	// the player last played under.
+	Name   string
+	IPID   string
+	Wins   int64
+	Losses int64
+}
+
+// RecordGameResult adds a win or a loss to the IPID's tally for game and
+// remembers name as their display name.
+func RecordGameResult(ipid, game, name string, won bool) error {
+	if db == nil {
+		return nil
+	}
+	wins, losses := 0, 1
+	if won {
+		wins, losses = 1, 0
+	}
+	_, err := db.Exec(`
+INSERT INTO GAME_RECORDS(IPID, GAME, NAME, WINS, LOSSES) VALUES(?, ?, ?, ?, ?)
+ON CONFLICT(IPID, GAME) DO UPDATE SET
+	NAME = excluded.NAME,
+	WINS = WINS + excluded.WINS,
+	LOSSES = LOSSES + excluded.LOSSES`, ipid, game, name, wins, losses)
+	return err
+}
+
+// GetTopGameRecords returns the top n players for game, most wins first and
+// fewest losses breaking ties.
+func GetTopGameRecords(game string, n int) ([]GameRecord, error) {
+	if db == nil {
+		return nil, nil
+	}
+	rows, err := db.Query(`
+SELECT g.IPID, COALESCE(u.USERNAME, g.NAME), g.WINS, g.LOSSES
+FROM GAME_RECORDS g
+LEFT JOIN USERS u ON u.IPID = g.IPID
+WHERE g.GAME = ?
+ORDER BY g.WINS DESC, g.LOSSES ASC LIMIT ?`, game, n)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	entries := make([]GameRecord, 0, n)
+	for rows.Next() {
+		var e GameRecord
+		if err := rows.Scan(&e.IPID, &e.Name, &e.Wins, &e.Losses); err != nil {
+			return entries, err
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// GetGameRecordsByIPIDs returns the game tallies of the given IPIDs, keyed by
+// IPID. IPIDs that never played are absent from the map.
+func GetGameRecordsByIPIDs(game string, ipids []string) (map[string]GameRecord, error) {
+	if db == nil || len(ipids) == 0 {
+		return map[string]GameRecord{}, nil
+	}
+	placeholders := make([]string, len(ipids))
+	args := make([]any, 0, len(ipids)+1)
+	args = append(args, game)
+	for i, id := range ipids {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	rows, err := db.Query(
+		"SELECT IPID, NAME, WINS, LOSSES FROM GAME_RECORDS WHERE GAME = ? AND IPID IN ("+strings.Join(placeholders, ",")+")",
+		args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	m := make(map[string]GameRecord, len(ipids))
+	for rows.Next() {
+		var e GameRecord
+		if err := rows.Scan(&e.IPID, &e.Name, &e.Wins, &e.Losses); err != nil {
+			return m, err
+		}
+		m[e.IPID] = e
+	}
+	return m, rows.Err()
+}
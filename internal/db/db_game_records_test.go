@@ -0,0 +1,65 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package db
+
+import (
+	"testing"
+)
+
+// TestRecordGameResultTallies verifies wins and losses accumulate per game and
+// that the leaderboard orders by wins.
+func TestRecordGameResultTallies(t *testing.T) {
+	teardown := setupTestDB(t)
+	defer teardown()
+
+	for _, r := range []struct {
+		ipid string
+		won  bool
+	}{
+		{"ipidA", true}, {"ipidA", true}, {"ipidA", false},
+		{"ipidB", true}, {"ipidB", false},
+	} {
+		if err := RecordGameResult(r.ipid, "rps", r.ipid+"-name", r.won); err != nil {
+			t.Fatalf("RecordGameResult: %v", err)
+		}
+	}
+	if err := RecordGameResult("ipidB", "coinflip", "B", true); err != nil {
+		t.Fatalf("RecordGameResult: %v", err)
+	}
+
+	top, err := GetTopGameRecords("rps", 10)
+	if err != nil {
+		t.Fatalf("GetTopGameRecords: %v", err)
+	}
+	if len(top) != 2 || top[0].IPID != "ipidA" || top[0].Wins != 2 || top[0].Losses != 1 {
+		t.Fatalf("unexpected rps leaderboard: %+v", top)
+	}
+	if top[0].Name != "ipidA-name" {
+		t.Errorf("name = %q, want the last recorded OOC name", top[0].Name)
+	}
+
+	byIPID, err := GetGameRecordsByIPIDs("coinflip", []string{"ipidA", "ipidB"})
+	if err != nil {
+		t.Fatalf("GetGameRecordsByIPIDs: %v", err)
+	}
+	if _, ok := byIPID["ipidA"]; ok {
+		t.Error("ipidA never played coinflip and should be absent")
+	}
+	if r := byIPID["ipidB"]; r.Wins != 1 || r.Losses != 0 {
+		t.Errorf("ipidB coinflip record = %+v, want 1-0", r)
+	}
+}
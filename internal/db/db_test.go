@@ -703,3 +703,228 @@ func TestRandomCharCurse(t *testing.T) {
 		t.Errorf("expected sql.ErrNoRows removing an already-removed curse, got %v", err)
 	}
 }
+
+func TestUnbanByIPID(t *testing.T) {
+	teardown := setupTestDB(t)
+	defer teardown()
+
+	now := time.Now().UTC().Unix()
+	ipid := "multi.banned"
+	perma, _ := AddBan(ipid, "hdid1", now, -1, "perma", "mod")
+	timed, _ := AddBan(ipid, "hdid2", now, now+3600, "timed", "mod")
+	if _, err := AddBan(ipid, "hdid3", now-7200, now-3600, "expired", "mod"); err != nil {
+		t.Fatalf("AddBan failed: %v", err)
+	}
+	if _, err := AddBan("someone.else", "hdid4", now, -1, "other", "mod"); err != nil {
+		t.Fatalf("AddBan failed: %v", err)
+	}
+
+	bans, err := UnbanByIPID(ipid)
+	if err != nil {
+		t.Fatalf("UnbanByIPID failed: %v", err)
+	}
+	got := map[int]bool{}
+	for _, b := range bans {
+		got[b.Id] = true
+	}
+	if len(bans) != 2 || !got[perma] || !got[timed] {
+		t.Fatalf("UnbanByIPID nullified %+v, want bans %d and %d", bans, perma, timed)
+	}
+	if banned, _, _ := IsBanned(IPID, ipid); banned {
+		t.Error("expected the IPID to have no active bans")
+	}
+	if banned, _, _ := IsBanned(IPID, "someone.else"); !banned {
+		t.Error("expected another IPID's ban to be untouched")
+	}
+	if bans, err := UnbanByIPID(ipid); err != nil || len(bans) != 0 {
+		t.Errorf("expected a second call to find nothing, got %v, %v", bans, err)
+	}
+}
+
+func TestUnbanByIPIDWithoutDB(t *testing.T) {
+	orig := db
+	db = nil
+	defer func() { db = orig }()
+	if bans, err := UnbanByIPID("anyone"); err != nil || bans != nil {
+		t.Errorf("UnbanByIPID with no database = %v, %v; want nil, nil", bans, err)
+	}
+}
+
+func TestGetBanByHDID(t *testing.T) {
+	teardown := setupTestDB(t)
+	defer teardown()
+
+	now := time.Now().UTC().Unix()
+	older, _ := AddBan("ip.one", "shared-hdid", now-60, -1, "first", "mod")
+	newer, _ := AddBan("ip.two", "shared-hdid", now, now+3600, "vpn hop", "mod")
+	if _, err := AddBan("ip.three", "other-hdid", now, -1, "other", "mod"); err != nil {
+		t.Fatalf("AddBan failed: %v", err)
+	}
+
+	bans, err := GetBan(HDID, "shared-hdid")
+	if err != nil {
+		t.Fatalf("GetBan(HDID) failed: %v", err)
+	}
+	if len(bans) != 2 || bans[0].Id != newer || bans[1].Id != older {
+		t.Fatalf("GetBan(HDID) = %+v, want bans %d then %d", bans, newer, older)
+	}
+	if bans[0].Ipid != "ip.two" || bans[0].Hdid != "shared-hdid" {
+		t.Errorf("unexpected ban row %+v", bans[0])
+	}
+	if bans, err := GetBan(HDID, "unknown-hdid"); err != nil || len(bans) != 0 {
+		t.Errorf("expected no bans for an unknown HDID, got %v, %v", bans, err)
+	}
+	if banned, info, _ := IsBanned(HDID, "shared-hdid"); !banned || (info.Id != older && info.Id != newer) {
+		t.Errorf("IsBanned(HDID) = %v, %+v", banned, info)
+	}
+	if _, err := GetBan(BanLookup(99), "x"); err == nil {
+		t.Error("expected an error for an unknown lookup kind")
+	}
+}
+
+func TestIsBannedExpiry(t *testing.T) {
+	teardown := setupTestDB(t)
+	defer teardown()
+
+	now := time.Now().UTC().Unix()
+	perma, _ := AddBan("ip.perma", "hd.perma", now, -1, "perma", "mod")
+	future, _ := AddBan("ip.future", "hd.future", now, now+3600, "future", "mod")
+	if _, err := AddBan("ip.past", "hd.past", now-7200, now-3600, "past", "mod"); err != nil {
+		t.Fatalf("AddBan failed: %v", err)
+	}
+	lifted, _ := AddBan("ip.lifted", "hd.lifted", now, -1, "lifted", "mod")
+	if err := UnBan(lifted); err != nil {
+		t.Fatalf("UnBan failed: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		suffix string
+		want   bool
+		id     int
+	}{
+		{"permanent", "perma", true, perma},
+		{"future expiry", "future", true, future},
+		{"past expiry", "past", false, 0},
+		{"nullified by /unban", "lifted", false, 0},
+	}
+	for _, tc := range cases {
+		for _, lookup := range []struct {
+			by    BanLookup
+			value string
+		}{{IPID, "ip." + tc.suffix}, {HDID, "hd." + tc.suffix}} {
+			banned, info, err := IsBanned(lookup.by, lookup.value)
+			if err != nil {
+				t.Fatalf("%s: IsBanned(%v) failed: %v", tc.name, lookup.value, err)
+			}
+			if banned != tc.want {
+				t.Errorf("%s: IsBanned(%v) = %v, want %v", tc.name, lookup.value, banned, tc.want)
+			}
+			if tc.want && info.Id != tc.id {
+				t.Errorf("%s: IsBanned(%v) returned ban %d, want %d", tc.name, lookup.value, info.Id, tc.id)
+			}
+		}
+	}
+}
+
+func TestPurgeExpiredBans(t *testing.T) {
+	teardown := setupTestDB(t)
+	defer teardown()
+
+	now := time.Now().UTC().Unix()
+	perma, _ := AddBan("ip.perma", "hd.perma", now, -1, "perma", "mod")
+	future, _ := AddBan("ip.future", "hd.future", now, now+3600, "future", "mod")
+	expired, err := AddBan("ip.past", "hd.past", now-7200, now-3600, "past", "mod")
+	if err != nil {
+		t.Fatalf("AddBan failed: %v", err)
+	}
+
+	bans, err := PurgeExpiredBans()
+	if err != nil {
+		t.Fatalf("PurgeExpiredBans failed: %v", err)
+	}
+	if len(bans) != 1 || bans[0].Id != expired || bans[0].Ipid != "ip.past" {
+		t.Fatalf("PurgeExpiredBans returned %+v, want only ban %d", bans, expired)
+	}
+	if b, _ := GetBan(BANID, expired); len(b) != 1 || b[0].Duration != 0 {
+		t.Errorf("expected the expired ban to be nullified, got %+v", b)
+	}
+	recent, _ := GetRecentBans()
+	ids := map[int]bool{}
+	for _, b := range recent {
+		ids[b.Id] = true
+	}
+	if len(recent) != 2 || !ids[perma] || !ids[future] {
+		t.Errorf("GetRecentBans returned %+v, want bans %d and %d", recent, perma, future)
+	}
+	if bans, err := PurgeExpiredBans(); err != nil || len(bans) != 0 {
+		t.Errorf("expected a second purge to find nothing, got %v, %v", bans, err)
+	}
+}
+
+func TestModnotes(t *testing.T) {
+	teardown := setupTestDB(t)
+	defer teardown()
+
+	if err := AddModnote("noted.ipid", "spams emotes", "mod1"); err != nil {
+		t.Fatalf("AddModnote failed: %v", err)
+	}
+	if err := AddModnote("noted.ipid", "second warning", "mod2"); err != nil {
+		t.Fatalf("AddModnote failed: %v", err)
+	}
+	if err := AddModnote("other.ipid", "unrelated", "mod1"); err != nil {
+		t.Fatalf("AddModnote failed: %v", err)
+	}
+
+	notes, err := GetModnotes("noted.ipid")
+	if err != nil {
+		t.Fatalf("GetModnotes failed: %v", err)
+	}
+	if len(notes) != 2 || notes[0].Note != "spams emotes" || notes[0].AddedBy != "mod1" || notes[1].Note != "second warning" {
+		t.Fatalf("GetModnotes = %+v", notes)
+	}
+
+	if err := DeleteModnote(notes[0].ID); err != nil {
+		t.Fatalf("DeleteModnote failed: %v", err)
+	}
+	if err := DeleteModnote(notes[0].ID); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected sql.ErrNoRows deleting a missing note, got %v", err)
+	}
+	if notes, _ := GetModnotes("noted.ipid"); len(notes) != 1 || notes[0].Note != "second warning" {
+		t.Errorf("after delete GetModnotes = %+v", notes)
+	}
+	if notes, _ := GetModnotes("other.ipid"); len(notes) != 1 {
+		t.Errorf("another IPID's notes were touched: %+v", notes)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	teardown := setupTestDB(t)
+	defer teardown()
+
+	if warnings, err := GetWarnings("warned.ipid"); err != nil || len(warnings) != 0 {
+		t.Fatalf("expected no warnings initially, got %v, %v", warnings, err)
+	}
+	for _, reason := range []string{"first", "second", "third"} {
+		if err := AddWarning("warned.ipid", reason, "mod#1234"); err != nil {
+			t.Fatalf("AddWarning failed: %v", err)
+		}
+	}
+	if err := AddWarning("other.ipid", "elsewhere", "mod#1234"); err != nil {
+		t.Fatalf("AddWarning failed: %v", err)
+	}
+
+	warnings, err := GetWarnings("warned.ipid")
+	if err != nil {
+		t.Fatalf("GetWarnings failed: %v", err)
+	}
+	if len(warnings) != 3 {
+		t.Fatalf("GetWarnings returned %d warnings, want 3", len(warnings))
+	}
+	// Same-second inserts fall back to insertion order, newest first.
+	for i, want := range []string{"third", "second", "first"} {
+		if warnings[i].Reason != want || warnings[i].Moderator != "mod#1234" || warnings[i].Time == 0 {
+			t.Errorf("warnings[%d] = %+v, want reason %q", i, warnings[i], want)
+		}
+	}
+}
@@ -18,6 +18,7 @@ package bot
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -57,6 +58,101 @@ func (b *Bot) handleClearArea(s *discordgo.Session, i *discordgo.InteractionCrea
 	respondEmbed(s, i, successEmbed("Area Cleared", fmt.Sprintf("All players have been moved out of **%s**.", areaArg)))
 }
 
+// handleSummon handles the /summon command.
+func (b *Bot) handleSummon(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !b.requireMod(s, i) {
+		return
+	}
+	areaArg := i.ApplicationCommandData().Options[0].StringValue()
+	count, err := b.server.SummonAll(areaArg)
+	if err != nil {
+		respondEmbed(s, i, errorEmbed(fmt.Sprintf("Failed to summon players: %v", err)))
+		return
+	}
+	if count == 0 {
+		respondEmbed(s, i, infoEmbed("Summon", "No users were summoned."))
+		return
+	}
+	respondEmbed(s, i, successEmbed("Players Summoned", fmt.Sprintf("Summoned %d user(s) to **%s**.", count, areaArg)))
+}
+
+// handleKickArea handles the /kickarea command.
+func (b *Bot) handleKickArea(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !b.requireMod(s, i) {
+		return
+	}
+	playerArg := i.ApplicationCommandData().Options[0].StringValue()
+	p := b.resolvePlayer(playerArg)
+	if p == nil {
+		respondEmbed(s, i, errorEmbed(fmt.Sprintf("Player not found: `%s`", playerArg)))
+		return
+	}
+	if err := b.server.KickFromArea(p.UID); err != nil {
+		respondEmbed(s, i, errorEmbed(fmt.Sprintf("Failed to kick player from area: %v", err)))
+		return
+	}
+	respondEmbed(s, i, successEmbed("Player Kicked From Area", fmt.Sprintf("**%s** [UID %d] has been kicked out of **%s**.", p.Character, p.UID, p.Area)))
+}
+
+// handleAreaPlayers handles the /area_players command.
+func (b *Bot) handleAreaPlayers(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !b.requireMod(s, i) {
+		return
+	}
+	areaArg := i.ApplicationCommandData().Options[0].StringValue()
+	players, err := b.server.GetAreaPlayers(areaArg)
+	if err != nil {
+		respondEmbed(s, i, errorEmbed(fmt.Sprintf("Failed to list area: %v", err)))
+		return
+	}
+	if len(players) == 0 {
+		respondEmbed(s, i, infoEmbed(fmt.Sprintf("👥 %s", areaArg), "Nobody is in this area."))
+		return
+	}
+	var lines []string
+	for _, p := range players {
+		lines = append(lines, fmt.Sprintf("**[%d]** %s (`%s`)", p.UID, p.Character, p.OOCName))
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("👥 %s (%d)", players[0].Area, len(players)),
+		Description: strings.Join(lines, "\n"),
+		Color:       colorBlue,
+	}
+	respondEmbed(s, i, embed)
+}
+
+// handleTestimony handles the /testimony command.
+func (b *Bot) handleTestimony(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !b.requireMod(s, i) {
+		return
+	}
+	areaArg := optionString(i.ApplicationCommandData().Options, "area")
+	info := b.server.FindArea(areaArg)
+	if info == nil {
+		respondEmbed(s, i, errorEmbed(fmt.Sprintf("Area not found: `%s`", areaArg)))
+		return
+	}
+	statements := b.server.GetTestimony(info.Index)
+	if len(statements) == 0 {
+		respondEmbed(s, i, infoEmbed(fmt.Sprintf("📜 %s", info.Name), "No testimony is recorded in this area."))
+		return
+	}
+	var lines []string
+	for n, stmt := range statements {
+		lines = append(lines, fmt.Sprintf("**%d.** %s", n+1, stmt))
+	}
+
+	// Discord embed descriptions have a 4096 char limit; truncate if needed.
+	desc := strings.Join(lines, "\n")
+	desc = truncateDesc(desc)
+	embed := &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📜 Testimony in %s (%d statements)", info.Name, len(statements)),
+		Description: desc,
+		Color:       colorBlue,
+	}
+	respondEmbed(s, i, embed)
+}
+
 // handleLock handles the /lock command.
 func (b *Bot) handleLock(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	if !b.requireMod(s, i) {
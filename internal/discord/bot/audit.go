@@ -42,9 +42,7 @@ func (b *Bot) handleLogs(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	}
 
 	desc := strings.Join(logs, "\n")
-	if len(desc) > 4000 {
-		desc = desc[:4000] + "\n…(truncated)"
-	}
+	desc = truncateDesc(desc)
 	embed := &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("📜 Logs — %s [UID %d]", p.Character, p.UID),
 		Description: fmt.Sprintf("```\n%s\n```", desc),
@@ -60,20 +58,27 @@ func (b *Bot) handleAuditLog(s *discordgo.Session, i *discordgo.InteractionCreat
 	}
 	opts := i.ApplicationCommandData().Options
 	filter := optionString(opts, "filter")
+	regex := optionBool(opts, "regex", false)
 
-	entries := b.server.GetAuditLog(filter)
+	entries, err := b.server.GetAuditLog(filter, regex)
+	if err != nil {
+		respondEmbed(s, i, errorEmbed(err.Error()))
+		return
+	}
 	if len(entries) == 0 {
 		respondEmbed(s, i, infoEmbed("📋 Audit Log", "No audit log entries found."))
 		return
 	}
 
 	desc := strings.Join(entries, "\n")
-	if len(desc) > 4000 {
-		desc = desc[:4000] + "\n…(truncated)"
-	}
+	desc = truncateDesc(desc)
 	title := "📋 Audit Log"
 	if filter != "" {
-		title += fmt.Sprintf(" (filter: %s)", filter)
+		if regex {
+			title += fmt.Sprintf(" (regex: %s)", filter)
+		} else {
+			title += fmt.Sprintf(" (filter: %s)", filter)
+		}
 	}
 	embed := &discordgo.MessageEmbed{
 		Title:       title,
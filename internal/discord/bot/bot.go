@@ -30,6 +30,7 @@ type Bot struct {
 	modRoleID  string
 	server     ServerInterface
 	commands   []*discordgo.ApplicationCommand
+	relay      *icRelay
 }
 
 // Config holds the configuration for the Discord bot.
@@ -37,6 +38,11 @@ type Config struct {
 	Token     string
 	GuildID   string
 	ModRoleID string
+
+	// ICRelayChannel and ICRelayAreas configure the IC chat relay; see
+	// RelayIC. An empty channel or area list leaves it off.
+	ICRelayChannel string
+	ICRelayAreas   []string
 }
 
 // New creates and returns a new Bot instance.
@@ -56,6 +62,9 @@ func New(cfg Config, srv ServerInterface) (*Bot, error) {
 		modRoleID: cfg.ModRoleID,
 		server:    srv,
 	}
+	if cfg.ICRelayChannel != "" && len(cfg.ICRelayAreas) > 0 {
+		b.relay = newICRelay(cfg.ICRelayChannel, cfg.ICRelayAreas)
+	}
 	return b, nil
 }
 
@@ -72,11 +81,18 @@ func (b *Bot) Start() error {
 		return fmt.Errorf("failed to register discord commands: %w", err)
 	}
 
+	if b.relay != nil {
+		b.server.SetICRelay(b.RelayIC)
+	}
 	return nil
 }
 
 // Stop gracefully shuts down the Discord bot, removing registered commands.
 func (b *Bot) Stop() {
+	if b.relay != nil {
+		b.server.SetICRelay(nil)
+		b.relay.flush(b.session)
+	}
 	for _, cmd := range b.commands {
 		if err := b.session.ApplicationCommandDelete(b.session.State.User.ID, b.guildID, cmd.ID); err != nil {
 			// Best-effort cleanup; log but do not block shutdown.
@@ -206,6 +206,13 @@ func applicationCommands() []*discordgo.ApplicationCommand {
 				{Type: discordgo.ApplicationCommandOptionString, Name: "duration", Description: "Duration.", Required: false},
 			},
 		},
+		{
+			Name:        "punishments",
+			Description: "View a player's active punishments with remaining durations.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "player", Description: "UID or OOC name.", Required: true},
+			},
+		},
 		// Communication
 		{
 			Name:        "pm",
@@ -246,6 +253,34 @@ func applicationCommands() []*discordgo.ApplicationCommand {
 				{Type: discordgo.ApplicationCommandOptionString, Name: "area", Description: "Area name.", Required: true},
 			},
 		},
+		{
+			Name:        "summon",
+			Description: "Move every player on the server to an area.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "area", Description: "Area name.", Required: true},
+			},
+		},
+		{
+			Name:        "kickarea",
+			Description: "Kick a player out of their current area (to the default area).",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "player", Description: "UID or OOC name.", Required: true},
+			},
+		},
+		{
+			Name:        "area_players",
+			Description: "List the players in a specific area.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "area", Description: "Area name.", Required: true},
+			},
+		},
+		{
+			Name:        "testimony",
+			Description: "View the testimony recorded in an area.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "area", Description: "Area name.", Required: true},
+			},
+		},
 		{
 			Name:        "lock",
 			Description: "Lock an area.",
@@ -272,12 +307,20 @@ func applicationCommands() []*discordgo.ApplicationCommand {
 			Name:        "auditlog",
 			Description: "View the server audit log.",
 			Options: []*discordgo.ApplicationCommandOption{
-				{Type: discordgo.ApplicationCommandOptionString, Name: "filter", Description: "Optional filter string.", Required: false},
+				{Type: discordgo.ApplicationCommandOptionString, Name: "filter", Description: "Space-separated terms that must all appear (or a regex with regex:true).", Required: false},
+				{Type: discordgo.ApplicationCommandOptionBoolean, Name: "regex", Description: "Treat the filter as a regular expression.", Required: false},
 			},
 		},
 		{
 			Name:        "banlist",
-			Description: "View the list of banned players.",
+			Description: "View the list of banned players, newest first.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionInteger, Name: "page", Description: "Page number (10 bans per page, default 1).", Required: false},
+			},
+		},
+		{
+			Name:        "banlist_export",
+			Description: "Export the full ban list as a CSV attachment.",
 		},
 		// Server control
 		{
@@ -311,6 +354,28 @@ func applicationCommands() []*discordgo.ApplicationCommand {
 					}},
 			},
 		},
+		// Fun helpers for event hosts, sharing the in-game implementations.
+		{
+			Name:        "roll",
+			Description: "Roll dice, same as in-game /roll.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "dice", Description: "Dice to roll (e.g. 2d6+3 or 3d8+2d4).", Required: true},
+			},
+		},
+		{
+			Name:        "choose",
+			Description: "Pick one of several options at random.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "options", Description: "Options separated by | or commas.", Required: true},
+			},
+		},
+		{
+			Name:        "8ball",
+			Description: "Ask the Magic 8-Ball a yes/no question.",
+			Options: []*discordgo.ApplicationCommandOption{
+				{Type: discordgo.ApplicationCommandOptionString, Name: "question", Description: "Your question.", Required: true},
+			},
+		},
 	}
 }
 
@@ -358,23 +423,33 @@ func (b *Bot) commandHandlers() map[string]func(*discordgo.Session, *discordgo.I
 		"whisper":     b.handlePunishment("whisper"),
 		"stutterstep": b.handlePunishment("stutterstep"),
 		"backward":    b.handlePunishment("backward"),
+		"punishments": b.handlePunishments,
 		// Communication
 		"pm":              b.handlePM,
 		"announce":        b.handleAnnounce,
 		"announce_player": b.handleAnnouncePlayer,
 		// Area control
-		"forcemove": b.handleForceMove,
-		"cleararea": b.handleClearArea,
-		"lock":      b.handleLock,
-		"unlock":    b.handleUnlock,
+		"forcemove":    b.handleForceMove,
+		"cleararea":    b.handleClearArea,
+		"summon":       b.handleSummon,
+		"kickarea":     b.handleKickArea,
+		"area_players": b.handleAreaPlayers,
+		"testimony":    b.handleTestimony,
+		"lock":         b.handleLock,
+		"unlock":       b.handleUnlock,
 		// Audit & Logs
-		"logs":     b.handleLogs,
-		"auditlog": b.handleAuditLog,
-		"banlist":  b.handleBanList,
+		"logs":           b.handleLogs,
+		"auditlog":       b.handleAuditLog,
+		"banlist":        b.handleBanList,
+		"banlist_export": b.handleBanListExport,
 		// Server control
 		"restart": b.handleRestart,
 		// Nyathena fork additions
 		"firewall": b.handleFirewall,
 		"lockdown": b.handleLockdown,
+		// Fun helpers
+		"roll":   b.handleRoll,
+		"choose": b.handleChoose,
+		"8ball":  b.handleEightBall,
 	}
 }
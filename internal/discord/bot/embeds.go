@@ -16,7 +16,11 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 
 package bot
 
-import "github.com/bwmarrin/discordgo"
+import (
+	"unicode/utf8"
+
+	"github.com/bwmarrin/discordgo"
+)
 
 const (
 	colorBlue   = 0x3498db
@@ -28,6 +32,32 @@ const (
 	colorGray   = 0x95a5a6
 )
 
+// maxDescLen is where long embed descriptions are cut, leaving room under
+// Discord's 4096 character limit for the truncation note.
+const maxDescLen = 4000
+
+// truncateUTF8 shortens s to at most limit bytes without splitting a
+// multi-byte character.
+func truncateUTF8(s string, limit int) string {
+	if len(s) <= limit {
+		return s
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut]
+}
+
+// truncateDesc cuts an embed description that is too long for Discord and
+// marks it as truncated.
+func truncateDesc(desc string) string {
+	if len(desc) <= maxDescLen {
+		return desc
+	}
+	return truncateUTF8(desc, maxDescLen) + "\n…(truncated)"
+}
+
 // newEmbed returns a new Discord embed with a given color.
 func newEmbed(color int) *discordgo.MessageEmbed {
 	return &discordgo.MessageEmbed{Color: color}
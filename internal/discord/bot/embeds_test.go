@@ -0,0 +1,39 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package bot
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestTruncateDescKeepsRunes verifies long descriptions are cut on a
+// character boundary so Discord never receives invalid UTF-8.
+func TestTruncateDescKeepsRunes(t *testing.T) {
+	desc := "a" + strings.Repeat("é", maxDescLen)
+	got := truncateDesc(desc)
+	if !utf8.ValidString(got) {
+		t.Fatal("truncated description is not valid UTF-8")
+	}
+	if !strings.HasSuffix(got, "\n…(truncated)") {
+		t.Errorf("truncated description is missing the marker: %q", got[len(got)-20:])
+	}
+	if short := "short"; truncateDesc(short) != short {
+		t.Error("a short description should be returned unchanged")
+	}
+}
@@ -0,0 +1,41 @@
+/* Athena - A server for Attorney Online 2 written in Go
+   Nyathena fork additions: Discord-side /roll, /choose and /8ball for event
+   hosts. The server adapter backs these with the same helpers as the
+   in-game commands, so both sides share limits and answer pools. */
+
+package bot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// handleRoll handles /roll <dice>.
+func (b *Bot) handleRoll(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	spec := optionString(i.ApplicationCommandData().Options, "dice")
+	result, err := b.server.RollDice(spec)
+	if err != nil {
+		respondEmbed(s, i, errorEmbed(err.Error()))
+		return
+	}
+	respondEmbed(s, i, infoEmbed("🎲 Dice Roll", fmt.Sprintf("Rolled **%s**. Results: %s.", spec, result)))
+}
+
+// handleChoose handles /choose <options>.
+func (b *Bot) handleChoose(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	options := optionString(i.ApplicationCommandData().Options, "options")
+	choice, parsed, err := b.server.Choose(options)
+	if err != nil {
+		respondEmbed(s, i, errorEmbed(err.Error()))
+		return
+	}
+	respondEmbed(s, i, infoEmbed("🤔 Choose", fmt.Sprintf("Choosing between %s.\n👉 I choose: **%s**", strings.Join(parsed, ", "), choice)))
+}
+
+// handleEightBall handles /8ball <question>.
+func (b *Bot) handleEightBall(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	question := optionString(i.ApplicationCommandData().Options, "question")
+	respondEmbed(s, i, infoEmbed("🎱 Magic 8-Ball", fmt.Sprintf("> %s\n%s", question, b.server.EightBall())))
+}
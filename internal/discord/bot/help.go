@@ -53,16 +53,21 @@ var commandHelp = map[string]struct {
 	"whisper":            {"/whisper <player> [duration]", "Force a player into whisper mode.", "Moderator", "/whisper 3 10m", []string{"spotlight"}},
 	"stutterstep":        {"/stutterstep <player> [duration]", "Apply a stutter effect to a player's messages.", "Moderator", "/stutterstep 3 10m", []string{"drunk"}},
 	"backward":           {"/backward <player> [duration]", "Reverse all of a player's messages.", "Moderator", "/backward 3 15m", []string{"drunk"}},
+	"punishments":        {"/punishments <player>", "View a player's active punishments (including lag, mute and jail) with remaining durations and issuer tiers. Check before punishing to avoid doubling up.", "Moderator", "/punishments 3", []string{"roulette", "mute"}},
 	"pm":                 {"/pm <player> <message>", "Send a private server message to a player.", "Moderator", "/pm 3 Hello!", []string{"announce"}},
 	"announce":           {"/announce <message>", "Send a server-wide announcement to all players.", "Moderator", "/announce Welcome everyone!", []string{"pm", "announce_player"}},
 	"announce_player":    {"/announce_player <player> <message>", "Send an announcement to a specific player.", "Moderator", "/announce_player 3 You're special!", []string{"announce", "pm"}},
 	"forcemove":          {"/forcemove <player> <area>", "Force move a player to a specified area.", "Moderator", "/forcemove 3 Courtroom", []string{"cleararea"}},
 	"cleararea":          {"/cleararea <area>", "Force move all players out of an area.", "Moderator", "/cleararea Lobby", []string{"forcemove", "lock"}},
+	"summon":             {"/summon <area>", "Move every player on the server to an area. Same as in-game /summon.", "Moderator", "/summon Courtroom", []string{"forcemove", "cleararea"}},
+	"kickarea":           {"/kickarea <player>", "Kick a player out of their current area to the default area, removing their invite. Same as in-game /kickarea.", "Moderator", "/kickarea 3", []string{"forcemove", "area_players"}},
+	"area_players":       {"/area_players <area>", "List the players currently in a specific area.", "Moderator", "/area_players Courtroom", []string{"find", "kickarea"}},
+	"testimony":          {"/testimony <area>", "View the testimony recorded in an area as a numbered list of statements.", "Moderator", "/testimony Courtroom", []string{"area_players"}},
 	"lock":               {"/lock <area>", "Lock an area so only invited players can enter.", "Moderator", "/lock Courtroom", []string{"unlock"}},
 	"unlock":             {"/unlock <area>", "Unlock a previously locked area.", "Moderator", "/unlock Courtroom", []string{"lock"}},
 	"logs":               {"/logs <player>", "View recent activity logs for a player.", "Moderator", "/logs 3", []string{"auditlog"}},
-	"auditlog":           {"/auditlog [filter]", "View the server audit log with an optional filter.", "Moderator", "/auditlog ban", []string{"logs"}},
-	"banlist":            {"/banlist", "View the full list of currently banned players.", "Moderator", "/banlist", []string{"ban", "unban"}},
+	"auditlog":           {"/auditlog [filter] [regex]", "View the server audit log. The filter keeps lines containing every space-separated term, or matching a regular expression when regex is true.", "Moderator", "/auditlog filter:ban ModX", []string{"logs"}},
+	"banlist":            {"/banlist [page]", "View currently banned players, newest first, 10 per page.", "Moderator", "/banlist 2", []string{"ban", "unban"}},
 	"restart":            {"/restart", "Restart the server process.", "Administrator", "/restart", []string{"status"}},
 	"thesaurusoverload":  {"/thesaurusoverload [-d duration] [-r reason] <uid1>,<uid2>...", "Forces IC messages to use comically pompous synonyms and smug parentheticals (e.g. 'go' → 'peregrinate').", "Moderator", "/thesaurusoverload 5 -d 10m -r \"Stop typing like a normal person\"", []string{"valleygirl", "babytalk", "unpunish"}},
 	"valleygirl":         {"/valleygirl [-d duration] [-r reason] <uid1>,<uid2>...", "Injects valley-girl filler words, vowel stretching, and dramatic tone into IC messages (e.g. 'stop' → 'Okay sooo like… literally stoppp??').", "Moderator", "/valleygirl 5 -d 30m -r \"Take a deep breath\"", []string{"thesaurusoverload", "babytalk", "unpunish"}},
@@ -70,6 +75,9 @@ var commandHelp = map[string]struct {
 	"thirdperson":        {"/thirdperson [-d duration] [-r reason] <uid1>,<uid2>...", "Forces IC messages into third-person narration using the player's display name, with automatic mood tags (e.g. 'HELLO??' → 'Phoenix demands an explanation [feral][confused]').", "Moderator", "/thirdperson 5 -d 1h -r \"Narration arc\"", []string{"unreliablenarrator", "spotlight", "unpunish"}},
 	"unreliablenarrator": {"/unreliablenarrator [-d duration] [-r reason] <uid1>,<uid2>...", "Makes IC messages sound suspiciously unreliable with hedges, contradictions, and self-doubting commentary (e.g. 'I didn't do it' → 'I allegedly didn't do it (or so I recall.)').", "Moderator", "/unreliablenarrator 5 -d 20m -r \"Stop gaslighting the courtroom\"", []string{"thirdperson", "paranoid", "unpunish"}},
 	"uncannyvalley":      {"/uncannyvalley [-d duration] [-r reason] <uid1>,<uid2>...", "Adds glitchy system notes to IC messages and subtly mutates the player's display name each message (e.g. name: 'Phoenix' → 'Phœnix_', message appended with '[checksum mismatch]').", "Moderator", "/uncannyvalley 5 -d 45m -r \"Become slightly incorrect\"", []string{"unreliablenarrator", "emoji", "unpunish"}},
+	"roll":               {"/roll <dice>", "Roll dice with the same limits as in-game /roll.", "None", "/roll 2d6", []string{"choose", "8ball"}},
+	"choose":             {"/choose <options>", "Pick one option at random. Separate options with | or commas, same as in-game /choose.", "None", "/choose pizza | tacos | sushi", []string{"roll", "8ball"}},
+	"8ball":              {"/8ball <question>", "Ask the Magic 8-Ball. Uses the same answers as in-game /8ball.", "None", "/8ball Will the defense win?", []string{"roll", "choose"}},
 	"maso":               {"/maso", "Self-apply a random punishment for 10 minutes. Type /maso again while active to reroll to a different random punishment.", "None (any player)", "/maso", []string{"unpunish", "roulette"}},
 	"firewall":           {"/firewall <on|off>", "Toggle the IPHub VPN/proxy firewall. Refuses to enable if iphub_api_key is unset.", "Moderator", "/firewall on", []string{"lockdown"}},
 	"lockdown":           {"/lockdown <on|off|whitelist_all>", "Toggle server-wide new-IPID lockdown, or whitelist every currently-connected IPID so they can rejoin during lockdown.", "Moderator", "/lockdown on", []string{"firewall"}},
@@ -141,7 +149,8 @@ func (b *Bot) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
 					"`/roulette` `/spotlight` `/whisper`\n" +
 					"`/stutterstep` `/backward`\n" +
 					"`/thesaurusoverload` `/valleygirl` `/babytalk`\n" +
-					"`/thirdperson` `/unreliablenarrator` `/uncannyvalley`",
+					"`/thirdperson` `/unreliablenarrator` `/uncannyvalley`\n" +
+					"`/punishments` — View a player's active punishments",
 				Inline: false,
 			},
 			{
@@ -155,6 +164,10 @@ func (b *Bot) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
 				Name: "🏛️ Area Control",
 				Value: "`/forcemove` — Move player to area\n" +
 					"`/cleararea` — Clear an area\n" +
+					"`/summon` — Move everyone to an area\n" +
+					"`/kickarea` — Kick a player out of their area\n" +
+					"`/area_players` — List players in an area\n" +
+					"`/testimony` — View an area's recorded testimony\n" +
 					"`/lock` `/unlock` — Lock/unlock an area",
 				Inline: false,
 			},
@@ -162,7 +175,8 @@ func (b *Bot) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
 				Name: "📝 Audit & Logs",
 				Value: "`/logs` — Player activity logs\n" +
 					"`/auditlog` — Server audit log\n" +
-					"`/banlist` — List of banned players",
+					"`/banlist` — List of banned players\n" +
+					"`/banlist_export` — Full ban list as CSV",
 				Inline: false,
 			},
 			{
@@ -177,8 +191,9 @@ func (b *Bot) handleHelp(s *discordgo.Session, i *discordgo.InteractionCreate) {
 				Inline: false,
 			},
 			{
-				Name:   "🎲 Fun (All Players)",
-				Value:  "`/maso` — Self-apply a random punishment for 10 min (type again to reroll)",
+				Name: "🎲 Fun (All Players)",
+				Value: "`/maso` — Self-apply a random punishment for 10 min (type again to reroll)\n" +
+					"`/roll` `/choose` `/8ball` — Dice, random picks and the Magic 8-Ball",
 				Inline: false,
 			},
 		},
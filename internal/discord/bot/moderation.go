@@ -17,7 +17,11 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 package bot
 
 import (
+	"bytes"
+	"encoding/csv"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -53,6 +57,26 @@ func optionString(options []*discordgo.ApplicationCommandInteractionDataOption,
 	return ""
 }
 
+// optionInt returns a named integer option value, or def if not present.
+func optionInt(options []*discordgo.ApplicationCommandInteractionDataOption, name string, def int) int {
+	for _, o := range options {
+		if o.Name == name {
+			return int(o.IntValue())
+		}
+	}
+	return def
+}
+
+// optionBool returns a named boolean option value, or def if not present.
+func optionBool(options []*discordgo.ApplicationCommandInteractionDataOption, name string, def bool) bool {
+	for _, o := range options {
+		if o.Name == name {
+			return o.BoolValue()
+		}
+	}
+	return def
+}
+
 // handleMute handles the /mute command.
 func (b *Bot) handleMute(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	if !b.requireMod(s, i) {
@@ -281,7 +305,11 @@ func (b *Bot) handleWarnings(s *discordgo.Session, i *discordgo.InteractionCreat
 	respondEmbed(s, i, embed)
 }
 
-// handleBanList handles the /banlist command.
+// banListPageSize is the number of bans shown per /banlist page.
+const banListPageSize = 10
+
+// handleBanList handles the /banlist command. Bans are listed newest-first,
+// banListPageSize per page; out-of-range pages are clamped.
 func (b *Bot) handleBanList(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	if !b.requireMod(s, i) {
 		return
@@ -291,9 +319,28 @@ func (b *Bot) handleBanList(s *discordgo.Session, i *discordgo.InteractionCreate
 		respondEmbed(s, i, infoEmbed("🚫 Ban List", "No active bans."))
 		return
 	}
+	sort.SliceStable(bans, func(x, y int) bool {
+		if bans[x].Time != bans[y].Time {
+			return bans[x].Time > bans[y].Time
+		}
+		return bans[x].ID > bans[y].ID
+	})
+
+	pages := (len(bans) + banListPageSize - 1) / banListPageSize
+	page := optionInt(i.ApplicationCommandData().Options, "page", 1)
+	if page < 1 {
+		page = 1
+	} else if page > pages {
+		page = pages
+	}
+	start := (page - 1) * banListPageSize
+	end := start + banListPageSize
+	if end > len(bans) {
+		end = len(bans)
+	}
 
 	var lines []string
-	for _, ban := range bans {
+	for _, ban := range bans[start:end] {
 		durStr := "Permanent"
 		if ban.Duration != -1 {
 			durStr = "Until " + time.Unix(ban.Duration, 0).UTC().Format("02 Jan 2006 15:04 UTC")
@@ -304,18 +351,89 @@ func (b *Bot) handleBanList(s *discordgo.Session, i *discordgo.InteractionCreate
 
 	// Discord embed descriptions have a 4096 char limit; truncate if needed.
 	desc := strings.Join(lines, "\n")
-	if len(desc) > 4000 {
-		desc = desc[:4000] + "\n…(truncated)"
-	}
+	desc = truncateDesc(desc)
 
 	embed := &discordgo.MessageEmbed{
 		Title:       fmt.Sprintf("🚫 Ban List (%d entries)", len(bans)),
 		Description: desc,
 		Color:       colorRed,
+		Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Page %d/%d", page, pages)},
 	}
 	respondEmbed(s, i, embed)
 }
 
+// handleBanListExport handles the /banlist_export command. Unlike /banlist it
+// is never truncated: every ban is written to a CSV file uploaded as an
+// attachment, for offline review.
+func (b *Bot) handleBanListExport(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !b.requireMod(s, i) {
+		return
+	}
+	bans := b.server.GetBanList()
+	if len(bans) == 0 {
+		respondEmbed(s, i, infoEmbed("🚫 Ban List", "No active bans."))
+		return
+	}
+	data, err := banListCSV(bans)
+	if err != nil {
+		respondEmbed(s, i, errorEmbed(fmt.Sprintf("Failed to build ban list export: %v", err)))
+		return
+	}
+	_ = s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{infoEmbed("🚫 Ban List Export", fmt.Sprintf("%d bans attached as CSV.", len(bans)))},
+			Files: []*discordgo.File{{
+				Name:        "banlist-" + time.Now().UTC().Format("20060102-150405") + ".csv",
+				ContentType: "text/csv",
+				Reader:      bytes.NewReader(data),
+			}},
+		},
+	})
+}
+
+// banListCSV renders bans as CSV with a header row. Duration is "Permanent"
+// or the expiry time; all times are UTC.
+func banListCSV(bans []BanRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"ID", "IPID", "HDID", "Reason", "Duration", "Moderator", "Time"}); err != nil {
+		return nil, err
+	}
+	for _, ban := range bans {
+		durStr := "Permanent"
+		if ban.Duration != -1 {
+			durStr = time.Unix(ban.Duration, 0).UTC().Format(time.RFC3339)
+		}
+		row := []string{
+			strconv.Itoa(ban.ID),
+			csvSafe(ban.IPID),
+			csvSafe(ban.HDID),
+			csvSafe(ban.Reason),
+			durStr,
+			csvSafe(ban.Moderator),
+			time.Unix(ban.Time, 0).UTC().Format(time.RFC3339),
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvSafe defuses a free-text CSV value that a spreadsheet would run as a
+// formula (one starting with =, +, -, @, tab or CR) by prefixing it with '.
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
 // handleRestart handles the /restart command.
 func (b *Bot) handleRestart(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	if !b.requireAdmin(s, i) {
@@ -0,0 +1,52 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package bot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+// TestBanListCSVDefusesFormulas verifies that values a spreadsheet would run
+// as formulas are exported as plain text.
+func TestBanListCSVDefusesFormulas(t *testing.T) {
+	data, err := banListCSV([]BanRecord{{
+		ID: 1, IPID: "@evil", HDID: "=HYPERLINK(\"http://x\")", Reason: "-2+3",
+		Duration: -1, Moderator: "\tmod", Time: 0,
+	}, {
+		ID: 2, IPID: "abc", HDID: "def", Reason: "spam", Duration: -1, Moderator: "mod", Time: 0,
+	}})
+	if err != nil {
+		t.Fatalf("banListCSV: %v", err)
+	}
+	rows, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("reading the CSV back: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want a header and two bans", len(rows))
+	}
+	for col, want := range map[int]string{1: "'@evil", 2: "'=HYPERLINK(\"http://x\")", 3: "'-2+3", 5: "'\tmod"} {
+		if rows[1][col] != want {
+			t.Errorf("column %d = %q, want %q", col, rows[1][col], want)
+		}
+	}
+	if rows[2][1] != "abc" || rows[2][3] != "spam" {
+		t.Errorf("plain values should be left alone, got %q", rows[2])
+	}
+}
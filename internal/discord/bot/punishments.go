@@ -18,6 +18,7 @@ package bot
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/bwmarrin/discordgo"
 )
@@ -59,3 +60,31 @@ func (b *Bot) handlePunishment(name string) func(*discordgo.Session, *discordgo.
 		))
 	}
 }
+
+// handlePunishments handles the /punishments command.
+func (b *Bot) handlePunishments(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if !b.requireMod(s, i) {
+		return
+	}
+	playerArg := i.ApplicationCommandData().Options[0].StringValue()
+	p := b.resolvePlayer(playerArg)
+	if p == nil {
+		respondEmbed(s, i, errorEmbed(fmt.Sprintf("Player not found: `%s`", playerArg)))
+		return
+	}
+	active := b.server.GetPlayerPunishments(p.UID)
+	title := fmt.Sprintf("⛓️ Punishments — %s [UID %d]", p.Character, p.UID)
+	if len(active) == 0 {
+		respondEmbed(s, i, infoEmbed(title, "No active punishments."))
+		return
+	}
+	desc := "• " + strings.Join(active, "\n• ")
+	desc = truncateDesc(desc)
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: desc,
+		Color:       colorOrange,
+		Footer:      &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("%d active", len(active))},
+	}
+	respondEmbed(s, i, embed)
+}
@@ -0,0 +1,176 @@
+/* Athena - A server for Attorney Online 2 written in Go
+Copyright (C) 2022 MangosArentLiterature <mango@transmenace.dev>
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published
+by the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>. */
+
+package bot
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MangosArentLiterature/Athena/internal/logger"
+	"github.com/bwmarrin/discordgo"
+)
+
+const (
+	// icRelayInterval is how long IC lines are collected before they are
+	// posted as one message, keeping a busy area under Discord's rate limits.
+	icRelayInterval = 2 * time.Second
+	// icRelayMaxLines caps a single batch; lines past it are summarised as a
+	// count so a flood can't turn into a burst of messages.
+	icRelayMaxLines = 30
+	// discordMessageLimit is the longest message content Discord accepts.
+	discordMessageLimit = 2000
+)
+
+// icRelay batches IC lines for the relay channel. The first line in a quiet
+// period starts a timer; everything that arrives before it fires is posted
+// together.
+type icRelay struct {
+	channel string
+	areas   map[string]struct{} // lowercased area names to relay
+
+	mu      sync.Mutex
+	lines   []string
+	dropped int
+	timer   *time.Timer
+}
+
+func newICRelay(channel string, areas []string) *icRelay {
+	r := &icRelay{channel: channel, areas: make(map[string]struct{}, len(areas))}
+	for _, a := range areas {
+		r.areas[strings.ToLower(strings.TrimSpace(a))] = struct{}{}
+	}
+	return r
+}
+
+// RelayIC queues an IC message for the relay channel if its area is on the
+// allowlist. It never blocks on Discord; the batch is posted from a timer.
+func (b *Bot) RelayIC(area, character, message string) {
+	r := b.relay
+	if r == nil {
+		return
+	}
+	if _, ok := r.areas[strings.ToLower(area)]; !ok {
+		return
+	}
+	text := stripAOFormatting(message)
+	if text == "" {
+		return
+	}
+	line := fmt.Sprintf("**[%s] %s:** %s", escapeMarkdown(area), escapeMarkdown(character), escapeMarkdown(text))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.lines) < icRelayMaxLines {
+		r.lines = append(r.lines, line)
+	} else {
+		r.dropped++
+	}
+	if r.timer == nil {
+		r.timer = time.AfterFunc(icRelayInterval, func() { r.flush(b.session) })
+	}
+}
+
+// flush posts and clears the pending batch.
+func (r *icRelay) flush(s *discordgo.Session) {
+	r.mu.Lock()
+	lines, dropped := r.lines, r.dropped
+	r.lines, r.dropped = nil, 0
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	r.mu.Unlock()
+	if dropped > 0 {
+		lines = append(lines, fmt.Sprintf("*…and %d more line(s)*", dropped))
+	}
+	for _, msg := range chunkLines(lines, discordMessageLimit) {
+		// Mentions are never parsed, so players can't ping roles from IC.
+		_, err := s.ChannelMessageSendComplex(r.channel, &discordgo.MessageSend{
+			Content:         msg,
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		if err != nil {
+			logger.LogErrorf("Failed to relay IC chat to Discord channel %v: %v", r.channel, err)
+			return
+		}
+	}
+}
+
+// chunkLines joins lines with newlines into as few messages of at most limit
+// bytes as possible, truncating any single line that is longer on its own at
+// a character boundary.
+func chunkLines(lines []string, limit int) []string {
+	var out []string
+	var cur strings.Builder
+	for _, line := range lines {
+		line = truncateUTF8(line, limit)
+		if cur.Len() > 0 && cur.Len()+1+len(line) > limit {
+			out = append(out, cur.String())
+			cur.Reset()
+		}
+		if cur.Len() > 0 {
+			cur.WriteByte('\n')
+		}
+		cur.WriteString(line)
+	}
+	if cur.Len() > 0 {
+		out = append(out, cur.String())
+	}
+	return out
+}
+
+// stripAOFormatting removes AO2 inline markup from an IC message: the hidden
+// colour markers (~ ` |), text speed braces, and the \s (shake) and \f (flash)
+// effects. \n becomes a space and any other backslash escape is kept as the
+// literal character.
+func stripAOFormatting(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	rs := []rune(s)
+	for i := 0; i < len(rs); i++ {
+		switch rs[i] {
+		case '\\':
+			if i+1 == len(rs) {
+				continue
+			}
+			i++
+			switch rs[i] {
+			case 's', 'f':
+			case 'n':
+				b.WriteByte(' ')
+			default:
+				b.WriteRune(rs[i])
+			}
+		case '~', '`', '|', '{', '}':
+		default:
+			b.WriteRune(rs[i])
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// escapeMarkdown backslash-escapes Discord markdown so relayed text shows as
+// typed.
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, ">", `\>`,
+)
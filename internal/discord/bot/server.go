@@ -65,6 +65,9 @@ type ServerInterface interface {
 	// Area queries
 	GetAreas() []AreaInfo
 	FindArea(name string) *AreaInfo
+	// GetTestimony returns the decoded statements (title excluded) recorded in
+	// the area at areaIndex; nil for an unknown index or an empty recorder.
+	GetTestimony(areaIndex int) []string
 
 	// Moderation actions
 	MutePlayer(uid int, duration time.Duration, reason string) error
@@ -81,6 +84,7 @@ type ServerInterface interface {
 	// Punishment actions
 	ApplyPunishment(uid int, punishmentName string, duration time.Duration) error
 	RemovePunishment(uid int, punishmentName string) error
+	GetPlayerPunishments(uid int) []string
 
 	// Communication
 	SendPrivateMessage(uid int, message string) error
@@ -90,12 +94,15 @@ type ServerInterface interface {
 	// Area control
 	ForceMove(uid int, areaName string) error
 	ClearArea(areaName string) error
+	SummonAll(areaName string) (int, error)
+	KickFromArea(uid int) error
+	GetAreaPlayers(areaName string) ([]PlayerInfo, error)
 	LockArea(areaName string) error
 	UnlockArea(areaName string) error
 
 	// Audit & Logs
 	GetPlayerLogs(ipid string) []string
-	GetAuditLog(filter string) []string
+	GetAuditLog(filter string, regex bool) ([]string, error)
 
 	// Server stats
 	GetServerName() string
@@ -111,4 +118,14 @@ type ServerInterface interface {
 	SetFirewall(on bool) error
 	SetLockdown(on bool) error
 	WhitelistAllConnected() (int, error)
+
+	// Fun helpers shared with the in-game /roll, /choose and /8ball, so event
+	// hosts on Discord get the same limits and answers as players in-game.
+	RollDice(spec string) (string, error)
+	Choose(options string) (string, []string, error)
+	EightBall() string
+
+	// SetICRelay registers relay to be called with each IC message the server
+	// broadcasts (area name, character name, decoded text); nil unregisters it.
+	SetICRelay(relay func(area, character, message string))
 }
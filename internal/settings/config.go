@@ -19,8 +19,11 @@ package settings
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
 	"strings"
 	"unicode/utf8"
 
@@ -39,6 +42,8 @@ type Config struct {
 	MSConfig      `toml:"MasterServer"`
 	DiscordConfig `toml:"Discord"`
 	VoiceConfig   `toml:"Voice"`
+	PunishmentConfig `toml:"Punishments"`
+	AreaTemplates    map[string]AreaTemplate `toml:"AreaTemplates"`
 }
 
 type ServerConfig struct {
@@ -48,6 +53,7 @@ type ServerConfig struct {
 	Name                  string `toml:"name"`
 	Desc                  string `toml:"description"`
 	MaxPlayers            int    `toml:"max_players"`
+	MaxSpectators         int    `toml:"max_spectators"`
 	MaxMsg                int    `toml:"max_message_length"`
 	BanLen                string `toml:"default_ban_duration"`
 	EnableWS              bool   `toml:"enable_webao"`
@@ -101,6 +107,7 @@ type ServerConfig struct {
 	TranslatorAPIKey           string `toml:"translator_api_key"`
 	TranslateCooldown          int    `toml:"translate_cooldown"`
 	EnableCasino               bool     `toml:"enable_casino"`
+	DailyChips                 int      `toml:"daily_chips"`
 	EnableAccounts             bool     `toml:"enable_accounts"`
 	RegisterCaptcha            bool     `toml:"register_captcha"`
 	EnableCommunityVote        bool     `toml:"enable_community_vote"`
@@ -112,6 +119,9 @@ type ServerConfig struct {
 	EnableNewspaper            bool     `toml:"enable_newspaper"`
 	NewspaperInterval          string   `toml:"newspaper_interval"`
 	NewspaperSections          []string `toml:"newspaper_sections"`
+	// EnableGameLeaderboards records RPS and coinflip battle results per IPID
+	// in the database and enables /leaderboard.
+	EnableGameLeaderboards bool `toml:"enable_game_leaderboards"`
 	// YouTubePlayPrefix, when non-empty and starting with "http", turns on the
 	// /play <youtube-link> integration. The prefix is the URL stem that
 	// clients fetch the downloaded MP3 from (e.g. "https://cdn.example.com/yt/").
@@ -151,6 +161,124 @@ type ServerConfig struct {
 	// wins if it is explicitly set; this entry is for operators who want the
 	// dashboard to be the default without remembering the flag.
 	EnableTUI bool `toml:"enable_tui"`
+
+	// JailArea is the index of a dedicated holding area. When >= 0, /jail
+	// without an explicit area moves the target there, and /unjail returns them
+	// to the area they were jailed from (or area 0). -1 jails players in place.
+	JailArea int `toml:"jail_area"`
+
+	// CapsFilterRatio and CapsFilterMinLength tune the per-area /capsfilter
+	// rule: an IC message counts as shouting when at least CapsFilterMinLength
+	// of its characters are letters and the uppercase share of those letters
+	// is at or above CapsFilterRatio. Shorter messages ("OBJECTION!") are exempt.
+	CapsFilterRatio     float64 `toml:"caps_filter_ratio"`
+	CapsFilterMinLength int     `toml:"caps_filter_min_length"`
+
+	// ChatControlChars decides what happens to IC/OOC text carrying control
+	// characters or invalid UTF-8: "strip" removes them, "reject" drops the
+	// whole message with a notice.
+	ChatControlChars string `toml:"chat_control_chars"`
+
+	// PersistAreaState saves every area's runtime settings (background,
+	// status, doc, description, iniswap/music flags...) to area_state.json in
+	// the config directory and restores them at startup.
+	// PersistAreaTestimony additionally carries the recorded testimony, and
+	// PersistAreaLocks the lock, admin lock and area password.
+	// AreaStateSaveInterval is how often, in seconds, the file is rewritten;
+	// 0 saves only on shutdown.
+	PersistAreaState      bool `toml:"persist_area_state"`
+	PersistAreaTestimony  bool `toml:"persist_area_testimony"`
+	PersistAreaLocks      bool `toml:"persist_area_locks"`
+	AreaStateSaveInterval int  `toml:"area_state_save_interval"`
+
+	// PersistAreaEvidence saves each area's evidence to its own file in
+	// EvidenceDir whenever it changes and loads it at startup, independently
+	// of PersistAreaState. EvidenceDir is relative to the config directory
+	// unless absolute.
+	PersistAreaEvidence bool   `toml:"persist_area_evidence"`
+	EvidenceDir         string `toml:"evidence_dir"`
+
+	// Locales is the directory of message catalogs (<code>.toml, one
+	// key = "template" pair per message), relative to the config directory.
+	// DefaultLocale is used for players who have not picked one with /lang.
+	// Messages missing from a catalog fall back to the built-in English.
+	Locales       string `toml:"locales"`
+	DefaultLocale string `toml:"default_locale"`
+
+	// WebhookJoins, WebhookLeaves and WebhookMoves post player joins, leaves
+	// and area moves to webhook_url. WebhookMoveAreas limits moves to those
+	// into or out of the listed areas (empty = every move). Events are
+	// batched into one post per WebhookActivityInterval seconds.
+	WebhookJoins            bool     `toml:"webhook_joins"`
+	WebhookLeaves           bool     `toml:"webhook_leaves"`
+	WebhookMoves            bool     `toml:"webhook_moves"`
+	WebhookMoveAreas        []string `toml:"webhook_move_areas"`
+	WebhookActivityInterval int      `toml:"webhook_activity_interval"`
+
+	// InterjectionCooldown is the minimum number of seconds between a
+	// client's interjections (objection, hold it, take that, custom shout).
+	// Shouts inside the window are dropped without a notice. 0 disables it.
+	InterjectionCooldown int `toml:"interjection_cooldown"`
+
+	// ModSpeakName picks the name shown after [MOD] / [MODCHAT] when staff
+	// speak with /mod and /modchat: "ooc" (their OOC name), "username" (their
+	// authenticated moderator username) or "both". Shadow mods always show
+	// their OOC name.
+	ModSpeakName string `toml:"mod_speak_name"`
+
+	// WebhookKicks and WebhookBans post an embed for every kick and for every
+	// ban / unban to punishment_webhook_url, or to webhook_url when no
+	// punishment webhook is set.
+	WebhookKicks bool `toml:"webhook_kicks"`
+	WebhookBans  bool `toml:"webhook_bans"`
+
+	// WelcomeRules and WelcomeHelp are sent after the MOTD when a player
+	// finishes joining, in that order: a rules link and a short nudge towards
+	// /help. Leave either blank to skip it.
+	WelcomeRules string `toml:"welcome_rules"`
+	WelcomeHelp  string `toml:"welcome_help"`
+
+	// OOCNameCooldown is the minimum number of seconds between OOC name
+	// changes. Messages sent under a new name inside the window are rejected.
+	// 0 disables it. ReservedOOCNames lists extra names (case-insensitive)
+	// nobody may use in OOC, on top of the server name and "Server".
+	OOCNameCooldown  int      `toml:"ooc_name_cooldown"`
+	ReservedOOCNames []string `toml:"reserved_ooc_names"`
+
+	// EffectCooldown is the minimum number of seconds between a client's IC
+	// messages that shake or flash the screen. Inside the window the effect
+	// is stripped and the text still goes through. 0 disables it.
+	EffectCooldown int `toml:"effect_cooldown"`
+
+	// Tournament tuning. TournamentMinPlayers is how many participants must
+	// be left at /tournament stop for a winner to be named (0 = no minimum).
+	// TournamentConsolation also clears the bottom-ranked player's
+	// punishments. TournamentDropAFK drops participants who sent no IC
+	// messages before the results are scored.
+	TournamentMinPlayers  int  `toml:"tournament_min_participants"`
+	TournamentConsolation bool `toml:"tournament_consolation"`
+	TournamentDropAFK     bool `toml:"tournament_drop_afk"`
+
+	// BanPurgeInterval is how often, in seconds, timed bans that have run
+	// out are nullified in the database. 0 disables the purge.
+	BanPurgeInterval int `toml:"ban_purge_interval"`
+
+	// Minigame timings, in seconds. RpsCooldown and PollCooldown may be 0 to
+	// turn the cooldown off; CoinflipExpiry and PollDuration fall back to
+	// their defaults when not positive.
+	RpsCooldown    int `toml:"rps_cooldown"`
+	CoinflipExpiry int `toml:"coinflip_expiry"`
+	PollCooldown   int `toml:"poll_cooldown"`
+	PollDuration   int `toml:"poll_duration"`
+
+	// SongQueueMax caps how many /songqueue requests an area holds at once.
+	// 0 removes the cap.
+	SongQueueMax int `toml:"song_queue_max"`
+
+	// IdleKickMinutes moves players who have sent no IC or OOC message for
+	// this many minutes out of non-lobby areas and back to character select.
+	// CMs and moderators are exempt. 0 disables it.
+	IdleKickMinutes int `toml:"idle_kick_minutes"`
 }
 
 type LogConfig struct {
@@ -171,6 +299,11 @@ type DiscordConfig struct {
 	BotToken  string `toml:"bot_token"`
 	GuildID   string `toml:"guild_id"`
 	ModRoleID string `toml:"mod_role_id"`
+
+	// ICRelayChannel is the Discord channel the bot mirrors IC chat into;
+	// "" disables the relay. Only areas named in ICRelayAreas are relayed.
+	ICRelayChannel string   `toml:"ic_relay_channel"`
+	ICRelayAreas   []string `toml:"ic_relay_areas"`
 }
 
 // VoiceConfig controls the optional server-relayed voice-chat feature.
@@ -190,6 +323,43 @@ type VoiceConfig struct {
 	NewIPIDVoiceCooldown    int  `toml:"new_ipid_voice_cooldown"`
 }
 
+// PunishmentConfig tunes how individual punishment effects behave. Every
+// default preserves the effect's original behaviour.
+type PunishmentConfig struct {
+	// EmojiNameReroll, when true, gives an /emoji target a fresh random emoji
+	// name on every IC message; when false one name is kept for the whole
+	// punishment.
+	EmojiNameReroll bool `toml:"emoji_name_reroll"`
+	// SubtitlesChance is the probability (0-1) that a /subtitles target's
+	// message gets a caption at all; 0 or less means never, 1 or more always.
+	SubtitlesChance float64 `toml:"subtitles_chance"`
+	// SubtitlesPlacement is where the caption goes: "end", "middle" (between
+	// two words) or "random" (either).
+	SubtitlesPlacement string `toml:"subtitles_placement"`
+	// SpotlightGlobal mirrors a /spotlight target's IC messages to every other
+	// area as a server message, on top of the normal in-area delivery.
+	SpotlightGlobal bool `toml:"spotlight_global"`
+	// ConfusedMode is how /confused shuffles words: "full" mixes the whole
+	// message, "sentence" only shuffles within each sentence.
+	ConfusedMode string `toml:"confused_mode"`
+	// AnnouncePunishments tells the issuer's area whenever a moderator applies
+	// a punishment effect, for event servers where the chaos is the point.
+	AnnouncePunishments bool `toml:"announce_punishments"`
+	// RngWeights, SpaghettiWeights and HotPotatoWeights bias the random
+	// picks made by /rng, /spaghetti and hot potato. Keys are effect names
+	// (e.g. "uwu"); effects left out weigh 1 and a weight of 0 removes one
+	// from the pool. Empty maps keep the uniform pick.
+	RngWeights       map[string]float64 `toml:"rng_weights"`
+	SpaghettiWeights map[string]float64 `toml:"spaghetti_weights"`
+	HotPotatoWeights map[string]float64 `toml:"hotpotato_weights"`
+	// RouletteChance is the probability (0-1) that a /roulette target's IC
+	// message is silently dropped.
+	RouletteChance float64 `toml:"roulette_chance"`
+	// DisabledPunishments lists punishment commands (e.g. "torment") that
+	// nobody may use on this server, in game or over the Discord bridge.
+	DisabledPunishments []string `toml:"disabled_punishments"`
+}
+
 // Returns a default configuration.
 func defaultConfig() *Config {
 	return DefaultConfig()
@@ -252,6 +422,7 @@ func DefaultConfig() *Config {
 			TranslatorAPIKey:           "",
 			TranslateCooldown:          25,
 			EnableCasino:               false,
+			DailyChips:                 25,
 			EnableAccounts:             true,
 			RegisterCaptcha:            true,
 			EnableCommunityVote:        false,
@@ -263,6 +434,34 @@ func DefaultConfig() *Config {
 			YouTubeDownloadDestination: "",
 			YouTubeMaxDurationSeconds:  600,
 			YouTubeCookiesPath:         "",
+			JailArea:                   -1,
+			CapsFilterRatio:            0.7,
+			CapsFilterMinLength:        12,
+			ChatControlChars:           "strip",
+			AreaStateSaveInterval:      60,
+			EvidenceDir:                "evidence",
+			Locales:                    "locales",
+			DefaultLocale:              "en",
+			WebhookActivityInterval:    10,
+			InterjectionCooldown:       3,
+			ModSpeakName:               "ooc",
+			WebhookKicks:               true,
+			WebhookBans:                true,
+			WelcomeRules:               "",
+			WelcomeHelp:                "Type /help to see the commands available to you.",
+			OOCNameCooldown:            10,
+			ReservedOOCNames:           []string{},
+			EffectCooldown:             2,
+			TournamentMinPlayers:       0,
+			TournamentConsolation:      false,
+			TournamentDropAFK:          false,
+			BanPurgeInterval:           3600,
+			RpsCooldown:                30,
+			CoinflipExpiry:             30,
+			PollCooldown:               300,
+			PollDuration:               120,
+			SongQueueMax:               10,
+			IdleKickMinutes:            0,
 		},
 		LogConfig{
 			BufSize:              150,
@@ -277,9 +476,11 @@ func DefaultConfig() *Config {
 			MSAddr:    "https://servers.aceattorneyonline.com/servers",
 		},
 		DiscordConfig{
-			BotToken:  "",
-			GuildID:   "",
-			ModRoleID: "",
+			BotToken:       "",
+			GuildID:        "",
+			ModRoleID:      "",
+			ICRelayChannel: "",
+			ICRelayAreas:   []string{},
 		},
 		VoiceConfig{
 			EnableVoice:             false,
@@ -293,9 +494,51 @@ func DefaultConfig() *Config {
 			FrameRateLimitWindow:    1,
 			NewIPIDVoiceCooldown:    30,
 		},
+		PunishmentConfig{
+			EmojiNameReroll:     true,
+			SubtitlesChance:     1,
+			SubtitlesPlacement:  "end",
+			SpotlightGlobal:     false,
+			ConfusedMode:        "full",
+			AnnouncePunishments: false,
+			RouletteChance:      0.25,
+			DisabledPunishments: []string{},
+		},
+		nil,
 	}
 }
 
+// AreaTemplate is a named set of area defaults, declared in config.toml as
+// [AreaTemplates.<name>] and applied with /areatemplates. Unset keys leave
+// the area's current value alone.
+type AreaTemplate struct {
+	Background   string `toml:"background"`
+	EvidenceMode string `toml:"evidence_mode"`
+	AllowIniswap *bool  `toml:"allow_iniswap"`
+	NoInterrupt  *bool  `toml:"force_nointerrupt"`
+	LockBG       *bool  `toml:"lock_bg"`
+	LockMusic    *bool  `toml:"lock_music"`
+}
+
+// validateAreaTemplates rejects templates that could never be applied.
+// Backgrounds are checked against backgrounds.txt later, at server start.
+func (conf *Config) validateAreaTemplates() error {
+	for name, t := range conf.AreaTemplates {
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return fmt.Errorf("area template %q: name must be a single word", name)
+		}
+		switch t.EvidenceMode {
+		case "", "any", "cms", "mods", "owner":
+		default:
+			return fmt.Errorf("area template %q: invalid evidence_mode %q", name, t.EvidenceMode)
+		}
+		if t == (AreaTemplate{}) {
+			return fmt.Errorf("area template %q sets nothing", name)
+		}
+	}
+	return nil
+}
+
 // Load reads the server's main configuration file.
 func (conf *Config) Load() error {
 	_, err := toml.DecodeFile(ConfigPath+"/config.toml", conf)
@@ -313,6 +556,9 @@ func GetConfig() (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := conf.validateAreaTemplates(); err != nil {
+		return nil, err
+	}
 
 	return conf, nil
 }
@@ -402,6 +648,34 @@ func LoadFile(file string) ([]string, error) {
 	return l, nil
 }
 
+// LoadLocales reads every <code>.toml message catalog in dir, keyed by the
+// lowercased locale code. A relative dir is resolved against the config
+// directory. A missing directory is not an error and yields no catalogs.
+func LoadLocales(dir string) (map[string]map[string]string, error) {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(ConfigPath, dir)
+	}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	catalogs := make(map[string]map[string]string)
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".toml" {
+			continue
+		}
+		var catalog map[string]string
+		if _, err := toml.DecodeFile(filepath.Join(dir, e.Name()), &catalog); err != nil {
+			return nil, fmt.Errorf("locale %v: %w", e.Name(), err)
+		}
+		code := strings.ToLower(strings.TrimSuffix(e.Name(), ".toml"))
+		catalogs[code] = catalog
+	}
+	return catalogs, nil
+}
+
 // LoadAreas reads the server's area configuration file, returning it's contents.
 func LoadAreas() ([]area.AreaData, error) {
 	var conf struct {
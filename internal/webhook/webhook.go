@@ -32,8 +32,23 @@ var (
 	ServerColor          uint32 = 0x05b2f7
 	PingRoleID           string
 	PunishmentWebhookURL string
+
+	// KickNotices and BanNotices gate the kick and ban/unban embeds so
+	// servers that find them noisy can switch either off.
+	KickNotices = true
+	BanNotices  = true
 )
 
+// punishmentURL returns where kick, ban and unban embeds are posted: the
+// dedicated punishment webhook when one is configured, otherwise the main
+// webhook. Other punishment embeds only go to the punishment webhook.
+func punishmentURL() string {
+	if PunishmentWebhookURL != "" {
+		return PunishmentWebhookURL
+	}
+	return discord.WebhookURL
+}
+
 // nonEmpty returns s if non-empty, otherwise "N/A".
 // This prevents Discord webhook 400 errors caused by embed fields
 // with empty values (the Discord API requires every field to have a value).
@@ -95,7 +110,8 @@ func PostJail(icName, showname, oocName, ipid, areaName, duration, reason, moder
 
 // PostBan sends a ban notification embed to the punishment webhook.
 func PostBan(icName, showname, oocName, ipid string, uid, banID int, duration, reason, moderator string) error {
-	if PunishmentWebhookURL == "" {
+	url := punishmentURL()
+	if url == "" || !BanNotices {
 		return nil
 	}
 	uidVal := fmt.Sprintf("%d", uid)
@@ -121,12 +137,13 @@ func PostBan(icName, showname, oocName, ipid string, uid, banID int, duration, r
 		Username: ServerName,
 		Embeds:   []discord.Embed{e},
 	}
-	return postToURL(PunishmentWebhookURL, p)
+	return postToURL(url, p)
 }
 
 // PostKick sends a kick notification embed to the punishment webhook.
 func PostKick(icName, showname, oocName, ipid, reason, moderator string, uid int) error {
-	if PunishmentWebhookURL == "" {
+	url := punishmentURL()
+	if url == "" || !KickNotices {
 		return nil
 	}
 	e := discord.Embed{
@@ -146,7 +163,7 @@ func PostKick(icName, showname, oocName, ipid, reason, moderator string, uid int
 		Username: ServerName,
 		Embeds:   []discord.Embed{e},
 	}
-	return postToURL(PunishmentWebhookURL, p)
+	return postToURL(url, p)
 }
 
 // PostUnban sends an unban notification embed to the punishment webhook.
@@ -156,7 +173,8 @@ func PostKick(icName, showname, oocName, ipid, reason, moderator string, uid int
 // The remaining string fields are taken directly from the stored ban record so
 // the embed is informative even when the player is offline.
 func PostUnban(banID int, ipid, originalReason, originalDuration, originalModerator, unbannedBy string) error {
-	if PunishmentWebhookURL == "" {
+	url := punishmentURL()
+	if url == "" || !BanNotices {
 		return nil
 	}
 	e := discord.Embed{
@@ -175,7 +193,7 @@ func PostUnban(banID int, ipid, originalReason, originalDuration, originalModera
 		Username: ServerName,
 		Embeds:   []discord.Embed{e},
 	}
-	return postToURL(PunishmentWebhookURL, p)
+	return postToURL(url, p)
 }
 
 // PostBotBan sends a single summary embed to the punishment webhook after a /botban sweep.
@@ -256,6 +274,26 @@ func PostModcall(character, showname, oocName, ipid, area, reason string, uid in
 	return err
 }
 
+// PostActivity sends a batch of player activity lines (joins, leaves, area
+// moves) to the discord webhook as a single embed, footed with the number of
+// players online.
+func PostActivity(lines []string, online int) error {
+	if len(lines) == 0 {
+		return nil
+	}
+	e := discord.Embed{
+		Title:       "👥 Player Activity",
+		Color:       ServerColor,
+		Description: strings.Join(lines, "\n"),
+		Footer:      &discord.Footer{Text: fmt.Sprintf("%d player(s) online", online)},
+	}
+	p := discord.PostOptions{
+		Username: ServerName,
+		Embeds:   []discord.Embed{e},
+	}
+	return discord.Post(p)
+}
+
 // PostReport sends a report file to the discord webhook.
 func PostReport(name string, contents string) error {
 	c := strings.NewReader(contents)
@@ -16,7 +16,14 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>. */
 
 package webhook
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ecnepsnai/discord"
+)
 
 func TestNonEmpty(t *testing.T) {
 	if got := nonEmpty(""); got != "N/A" {
@@ -29,3 +36,69 @@ func TestNonEmpty(t *testing.T) {
 		t.Errorf("nonEmpty(\"N/A\") = %q, want \"N/A\"", got)
 	}
 }
+
+func TestPunishmentURLFallback(t *testing.T) {
+	origPunish, origMain := PunishmentWebhookURL, discord.WebhookURL
+	defer func() { PunishmentWebhookURL, discord.WebhookURL = origPunish, origMain }()
+
+	PunishmentWebhookURL, discord.WebhookURL = "", "https://example.invalid/main"
+	if got := punishmentURL(); got != discord.WebhookURL {
+		t.Errorf("punishmentURL() = %q, want the main webhook when no punishment webhook is set", got)
+	}
+	PunishmentWebhookURL = "https://example.invalid/punish"
+	if got := punishmentURL(); got != PunishmentWebhookURL {
+		t.Errorf("punishmentURL() = %q, want the punishment webhook", got)
+	}
+}
+
+func TestKickNoticesToggle(t *testing.T) {
+	var posts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	origPunish, origKicks := PunishmentWebhookURL, KickNotices
+	defer func() { PunishmentWebhookURL, KickNotices = origPunish, origKicks }()
+	PunishmentWebhookURL = srv.URL
+
+	KickNotices = false
+	if err := PostKick("Phoenix", "Nick", "nick", "ipid", "spam", "ModX", 3); err != nil {
+		t.Fatalf("PostKick: %v", err)
+	}
+	if n := atomic.LoadInt32(&posts); n != 0 {
+		t.Errorf("expected no post with kick notices off, got %d", n)
+	}
+
+	KickNotices = true
+	if err := PostKick("Phoenix", "Nick", "nick", "ipid", "spam", "ModX", 3); err != nil {
+		t.Fatalf("PostKick: %v", err)
+	}
+	if n := atomic.LoadInt32(&posts); n != 1 {
+		t.Errorf("expected one post with kick notices on, got %d", n)
+	}
+}
+
+func TestJailNeedsPunishmentWebhook(t *testing.T) {
+	var posts int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&posts, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	origPunish, origMain := PunishmentWebhookURL, discord.WebhookURL
+	defer func() { PunishmentWebhookURL, discord.WebhookURL = origPunish, origMain }()
+	PunishmentWebhookURL, discord.WebhookURL = "", srv.URL
+
+	if err := PostJail("Phoenix", "Nick", "nick", "ipid", "Lobby", "1h", "spam", "ModX", 3); err != nil {
+		t.Fatalf("PostJail: %v", err)
+	}
+	if err := PostBotBan(2, "a, b", "ModX"); err != nil {
+		t.Fatalf("PostBotBan: %v", err)
+	}
+	if n := atomic.LoadInt32(&posts); n != 0 {
+		t.Errorf("expected jail and botban embeds to skip the main webhook, got %d posts", n)
+	}
+}